@@ -0,0 +1,172 @@
+// Este arquivo (anexo.go) define os subcomandos de 'aula' que gerenciam os anexos das aulas:
+// arquivos (slides, fichas, páginas do livro digitalizadas) e links (vídeos, simuladores).
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/config"
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+var lessonAttachCmd = &cobra.Command{
+	Use:   "anexar [ID_da_aula] [arquivo|url]",
+	Short: "Anexa um arquivo ou um link a uma aula",
+	Long: `Anexa um recurso à aula. Um endereço http:// ou https:// é guardado como link; qualquer outro valor
+é tratado como caminho de arquivo, que é copiado para o diretório de anexos ao lado do banco de dados
+(o original pode ser movido ou apagado depois). Use --nome para escolher o nome exibido.`,
+	Example: `  vigenda aula anexar 12 slides_fracoes.pdf
+  vigenda aula anexar 12 https://www.youtube.com/watch?v=abc123 --nome "Vídeo: frações equivalentes"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lessonID, err := parseIDArg(args[0], "aula")
+		if err != nil {
+			return err
+		}
+		name, _ := cmd.Flags().GetString("nome")
+		ctx := context.Background()
+
+		var attachment models.LessonAttachment
+		if isLinkArg(args[1]) {
+			attachment, err = attachmentService.AttachLink(ctx, lessonID, args[1], name)
+		} else {
+			attachment, err = attachmentService.AttachFile(ctx, lessonID, args[1], name)
+		}
+		if err != nil {
+			return fmt.Errorf("erro ao anexar: %w", err)
+		}
+		fmt.Printf("Anexo '%s' (ID: %d) adicionado à aula ID %d.\n", attachment.Name, attachment.ID, lessonID)
+		return nil
+	},
+}
+
+var lessonAttachmentsCmd = &cobra.Command{
+	Use:     "anexos [ID_da_aula]",
+	Short:   "Lista os anexos de uma aula",
+	Example: `  vigenda aula anexos 12`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lessonID, err := parseIDArg(args[0], "aula")
+		if err != nil {
+			return err
+		}
+		attachments, err := attachmentService.ListAttachments(context.Background(), lessonID)
+		if err != nil {
+			return fmt.Errorf("erro ao listar anexos: %w", err)
+		}
+		if len(attachments) == 0 && isTableOutput() {
+			fmt.Println("Nenhum anexo nesta aula.")
+			return nil
+		}
+		return writeAttachments(os.Stdout, fmt.Sprintf("ANEXOS DA AULA ID %d", lessonID), attachments)
+	},
+}
+
+var lessonOpenAttachmentCmd = &cobra.Command{
+	Use:   "abrir-anexo [ID_do_anexo]",
+	Short: "Abre um anexo com o programa padrão do sistema",
+	Long: `Abre o arquivo ou link com o programa padrão do sistema (xdg-open no Linux, open no macOS).
+Para usar outro programa, defina a variável de ambiente VIGENDA_OPENER (ex: VIGENDA_OPENER="firefox --new-tab").`,
+	Example: `  vigenda aula abrir-anexo 3`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		attachmentID, err := parseIDArg(args[0], "anexo")
+		if err != nil {
+			return err
+		}
+		attachment, err := attachmentService.GetAttachment(context.Background(), attachmentID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar anexo: %w", err)
+		}
+		target := attachmentService.Target(attachment)
+		if attachment.Kind == models.AttachmentKindFile {
+			if _, err := os.Stat(target); err != nil {
+				return service.NotFoundErrorf("o arquivo do anexo '%s' não está mais em %s", attachment.Name, target)
+			}
+		}
+		opener := config.OpenCommand(target)
+		if err := opener.Start(); err != nil {
+			return service.ValidationErrorf("não foi possível executar '%s' para abrir o anexo: defina o programa em %s", opener.Path, config.OpenerEnv)
+		}
+		_ = opener.Process.Release()
+		fmt.Printf("Abrindo '%s'...\n", attachment.Name)
+		return nil
+	},
+}
+
+var lessonDeleteAttachmentCmd = &cobra.Command{
+	Use:     "remover-anexo [ID_do_anexo]",
+	Short:   "Remove um anexo de uma aula",
+	Long:    `Remove o anexo e, se for um arquivo, a cópia guardada no diretório de anexos.`,
+	Example: `  vigenda aula remover-anexo 3`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		attachmentID, err := parseIDArg(args[0], "anexo")
+		if err != nil {
+			return err
+		}
+		if err := attachmentService.DeleteAttachment(context.Background(), attachmentID); err != nil {
+			return fmt.Errorf("erro ao remover anexo: %w", err)
+		}
+		fmt.Printf("Anexo ID %d removido.\n", attachmentID)
+		return nil
+	},
+}
+
+// writeAttachments escreve uma lista de anexos no formato de --formato.
+func writeAttachments(w io.Writer, header string, attachments []models.LessonAttachment) error {
+	columns := []table.Column{
+		{Title: "ID", Width: 4},
+		{Title: "TIPO", Width: 7},
+		{Title: "NOME", Width: 30},
+		{Title: "LOCAL", Width: 50},
+	}
+	rows := []table.Row{}
+	for _, attachment := range attachments {
+		rows = append(rows, table.Row{fmt.Sprintf("%d", attachment.ID), attachment.Kind, attachment.Name, attachmentLocation(attachment)})
+	}
+	return writeList(w, listOutput{Header: header, Columns: columns, Rows: rows, Data: attachments})
+}
+
+// attachmentLocation é o que as listagens mostram de um anexo: a URL de um link ou o caminho
+// completo da cópia de um arquivo.
+func attachmentLocation(attachment models.LessonAttachment) string {
+	return attachmentService.Target(attachment)
+}
+
+// withAttachments preenche os anexos de cada aula para a saída json. Em caso de erro, as aulas são
+// retornadas sem anexos.
+func withAttachments(ctx context.Context, lessons []models.Lesson) []models.Lesson {
+	if attachmentService == nil || len(lessons) == 0 {
+		return lessons
+	}
+	byLesson, err := attachmentService.ListAllAttachments(ctx)
+	if err != nil {
+		return lessons
+	}
+	filled := make([]models.Lesson, len(lessons))
+	for i, lesson := range lessons {
+		lesson.Attachments = byLesson[lesson.ID]
+		filled[i] = lesson
+	}
+	return filled
+}
+
+// isLinkArg indica se o argumento de 'aula anexar' é um link em vez de um caminho de arquivo.
+func isLinkArg(value string) bool {
+	lower := strings.ToLower(strings.TrimSpace(value))
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+func init() {
+	lessonAttachCmd.Flags().String("nome", "", "Nome exibido do anexo (padrão: o nome do arquivo ou a URL).")
+
+	lessonCmd.AddCommand(lessonAttachCmd, lessonAttachmentsCmd, lessonOpenAttachmentCmd, lessonDeleteAttachmentCmd)
+}
@@ -0,0 +1,476 @@
+// Este arquivo (aula.go) define o comando 'aula', que gerencia as aulas planejadas de cada turma
+// e o seu plano de aula em Markdown.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+	"vigenda/internal/tui"
+	"vigenda/internal/tui/markdown"
+)
+
+// lessonDateTimeLayout é o formato de data e hora aceito nas flags --data dos comandos de aula.
+const lessonDateTimeLayout = "2006-01-02 15:04"
+
+// lessonPlanWidth é a largura usada para quebrar o plano de aula em 'aula ver'.
+const lessonPlanWidth = 80
+
+var lessonCmd = &cobra.Command{
+	Use:   "aula",
+	Short: "Gerencia aulas e planos de aula (criar, listar, ver, editar, registrar, anexar, remover, hoje, semana)",
+	Long: `O comando 'aula' permite planejar as aulas de cada turma.
+Cada aula tem título, data/hora e um plano de aula em Markdown, que pode ser lido de um arquivo
+ou da entrada padrão com --plano. Depois da aula, 'aula registrar' anota a situação (ministrada,
+parcial ou cancelada) e o conteúdo efetivamente ministrado, e 'aula anexar' guarda slides, fichas,
+vídeos e links usados na aula.`,
+	Example: `  vigenda aula criar "Frações equivalentes" --turma 1 --data "2025-06-20 08:00" --plano frações.md
+  vigenda aula listar --turma 1
+  vigenda aula ver 12
+  vigenda aula registrar 12 --situacao ministrada --conteudo "Frações equivalentes e simplificação"
+  vigenda aula anexar 12 slides.pdf
+  vigenda aula hoje
+  vigenda aula semana`,
+}
+
+var lessonCreateCmd = &cobra.Command{
+	Use:   "criar [título]",
+	Short: "Cria uma nova aula para uma turma",
+	Long: `Cria uma aula para a turma informada em --turma, na data e hora de --data (AAAA-MM-DD HH:MM).
+O plano de aula é lido do arquivo Markdown indicado em --plano (use --plano - para ler da entrada padrão)
+ou copiado do modelo de plano informado em --modelo (ver 'vigenda modelo listar').`,
+	Example: `  vigenda aula criar "Frações equivalentes" --turma 1 --data "2025-06-20 08:00" --plano frações.md
+  cat plano.md | vigenda aula criar "Revisão" --turma 2 --data "2025-06-21 10:00" --plano -
+  vigenda aula criar "Densidade" --turma 1 --data "2025-06-23 08:00" --modelo 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		classIDStr, _ := cmd.Flags().GetString("turma")
+		dateStr, _ := cmd.Flags().GetString("data")
+		planPath, _ := cmd.Flags().GetString("plano")
+		templateID, _ := cmd.Flags().GetInt64("modelo")
+		if planPath != "" && templateID != 0 {
+			return service.ValidationErrorf("use --plano ou --modelo, não ambos")
+		}
+
+		classID, err := parseIDArg(classIDStr, "turma")
+		if err != nil {
+			return err
+		}
+		scheduledAt, err := parseLessonDateTime(dateStr)
+		if err != nil {
+			return err
+		}
+		planContent, err := readLessonPlan(planPath)
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		if templateID != 0 {
+			if planContent, err = templatePlan(ctx, templateID); err != nil {
+				return err
+			}
+		}
+
+		lesson, err := lessonService.CreateLesson(ctx, classID, args[0], planContent, scheduledAt)
+		if err != nil {
+			return fmt.Errorf("erro ao criar aula: %w", err)
+		}
+		fmt.Printf("Aula '%s' (ID: %d) criada para %s.\n", lesson.Title, lesson.ID, lesson.ScheduledAt.Format("02/01/2006 15:04"))
+		return nil
+	},
+}
+
+var lessonListCmd = &cobra.Command{
+	Use:     "listar",
+	Short:   "Lista as aulas de uma turma",
+	Example: `  vigenda aula listar --turma 1`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		classIDStr, _ := cmd.Flags().GetString("turma")
+		classID, err := parseIDArg(classIDStr, "turma")
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		lessons, err := lessonService.GetLessonsByClassID(ctx, classID)
+		if err != nil {
+			return fmt.Errorf("erro ao listar aulas: %w", err)
+		}
+		header := fmt.Sprintf("AULAS PARA: Turma ID %d", classID)
+		if class, err := classService.GetClassByID(ctx, classID); err == nil {
+			header = fmt.Sprintf("AULAS PARA: %s", class.Name)
+		}
+		if len(lessons) == 0 && isTableOutput() {
+			fmt.Println("Nenhuma aula cadastrada para esta turma.")
+			return nil
+		}
+		return writeLessons(os.Stdout, header, lessons)
+	},
+}
+
+var lessonShowCmd = &cobra.Command{
+	Use:     "ver [ID_da_aula]",
+	Short:   "Mostra uma aula com o plano de aula formatado",
+	Example: `  vigenda aula ver 12`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lessonID, err := parseIDArg(args[0], "aula")
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		lesson, err := lessonService.GetLessonByID(ctx, lessonID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar aula: %w", err)
+		}
+		if !isTableOutput() {
+			return writeLessons(os.Stdout, "", []models.Lesson{lesson})
+		}
+
+		fmt.Println(lesson.Title)
+		fmt.Println(strings.Repeat("=", len([]rune(lesson.Title))))
+		fmt.Printf("Turma: %s\n", lessonClassNames(ctx)[lesson.ClassID])
+		fmt.Printf("Data:  %s\n", formatLessonDate(lesson.ScheduledAt))
+		fmt.Printf("Situação: %s\n\n", lesson.Status)
+		if strings.TrimSpace(lesson.PlanContent) == "" {
+			fmt.Println("(sem plano de aula)")
+		} else {
+			fmt.Println(markdown.Render(lesson.PlanContent, lessonPlanWidth))
+		}
+		if lesson.CoveredContent != "" {
+			fmt.Printf("\nConteúdo ministrado: %s\n", lesson.CoveredContent)
+		}
+		if lesson.Reflection != "" {
+			fmt.Printf("Reflexão: %s\n", lesson.Reflection)
+		}
+		if attachments, err := attachmentService.ListAttachments(ctx, lesson.ID); err == nil && len(attachments) > 0 {
+			fmt.Println("\nAnexos:")
+			for _, attachment := range attachments {
+				fmt.Printf("  [%d] %s (%s)\n", attachment.ID, attachment.Name, attachmentLocation(attachment))
+			}
+		}
+		return nil
+	},
+}
+
+var lessonEditCmd = &cobra.Command{
+	Use:   "editar [ID_da_aula]",
+	Short: "Altera título, data ou plano de uma aula",
+	Long:  `Altera apenas os campos informados: --titulo, --data (AAAA-MM-DD HH:MM) e/ou --plano (arquivo Markdown ou - para a entrada padrão).`,
+	Example: `  vigenda aula editar 12 --data "2025-06-27 08:00"
+  vigenda aula editar 12 --plano plano_revisado.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lessonID, err := parseIDArg(args[0], "aula")
+		if err != nil {
+			return err
+		}
+		flags := cmd.Flags()
+		if !flags.Changed("titulo") && !flags.Changed("data") && !flags.Changed("plano") {
+			return service.ValidationErrorf("informe ao menos um campo para alterar: --titulo, --data ou --plano")
+		}
+
+		ctx := context.Background()
+		lesson, err := lessonService.GetLessonByID(ctx, lessonID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar aula: %w", err)
+		}
+		if flags.Changed("titulo") {
+			lesson.Title, _ = flags.GetString("titulo")
+		}
+		if flags.Changed("data") {
+			dateStr, _ := flags.GetString("data")
+			if lesson.ScheduledAt, err = parseLessonDateTime(dateStr); err != nil {
+				return err
+			}
+		}
+		if flags.Changed("plano") {
+			planPath, _ := flags.GetString("plano")
+			if lesson.PlanContent, err = readLessonPlan(planPath); err != nil {
+				return err
+			}
+		}
+
+		updated, err := lessonService.UpdateLesson(ctx, lessonID, lesson.Title, lesson.PlanContent, lesson.ScheduledAt)
+		if err != nil {
+			return fmt.Errorf("erro ao atualizar aula: %w", err)
+		}
+		fmt.Printf("Aula ID %d atualizada: '%s' em %s.\n", updated.ID, updated.Title, updated.ScheduledAt.Format("02/01/2006 15:04"))
+		return nil
+	},
+}
+
+var lessonRecordCmd = &cobra.Command{
+	Use:   "registrar [ID_da_aula]",
+	Short: "Registra a situação e o conteúdo ministrado de uma aula",
+	Long: `Registra o que aconteceu na aula: a situação (--situacao planejada, ministrada, parcial ou cancelada),
+o conteúdo efetivamente ministrado (--conteudo, obrigatório para aulas ministradas ou parciais) e uma
+reflexão curta (--reflexao).
+
+Numa aula parcial, o conteúdo que faltou pode ir para o início do plano da próxima aula da mesma turma:
+informe-o em --pendente ou responda à pergunta feita ao final do registro. Numa aula cancelada,
+--pendente "" leva o plano inteiro para a próxima aula.`,
+	Example: `  vigenda aula registrar 12 --situacao ministrada --conteudo "Frações equivalentes" --reflexao "Turma participativa"
+  vigenda aula registrar 13 --situacao parcial --conteudo "Soma de frações" --pendente "Subtração de frações"
+  vigenda aula registrar 14 --situacao cancelada --pendente ""`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lessonID, err := parseIDArg(args[0], "aula")
+		if err != nil {
+			return err
+		}
+		flags := cmd.Flags()
+		status, _ := flags.GetString("situacao")
+		covered, _ := flags.GetString("conteudo")
+		reflection, _ := flags.GetString("reflexao")
+
+		ctx := context.Background()
+		lesson, err := lessonService.RecordDelivery(ctx, lessonID, status, covered, reflection)
+		if err != nil {
+			return fmt.Errorf("erro ao registrar aula: %w", err)
+		}
+		if isTableOutput() {
+			fmt.Printf("Aula ID %d registrada como %s.\n", lesson.ID, lesson.Status)
+		}
+
+		pending, _ := flags.GetString("pendente")
+		carry := flags.Changed("pendente")
+		if !carry && lesson.Status == models.LessonStatusPartial && isTableOutput() {
+			answer, err := tui.GetInput("Conteúdo que ficou pendente para a próxima aula (Enter para nenhum)", os.Stdout, os.Stdin)
+			if err != nil {
+				return fmt.Errorf("erro ao ler conteúdo pendente: %w", err)
+			}
+			pending, carry = answer, strings.TrimSpace(answer) != ""
+		}
+		if !carry {
+			if !isTableOutput() {
+				return writeLessons(os.Stdout, "", []models.Lesson{lesson})
+			}
+			return nil
+		}
+
+		next, err := lessonService.CarryOverContent(ctx, lessonID, pending)
+		if err != nil {
+			return fmt.Errorf("erro ao levar conteúdo pendente: %w", err)
+		}
+		if !isTableOutput() {
+			return writeLessons(os.Stdout, "", []models.Lesson{lesson, next})
+		}
+		fmt.Printf("Conteúdo pendente incluído no plano da aula ID %d ('%s', %s).\n", next.ID, next.Title, formatLessonDate(next.ScheduledAt))
+		return nil
+	},
+}
+
+var lessonDeleteCmd = &cobra.Command{
+	Use:     "remover [ID_da_aula]",
+	Short:   "Remove uma aula",
+	Long:    `Remove uma aula, o seu plano e os seus anexos. Pede confirmação, a menos que --sim seja informado.`,
+	Example: `  vigenda aula remover 12 --sim`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lessonID, err := parseIDArg(args[0], "aula")
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		lesson, err := lessonService.GetLessonByID(ctx, lessonID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar aula: %w", err)
+		}
+		if confirmed, _ := cmd.Flags().GetBool("sim"); !confirmed {
+			answer, err := tui.GetInput(fmt.Sprintf("Remover a aula '%s' de %s? (s/N)", lesson.Title, lesson.ScheduledAt.Format("02/01/2006 15:04")), os.Stdout, os.Stdin)
+			if err != nil {
+				return fmt.Errorf("erro ao ler confirmação: %w", err)
+			}
+			if answer := strings.ToLower(strings.TrimSpace(answer)); answer != "s" && answer != "sim" {
+				fmt.Println("Remoção cancelada.")
+				return nil
+			}
+		}
+		if err := lessonService.DeleteLesson(ctx, lessonID); err != nil {
+			return fmt.Errorf("erro ao remover aula: %w", err)
+		}
+		if err := attachmentService.DeleteLessonAttachments(ctx, lessonID); err != nil {
+			return fmt.Errorf("aula removida, mas não foi possível remover os seus anexos: %w", err)
+		}
+		fmt.Printf("Aula ID %d removida.\n", lessonID)
+		return nil
+	},
+}
+
+var lessonTodayCmd = &cobra.Command{
+	Use:   "hoje",
+	Short: "Lista as aulas de hoje",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		lessons, err := lessonService.GetLessonsForDate(context.Background(), 1, now) // UserID 1 até haver autenticação.
+		if err != nil {
+			return fmt.Errorf("erro ao listar aulas de hoje: %w", err)
+		}
+		if len(lessons) == 0 && isTableOutput() {
+			fmt.Println("Nenhuma aula hoje.")
+			return nil
+		}
+		return writeLessons(os.Stdout, fmt.Sprintf("AULAS DE HOJE (%s)", now.Format("02/01/2006")), lessons)
+	},
+}
+
+var lessonWeekCmd = &cobra.Command{
+	Use:   "semana",
+	Short: "Lista as aulas da semana (segunda a domingo)",
+	Long:  `Lista as aulas da semana atual, ou da semana que contém a data informada em --data (AAAA-MM-DD).`,
+	Example: `  vigenda aula semana
+  vigenda aula semana --data 2025-06-23`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		day := time.Now()
+		if dateStr, _ := cmd.Flags().GetString("data"); dateStr != "" {
+			parsed, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+			if err != nil {
+				return service.ValidationErrorf("data inválida '%s': use o formato AAAA-MM-DD", dateStr)
+			}
+			day = parsed
+		}
+		lessons, err := lessonService.GetLessonsForWeek(context.Background(), 1, day) // UserID 1 até haver autenticação.
+		if err != nil {
+			return fmt.Errorf("erro ao listar aulas da semana: %w", err)
+		}
+		start := service.WeekStart(day)
+		header := fmt.Sprintf("AULAS DA SEMANA (%s a %s)", start.Format("02/01"), start.AddDate(0, 0, 6).Format("02/01/2006"))
+		if len(lessons) == 0 && isTableOutput() {
+			fmt.Println("Nenhuma aula nesta semana.")
+			return nil
+		}
+		return writeLessons(os.Stdout, header, lessons)
+	},
+}
+
+// writeLessons escreve uma lista de aulas no formato de --formato.
+// No formato json, o plano de aula completo é incluído em cada aula.
+func writeLessons(w io.Writer, header string, lessons []models.Lesson) error {
+	classNames := lessonClassNames(context.Background())
+	columns := []table.Column{
+		{Title: "ID", Width: 4},
+		{Title: "DATA", Width: 14},
+		{Title: "HORA", Width: 5},
+		{Title: "TURMA", Width: 15},
+		{Title: "TÍTULO", Width: 40},
+		{Title: "SITUAÇÃO", Width: 10},
+	}
+	rows := []table.Row{}
+	for _, lesson := range lessons {
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%d", lesson.ID),
+			formatLessonDay(lesson.ScheduledAt),
+			lesson.ScheduledAt.Format("15:04"),
+			classNames[lesson.ClassID],
+			lesson.Title,
+			lesson.Status,
+		})
+	}
+	if lessons == nil {
+		lessons = []models.Lesson{}
+	}
+	if !isTableOutput() {
+		lessons = withAttachments(context.Background(), lessons)
+	}
+	return writeList(w, listOutput{Header: header, Columns: columns, Rows: rows, Data: lessons})
+}
+
+// lessonClassNames mapeia o ID de cada turma para o seu nome. Em caso de erro, o mapa fica vazio
+// e as listagens mostram a coluna de turma em branco.
+func lessonClassNames(ctx context.Context) map[int64]string {
+	names := make(map[int64]string)
+	classes, err := classService.ListAllClasses(ctx)
+	if err != nil {
+		return names
+	}
+	for _, class := range classes {
+		names[class.ID] = class.Name
+	}
+	return names
+}
+
+// parseLessonDateTime interpreta a data e hora de uma aula no fuso local.
+func parseLessonDateTime(value string) (time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return time.Time{}, service.ValidationErrorf("a data da aula (--data) é obrigatória, no formato AAAA-MM-DD HH:MM")
+	}
+	parsed, err := time.ParseInLocation(lessonDateTimeLayout, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return time.Time{}, service.ValidationErrorf("data inválida '%s': use o formato AAAA-MM-DD HH:MM", value)
+	}
+	return parsed, nil
+}
+
+// readLessonPlan lê o plano de aula do arquivo em 'path', ou da entrada padrão se 'path' for "-".
+// Um caminho vazio significa aula sem plano.
+func readLessonPlan(path string) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	switch path {
+	case "":
+		return "", nil
+	case "-":
+		data, err = io.ReadAll(os.Stdin)
+	default:
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", &service.Error{Category: service.CategoryValidation, Message: fmt.Sprintf("não foi possível ler o plano de aula '%s'", path), Err: err}
+	}
+	return string(data), nil
+}
+
+// weekdayShortNames são as abreviações dos dias da semana, indexadas por time.Weekday.
+var weekdayShortNames = [...]string{"Dom", "Seg", "Ter", "Qua", "Qui", "Sex", "Sáb"}
+
+// formatLessonDay formata a data de uma aula como "Seg 20/06/2025".
+func formatLessonDay(t time.Time) string {
+	return weekdayShortNames[t.Weekday()] + " " + t.Format("02/01/2006")
+}
+
+// formatLessonDate formata data e hora de uma aula como "Seg 20/06/2025 08:00".
+func formatLessonDate(t time.Time) string {
+	return formatLessonDay(t) + " " + t.Format("15:04")
+}
+
+func init() {
+	lessonCreateCmd.Flags().String("turma", "", "ID da turma da aula (obrigatório).")
+	_ = lessonCreateCmd.MarkFlagRequired("turma")
+	lessonCreateCmd.Flags().String("data", "", "Data e hora da aula no formato AAAA-MM-DD HH:MM (obrigatório).")
+	_ = lessonCreateCmd.MarkFlagRequired("data")
+	lessonCreateCmd.Flags().String("plano", "", "Arquivo Markdown com o plano de aula, ou - para ler da entrada padrão.")
+	lessonCreateCmd.Flags().Int64("modelo", 0, "ID do modelo de plano de aula usado como plano.")
+
+	lessonListCmd.Flags().String("turma", "", "ID da turma (obrigatório).")
+	_ = lessonListCmd.MarkFlagRequired("turma")
+
+	lessonEditCmd.Flags().String("titulo", "", "Novo título da aula.")
+	lessonEditCmd.Flags().String("data", "", "Nova data e hora no formato AAAA-MM-DD HH:MM.")
+	lessonEditCmd.Flags().String("plano", "", "Arquivo Markdown com o novo plano, ou - para ler da entrada padrão.")
+
+	lessonRecordCmd.Flags().String("situacao", "", "Situação da aula: planejada, ministrada, parcial ou cancelada (obrigatório).")
+	_ = lessonRecordCmd.MarkFlagRequired("situacao")
+	lessonRecordCmd.Flags().String("conteudo", "", "Conteúdo efetivamente ministrado.")
+	lessonRecordCmd.Flags().String("reflexao", "", "Reflexão curta sobre a aula.")
+	lessonRecordCmd.Flags().String("pendente", "", "Conteúdo a levar para a próxima aula da turma (aulas parciais ou canceladas).")
+
+	lessonDeleteCmd.Flags().Bool("sim", false, "Remove sem pedir confirmação.")
+
+	lessonWeekCmd.Flags().String("data", "", "Uma data da semana desejada, no formato AAAA-MM-DD (padrão: hoje).")
+
+	lessonCmd.AddCommand(lessonCreateCmd, lessonListCmd, lessonShowCmd, lessonEditCmd, lessonRecordCmd, lessonDeleteCmd, lessonTodayCmd, lessonWeekCmd)
+	rootCmd.AddCommand(lessonCmd)
+}
@@ -0,0 +1,133 @@
+// Este arquivo (backup.go) define o comando 'backup', que grava uma cópia do banco de dados e dos
+// anexos das aulas em um único arquivo .zip.
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"vigenda/internal/database"
+	"vigenda/internal/service"
+)
+
+// backupDBEntry é o nome do banco de dados dentro do arquivo de backup; os anexos ficam em backupAttachmentsEntry/.
+const (
+	backupDBEntry          = "vigenda.db"
+	backupAttachmentsEntry = "anexos"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Grava uma cópia do banco de dados e dos anexos em um arquivo .zip",
+	Long: `Gera um arquivo .zip com uma cópia consistente do banco de dados (vigenda.db) e todos os arquivos
+anexados às aulas (anexos/). Para restaurar, extraia o arquivo no diretório do banco de dados.
+Disponível apenas para o banco SQLite; no PostgreSQL, use as ferramentas do próprio servidor.`,
+	Example: `  vigenda backup
+  vigenda backup --saida ~/Documentos/vigenda-2025-07-01.zip`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbPath, ok := database.SQLiteFilePath(dbConfig)
+		if !ok {
+			return service.ValidationErrorf("o backup só está disponível para o banco SQLite (VIGENDA_DB_TYPE=%s)", dbConfig.DBType)
+		}
+		output, _ := cmd.Flags().GetString("saida")
+		if output == "" {
+			output = fmt.Sprintf("vigenda-backup-%s.zip", time.Now().Format("20060102-1504"))
+		}
+
+		// VACUUM INTO gera uma cópia consistente mesmo com o banco em uso.
+		tmpDir, err := os.MkdirTemp("", "vigenda-backup-")
+		if err != nil {
+			return service.StorageError("não foi possível criar o diretório temporário do backup", err)
+		}
+		defer os.RemoveAll(tmpDir)
+		snapshot := filepath.Join(tmpDir, backupDBEntry)
+		if _, err := db.Exec(`VACUUM INTO ?`, snapshot); err != nil {
+			return service.StorageError(fmt.Sprintf("não foi possível copiar o banco de dados '%s'", dbPath), err)
+		}
+
+		files, err := writeBackup(output, snapshot, attachmentService.Dir())
+		if err != nil {
+			os.Remove(output)
+			return service.StorageError(fmt.Sprintf("não foi possível gravar o backup '%s'", output), err)
+		}
+		fmt.Printf("Backup gravado em %s (banco de dados e %d arquivo(s) anexado(s)).\n", output, files)
+		return nil
+	},
+}
+
+// writeBackup grava em 'output' o banco 'snapshot' e os arquivos do diretório de anexos, e retorna o
+// número de anexos incluídos. Um diretório de anexos inexistente é tratado como vazio.
+func writeBackup(output, snapshot, attachmentsDir string) (int, error) {
+	file, err := os.Create(output)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	archive := zip.NewWriter(file)
+
+	if err := addBackupFile(archive, snapshot, backupDBEntry); err != nil {
+		return 0, err
+	}
+	files := 0
+	err = filepath.WalkDir(attachmentsDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == attachmentsDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		relative, err := filepath.Rel(attachmentsDir, path)
+		if err != nil {
+			return err
+		}
+		files++
+		return addBackupFile(archive, path, backupAttachmentsEntry+"/"+filepath.ToSlash(relative))
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := archive.Close(); err != nil {
+		return 0, err
+	}
+	return files, file.Close()
+}
+
+// addBackupFile copia o arquivo 'path' para a entrada 'name' do zip.
+func addBackupFile(archive *zip.Writer, path, name string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+	dst, err := archive.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	return err
+}
+
+func init() {
+	backupCmd.Flags().String("saida", "", "Arquivo .zip de destino (padrão: vigenda-backup-AAAAMMDD-HHMM.zip no diretório atual).")
+
+	rootCmd.AddCommand(backupCmd)
+}
@@ -0,0 +1,176 @@
+// Este arquivo (boletim.go) define o comando 'relatorio boletim', que gera os boletins dos alunos
+// para imprimir ou enviar às famílias, em HTML ou PDF.
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/report"
+	"vigenda/internal/service"
+)
+
+var reportCardCmd = &cobra.Command{
+	Use:   "boletim",
+	Short: "Gera o boletim de um aluno ou de todos os alunos de uma turma (HTML/PDF)",
+	Long: `Gera o boletim escolar para imprimir ou enviar às famílias: as notas de cada avaliação por
+bimestre, a média de cada bimestre e a média anual (segundo a política de notas da turma, com as
+recuperações aplicadas), a nota final, o resultado (aprovado ou reprovado pela média de aprovação,
+a partir da nota final ou, sem ela, da média anual) e o comentário do(a) professor(a).
+
+Com --aluno, gera o boletim de um aluno; com --turma, um boletim por aluno ativo, cada um em uma
+página. --comentario grava o comentário do boletim do aluno de --aluno (--comentario "" o apaga);
+o comentário fica guardado para os próximos boletins.
+
+O arquivo de --saida é gravado em HTML ou PDF conforme a extensão; sem --saida, o HTML é escrito na
+saída padrão. O cabeçalho usa o nome da escola de VIGENDA_ESCOLA, o logotipo (PNG ou JPEG) de
+VIGENDA_LOGO e o nome do(a) professor(a) de VIGENDA_PROFESSOR.`,
+	Example: `  vigenda relatorio boletim --aluno 12 --comentario "Ótima evolução no bimestre." --saida boletim-ana.pdf
+  vigenda relatorio boletim --turma "Turma 9A" --saida boletins-9a.pdf`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		studentArg, _ := cmd.Flags().GetString("aluno")
+		classArg, _ := cmd.Flags().GetString("turma")
+		output, _ := cmd.Flags().GetString("saida")
+		if (studentArg == "") == (classArg == "") {
+			return service.ValidationErrorf("informe --aluno ou --turma")
+		}
+		format, err := reportFormat(output)
+		if err != nil {
+			return err
+		}
+		if format == report.FormatCSV {
+			return service.ValidationErrorf("o boletim é gerado em HTML ou PDF: use --saida com extensão .html ou .pdf")
+		}
+
+		var class models.Class
+		var cards []service.ReportCard
+		if studentArg != "" {
+			studentID, err := parseIDArg(studentArg, "aluno")
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("comentario") {
+				comment, _ := cmd.Flags().GetString("comentario")
+				if err := assessmentService.SetReportCardComment(ctx, studentID, comment); err != nil {
+					return fmt.Errorf("erro ao gravar o comentário do boletim: %w", err)
+				}
+			}
+			card, err := assessmentService.GetStudentReportCard(ctx, studentID)
+			if err != nil {
+				return fmt.Errorf("erro ao gerar o boletim: %w", err)
+			}
+			if class, err = classService.GetClassByID(ctx, card.Student.ClassID); err != nil {
+				return fmt.Errorf("erro ao carregar a turma do aluno: %w", err)
+			}
+			cards = []service.ReportCard{card}
+		} else {
+			if cmd.Flags().Changed("comentario") {
+				return service.ValidationErrorf("--comentario é gravado para um aluno: use-o com --aluno")
+			}
+			if class, err = resolveClass(ctx, classArg); err != nil {
+				return err
+			}
+			if cards, err = assessmentService.GetReportCards(ctx, class.ID); err != nil {
+				return fmt.Errorf("erro ao gerar os boletins: %w", err)
+			}
+			if len(cards) == 0 {
+				return service.ValidationErrorf("a turma '%s' não tem alunos ativos", class.Name)
+			}
+		}
+
+		docs := make([]report.Document, len(cards))
+		for i, card := range cards {
+			docs[i] = reportCardDocument(class, card)
+		}
+		return writeReport(output, docs...)
+	},
+}
+
+// reportCardDocument monta o boletim impresso de um aluno: uma tabela de notas por bimestre, o
+// resultado final e o comentário do(a) professor(a).
+func reportCardDocument(class models.Class, card service.ReportCard) report.Document {
+	policy := card.Policy
+	grade := func(value *float64) string {
+		if value == nil {
+			return "-"
+		}
+		return service.FormatGrade(policy, *value)
+	}
+
+	info := []string{"Aluno(a): " + card.Student.FullName}
+	if card.Student.EnrollmentID != "" {
+		info[0] += " · Nº " + card.Student.EnrollmentID
+	}
+	if card.Student.Status != "ativo" {
+		info = append(info, "Situação: "+card.Student.Status)
+	}
+	doc := printableDocument("Boletim Escolar", class, info...)
+
+	for _, term := range card.Terms {
+		table := &report.Table{Columns: []report.Column{
+			{Title: "AVALIAÇÃO", Width: 5},
+			{Title: "PESO", Width: 1, Align: report.AlignCenter},
+			{Title: "NOTA", Width: 1, Align: report.AlignCenter},
+		}}
+		for _, g := range term.Grades {
+			name, weight := g.Assessment.Name, decimalComma(g.Assessment.Weight)
+			if service.IsRecovery(g.Assessment) {
+				name, weight = name+" (recuperação)", "-"
+			}
+			table.Rows = append(table.Rows, report.Row{Cells: []string{name, weight, grade(g.Grade)}})
+		}
+		table.Rows = append(table.Rows, report.Row{Cells: []string{"Média do bimestre", "", grade(term.Average)}})
+		doc.Sections = append(doc.Sections, report.Section{Heading: service.TermLabel(term.Term), Table: table})
+	}
+	if len(card.Terms) == 0 {
+		doc.Sections = append(doc.Sections, report.Section{Heading: "Notas", Notes: []string{"Nenhuma avaliação registrada."}})
+	}
+
+	result := &report.Table{}
+	row := report.Row{}
+	for _, term := range card.Terms {
+		result.Columns = append(result.Columns, report.Column{Title: strings.ToUpper(service.TermLabel(term.Term)), Align: report.AlignCenter})
+		row.Cells = append(row.Cells, grade(term.Average))
+	}
+	result.Columns = append(result.Columns,
+		report.Column{Title: "MÉDIA ANUAL", Align: report.AlignCenter},
+		report.Column{Title: "NOTA FINAL", Align: report.AlignCenter},
+		report.Column{Title: "RESULTADO", Width: 1.5, Align: report.AlignCenter})
+	resultLabel := service.ReportCardResultLabel(card.Result)
+	if resultLabel == "" {
+		resultLabel = "-"
+	}
+	row.Cells = append(row.Cells, grade(card.Average), grade(card.FinalGrade), resultLabel)
+	result.Rows = []report.Row{row}
+	doc.Sections = append(doc.Sections, report.Section{
+		Heading: "Resultado",
+		Table:   result,
+		Notes: []string{fmt.Sprintf("Média de aprovação: %s (%s).",
+			service.FormatGrade(policy, policy.PassingGrade), service.GradeScaleLabel(policy.GradeScale))},
+	})
+
+	comment := card.Comment
+	if comment == "" {
+		comment = "________________________________________________________________________________"
+	}
+	doc.Sections = append(doc.Sections, report.Section{Heading: "Comentário do(a) professor(a)", Notes: []string{comment}})
+	doc.Footer = []string{
+		"Assinatura do(a) professor(a): ________________________________________",
+		"Assinatura do(a) responsável: ________________________________________",
+	}
+	return doc
+}
+
+func init() {
+	reportCardCmd.Flags().String("aluno", "", "ID do aluno.")
+	reportCardCmd.Flags().String("turma", "", "ID ou nome da turma (um boletim por aluno ativo).")
+	reportCardCmd.Flags().String("comentario", "", "Comentário do(a) professor(a) a gravar no boletim do aluno de --aluno.")
+	reportCardCmd.Flags().String("saida", "", "Arquivo de saída, .html ou .pdf (padrão: HTML na saída padrão).")
+
+	reportCmd.AddCommand(reportCardCmd)
+}
@@ -0,0 +1,325 @@
+// Este arquivo (calendario.go) define o comando 'calendario', que mantém o calendário escolar:
+// as datas dos bimestres e os dias sem aula (feriados, recessos e dias de planejamento).
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendario",
+	Short: "Gerencia o calendário escolar: bimestres, feriados, recessos e dias de planejamento",
+	Long: `O comando 'calendario' mantém as datas de início e fim de cada bimestre do ano letivo e os dias
+sem aula: feriados (além dos nacionais, que são sempre considerados), recessos e dias de planejamento.
+O calendário é consultado automaticamente: 'horario gerar' pula os dias sem aula, 'avaliacao criar --data'
+define o bimestre pela data e o painel mostra o bimestre e a semana atuais.
+O calendário pode ser importado de um arquivo CSV ou iCalendar (.ics) com 'calendario importar'.`,
+	Example: `  vigenda calendario bimestre 1 --inicio 2025-02-03 --fim 2025-04-11
+  vigenda calendario adicionar --tipo recesso --inicio 2025-07-14 --fim 2025-07-25 --descricao "Recesso de julho"
+  vigenda calendario importar calendario-2025.csv
+  vigenda calendario listar --ano 2025
+  vigenda calendario hoje`,
+}
+
+var calendarListCmd = &cobra.Command{
+	Use:   "listar",
+	Short: "Lista os bimestres e os dias sem aula de um ano letivo",
+	Long: `Lista, em ordem de data, os bimestres, os eventos cadastrados e os feriados nacionais do ano
+informado em --ano (padrão: ano atual). Os feriados nacionais não têm ID e não podem ser removidos.`,
+	Example: `  vigenda calendario listar
+  vigenda calendario listar --ano 2025 --formato csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, _ := cmd.Flags().GetInt("ano")
+		if year == 0 {
+			year = time.Now().Year()
+		}
+		ctx := context.Background()
+		terms, err := calendarService.ListTerms(ctx, year)
+		if err != nil {
+			return fmt.Errorf("erro ao listar bimestres: %w", err)
+		}
+		from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+		events, err := calendarService.ListEvents(ctx, from, from.AddDate(1, 0, -1))
+		if err != nil {
+			return fmt.Errorf("erro ao listar eventos do calendário: %w", err)
+		}
+		return writeCalendar(os.Stdout, fmt.Sprintf("CALENDÁRIO ESCOLAR %d", year), terms, events, service.NationalHolidays(year))
+	},
+}
+
+var calendarTermCmd = &cobra.Command{
+	Use:   "bimestre [numero]",
+	Short: "Define as datas de início e fim de um bimestre",
+	Long: `Grava as datas de um bimestre (1 a 4). O ano letivo é o da data de início; se o bimestre já
+estiver cadastrado nesse ano, suas datas são substituídas. Bimestres não podem se sobrepor.`,
+	Example: `  vigenda calendario bimestre 2 --inicio 2025-04-22 --fim 2025-07-04`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		number, err := strconv.Atoi(args[0])
+		if err != nil {
+			return service.ValidationErrorf("bimestre inválido '%s': informe um número de 1 a 4", args[0])
+		}
+		startStr, _ := cmd.Flags().GetString("inicio")
+		endStr, _ := cmd.Flags().GetString("fim")
+		start, err := parseDateFlag("inicio", startStr)
+		if err != nil {
+			return err
+		}
+		end, err := parseDateFlag("fim", endStr)
+		if err != nil {
+			return err
+		}
+
+		term, err := calendarService.SetTerm(context.Background(), number, start, end)
+		if err != nil {
+			return fmt.Errorf("erro ao gravar bimestre: %w", err)
+		}
+		fmt.Printf("%s de %d (ID: %d): %s a %s.\n", service.TermLabel(term.Number), term.Year, term.ID,
+			term.StartDate.Format("02/01/2006"), term.EndDate.Format("02/01/2006"))
+		return nil
+	},
+}
+
+var calendarAddCmd = &cobra.Command{
+	Use:   "adicionar",
+	Short: "Adiciona um feriado, recesso ou dia de planejamento",
+	Long: `Adiciona um evento sem aula ao calendário. --fim é opcional para eventos de um dia só.
+Tipos: feriado (ex: feriados municipais), recesso e planejamento (dias de formação ou conselho de classe).`,
+	Example: `  vigenda calendario adicionar --tipo feriado --inicio 2025-06-13 --descricao "Santo Antônio"
+  vigenda calendario adicionar --tipo recesso --inicio 2025-07-14 --fim 2025-07-25 --descricao "Recesso de julho"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, _ := cmd.Flags().GetString("tipo")
+		description, _ := cmd.Flags().GetString("descricao")
+		startStr, _ := cmd.Flags().GetString("inicio")
+		endStr, _ := cmd.Flags().GetString("fim")
+		start, err := parseDateFlag("inicio", startStr)
+		if err != nil {
+			return err
+		}
+		var end time.Time
+		if endStr != "" {
+			if end, err = parseDateFlag("fim", endStr); err != nil {
+				return err
+			}
+		}
+
+		event, err := calendarService.AddEvent(context.Background(), kind, description, start, end)
+		if err != nil {
+			return fmt.Errorf("erro ao adicionar evento ao calendário: %w", err)
+		}
+		fmt.Printf("Evento '%s' (%s, ID: %d) adicionado: %s.\n", event.Description, event.Kind, event.ID, formatCalendarPeriod(event.StartDate, event.EndDate))
+		return nil
+	},
+}
+
+var calendarDeleteCmd = &cobra.Command{
+	Use:   "remover [ID]",
+	Short: "Remove um evento (ou, com --bimestre, um bimestre) do calendário",
+	Example: `  vigenda calendario remover 7
+  vigenda calendario remover 2 --bimestre`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		isTerm, _ := cmd.Flags().GetBool("bimestre")
+		label := "evento"
+		if isTerm {
+			label = "bimestre"
+		}
+		id, err := parseIDArg(args[0], label)
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		if isTerm {
+			err = calendarService.DeleteTerm(ctx, id)
+		} else {
+			err = calendarService.DeleteEvent(ctx, id)
+		}
+		if err != nil {
+			return fmt.Errorf("erro ao remover %s: %w", label, err)
+		}
+		if isTerm {
+			fmt.Printf("Bimestre ID %d removido do calendário.\n", id)
+		} else {
+			fmt.Printf("Evento ID %d removido do calendário.\n", id)
+		}
+		return nil
+	},
+}
+
+var calendarImportCmd = &cobra.Command{
+	Use:   "importar [arquivo]",
+	Short: "Importa o calendário escolar de um arquivo CSV ou iCalendar (.ics)",
+	Long: `Importa bimestres e dias sem aula. O formato é deduzido da extensão (.csv ou .ics).
+
+CSV: colunas tipo, inicio, fim e descricao, separadas por vírgula ou ponto e vírgula, com
+cabeçalho opcional. O tipo é bimestre, feriado, recesso ou planejamento; datas em AAAA-MM-DD
+ou DD/MM/AAAA; fim vazio indica um dia só. Para bimestres, a descrição é o número (1 a 4).
+
+    tipo,inicio,fim,descricao
+    bimestre,2025-02-03,2025-04-11,1
+    recesso,2025-07-14,2025-07-25,Recesso de julho
+    planejamento,2025-03-14,,Conselho de classe
+
+iCalendar: cada VEVENT vira um evento; o tipo vem de CATEGORIES ou do título (SUMMARY):
+"2º bimestre" define um bimestre, "recesso"/"férias" um recesso, "planejamento"/"formação"/
+"conselho" um dia de planejamento e os demais são feriados.
+
+Se alguma linha for inválida, nada é importado. Bimestres já cadastrados são substituídos e
+eventos repetidos são ignorados, então importar o mesmo arquivo de novo não duplica nada.`,
+	Example: `  vigenda calendario importar calendario-2025.csv
+  vigenda calendario importar calendario-rede.ics`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := args[0]
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return service.ValidationErrorf("não foi possível ler o arquivo '%s': %v", path, err)
+		}
+		format := filepath.Ext(path)
+		result, err := calendarService.Import(context.Background(), data, format)
+		if err != nil {
+			return fmt.Errorf("erro ao importar calendário: %w", err)
+		}
+		fmt.Printf("Calendário importado: %d bimestres, %d eventos", result.Terms, result.Events)
+		if result.Duplicates > 0 {
+			fmt.Printf(" (%d eventos já cadastrados foram ignorados)", result.Duplicates)
+		}
+		fmt.Println(".")
+		return nil
+	},
+}
+
+var calendarTodayCmd = &cobra.Command{
+	Use:   "hoje",
+	Short: "Mostra o bimestre e a semana de hoje (ou de --data)",
+	Example: `  vigenda calendario hoje
+  vigenda calendario hoje --data 2025-09-15`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		day := time.Now()
+		if dateStr, _ := cmd.Flags().GetString("data"); dateStr != "" {
+			var err error
+			if day, err = parseDateFlag("data", dateStr); err != nil {
+				return err
+			}
+		}
+		label, err := calendarService.DescribeDate(context.Background(), day)
+		if err != nil {
+			return fmt.Errorf("erro ao consultar calendário: %w", err)
+		}
+		if label == "" {
+			label = "fora do período letivo"
+		}
+		fmt.Printf("%s: %s\n", day.Format("02/01/2006"), label)
+		return nil
+	},
+}
+
+// calendarEntryOutput é uma linha da listagem do calendário no formato json.
+type calendarEntryOutput struct {
+	ID          int64  `json:"id,omitempty"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+}
+
+// writeCalendar escreve bimestres, eventos e feriados nacionais numa só listagem, em ordem de data.
+func writeCalendar(w io.Writer, header string, terms []models.AcademicTerm, events []models.CalendarEvent, holidays []service.Holiday) error {
+	entries := []calendarEntryOutput{}
+	for _, term := range terms {
+		entries = append(entries, calendarEntryOutput{ID: term.ID, Kind: "bimestre", Description: service.TermLabel(term.Number),
+			StartDate: term.StartDate.Format("2006-01-02"), EndDate: term.EndDate.Format("2006-01-02")})
+	}
+	for _, event := range events {
+		entries = append(entries, calendarEntryOutput{ID: event.ID, Kind: event.Kind, Description: event.Description,
+			StartDate: event.StartDate.Format("2006-01-02"), EndDate: event.EndDate.Format("2006-01-02")})
+	}
+	for _, holiday := range holidays {
+		day := holiday.Date.Format("2006-01-02")
+		entries = append(entries, calendarEntryOutput{Kind: "feriado nacional", Description: holiday.Name, StartDate: day, EndDate: day})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].StartDate < entries[j].StartDate })
+
+	out := listOutput{
+		Header: header,
+		Columns: []table.Column{
+			{Title: "ID", Width: 4}, {Title: "TIPO", Width: 16}, {Title: "PERÍODO", Width: 25}, {Title: "DESCRIÇÃO", Width: 30},
+		},
+		Data: entries,
+	}
+	for _, entry := range entries {
+		id := ""
+		if entry.ID != 0 {
+			id = strconv.FormatInt(entry.ID, 10)
+		}
+		start, _ := time.Parse("2006-01-02", entry.StartDate)
+		end, _ := time.Parse("2006-01-02", entry.EndDate)
+		out.Rows = append(out.Rows, table.Row{id, entry.Kind, formatCalendarPeriod(start, end), entry.Description})
+	}
+	return writeList(w, out)
+}
+
+// formatCalendarPeriod formata um período como "14/07/2025 a 25/07/2025", ou só a data se for um dia.
+func formatCalendarPeriod(start, end time.Time) string {
+	if start.Equal(end) {
+		return start.Format("02/01/2006")
+	}
+	return start.Format("02/01/2006") + " a " + end.Format("02/01/2006")
+}
+
+// findTerm busca o bimestre 'number' do ano letivo 'year' (0 para o ano atual) no calendário escolar.
+func findTerm(ctx context.Context, number, year int) (models.AcademicTerm, error) {
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	terms, err := calendarService.ListTerms(ctx, year)
+	if err != nil {
+		return models.AcademicTerm{}, fmt.Errorf("erro ao consultar calendário escolar: %w", err)
+	}
+	for _, term := range terms {
+		if term.Number == number {
+			return term, nil
+		}
+	}
+	return models.AcademicTerm{}, service.NotFoundErrorf("o %s de %d não está no calendário escolar: use 'vigenda calendario bimestre %d --inicio ... --fim ...'",
+		service.TermLabel(number), year, number)
+}
+
+func init() {
+	calendarListCmd.Flags().Int("ano", 0, "Ano letivo (padrão: ano atual).")
+
+	calendarTermCmd.Flags().String("inicio", "", "Primeiro dia do bimestre, AAAA-MM-DD (obrigatório).")
+	_ = calendarTermCmd.MarkFlagRequired("inicio")
+	calendarTermCmd.Flags().String("fim", "", "Último dia do bimestre, AAAA-MM-DD (obrigatório).")
+	_ = calendarTermCmd.MarkFlagRequired("fim")
+
+	calendarAddCmd.Flags().String("tipo", "", "Tipo do evento: feriado, recesso ou planejamento (obrigatório).")
+	_ = calendarAddCmd.MarkFlagRequired("tipo")
+	calendarAddCmd.Flags().String("inicio", "", "Primeiro dia do evento, AAAA-MM-DD (obrigatório).")
+	_ = calendarAddCmd.MarkFlagRequired("inicio")
+	calendarAddCmd.Flags().String("fim", "", "Último dia do evento, AAAA-MM-DD (padrão: o mesmo de --inicio).")
+	calendarAddCmd.Flags().String("descricao", "", "Descrição do evento (obrigatório).")
+	_ = calendarAddCmd.MarkFlagRequired("descricao")
+
+	calendarDeleteCmd.Flags().Bool("bimestre", false, "O ID informado é de um bimestre, não de um evento.")
+
+	calendarTodayCmd.Flags().String("data", "", "Dia a consultar, AAAA-MM-DD (padrão: hoje).")
+
+	calendarCmd.AddCommand(calendarListCmd, calendarTermCmd, calendarAddCmd, calendarDeleteCmd, calendarImportCmd, calendarTodayCmd)
+	rootCmd.AddCommand(calendarCmd)
+}
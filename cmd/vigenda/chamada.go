@@ -0,0 +1,250 @@
+// Este arquivo (chamada.go) define o comando 'chamada', que registra a frequência dos alunos em
+// cada aula e totaliza as faltas por bimestre.
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+var attendanceCmd = &cobra.Command{
+	Use:   "chamada",
+	Short: "Registra e consulta a frequência dos alunos (registrar, ver, resumo)",
+	Long: `O comando 'chamada' registra a frequência de cada aluno nas aulas: presente, falta, atraso ou
+falta justificada. 'chamada resumo' totaliza as faltas de cada aluno em um bimestre, com a
+porcentagem usada para acompanhar a frequência mínima de 75%.`,
+	Example: `  vigenda chamada registrar 12
+  vigenda chamada registrar 12 --faltas 3,7 --atrasos 5
+  vigenda chamada ver 12
+  vigenda chamada resumo --turma "Turma 9A" --bimestre 1`,
+}
+
+var attendanceRecordCmd = &cobra.Command{
+	Use:   "registrar [ID_da_aula]",
+	Short: "Registra a chamada de uma aula",
+	Long: `Registra a frequência dos alunos na aula. Com --faltas, --atrasos e/ou --justificadas (IDs dos
+alunos separados por vírgula), os demais alunos da lista de chamada são registrados como presentes.
+Sem essas flags, a chamada é feita aluno a aluno: responda p (presente), f (falta), a (atraso) ou
+j (justificada); enter mantém a situação já registrada ou, se não houver, registra presença.`,
+	Example: `  vigenda chamada registrar 12
+  vigenda chamada registrar 12 --faltas 3,7 --atrasos 5 --justificadas 9`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lessonID, err := parseIDArg(args[0], "aula")
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		lesson, entries, err := attendanceService.GetRollCall(ctx, lessonID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar a chamada: %w", err)
+		}
+		if len(entries) == 0 {
+			return service.ValidationErrorf("a turma da aula '%s' não tem alunos ativos", lesson.Title)
+		}
+
+		flags := cmd.Flags()
+		var statuses map[int64]string
+		if flags.Changed("faltas") || flags.Changed("atrasos") || flags.Changed("justificadas") {
+			statuses, err = attendanceFromFlags(cmd, entries)
+		} else if isTableOutput() {
+			fmt.Printf("Chamada de '%s' (%s) — p: presente, f: falta, a: atraso, j: justificada\n", lesson.Title, formatLessonDate(lesson.ScheduledAt))
+			statuses, err = promptAttendance(os.Stdout, os.Stdin, entries)
+		} else {
+			err = service.ValidationErrorf("informe --faltas, --atrasos ou --justificadas para registrar a chamada com --formato %s", outputFormat)
+		}
+		if err != nil {
+			return err
+		}
+		if err := attendanceService.RecordAttendance(ctx, lessonID, statuses); err != nil {
+			return fmt.Errorf("erro ao registrar a chamada: %w", err)
+		}
+		counts := make(map[string]int)
+		for _, status := range statuses {
+			counts[status]++
+		}
+		fmt.Printf("Chamada da aula ID %d registrada: %d presente(s), %d falta(s), %d atraso(s), %d justificada(s).\n", lessonID,
+			counts[models.AttendancePresent], counts[models.AttendanceAbsent], counts[models.AttendanceLate], counts[models.AttendanceJustified])
+		return nil
+	},
+}
+
+var attendanceShowCmd = &cobra.Command{
+	Use:     "ver [ID_da_aula]",
+	Short:   "Mostra a chamada de uma aula",
+	Example: `  vigenda chamada ver 12`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lessonID, err := parseIDArg(args[0], "aula")
+		if err != nil {
+			return err
+		}
+		lesson, entries, err := attendanceService.GetRollCall(context.Background(), lessonID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar a chamada: %w", err)
+		}
+		columns := []table.Column{
+			{Title: "ID", Width: 4},
+			{Title: "ALUNO", Width: 35},
+			{Title: "MATRÍCULA", Width: 10},
+			{Title: "SITUAÇÃO", Width: 12},
+		}
+		rows := []table.Row{}
+		for _, entry := range entries {
+			status := entry.Status
+			if status == "" {
+				status = "-"
+			}
+			rows = append(rows, table.Row{fmt.Sprintf("%d", entry.Student.ID), entry.Student.FullName, entry.Student.EnrollmentID, status})
+		}
+		header := fmt.Sprintf("CHAMADA: %s · %s", lesson.Title, formatLessonDate(lesson.ScheduledAt))
+		return writeList(os.Stdout, listOutput{Header: header, Columns: columns, Rows: rows, Data: entries})
+	},
+}
+
+var attendanceSummaryCmd = &cobra.Command{
+	Use:   "resumo",
+	Short: "Totaliza as faltas de cada aluno em um bimestre ou período",
+	Long: `Mostra, para cada aluno da turma, as aulas com chamada registrada, presenças, atrasos e faltas
+(sem e com justificativa) no bimestre de --bimestre (do ano de --ano, padrão o ano atual) ou entre
+--de e --ate. Atrasos contam como presença; faltas justificadas contam como falta. Alunos com mais
+de 25% de faltas, abaixo da frequência mínima de 75%, são marcados com '!'.`,
+	Example: `  vigenda chamada resumo --turma "Turma 9A" --bimestre 1
+  vigenda chamada resumo --turma 1 --de 2025-02-03 --ate 2025-04-30 --formato csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		classArg, _ := cmd.Flags().GetString("turma")
+		ctx := context.Background()
+		class, err := resolveClass(ctx, classArg)
+		if err != nil {
+			return err
+		}
+		from, to, period, err := reportPeriod(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		summaries, err := attendanceService.GetAbsenceSummary(ctx, class.ID, from, to)
+		if err != nil {
+			return fmt.Errorf("erro ao totalizar a frequência: %w", err)
+		}
+		if len(summaries) == 0 && isTableOutput() {
+			fmt.Printf("Nenhum aluno ativo em %s.\n", class.Name)
+			return nil
+		}
+		return writeAttendanceSummary(os.Stdout, fmt.Sprintf("FREQUÊNCIA: %s · %s", class.Name, period), summaries)
+	},
+}
+
+// writeAttendanceSummary escreve os totais de frequência no formato de --formato.
+func writeAttendanceSummary(w io.Writer, header string, summaries []service.AttendanceSummary) error {
+	columns := []table.Column{
+		{Title: "ID", Width: 4},
+		{Title: "ALUNO", Width: 30},
+		{Title: "AULAS", Width: 5},
+		{Title: "PRESENÇAS", Width: 9},
+		{Title: "ATRASOS", Width: 7},
+		{Title: "FALTAS", Width: 6},
+		{Title: "JUSTIFICADAS", Width: 12},
+		{Title: "% FALTAS", Width: 8},
+	}
+	rows := []table.Row{}
+	for _, summary := range summaries {
+		percent := fmt.Sprintf("%.1f%%", summary.AbsencePercent)
+		if summary.OverLimit() {
+			percent += " !"
+		}
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%d", summary.Student.ID),
+			summary.Student.FullName,
+			fmt.Sprintf("%d", summary.Lessons),
+			fmt.Sprintf("%d", summary.Present),
+			fmt.Sprintf("%d", summary.Late),
+			fmt.Sprintf("%d", summary.Absent),
+			fmt.Sprintf("%d", summary.Justified),
+			percent,
+		})
+	}
+	return writeList(w, listOutput{Header: header, Columns: columns, Rows: rows, Data: summaries})
+}
+
+// attendanceFromFlags monta a chamada a partir de --faltas, --atrasos e --justificadas; os demais
+// alunos da lista de chamada ficam presentes.
+func attendanceFromFlags(cmd *cobra.Command, entries []service.RollCallEntry) (map[int64]string, error) {
+	statuses := make(map[int64]string, len(entries))
+	for _, entry := range entries {
+		statuses[entry.Student.ID] = models.AttendancePresent
+	}
+	marked := make(map[int64]string)
+	for flag, status := range map[string]string{"faltas": models.AttendanceAbsent, "atrasos": models.AttendanceLate, "justificadas": models.AttendanceJustified} {
+		ids, _ := cmd.Flags().GetInt64Slice(flag)
+		for _, id := range ids {
+			if previous, ok := marked[id]; ok && previous != status {
+				return nil, service.ValidationErrorf("o aluno ID %d foi informado como %s e como %s", id, previous, status)
+			}
+			marked[id] = status
+			statuses[id] = status
+		}
+	}
+	return statuses, nil
+}
+
+// promptAttendance pergunta a situação de cada aluno, em ordem. Lê as respostas linha a linha, de um
+// terminal ou de um arquivo redirecionado para a entrada padrão.
+func promptAttendance(w io.Writer, r io.Reader, entries []service.RollCallEntry) (map[int64]string, error) {
+	scanner := bufio.NewScanner(r)
+	statuses := make(map[int64]string, len(entries))
+	for _, entry := range entries {
+		current := entry.Status
+		if current == "" {
+			current = models.AttendancePresent
+		}
+		for {
+			fmt.Fprintf(w, "%-35s [%s]: ", entry.Student.FullName, current)
+			if !scanner.Scan() {
+				if err := scanner.Err(); err != nil {
+					return nil, fmt.Errorf("erro ao ler a chamada: %w", err)
+				}
+				fmt.Fprintln(w)
+				return nil, service.ValidationErrorf("chamada interrompida antes do último aluno: nada foi registrado")
+			}
+			answer := strings.TrimSpace(scanner.Text())
+			if answer == "" {
+				statuses[entry.Student.ID] = current
+				break
+			}
+			status, err := service.ParseAttendanceStatus(answer)
+			if err != nil {
+				fmt.Fprintln(w, "  use p, f, a ou j")
+				continue
+			}
+			statuses[entry.Student.ID] = status
+			break
+		}
+	}
+	return statuses, nil
+}
+
+func init() {
+	attendanceRecordCmd.Flags().Int64Slice("faltas", nil, "IDs dos alunos que faltaram, separados por vírgula.")
+	attendanceRecordCmd.Flags().Int64Slice("atrasos", nil, "IDs dos alunos que chegaram atrasados.")
+	attendanceRecordCmd.Flags().Int64Slice("justificadas", nil, "IDs dos alunos com falta justificada.")
+
+	attendanceSummaryCmd.Flags().String("turma", "", "ID ou nome da turma (obrigatório).")
+	_ = attendanceSummaryCmd.MarkFlagRequired("turma")
+	attendanceSummaryCmd.Flags().Int("bimestre", 0, "Número do bimestre (1 a 4) do calendário escolar.")
+	attendanceSummaryCmd.Flags().Int("ano", 0, "Ano do bimestre (padrão: o ano atual).")
+	attendanceSummaryCmd.Flags().String("de", "", "Início do período, no formato AAAA-MM-DD.")
+	attendanceSummaryCmd.Flags().String("ate", "", "Fim do período, no formato AAAA-MM-DD.")
+
+	attendanceCmd.AddCommand(attendanceRecordCmd, attendanceShowCmd, attendanceSummaryCmd)
+	rootCmd.AddCommand(attendanceCmd)
+}
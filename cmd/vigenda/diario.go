@@ -0,0 +1,201 @@
+// Este arquivo (diario.go) define o comando 'relatorio diario', que reúne em um só documento o que é
+// copiado para o diário de classe oficial ao fim de cada bimestre.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/report"
+	"vigenda/internal/service"
+)
+
+// diaryGridColumns é o número máximo de datas por grade de frequência impressa; bimestres com mais
+// aulas são divididos em várias grades para as colunas continuarem legíveis.
+const diaryGridColumns = 20
+
+var reportDiaryCmd = &cobra.Command{
+	Use:   "diario",
+	Short: "Gera o diário de classe de um bimestre (HTML/PDF/CSV)",
+	Long: `Gera o diário de classe de uma turma no bimestre de --bimestre (do ano de --ano, padrão o ano
+atual), no formato pedido pelas secretarias:
+
+  1. Frequência: a grade de chamada em branco, com uma coluna por data de aula do bimestre
+  2. Conteúdo ministrado: data e conteúdo de cada aula ministrada ('vigenda aula registrar')
+  3. Avaliações: nome, data e peso das avaliações do bimestre
+  4. Médias: a média ponderada do bimestre de cada aluno
+
+O arquivo de --saida é gravado em HTML, PDF ou CSV conforme a extensão; sem --saida, o HTML (ou o
+CSV, com --formato csv) é escrito na saída padrão. O cabeçalho usa o nome da escola de
+VIGENDA_ESCOLA e o do(a) professor(a) de VIGENDA_PROFESSOR.`,
+	Example: `  vigenda relatorio diario --turma "Turma 9A" --bimestre 2 --saida diario-9a-2bim.pdf
+  vigenda relatorio diario --turma 1 --bimestre 2 --saida diario.csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		classArg, _ := flags.GetString("turma")
+		termNumber, _ := flags.GetInt("bimestre")
+		year, _ := flags.GetInt("ano")
+		output, _ := flags.GetString("saida")
+		format, err := reportFormat(output)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		class, err := resolveClass(ctx, classArg)
+		if err != nil {
+			return err
+		}
+		term, err := findTerm(ctx, termNumber, year)
+		if err != nil {
+			return err
+		}
+		doc, err := classDiary(ctx, class, term, format != report.FormatCSV)
+		if err != nil {
+			return err
+		}
+		return writeReport(output, doc)
+	},
+}
+
+// classDiary monta o diário de classe da turma no bimestre. Com 'split', a grade de frequência é
+// dividida em grades de até diaryGridColumns datas, para a impressão.
+func classDiary(ctx context.Context, class models.Class, term models.AcademicTerm, split bool) (report.Document, error) {
+	students, err := classService.GetStudentsByClassID(ctx, class.ID)
+	if err != nil {
+		return report.Document{}, fmt.Errorf("erro ao carregar alunos: %w", err)
+	}
+	service.SortStudentsByEnrollment(students)
+
+	lessons, err := lessonService.GetLessonsByClassID(ctx, class.ID)
+	if err != nil {
+		return report.Document{}, fmt.Errorf("erro ao carregar aulas: %w", err)
+	}
+	sort.SliceStable(lessons, func(i, j int) bool { return lessons[i].ScheduledAt.Before(lessons[j].ScheduledAt) })
+	end := term.EndDate.AddDate(0, 0, 1)
+	var dates []report.Column
+	for _, lesson := range lessons {
+		if lesson.ScheduledAt.Before(term.StartDate) || !lesson.ScheduledAt.Before(end) || lesson.Status == models.LessonStatusCancelled {
+			continue
+		}
+		dates = append(dates, report.Column{Title: lesson.ScheduledAt.Format("02/01"), Width: 1, Align: report.AlignCenter})
+	}
+
+	doc := printableDocument("Diário de Classe", class, fmt.Sprintf("Período: %s de %d (%s)",
+		service.TermLabel(term.Number), term.Year, formatCalendarPeriod(term.StartDate, term.EndDate)))
+	doc.Landscape = true
+	doc.Sections = append(doc.Sections, diaryAttendance(students, dates, split)...)
+
+	delivered, err := lessonService.GetDeliveredContent(ctx, class.ID, term.StartDate, term.EndDate)
+	if err != nil {
+		return report.Document{}, fmt.Errorf("erro ao carregar o conteúdo ministrado: %w", err)
+	}
+	content := report.Section{Heading: "Conteúdo ministrado", Table: &report.Table{Columns: []report.Column{
+		{Title: "DATA", Width: 1.2}, {Title: "AULA", Width: 3}, {Title: "CONTEÚDO MINISTRADO", Width: 8},
+	}}}
+	for _, lesson := range delivered {
+		covered := lesson.CoveredContent
+		if lesson.Status == models.LessonStatusPartial {
+			covered += " (parcial)"
+		}
+		content.Table.Rows = append(content.Table.Rows, report.Row{Cells: []string{lesson.ScheduledAt.Format("02/01/2006"), lesson.Title, covered}})
+	}
+	if len(delivered) == 0 {
+		content.Notes = []string{"Nenhuma aula ministrada registrada no bimestre."}
+	}
+	doc.Sections = append(doc.Sections, content)
+
+	assessments, err := assessmentService.ListAllAssessments(ctx)
+	if err != nil {
+		return report.Document{}, fmt.Errorf("erro ao carregar avaliações: %w", err)
+	}
+	assessmentSection := report.Section{Heading: "Avaliações", Table: &report.Table{Columns: []report.Column{
+		{Title: "AVALIAÇÃO", Width: 6}, {Title: "DATA", Width: 1.5, Align: report.AlignCenter}, {Title: "PESO", Width: 1, Align: report.AlignCenter},
+	}}}
+	for _, assessment := range assessments {
+		if assessment.ClassID != class.ID || assessment.Term != term.Number {
+			continue
+		}
+		date := ""
+		if assessment.AssessmentDate != nil {
+			date = assessment.AssessmentDate.Format("02/01/2006")
+		}
+		name, weight := assessment.Name, fmt.Sprintf("%.1f", assessment.Weight)
+		if service.IsRecovery(assessment) {
+			name, weight = name+" (recuperação)", "-"
+		}
+		assessmentSection.Table.Rows = append(assessmentSection.Table.Rows, report.Row{Cells: []string{name, date, weight}})
+	}
+	if len(assessmentSection.Table.Rows) == 0 {
+		assessmentSection.Notes = []string{"Nenhuma avaliação cadastrada no bimestre."}
+	}
+	doc.Sections = append(doc.Sections, assessmentSection)
+
+	averages, err := assessmentService.CalculateClassAverage(ctx, class.ID, []int{term.Number})
+	if err != nil && !errors.Is(err, service.ErrNotFound) {
+		return report.Document{}, fmt.Errorf("erro ao calcular as médias: %w", err)
+	}
+	policy, err := assessmentService.GetGradingPolicy(ctx, class.ID)
+	if err != nil {
+		return report.Document{}, fmt.Errorf("erro ao carregar a política de notas: %w", err)
+	}
+	averageSection := report.Section{Heading: "Médias do bimestre", Table: rosterTable([]report.Column{{Title: "MÉDIA", Width: 1.2, Align: report.AlignCenter}}, 0)}
+	fillRoster(averageSection.Table, students)
+	for i, student := range students {
+		average := "-"
+		if value, ok := averages[student.ID]; ok {
+			average = formatAverage(policy, value)
+		}
+		averageSection.Table.Rows[i].Cells = append(averageSection.Table.Rows[i].Cells, average)
+	}
+	doc.Sections = append(doc.Sections, averageSection)
+	doc.Footer = []string{
+		"Assinatura do(a) professor(a): ________________________________________",
+		"Visto da coordenação: ________________________________________   Data: ____/____/________",
+	}
+	return doc, nil
+}
+
+// diaryAttendance monta a grade de frequência em branco do diário, com a coluna de total de faltas.
+func diaryAttendance(students []models.Student, dates []report.Column, split bool) []report.Section {
+	if len(dates) == 0 {
+		return []report.Section{{Heading: "Frequência", Notes: []string{"Nenhuma aula no bimestre."}}}
+	}
+	chunk := len(dates)
+	if split && chunk > diaryGridColumns {
+		chunk = diaryGridColumns
+	}
+	parts := (len(dates) + chunk - 1) / chunk
+	var sections []report.Section
+	for part := 0; part < parts; part++ {
+		columns := append([]report.Column{}, dates[part*chunk:min((part+1)*chunk, len(dates))]...)
+		heading := "Frequência"
+		if parts > 1 {
+			heading = fmt.Sprintf("Frequência (%d/%d)", part+1, parts)
+		}
+		if part == parts-1 {
+			columns = append(columns, report.Column{Title: "FALTAS", Width: 1.3, Align: report.AlignCenter})
+		}
+		table := rosterTable(columns, 0)
+		fillRoster(table, students)
+		sections = append(sections, report.Section{Heading: heading, Table: table})
+	}
+	sections[len(sections)-1].Notes = []string{"P: presente · F: falta · A: atraso · J: falta justificada"}
+	return sections
+}
+
+func init() {
+	reportDiaryCmd.Flags().String("turma", "", "ID ou nome da turma (obrigatório).")
+	_ = reportDiaryCmd.MarkFlagRequired("turma")
+	reportDiaryCmd.Flags().Int("bimestre", 0, "Número do bimestre (1 a 4) do calendário escolar (obrigatório).")
+	_ = reportDiaryCmd.MarkFlagRequired("bimestre")
+	reportDiaryCmd.Flags().Int("ano", 0, "Ano do bimestre (padrão: o ano atual).")
+	reportDiaryCmd.Flags().String("saida", "", "Arquivo de saída, .html, .pdf ou .csv (padrão: HTML na saída padrão).")
+
+	reportCmd.AddCommand(reportDiaryCmd)
+}
@@ -0,0 +1,144 @@
+// Este arquivo (errors.go) define como a CLI reporta erros: mensagem em pt-BR no stderr,
+// código de saída por categoria de erro (ver service.ErrorCategory) e a flag global --debug,
+// que mostra a cadeia completa de erros encapsulados.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"vigenda/internal/service"
+)
+
+// Códigos de saída do processo.
+const (
+	exitOK         = 0
+	exitFailure    = 1 // Erro não categorizado.
+	exitValidation = 2 // Argumentos, flags ou dados de entrada inválidos.
+	exitNotFound   = 3 // Registro referenciado não existe.
+	exitConflict   = 4 // Operação conflita com o estado atual (duplicado, já concluído etc.).
+	exitStorage    = 5 // Falha ao ler ou gravar no banco de dados.
+)
+
+// debugErrors guarda o valor da flag global --debug.
+var debugErrors bool
+
+// exitCodeFor retorna o código de saída correspondente à categoria de 'err'.
+func exitCodeFor(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	switch service.CategoryOf(err) {
+	case service.CategoryValidation:
+		return exitValidation
+	case service.CategoryNotFound:
+		return exitNotFound
+	case service.CategoryConflict:
+		return exitConflict
+	case service.CategoryStorage:
+		return exitStorage
+	default:
+		return exitFailure
+	}
+}
+
+// reportError escreve 'err' em 'w' no formato "Erro: <mensagem>". Erros de validação vêm com
+// a indicação do --help do comando; com --debug, a categoria e cada nível da cadeia são listados.
+func reportError(w io.Writer, cmd *cobra.Command, err error) {
+	category := service.CategoryOf(err)
+	fmt.Fprintf(w, "Erro: %s\n", userMessage(err, category))
+
+	if category == service.CategoryValidation && cmd != nil {
+		fmt.Fprintf(w, "Use \"%s --help\" para ver o uso do comando.\n", cmd.CommandPath())
+	}
+	if !debugErrors {
+		if category == service.CategoryStorage || category == service.CategoryUnknown {
+			fmt.Fprintln(w, "Use --debug para ver os detalhes.")
+		}
+		return
+	}
+	fmt.Fprintf(w, "Categoria: %s (código de saída %d)\n", category, exitCodeFor(err))
+	fmt.Fprintln(w, "Cadeia de erros:")
+	for level := err; level != nil; level = errors.Unwrap(level) {
+		fmt.Fprintf(w, "  - %s\n", level.Error())
+	}
+}
+
+// categorySentinels descreve cada categoria quando o erro não traz uma mensagem de serviço.
+var categorySentinels = map[service.ErrorCategory]error{
+	service.CategoryValidation: service.ErrValidation,
+	service.CategoryNotFound:   service.ErrNotFound,
+	service.CategoryConflict:   service.ErrConflict,
+	service.CategoryStorage:    service.ErrStorage,
+}
+
+// userMessage monta a mensagem curta para o usuário. Erros de serviço usam a própria Message;
+// erros classificados pelo driver do banco mostram só o contexto mais externo (ex: "erro ao
+// concluir tarefa ID 9") seguido da descrição da categoria, deixando os detalhes para --debug.
+func userMessage(err error, category service.ErrorCategory) string {
+	var serviceErr *service.Error
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Message
+	}
+	sentinel, ok := categorySentinels[category]
+	if !ok {
+		return err.Error()
+	}
+	inner := errors.Unwrap(err)
+	if inner == nil {
+		return sentinel.Error()
+	}
+	return strings.TrimSuffix(err.Error(), ": "+inner.Error()) + ": " + sentinel.Error()
+}
+
+// usageError marca erros de uso do cobra (flags desconhecidas, número de argumentos) como validação.
+func usageError(err error) error {
+	return &service.Error{Category: service.CategoryValidation, Message: err.Error()}
+}
+
+// cobraUsagePrefixes são os inícios das mensagens de erro de uso geradas pelo próprio cobra
+// fora dos pontos de extensão (flags obrigatórias, subcomando desconhecido).
+var cobraUsagePrefixes = []string{"required flag(s)", "unknown command", "if any flags in the group"}
+
+// normalizeCobraError converte os erros de uso gerados pelo cobra em erros de validação.
+func normalizeCobraError(err error) error {
+	if err == nil || service.CategoryOf(err) != service.CategoryUnknown {
+		return err
+	}
+	for _, prefix := range cobraUsagePrefixes {
+		if strings.HasPrefix(err.Error(), prefix) {
+			return usageError(err)
+		}
+	}
+	return err
+}
+
+// wrapArgsValidation faz com que os validadores de argumentos de 'cmd' e de todos os seus
+// subcomandos devolvam erros de validação. Deve ser chamada depois de todos os init().
+func wrapArgsValidation(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(c *cobra.Command, args []string) error {
+			if err := validate(c, args); err != nil {
+				return usageError(err)
+			}
+			return nil
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		wrapArgsValidation(sub)
+	}
+}
+
+// parseIDArg interpreta um ID numérico informado pelo usuário. 'label' descreve o ID na mensagem
+// de erro (ex: "tarefa", "turma").
+func parseIDArg(value, label string) (int64, error) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, service.ValidationErrorf("ID de %s inválido '%s': informe um número inteiro positivo", label, value)
+	}
+	return id, nil
+}
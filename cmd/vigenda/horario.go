@@ -0,0 +1,380 @@
+// Este arquivo (horario.go) define o comando 'horario', que mantém o horário semanal das turmas
+// e gera as aulas de um período (bimestre, semestre) a partir dele.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// defaultSlotMinutes é a duração padrão de um horário: uma hora-aula.
+var defaultSlotMinutes = int(service.DefaultLessonDuration / time.Minute)
+
+var timetableCmd = &cobra.Command{
+	Use:   "horario",
+	Short: "Gerencia o horário semanal e gera as aulas de um período (listar, adicionar, editar, remover, gerar)",
+	Long: `O comando 'horario' mantém o horário semanal: em que dia, a que horas e por quanto tempo cada turma tem aula.
+A partir dele, 'horario gerar' cria as aulas de todo um período, pulando feriados e os demais dias sem
+aula do calendário escolar ('vigenda calendario'), os dias informados em --pular e os horários em que
+já existe aula. Horários que se sobrepõem são recusados.`,
+	Example: `  vigenda horario adicionar --turma 1 --dia seg --inicio 07:00 --duracao 50
+  vigenda horario listar
+  vigenda horario gerar --de 2025-02-03 --ate 2025-04-30 --simular
+  vigenda horario gerar --bimestre 2 --pular 2025-05-02`,
+}
+
+var timetableListCmd = &cobra.Command{
+	Use:     "listar",
+	Short:   "Lista o horário semanal",
+	Example: `  vigenda horario listar`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		slots, err := timetableService.ListSlots(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao listar horário: %w", err)
+		}
+		if len(slots) == 0 && isTableOutput() {
+			fmt.Println("Nenhum horário cadastrado. Use 'vigenda horario adicionar'.")
+			return nil
+		}
+		if err := writeSlots(os.Stdout, "HORÁRIO SEMANAL", slots); err != nil {
+			return err
+		}
+		if !isTableOutput() {
+			return nil
+		}
+		overlaps, err := timetableService.FindOverlaps(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao verificar sobreposições: %w", err)
+		}
+		if len(overlaps) > 0 {
+			fmt.Println()
+			for _, overlap := range overlaps {
+				fmt.Printf("Atenção: os horários ID %d e ID %d se sobrepõem (%s).\n",
+					overlap.First.ID, overlap.Second.ID, service.WeekdayName(overlap.First.Weekday))
+			}
+		}
+		return nil
+	},
+}
+
+var timetableAddCmd = &cobra.Command{
+	Use:   "adicionar",
+	Short: "Adiciona um horário semanal para uma turma",
+	Long: `Adiciona um horário: dia da semana (--dia seg, ter, qua, qui, sex, sab, dom), hora de início
+(--inicio HH:MM) e duração em minutos (--duracao, padrão de uma hora-aula).`,
+	Example: `  vigenda horario adicionar --turma 1 --dia seg --inicio 07:00
+  vigenda horario adicionar --turma 2 --dia quarta --inicio 09:40 --duracao 100`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		classIDStr, _ := cmd.Flags().GetString("turma")
+		dayStr, _ := cmd.Flags().GetString("dia")
+		start, _ := cmd.Flags().GetString("inicio")
+		duration, _ := cmd.Flags().GetInt("duracao")
+
+		classID, err := parseIDArg(classIDStr, "turma")
+		if err != nil {
+			return err
+		}
+		weekday, err := service.ParseWeekday(dayStr)
+		if err != nil {
+			return err
+		}
+		slot, err := timetableService.AddSlot(context.Background(), classID, weekday, start, duration)
+		if err != nil {
+			return fmt.Errorf("erro ao adicionar horário: %w", err)
+		}
+		fmt.Printf("Horário ID %d adicionado: %s.\n", slot.ID, describeSlot(slot))
+		return nil
+	},
+}
+
+var timetableEditCmd = &cobra.Command{
+	Use:     "editar [ID_do_horario]",
+	Short:   "Altera dia, início ou duração de um horário",
+	Example: `  vigenda horario editar 3 --inicio 08:40`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		slotID, err := parseIDArg(args[0], "horário")
+		if err != nil {
+			return err
+		}
+		flags := cmd.Flags()
+		if !flags.Changed("dia") && !flags.Changed("inicio") && !flags.Changed("duracao") {
+			return service.ValidationErrorf("informe ao menos um campo para alterar: --dia, --inicio ou --duracao")
+		}
+
+		ctx := context.Background()
+		slots, err := timetableService.ListSlots(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar horário: %w", err)
+		}
+		var slot *models.TimetableSlot
+		for i := range slots {
+			if slots[i].ID == slotID {
+				slot = &slots[i]
+			}
+		}
+		if slot == nil {
+			return service.NotFoundErrorf("horário com ID %d não encontrado", slotID)
+		}
+
+		weekday, start, duration := slot.Weekday, slot.StartTime, slot.DurationMinutes
+		if flags.Changed("dia") {
+			dayStr, _ := flags.GetString("dia")
+			if weekday, err = service.ParseWeekday(dayStr); err != nil {
+				return err
+			}
+		}
+		if flags.Changed("inicio") {
+			start, _ = flags.GetString("inicio")
+		}
+		if flags.Changed("duracao") {
+			duration, _ = flags.GetInt("duracao")
+		}
+		updated, err := timetableService.UpdateSlot(ctx, slotID, weekday, start, duration)
+		if err != nil {
+			return fmt.Errorf("erro ao atualizar horário: %w", err)
+		}
+		fmt.Printf("Horário ID %d atualizado: %s.\n", updated.ID, describeSlot(updated))
+		return nil
+	},
+}
+
+var timetableDeleteCmd = &cobra.Command{
+	Use:     "remover [ID_do_horario]",
+	Short:   "Remove um horário (as aulas já geradas são mantidas)",
+	Example: `  vigenda horario remover 3`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		slotID, err := parseIDArg(args[0], "horário")
+		if err != nil {
+			return err
+		}
+		if err := timetableService.DeleteSlot(context.Background(), slotID); err != nil {
+			return fmt.Errorf("erro ao remover horário: %w", err)
+		}
+		fmt.Printf("Horário ID %d removido.\n", slotID)
+		return nil
+	},
+}
+
+var timetableGenerateCmd = &cobra.Command{
+	Use:   "gerar",
+	Short: "Gera as aulas de um período a partir do horário semanal",
+	Long: `Cria uma aula para cada horário em cada semana entre --de e --ate (inclusive), ou nas datas
+de um bimestre do calendário escolar com --bimestre (e --ano, se não for o ano atual).
+São pulados os feriados nacionais, os feriados, recessos e dias de planejamento do calendário escolar,
+os dias em --pular (lista separada por vírgulas) e os horários em que já existe uma aula, então gerar
+de novo o mesmo período não duplica aulas. Use --simular para ver o resultado sem criar nada.`,
+	Example: `  vigenda horario gerar --de 2025-02-03 --ate 2025-04-30 --simular
+  vigenda horario gerar --bimestre 2 --ano 2025
+  vigenda horario gerar --de 2025-02-03 --ate 2025-04-30 --turma 2 --pular 2025-03-14,2025-04-17`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromStr, _ := cmd.Flags().GetString("de")
+		toStr, _ := cmd.Flags().GetString("ate")
+		classIDStr, _ := cmd.Flags().GetString("turma")
+		skipStr, _ := cmd.Flags().GetString("pular")
+		dryRun, _ := cmd.Flags().GetBool("simular")
+		termNumber, _ := cmd.Flags().GetInt("bimestre")
+		year, _ := cmd.Flags().GetInt("ano")
+
+		ctx := context.Background()
+		opts := service.GenerateLessonsOptions{DryRun: dryRun}
+		var err error
+		if termNumber != 0 {
+			if fromStr != "" || toStr != "" {
+				return service.ValidationErrorf("use --bimestre ou --de/--ate, não ambos")
+			}
+			term, err := findTerm(ctx, termNumber, year)
+			if err != nil {
+				return err
+			}
+			opts.From, opts.To = term.StartDate, term.EndDate
+		} else {
+			if opts.From, err = parseDateFlag("de", fromStr); err != nil {
+				return err
+			}
+			if opts.To, err = parseDateFlag("ate", toStr); err != nil {
+				return err
+			}
+		}
+		if classIDStr != "" {
+			if opts.ClassID, err = parseIDArg(classIDStr, "turma"); err != nil {
+				return err
+			}
+		}
+		for _, part := range strings.Split(skipStr, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			day, err := parseDateFlag("pular", part)
+			if err != nil {
+				return err
+			}
+			opts.SkipDates = append(opts.SkipDates, day)
+		}
+
+		result, err := timetableService.GenerateLessons(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("erro ao gerar aulas: %w", err)
+		}
+		header := fmt.Sprintf("AULAS GERADAS (%s a %s)", opts.From.Format("02/01/2006"), opts.To.Format("02/01/2006"))
+		if dryRun {
+			header = fmt.Sprintf("SIMULAÇÃO (%s a %s) - nenhuma aula foi criada", opts.From.Format("02/01/2006"), opts.To.Format("02/01/2006"))
+		}
+		if err := writeGenerationResult(os.Stdout, header, result, dryRun); err != nil {
+			return err
+		}
+		if isTableOutput() {
+			verb := "criadas"
+			if dryRun {
+				verb = "seriam criadas"
+			}
+			fmt.Printf("\n%d aulas %s, %d horários pulados.\n", len(result.Created), verb, len(result.Skipped))
+		}
+		return nil
+	},
+}
+
+// generatedLessonOutput é uma linha do resultado de 'horario gerar' nos formatos json e csv.
+type generatedLessonOutput struct {
+	LessonID int64     `json:"lesson_id,omitempty"`
+	ClassID  int64     `json:"class_id"`
+	SlotID   int64     `json:"slot_id,omitempty"`
+	Start    time.Time `json:"start"`
+	Created  bool      `json:"created"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// writeGenerationResult lista, em ordem de data, as aulas criadas e os horários pulados com o motivo.
+func writeGenerationResult(w io.Writer, header string, result service.LessonGenerationResult, dryRun bool) error {
+	classNames := lessonClassNames(context.Background())
+	var data []generatedLessonOutput
+	for _, lesson := range result.Created {
+		data = append(data, generatedLessonOutput{LessonID: lesson.ID, ClassID: lesson.ClassID, Start: lesson.ScheduledAt, Created: true})
+	}
+	for _, skipped := range result.Skipped {
+		data = append(data, generatedLessonOutput{ClassID: skipped.Slot.ClassID, SlotID: skipped.Slot.ID, Start: skipped.Start, Reason: skipped.Reason})
+	}
+	sort.SliceStable(data, func(i, j int) bool { return data[i].Start.Before(data[j].Start) })
+
+	created := "criada"
+	if dryRun {
+		created = "será criada"
+	}
+	columns := []table.Column{
+		{Title: "DATA", Width: 14},
+		{Title: "HORA", Width: 5},
+		{Title: "TURMA", Width: 15},
+		{Title: "SITUAÇÃO", Width: 45},
+	}
+	rows := []table.Row{}
+	for _, item := range data {
+		status := created
+		if !item.Created {
+			status = "pulada: " + item.Reason
+		}
+		rows = append(rows, table.Row{formatLessonDay(item.Start), item.Start.Format("15:04"), classNames[item.ClassID], status})
+	}
+	if data == nil {
+		data = []generatedLessonOutput{}
+	}
+	return writeList(w, listOutput{Header: header, Columns: columns, Rows: rows, Data: data})
+}
+
+// writeSlots escreve o horário semanal no formato de --formato.
+func writeSlots(w io.Writer, header string, slots []models.TimetableSlot) error {
+	classNames := lessonClassNames(context.Background())
+	columns := []table.Column{
+		{Title: "ID", Width: 4},
+		{Title: "DIA", Width: 8},
+		{Title: "INÍCIO", Width: 6},
+		{Title: "FIM", Width: 5},
+		{Title: "DURAÇÃO", Width: 7},
+		{Title: "TURMA", Width: 20},
+	}
+	rows := []table.Row{}
+	for _, slot := range slots {
+		rows = append(rows, table.Row{
+			strconv.FormatInt(slot.ID, 10),
+			service.WeekdayName(slot.Weekday),
+			slot.StartTime,
+			slotEnd(slot),
+			fmt.Sprintf("%d min", slot.DurationMinutes),
+			classNames[slot.ClassID],
+		})
+	}
+	if slots == nil {
+		slots = []models.TimetableSlot{}
+	}
+	return writeList(w, listOutput{Header: header, Columns: columns, Rows: rows, Data: slots})
+}
+
+// slotEnd calcula a hora de término de um horário (HH:MM).
+func slotEnd(slot models.TimetableSlot) string {
+	start, err := time.Parse("15:04", slot.StartTime)
+	if err != nil {
+		return ""
+	}
+	return start.Add(time.Duration(slot.DurationMinutes) * time.Minute).Format("15:04")
+}
+
+// describeSlot descreve um horário como "Segunda 07:00–07:50 (Turma 9A)".
+func describeSlot(slot models.TimetableSlot) string {
+	description := fmt.Sprintf("%s %s–%s", service.WeekdayName(slot.Weekday), slot.StartTime, slotEnd(slot))
+	if name := lessonClassNames(context.Background())[slot.ClassID]; name != "" {
+		description += " (" + name + ")"
+	}
+	return description
+}
+
+// parseDateFlag interpreta uma data AAAA-MM-DD no fuso local; 'name' é a flag, para a mensagem de erro.
+func parseDateFlag(name, value string) (time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return time.Time{}, service.ValidationErrorf("a data --%s é obrigatória, no formato AAAA-MM-DD", name)
+	}
+	day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return time.Time{}, service.ValidationErrorf("data inválida para --%s '%s': use o formato AAAA-MM-DD", name, value)
+	}
+	return day, nil
+}
+
+func init() {
+	timetableAddCmd.Flags().String("turma", "", "ID da turma (obrigatório).")
+	_ = timetableAddCmd.MarkFlagRequired("turma")
+	timetableAddCmd.Flags().String("dia", "", "Dia da semana: seg, ter, qua, qui, sex, sab ou dom (obrigatório).")
+	_ = timetableAddCmd.MarkFlagRequired("dia")
+	timetableAddCmd.Flags().String("inicio", "", "Hora de início no formato HH:MM (obrigatório).")
+	_ = timetableAddCmd.MarkFlagRequired("inicio")
+	timetableAddCmd.Flags().Int("duracao", defaultSlotMinutes, "Duração da aula em minutos.")
+
+	timetableEditCmd.Flags().String("dia", "", "Novo dia da semana.")
+	timetableEditCmd.Flags().String("inicio", "", "Nova hora de início (HH:MM).")
+	timetableEditCmd.Flags().Int("duracao", defaultSlotMinutes, "Nova duração em minutos.")
+
+	timetableGenerateCmd.Flags().String("de", "", "Primeiro dia do período, AAAA-MM-DD (obrigatório sem --bimestre).")
+	timetableGenerateCmd.Flags().String("ate", "", "Último dia do período, AAAA-MM-DD (obrigatório sem --bimestre).")
+	timetableGenerateCmd.Flags().Int("bimestre", 0, "Gera nas datas deste bimestre do calendário escolar (1 a 4).")
+	timetableGenerateCmd.Flags().Int("ano", 0, "Ano letivo do --bimestre (padrão: ano atual).")
+	timetableGenerateCmd.Flags().String("turma", "", "Gera apenas para a turma com este ID.")
+	timetableGenerateCmd.Flags().String("pular", "", "Dias sem aula além dos do calendário escolar, separados por vírgula (AAAA-MM-DD).")
+	timetableGenerateCmd.Flags().Bool("simular", false, "Mostra o que seria gerado sem criar as aulas.")
+
+	timetableCmd.AddCommand(timetableListCmd, timetableAddCmd, timetableEditCmd, timetableDeleteCmd, timetableGenerateCmd)
+	rootCmd.AddCommand(timetableCmd)
+}
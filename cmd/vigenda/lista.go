@@ -0,0 +1,239 @@
+// Este arquivo (lista.go) define o comando 'relatorio lista', que gera as listas impressas da turma:
+// lista de chamada, lista de assinaturas e ficha de notas, em HTML, PDF ou CSV.
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"vigenda/internal/config"
+	"vigenda/internal/models"
+	"vigenda/internal/report"
+	"vigenda/internal/service"
+)
+
+// Modelos aceitos por 'relatorio lista --modelo'.
+const (
+	rosterModelAttendance = "chamada"
+	rosterModelSignature  = "assinatura"
+	rosterModelGrades     = "notas"
+)
+
+var monthNames = [...]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"}
+
+var reportRosterCmd = &cobra.Command{
+	Use:   "lista",
+	Short: "Gera listas imprimíveis da turma: chamada, assinaturas ou notas (HTML/PDF/CSV)",
+	Long: `Gera uma lista da turma para imprimir, com os alunos em ordem de número de matrícula/chamada:
+
+  chamada     uma coluna por aula do mês de --mes (padrão: o mês atual)
+  assinatura  uma coluna para a assinatura de cada aluno, para provas e atividades (--titulo)
+  notas       uma coluna por avaliação da turma (do bimestre de --bimestre, se informado) e a média
+
+--colunas substitui as colunas geradas pelo modelo (ex: --colunas "P1,P2,Trabalho"). Alunos inativos
+ou transferidos aparecem esmaecidos, com a situação ao lado do nome, ou são omitidos com
+--inativos ocultar. O arquivo de --saida é gravado em HTML, PDF ou CSV conforme a extensão; sem
+--saida, o HTML (ou o CSV, com --formato csv) é escrito na saída padrão. O cabeçalho usa o nome da
+escola de VIGENDA_ESCOLA e o do(a) professor(a) de VIGENDA_PROFESSOR.`,
+	Example: `  vigenda relatorio lista --turma "Turma 9A" --modelo chamada --mes 2025-05 --saida chamada-maio.pdf
+  vigenda relatorio lista --turma 1 --modelo assinatura --titulo "Prova bimestral" --saida assinaturas.html
+  vigenda relatorio lista --turma "Turma 9A" --modelo notas --bimestre 2 --inativos ocultar --saida notas.pdf`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		classArg, _ := flags.GetString("turma")
+		model, _ := flags.GetString("modelo")
+		inactive, _ := flags.GetString("inativos")
+		output, _ := flags.GetString("saida")
+		model = strings.ToLower(strings.TrimSpace(model))
+		if model != rosterModelAttendance && model != rosterModelSignature && model != rosterModelGrades {
+			return service.ValidationErrorf("modelo de lista inválido '%s': use chamada, assinatura ou notas", model)
+		}
+		if inactive != "marcar" && inactive != "ocultar" {
+			return service.ValidationErrorf("valor inválido para --inativos '%s': use marcar ou ocultar", inactive)
+		}
+
+		ctx := context.Background()
+		class, err := resolveClass(ctx, classArg)
+		if err != nil {
+			return err
+		}
+		students, err := classService.GetStudentsByClassID(ctx, class.ID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar alunos: %w", err)
+		}
+		service.SortStudentsByEnrollment(students)
+		if inactive == "ocultar" {
+			active := students[:0]
+			for _, student := range students {
+				if student.Status == "ativo" {
+					active = append(active, student)
+				}
+			}
+			students = active
+		}
+		if len(students) == 0 {
+			return service.ValidationErrorf("a turma '%s' não tem alunos para listar", class.Name)
+		}
+
+		var doc report.Document
+		switch model {
+		case rosterModelAttendance:
+			doc, err = attendanceSheet(ctx, cmd, class)
+		case rosterModelSignature:
+			doc, err = signatureSheet(cmd, class)
+		case rosterModelGrades:
+			doc, err = gradeSheet(ctx, cmd, class)
+		}
+		if err != nil {
+			return err
+		}
+		fillRoster(doc.Sections[0].Table, students)
+		return writeReport(output, doc)
+	},
+}
+
+// printableDocument monta o cabeçalho comum dos relatórios impressos de uma turma.
+func printableDocument(title string, class models.Class, info ...string) report.Document {
+	lines := []string{"Turma: " + class.Name}
+	if teacher := config.TeacherName(); teacher != "" {
+		lines = append(lines, "Professor(a): "+teacher)
+	}
+	return report.Document{School: config.SchoolName(), Title: title, Info: append(lines, info...)}
+}
+
+// rosterTable cria a tabela de uma lista com as colunas Nº e ALUNO seguidas de 'columns'.
+func rosterTable(columns []report.Column, rowHeight float64) *report.Table {
+	return &report.Table{
+		Columns:   append([]report.Column{{Title: "Nº", Width: 0.8, Align: report.AlignCenter}, {Title: "ALUNO", Width: 5}}, columns...),
+		RowHeight: rowHeight,
+	}
+}
+
+// fillRoster preenche uma linha por aluno, deixando as demais colunas em branco para preencher à
+// mão. Alunos que não estão ativos ficam esmaecidos, com a situação ao lado do nome.
+func fillRoster(t *report.Table, students []models.Student) {
+	for _, student := range students {
+		name := student.FullName
+		if student.Status != "ativo" {
+			name += " (" + student.Status + ")"
+		}
+		t.Rows = append(t.Rows, report.Row{Cells: []string{student.EnrollmentID, name}, Muted: student.Status != "ativo"})
+	}
+}
+
+// customColumns retorna as colunas de --colunas, se informadas.
+func customColumns(cmd *cobra.Command, width float64) []report.Column {
+	titles, _ := cmd.Flags().GetStringSlice("colunas")
+	var columns []report.Column
+	for _, title := range titles {
+		if title = strings.TrimSpace(title); title != "" {
+			columns = append(columns, report.Column{Title: title, Width: width, Align: report.AlignCenter})
+		}
+	}
+	return columns
+}
+
+func attendanceSheet(ctx context.Context, cmd *cobra.Command, class models.Class) (report.Document, error) {
+	monthStr, _ := cmd.Flags().GetString("mes")
+	month := time.Now()
+	if strings.TrimSpace(monthStr) != "" {
+		var err error
+		if month, err = time.ParseInLocation("2006-01", strings.TrimSpace(monthStr), time.Local); err != nil {
+			return report.Document{}, service.ValidationErrorf("mês inválido para --mes '%s': use o formato AAAA-MM", monthStr)
+		}
+	}
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 1, 0)
+
+	columns := customColumns(cmd, 1)
+	if columns == nil {
+		lessons, err := lessonService.GetLessonsByClassID(ctx, class.ID)
+		if err != nil {
+			return report.Document{}, fmt.Errorf("erro ao carregar aulas: %w", err)
+		}
+		sort.SliceStable(lessons, func(i, j int) bool { return lessons[i].ScheduledAt.Before(lessons[j].ScheduledAt) })
+		for _, lesson := range lessons {
+			if lesson.ScheduledAt.Before(start) || !lesson.ScheduledAt.Before(end) || lesson.Status == models.LessonStatusCancelled {
+				continue
+			}
+			columns = append(columns, report.Column{Title: lesson.ScheduledAt.Format("02/01"), Width: 1, Align: report.AlignCenter})
+		}
+		if columns == nil {
+			return report.Document{}, service.ValidationErrorf("%s não tem aulas em %s de %d: escolha outro --mes ou informe as colunas em --colunas",
+				class.Name, monthNames[start.Month()-1], start.Year())
+		}
+	}
+
+	doc := printableDocument("Lista de Chamada", class, fmt.Sprintf("Mês: %s de %d", monthNames[start.Month()-1], start.Year()))
+	doc.Landscape = len(columns) > 8
+	doc.Sections = []report.Section{{
+		Table: rosterTable(columns, 0),
+		Notes: []string{"P: presente · F: falta · A: atraso · J: falta justificada"},
+	}}
+	doc.Footer = []string{"Assinatura do(a) professor(a): ________________________________________"}
+	return doc, nil
+}
+
+func signatureSheet(cmd *cobra.Command, class models.Class) (report.Document, error) {
+	title, _ := cmd.Flags().GetString("titulo")
+	info := []string{"Data: ____/____/________"}
+	if title = strings.TrimSpace(title); title != "" {
+		info = append([]string{"Atividade: " + title}, info...)
+	}
+	doc := printableDocument("Lista de Assinaturas", class, info...)
+	columns := append(customColumns(cmd, 1.5), report.Column{Title: "ASSINATURA", Width: 6})
+	doc.Sections = []report.Section{{Table: rosterTable(columns, 9)}}
+	return doc, nil
+}
+
+func gradeSheet(ctx context.Context, cmd *cobra.Command, class models.Class) (report.Document, error) {
+	term, _ := cmd.Flags().GetInt("bimestre")
+	if term < 0 || term > 4 {
+		return report.Document{}, service.ValidationErrorf("bimestre inválido %d: use um número de 1 a 4", term)
+	}
+	columns := customColumns(cmd, 1)
+	if columns == nil {
+		assessments, err := assessmentService.ListAllAssessments(ctx)
+		if err != nil {
+			return report.Document{}, fmt.Errorf("erro ao carregar avaliações: %w", err)
+		}
+		for _, assessment := range assessments {
+			if assessment.ClassID == class.ID && (term == 0 || assessment.Term == term) {
+				columns = append(columns, report.Column{Title: assessment.Name, Width: 1.2, Align: report.AlignCenter})
+			}
+		}
+		if columns == nil {
+			columns = []report.Column{{Title: "NOTA", Width: 1.2, Align: report.AlignCenter}}
+		}
+		columns = append(columns, report.Column{Title: "MÉDIA", Width: 1, Align: report.AlignCenter})
+	}
+
+	var info []string
+	if term != 0 {
+		info = append(info, "Período: "+service.TermLabel(term))
+	}
+	doc := printableDocument("Ficha de Notas", class, info...)
+	doc.Landscape = len(columns) > 6
+	doc.Sections = []report.Section{{Table: rosterTable(columns, 7)}}
+	doc.Footer = []string{"Assinatura do(a) professor(a): ________________________________________"}
+	return doc, nil
+}
+
+func init() {
+	reportRosterCmd.Flags().String("turma", "", "ID ou nome da turma (obrigatório).")
+	_ = reportRosterCmd.MarkFlagRequired("turma")
+	reportRosterCmd.Flags().String("modelo", rosterModelAttendance, "Modelo da lista: chamada, assinatura ou notas.")
+	reportRosterCmd.Flags().String("mes", "", "Mês das aulas da lista de chamada, no formato AAAA-MM (padrão: o mês atual).")
+	reportRosterCmd.Flags().Int("bimestre", 0, "Bimestre das avaliações da ficha de notas (padrão: todas).")
+	reportRosterCmd.Flags().String("titulo", "", "Atividade da lista de assinaturas (ex: \"Prova bimestral\").")
+	reportRosterCmd.Flags().StringSlice("colunas", nil, "Títulos das colunas, separados por vírgula, no lugar das geradas pelo modelo.")
+	reportRosterCmd.Flags().String("inativos", "marcar", "Alunos inativos ou transferidos: marcar ou ocultar.")
+	reportRosterCmd.Flags().String("saida", "", "Arquivo de saída, .html, .pdf ou .csv (padrão: HTML na saída padrão).")
+
+	reportCmd.AddCommand(reportRosterCmd)
+}
@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"encoding/json" // Added missing import
 	"fmt"
+	"io"
 	"log" // Adicionado para logging
 	"os"
 	"path/filepath" // Adicionado para manipulação de caminhos de arquivo
@@ -15,8 +16,11 @@ import (
 	"time"
 
 	"github.com/charmbracelet/bubbles/table" // Reativado para columns e rows
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 	"vigenda/internal/app" // Import for the new BubbleTea app
+	"vigenda/internal/app/grades"
 	"vigenda/internal/database"
 	"vigenda/internal/models" // Added import for models package
 	"vigenda/internal/repository"
@@ -25,6 +29,7 @@ import (
 )
 
 var db *sql.DB // Global database connection pool
+var dbConfig database.DBConfig // Configuração usada para abrir 'db' (usada pelo backup e pelo diretório de anexos)
 var logFile *os.File // Global para o arquivo de log, para poder fechar no final
 
 var taskService service.TaskService
@@ -44,17 +49,29 @@ Funcionalidades Principais:
   - Dashboard: Visão geral da agenda do dia, tarefas urgentes e notificações.
   - Gestão de Tarefas: Crie, liste e marque tarefas como concluídas.
   - Gestão de Turmas: Administre turmas, alunos (incluindo importação) e seus status.
-  - Gestão de Avaliações: Crie avaliações, lance notas e calcule médias.
+  - Planejamento de Aulas: Crie aulas com planos em Markdown, veja as aulas do dia e da semana e registre o conteúdo ministrado.
+  - Modelos e Sequências: Modelos de plano de aula e sequências didáticas compartilháveis.
+  - Anexos: Slides, fichas, vídeos e links anexados às aulas.
+  - Horário Semanal: Cadastre o horário das turmas e gere as aulas de um bimestre inteiro.
+  - Calendário Escolar: Datas dos bimestres, feriados, recessos e dias de planejamento.
+  - Chamada: Frequência dos alunos em cada aula e totais de faltas por bimestre.
+  - Relatórios: Conteúdo ministrado, diário de classe do bimestre e listas impressas (chamada, assinaturas, notas).
+  - Gestão de Avaliações: Crie avaliações, lance notas e calcule médias conforme a política de notas de cada turma.
   - Banco de Questões: Mantenha um banco de questões e gere provas.
+  - Backup: Cópia do banco de dados e dos anexos em um arquivo .zip.
 
 Use "vigenda [comando] --help" para mais informações sobre um comando específico.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Launch the BubbleTea application
 		// PersistentPreRunE ensures all necessary services are initialized.
 		// Pass the initialized services to the TUI application.
-		app.StartApp(taskService, classService, assessmentService, questionService, proofService, lessonService)
+		return app.StartApp(taskService, classService, assessmentService, questionService, proofService, lessonService, planningService, timetableService, calendarService, lessonTemplateService, attachmentService, attendanceService)
 	},
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(); err != nil {
+			return err
+		}
+
 		// Setup logging to file first
 		if err := setupLogging(); err != nil {
 			// Se não conseguir configurar o log, ainda tenta continuar, mas loga no stderr.
@@ -109,11 +126,11 @@ Use "vigenda [comando] --help" para mais informações sobre um comando específ
 					}
 					if dbUser == "" {
 						// User must be provided for PostgreSQL typically
-						return fmt.Errorf("VIGENDA_DB_USER must be set for PostgreSQL connection")
+						return service.ValidationErrorf("VIGENDA_DB_USER deve ser definida para conexão PostgreSQL")
 					}
 					if dbName == "" {
 						// DB Name must be provided
-						return fmt.Errorf("VIGENDA_DB_NAME must be set for PostgreSQL connection")
+						return service.ValidationErrorf("VIGENDA_DB_NAME deve ser definida para conexão PostgreSQL")
 					}
 					if dbSSLMode == "" {
 						dbSSLMode = "disable" // Default SSLMode
@@ -124,15 +141,16 @@ Use "vigenda [comando] --help" para mais informações sobre um comando específ
 						dbHost, dbPort, dbUser, dbPassword, dbName, dbSSLMode)
 				}
 			default:
-				return fmt.Errorf("unsupported VIGENDA_DB_TYPE: %s. Supported types are 'sqlite', 'postgres'", dbType)
+				return service.ValidationErrorf("VIGENDA_DB_TYPE não suportado '%s': use 'sqlite' ou 'postgres'", dbType)
 			}
 
 			var err error
 			// Use the non-conflicting GetDBConnection from connection.go
 			db, err = database.GetDBConnection(config)
 			if err != nil {
-				return fmt.Errorf("failed to initialize database (type: %s): %w", config.DBType, err)
+				return service.StorageError(fmt.Sprintf("não foi possível abrir o banco de dados (%s)", config.DBType), err)
 			}
+			dbConfig = config
 			// Initialize services here, after DB is ready
 			initializeServices(db)
 		}
@@ -142,11 +160,14 @@ Use "vigenda [comando] --help" para mais informações sobre um comando específ
 
 var taskCmd = &cobra.Command{
 	Use:   "tarefa",
-	Short: "Gerencia tarefas (add, listar, complete)",
-	Long:  `O comando 'tarefa' permite gerenciar todas as suas atividades e pendências. Você pode adicionar novas tarefas, listar tarefas existentes (filtrando por turma) e marcar tarefas como concluídas.`,
+	Short: "Gerencia tarefas (add, listar, complete, adiar, mover, estimar)",
+	Long:  `O comando 'tarefa' permite gerenciar todas as suas atividades e pendências. Você pode adicionar novas tarefas, listar tarefas existentes (filtrando por turma), marcar tarefas como concluídas e adiar prazos.`,
 	Example: `  vigenda tarefa add "Preparar aula de Revolução Francesa" --classid 1 --duedate 2024-07-15
   vigenda tarefa listar --classid 1
-  vigenda tarefa complete 5`,
+  vigenda tarefa complete 5
+  vigenda tarefa adiar 5 2d
+  vigenda tarefa mover 5 fazendo
+  vigenda tarefa estimar 5 45`,
 }
 
 var taskAddCmd = &cobra.Command{
@@ -156,20 +177,31 @@ var taskAddCmd = &cobra.Command{
 Você pode fornecer uma descrição detalhada, associar a tarefa a uma turma específica
 e definir um prazo de conclusão utilizando as flags correspondentes.`,
 	Example: `  vigenda tarefa add "Corrigir provas bimestrais" --description "Corrigir as provas do 2º bimestre da turma 9A." --classid 1 --duedate 2024-07-20
-  vigenda tarefa add "Planejar próxima unidade" --duedate 2024-08-01`,
+  vigenda tarefa add "Planejar próxima unidade" --duedate 2024-08-01
+  vigenda tarefa add "Lançar notas" --duedate 2024-08-01 --estimativa 45 --prioridade alta`,
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		title := args[0]
 		description, _ := cmd.Flags().GetString("description")
 		classIDStr, _ := cmd.Flags().GetString("classid")
 		dueDateStr, _ := cmd.Flags().GetString("duedate")
+		estimate, _ := cmd.Flags().GetInt("estimativa")
+		priorityStr, _ := cmd.Flags().GetString("prioridade")
+
+		var priority int
+		if priorityStr != "" {
+			p, err := service.ParseTaskPriority(priorityStr)
+			if err != nil {
+				return err
+			}
+			priority = p
+		}
 
 		var classID *int64
 		if classIDStr != "" {
-			cid, err := strconv.ParseInt(classIDStr, 10, 64)
+			cid, err := parseIDArg(classIDStr, "turma")
 			if err != nil {
-				fmt.Println("Error parsing class ID:", err)
-				return
+				return err
 			}
 			classID = &cid
 		}
@@ -178,8 +210,7 @@ e definir um prazo de conclusão utilizando as flags correspondentes.`,
 		if dueDateStr != "" {
 			parsedDate, err := time.Parse("2006-01-02", dueDateStr)
 			if err != nil {
-				fmt.Println("Error parsing due date (use YYYY-MM-DD format):", err)
-				return
+				return service.ValidationErrorf("prazo inválido '%s': use o formato AAAA-MM-DD", dueDateStr)
 			}
 			dueDate = &parsedDate
 		}
@@ -187,18 +218,26 @@ e definir um prazo de conclusão utilizando as flags correspondentes.`,
 		if description == "" {
 			desc, err := tui.GetInput("Enter task description (optional):", os.Stdout, os.Stdin)
 			if err != nil {
-				fmt.Println("Error getting description:", err)
-				// Decide if we should proceed or return
+				return fmt.Errorf("erro ao ler a descrição: %w", err)
 			}
 			description = desc
 		}
 
 		task, err := taskService.CreateTask(context.Background(), title, description, classID, dueDate)
 		if err != nil {
-			fmt.Println("Error creating task:", err)
-			return
+			return fmt.Errorf("erro ao criar tarefa: %w", err)
+		}
+		if estimate > 0 || priority != 0 {
+			var estimatedMinutes *int
+			if estimate > 0 {
+				estimatedMinutes = &estimate
+			}
+			if _, err := taskService.SetTaskPlanning(context.Background(), task.ID, estimatedMinutes, priority); err != nil {
+				return fmt.Errorf("tarefa ID %d criada, mas houve erro ao definir estimativa/prioridade: %w", task.ID, err)
+			}
 		}
 		fmt.Printf("Task '%s' (ID: %d) created successfully.\n", task.Title, task.ID)
+		return nil
 	},
 }
 
@@ -208,7 +247,7 @@ var taskListCmd = &cobra.Command{
 	Long:  `Lista todas as tarefas ativas. É obrigatório filtrar as tarefas por um ID de turma específico usando a flag --classid.`,
 	Example: `  vigenda tarefa listar --classid 1
   vigenda tarefa listar --classid 3`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		classIDStr, _ := cmd.Flags().GetString("classid")
 		showAllStr, _ := cmd.Flags().GetString("all") // Check for the --all flag
 		showAll := showAllStr == "true" // Convert to boolean
@@ -218,15 +257,13 @@ var taskListCmd = &cobra.Command{
 		var headerMsg string
 
 		if classIDStr != "" {
-			classID, parseErr := strconv.ParseInt(classIDStr, 10, 64)
+			classID, parseErr := parseIDArg(classIDStr, "turma")
 			if parseErr != nil {
-				fmt.Println("Error parsing class ID:", parseErr)
-				return
+				return parseErr
 			}
 			tasks, err = taskService.ListActiveTasksByClass(context.Background(), classID)
 			if err != nil {
-				fmt.Println("Error listing tasks:", err)
-				return
+				return fmt.Errorf("erro ao listar tarefas: %w", err)
 			}
 			class, classErr := classService.GetClassByID(context.Background(), classID)
 			if classErr == nil && class.ID != 0 {
@@ -256,32 +293,25 @@ var taskListCmd = &cobra.Command{
 			// where filter could specify ClassID (optional) and IsCompleted (optional).
 			// For now, we'll just say "All Tasks"
 			if err != nil { // No longer need to check for "not found" as ListAllActiveTasks doesn't depend on a classID
-				fmt.Println("Error listing all tasks:", err)
-				return
+				return fmt.Errorf("erro ao listar todas as tarefas: %w", err)
 			}
 			headerMsg = "TODAS AS TAREFAS (INCLUINDO BUGS DO SISTEMA)" // Restaurado
 		} else {
-			fmt.Println("Erro: Especifique --classid OU use --all para listar todas as tarefas (incluindo bugs).")
-			fmt.Println("Exemplo: vigenda tarefa listar --classid 1")
-			fmt.Println("Exemplo: vigenda tarefa listar --all")
-			return
+			return service.ValidationErrorf("especifique --classid OU use --all para listar todas as tarefas (incluindo bugs)")
 		}
 
 
-		if len(tasks) == 0 {
+		if len(tasks) == 0 && isTableOutput() {
 			fmt.Println("No active tasks found matching criteria.")
-			return
+			return nil
 		}
 
-		fmt.Printf("%s\n\n", headerMsg) // Restaurado \n\n para espaço antes da tabela
-
-		// Reativar a definição de colunas e o preenchimento de rows
 		columns := []table.Column{
 			{Title: "ID", Width: 3},
 			{Title: "TAREFA", Width: 35},
 			{Title: "PRAZO", Width: 10},
 		}
-		var rows []table.Row
+		rows := []table.Row{}
 		for _, task := range tasks {
 			dueDateStr := "N/A"
 			if task.DueDate != nil {
@@ -293,13 +323,10 @@ var taskListCmd = &cobra.Command{
 				dueDateStr,
 			})
 		}
-
-		// Reativar impressão manual da tabela
-		fmt.Printf("%-*s | %-*s | %s\n", columns[0].Width, columns[0].Title, columns[1].Width, columns[1].Title, columns[2].Title)
-		fmt.Printf("%s | %s | %s\n", strings.Repeat("-", columns[0].Width), strings.Repeat("-", columns[1].Width), strings.Repeat("-", columns[2].Width))
-		for _, row := range rows {
-			fmt.Printf("%-*s | %-*s | %s\n", columns[0].Width, row[0], columns[1].Width, row[1], row[2])
+		if tasks == nil {
+			tasks = []models.Task{}
 		}
+		return writeList(os.Stdout, listOutput{Header: headerMsg, Columns: columns, Rows: rows, Data: tasks})
 	},
 }
 
@@ -310,18 +337,106 @@ var taskCompleteCmd = &cobra.Command{
 	Example: `  vigenda tarefa complete 12
   vigenda tarefa complete 3`,
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		taskID, err := strconv.ParseInt(args[0], 10, 64)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		taskID, err := parseIDArg(args[0], "tarefa")
 		if err != nil {
-			fmt.Println("Error parsing task ID:", err)
-			return
+			return err
 		}
 		err = taskService.MarkTaskAsCompleted(context.Background(), taskID)
 		if err != nil {
-			fmt.Println("Error marking task as completed:", err)
-			return
+			return fmt.Errorf("erro ao concluir tarefa ID %d: %w", taskID, err)
 		}
 		fmt.Printf("Task ID %d marked as completed.\n", taskID)
+		return nil
+	},
+}
+
+var taskPostponeCmd = &cobra.Command{
+	Use:   "adiar [ID_da_tarefa] [intervalo]",
+	Short: "Adia o prazo de uma tarefa",
+	Long: `Adia o prazo de uma tarefa pelo intervalo informado e incrementa o contador de adiamentos.
+O intervalo é um número seguido da unidade: d (dias), w ou sem (semanas), h (horas).
+Se a tarefa já estiver atrasada, o intervalo é contado a partir de hoje.`,
+	Example: `  vigenda tarefa adiar 12 2d
+  vigenda tarefa adiar 3 1sem`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		taskID, err := parseIDArg(args[0], "tarefa")
+		if err != nil {
+			return err
+		}
+		delay, err := service.ParsePostponeDuration(args[1])
+		if err != nil {
+			return err
+		}
+		task, err := taskService.PostponeTask(context.Background(), taskID, delay)
+		if err != nil {
+			return fmt.Errorf("erro ao adiar tarefa: %w", err)
+		}
+		fmt.Printf("Tarefa ID %d adiada para %s (adiada %d vez(es)).\n", task.ID, task.DueDate.Format("02/01/2006 15:04"), task.PostponeCount)
+		return nil
+	},
+}
+
+var taskMoveCmd = &cobra.Command{
+	Use:   "mover [ID_da_tarefa] [status]",
+	Short: "Move uma tarefa para outra coluna do quadro (a_fazer, fazendo, aguardando, feito)",
+	Long: `Altera o status de uma tarefa no quadro Kanban.
+Status válidos: a_fazer, fazendo, aguardando, feito. Mover para 'feito' conclui a tarefa.`,
+	Example: `  vigenda tarefa mover 12 fazendo
+  vigenda tarefa mover 3 aguardando`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		taskID, err := parseIDArg(args[0], "tarefa")
+		if err != nil {
+			return err
+		}
+		status := strings.ToLower(strings.TrimSpace(args[1]))
+		if err := taskService.UpdateTaskStatus(context.Background(), taskID, status); err != nil {
+			return fmt.Errorf("erro ao mover tarefa: %w", err)
+		}
+		fmt.Printf("Tarefa ID %d movida para '%s'.\n", taskID, status)
+		return nil
+	},
+}
+
+var taskEstimateCmd = &cobra.Command{
+	Use:   "estimar [ID_da_tarefa] [minutos]",
+	Short: "Define a duração estimada (e opcionalmente a prioridade) de uma tarefa",
+	Long: `Define quantos minutos uma tarefa deve levar, usado por 'vigenda planejar' para encaixá-la nos horários livres.
+Use 0 minutos para remover a estimativa. A prioridade (baixa, media, alta) pode ser ajustada com --prioridade.`,
+	Example: `  vigenda tarefa estimar 12 45
+  vigenda tarefa estimar 3 90 --prioridade alta`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		taskID, err := parseIDArg(args[0], "tarefa")
+		if err != nil {
+			return err
+		}
+		minutes, err := strconv.Atoi(args[1])
+		if err != nil || minutes < 0 {
+			return service.ValidationErrorf("duração inválida '%s': informe os minutos como número inteiro", args[1])
+		}
+		var estimatedMinutes *int
+		if minutes > 0 {
+			estimatedMinutes = &minutes
+		}
+		var priority int
+		if priorityStr, _ := cmd.Flags().GetString("prioridade"); priorityStr != "" {
+			if priority, err = service.ParseTaskPriority(priorityStr); err != nil {
+				return err
+			}
+		}
+		task, err := taskService.SetTaskPlanning(context.Background(), taskID, estimatedMinutes, priority)
+		if err != nil {
+			return fmt.Errorf("erro ao atualizar tarefa: %w", err)
+		}
+		estimateStr := "sem estimativa"
+		if task.EstimatedMinutes != nil {
+			estimateStr = fmt.Sprintf("%d min", *task.EstimatedMinutes)
+		}
+		fmt.Printf("Tarefa ID %d: %s, prioridade %s.\n", task.ID, estimateStr, service.TaskPriorityLabel(task.Priority))
+		return nil
 	},
 }
 
@@ -341,7 +456,9 @@ func initializeServices(db *sql.DB) {
 	// For now, let's assume they can take the real repos.
 	// If NewStubClassService was a placeholder for NewClassService:
 	classService = service.NewClassService(classRepo, subjectRepo) // Assuming ClassService might need SubjectRepo too, or just ClassRepo
-	assessmentService = service.NewAssessmentService(assessmentRepo, classRepo) // AssessmentService might need ClassRepo to get students
+	// CalendarService guarda bimestres e dias sem aula; avaliações e o gerador de aulas o consultam
+	calendarService = service.NewCalendarService(repository.NewCalendarRepository(db))
+	assessmentService = service.NewAssessmentService(assessmentRepo, classRepo, calendarService, repository.NewGradingPolicyRepository(db)) // AssessmentService might need ClassRepo to get students
 
 	questionService = service.NewQuestionService(questionRepo, subjectRepo)
 	proofService = service.NewProofService(questionRepo) // ProofService uses QuestionRepository for GetQuestionsByCriteriaProofGeneration
@@ -350,11 +467,41 @@ func initializeServices(db *sql.DB) {
 	lessonRepo := repository.NewLessonRepository(db)
 	// LessonService precisa do ClassRepository para validação de propriedade da turma
 	lessonService = service.NewLessonService(lessonRepo, classRepo)
+
+	// PlanningService combina as aulas do dia (LessonService) com as tarefas pendentes
+	planningService = service.NewPlanningService(taskRepo, lessonService, repository.NewPlanRepository(db))
+
+	// TimetableService gera aulas (LessonRepository) a partir do horário semanal
+	timetableService = service.NewTimetableService(repository.NewTimetableRepository(db), lessonRepo, classRepo, calendarService)
+
+	// LessonTemplateService guarda modelos de plano e sequências didáticas e as aplica às aulas das turmas
+	lessonTemplateService = service.NewLessonTemplateService(repository.NewLessonTemplateRepository(db), lessonRepo, classRepo)
+
+	// AttachmentService guarda os arquivos anexados às aulas no diretório "anexos", ao lado do banco
+	attachmentService = service.NewAttachmentService(repository.NewLessonAttachmentRepository(db), lessonRepo, database.AttachmentsDir(dbConfig))
+
+	// AttendanceService registra a chamada das aulas (LessonRepository) para os alunos das turmas (ClassRepository)
+	attendanceService = service.NewAttendanceService(repository.NewAttendanceRepository(db), lessonRepo, classRepo)
 }
 
 // Variável global para LessonService para ser acessível pelo rootCmd.Run e app.StartApp
 var lessonService service.LessonService
 
+// timetableService mantém o horário semanal e gera as aulas a partir dele (comando 'horario' e TUI).
+var timetableService service.TimetableService
+
+// calendarService mantém o calendário escolar: bimestres, feriados, recessos e dias de planejamento (comando 'calendario').
+var calendarService service.CalendarService
+
+// lessonTemplateService mantém os modelos de plano de aula e as sequências didáticas (comandos 'modelo' e 'sequencia').
+var lessonTemplateService service.LessonTemplateService
+
+// attachmentService mantém os anexos das aulas: arquivos e links (comandos 'aula anexar', 'aula anexos' e TUI).
+var attachmentService service.AttachmentService
+
+// attendanceService registra a frequência dos alunos em cada aula (comando 'chamada' e TUI).
+var attendanceService service.AttendanceService
+
 func init() {
 	// Cobra command definitions and flag setups remain in init()
 
@@ -362,6 +509,9 @@ func init() {
 	taskAddCmd.Flags().StringP("description", "d", "", "Descrição detalhada da tarefa.")
 	taskAddCmd.Flags().String("classid", "", "ID da turma para associar a tarefa (opcional).")
 	taskAddCmd.Flags().String("duedate", "", "Data de conclusão da tarefa no formato YYYY-MM-DD (opcional).")
+	taskAddCmd.Flags().Int("estimativa", 0, "Duração estimada da tarefa em minutos (opcional, usada por 'planejar').")
+	taskAddCmd.Flags().String("prioridade", "", "Prioridade da tarefa: baixa, media ou alta (padrão: media).")
+	taskEstimateCmd.Flags().String("prioridade", "", "Nova prioridade da tarefa: baixa, media ou alta.")
 
 	// Setup flags for task list command
 	//taskListCmd.Flags().String("classid", "", "ID da turma para filtrar as tarefas (obrigatório).")
@@ -370,7 +520,15 @@ func init() {
 	taskListCmd.Flags().String("all", "false", "Listar todas as tarefas, incluindo tarefas de sistema/bugs (ignora --classid se presente).")
 
 
-	taskCmd.AddCommand(taskAddCmd, taskListCmd, taskCompleteCmd)
+	taskCmd.AddCommand(taskAddCmd, taskListCmd, taskCompleteCmd, taskPostponeCmd, taskMoveCmd, taskEstimateCmd)
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "formato", formatTable, "Formato de saída das listagens e relatórios: tabela, json ou csv.")
+	rootCmd.PersistentFlags().BoolVar(&debugErrors, "debug", false, "Em caso de erro, mostra a categoria e a cadeia completa de erros.")
+	// Erros são impressos por reportError em main; a ajuda completa só aparece com --help.
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError(err)
+	})
 	rootCmd.AddCommand(taskCmd)
 
 	// Class Service Commands
@@ -386,10 +544,11 @@ func init() {
 	// Assessment Service Commands
 	assessmentCreateCmd.Flags().String("classid", "", "ID da turma para a qual a avaliação será criada (obrigatório).")
 	_ = assessmentCreateCmd.MarkFlagRequired("classid")
-	assessmentCreateCmd.Flags().String("term", "", "Período/bimestre da avaliação (ex: 1, 2) (obrigatório).")
-	_ = assessmentCreateCmd.MarkFlagRequired("term")
+	assessmentCreateCmd.Flags().String("term", "", "Período/bimestre da avaliação (ex: 1, 2) (obrigatório sem --data).")
+	assessmentCreateCmd.Flags().String("data", "", "Data de aplicação, AAAA-MM-DD; sem --term, o bimestre vem do calendário escolar.")
 	assessmentCreateCmd.Flags().String("weight", "", "Peso da avaliação na média final (ex: 4.0) (obrigatório).")
 	_ = assessmentCreateCmd.MarkFlagRequired("weight")
+	assessmentCreateCmd.Flags().String("categoria", "", "Categoria da avaliação para a política de notas por categorias (ex: prova, trabalho).")
 
 	assessmentCmd.AddCommand(assessmentCreateCmd, assessmentEnterGradesCmd, assessmentClassAverageCmd)
 	rootCmd.AddCommand(assessmentCmd)
@@ -405,6 +564,7 @@ func init() {
 	proofGenerateCmd.Flags().String("easy", "0", "Número de questões fáceis.")
 	proofGenerateCmd.Flags().String("medium", "0", "Número de questões médias.")
 	proofGenerateCmd.Flags().String("hard", "0", "Número de questões difíceis.")
+	proofGenerateCmd.Flags().Bool("gabarito", false, "Inclui o gabarito (respostas) na saída.")
 	proofCmd.AddCommand(proofGenerateCmd)
 	rootCmd.AddCommand(proofCmd)
 }
@@ -473,13 +633,15 @@ func mustGetwd() string {
 
 var classCmd = &cobra.Command{
 	Use:   "turma",
-	Short: "Gerencia turmas e alunos (importar-alunos, atualizar-status)",
+	Short: "Gerencia turmas e alunos (importar-alunos, atualizar-status, politica-notas)",
 	Long: `O comando 'turma' é usado para administrar turmas,
 incluindo a importação de listas de alunos de ficheiros CSV
-e a atualização do status de alunos individuais (ex: ativo, inativo, transferido).
+e a atualização do status de alunos individuais (ex: ativo, inativo, transferido),
+além da política de cálculo das médias de cada turma.
 A criação de turmas é feita através da interface interativa principal (executando 'vigenda' sem subcomandos).`,
 	Example: `  vigenda turma importar-alunos 1 alunos_9a.csv
-  vigenda turma atualizar-status 15 transferido`,
+  vigenda turma atualizar-status 15 transferido
+  vigenda turma politica-notas 1 --esquema categorias --categorias "prova=60,trabalho=40"`,
 }
 
 // var classCreateCmd = &cobra.Command{...} // Removido
@@ -493,26 +655,24 @@ Consulte a documentação (README.md, Artefacto 9.1) para a estrutura detalhada
 	Example: `  vigenda turma importar-alunos 1 ./lista_alunos_turma_a.csv
   vigenda turma importar-alunos 3 /documentos/alunos_turma_c.csv`,
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		classID, err := strconv.ParseInt(args[0], 10, 64)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		classID, err := parseIDArg(args[0], "turma")
 		if err != nil {
-			fmt.Println("Error parsing Class ID:", err)
-			return
+			return err
 		}
 		csvFilePath := args[1]
 
 		csvData, err := os.ReadFile(csvFilePath)
 		if err != nil {
-			fmt.Println("Error reading CSV file:", err)
-			return
+			return &service.Error{Category: service.CategoryValidation, Message: fmt.Sprintf("não foi possível ler o arquivo CSV '%s'", csvFilePath), Err: err}
 		}
 
 		count, err := classService.ImportStudentsFromCSV(context.Background(), classID, csvData)
 		if err != nil {
-			fmt.Println("Error importing students:", err)
-			return
+			return fmt.Errorf("erro ao importar alunos: %w", err)
 		}
 		fmt.Printf("%d students imported successfully into class ID %d.\n", count, classID)
+		return nil
 	},
 }
 
@@ -525,11 +685,10 @@ Status permitidos: 'ativo', 'inativo', 'transferido'.`,
 	Example: `  vigenda turma atualizar-status 25 ativo
   vigenda turma atualizar-status 103 transferido`,
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		studentID, err := strconv.ParseInt(args[0], 10, 64)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		studentID, err := parseIDArg(args[0], "aluno")
 		if err != nil {
-			fmt.Println("Error parsing Student ID:", err)
-			return
+			return err
 		}
 		newStatus := args[1]
 		// TODO: Validate newStatus against allowed values ('ativo', 'inativo', 'transferido')
@@ -537,10 +696,10 @@ Status permitidos: 'ativo', 'inativo', 'transferido'.`,
 
 		err = classService.UpdateStudentStatus(context.Background(), studentID, newStatus)
 		if err != nil {
-			fmt.Println("Error updating student status:", err)
-			return
+			return fmt.Errorf("erro ao atualizar a situação do aluno: %w", err)
 		}
 		fmt.Printf("Status of student ID %d updated to '%s'.\n", studentID, newStatus)
+		return nil
 	},
 }
 
@@ -548,7 +707,7 @@ Status permitidos: 'ativo', 'inativo', 'transferido'.`,
 
 var assessmentCmd = &cobra.Command{
 	Use:   "avaliacao",
-	Short: "Gerencia avaliações e notas (criar, lancar-notas, media-turma)",
+	Short: "Gerencia avaliações e notas (criar, criar-recuperacao, categoria, lancar-notas, importar-notas, exportar, proximas, media-turma)",
 	Long: `O comando 'avaliacao' permite gerenciar todo o ciclo de vida das avaliações,
 desde a sua criação, passando pelo lançamento interativo de notas dos alunos,
 até o cálculo da média final da turma para uma avaliação específica.`,
@@ -562,174 +721,242 @@ var assessmentCreateCmd = &cobra.Command{
 	Short: "Cria uma nova avaliação para uma turma",
 	Long: `Cria uma nova avaliação associada a uma turma específica.
 É necessário fornecer o nome da avaliação e, através de flags, o ID da turma,
-o período/bimestre e o peso da avaliação na média final.`,
+o período/bimestre e o peso da avaliação na média final.
+Com --data (data de aplicação), o bimestre pode ser omitido: ele é obtido do calendário escolar.
+--categoria classifica a avaliação para a política de notas por categorias da turma.`,
 	Example: `  vigenda avaliacao criar "Trabalho de História Moderna" --classid 2 --term 3 --weight 3.5
-  vigenda avaliacao criar "Seminário de Literatura" --classid 1 --term 2 --weight 2.0`,
+  vigenda avaliacao criar "Seminário de Literatura" --classid 1 --term 2 --weight 2.0
+  vigenda avaliacao criar "Prova Bimestral 3" --classid 1 --data 2025-09-15 --weight 4.0
+  vigenda avaliacao criar "Trabalho em grupo" --classid 1 --term 2 --weight 1 --categoria trabalho`,
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 		classIDStr, _ := cmd.Flags().GetString("classid")
 		termStr, _ := cmd.Flags().GetString("term")
 		weightStr, _ := cmd.Flags().GetString("weight")
+		dateStr, _ := cmd.Flags().GetString("data")
+		category, _ := cmd.Flags().GetString("categoria")
 
 		// Interactive prompts for missing required flags
 		var err error
 		if classIDStr == "" {
 			classIDStr, err = tui.GetInput("Enter Class ID for the assessment:", os.Stdout, os.Stdin)
 			if err != nil || classIDStr == "" {
-				fmt.Println("Class ID is required.")
-				return
+				return service.ValidationErrorf("ID da turma é obrigatório")
 			}
 		}
-		if termStr == "" {
+		if termStr == "" && dateStr == "" {
 			termStr, err = tui.GetInput("Enter Term (e.g., 1, 2, 3, 4) for the assessment:", os.Stdout, os.Stdin)
 			if err != nil || termStr == "" {
-				fmt.Println("Term is required.")
-				return
+				return service.ValidationErrorf("bimestre é obrigatório")
 			}
 		}
 		if weightStr == "" {
 			weightStr, err = tui.GetInput("Enter Weight (e.g., 4.0) for the assessment:", os.Stdout, os.Stdin)
 			if err != nil || weightStr == "" {
-				fmt.Println("Weight is required.")
-				return
+				return service.ValidationErrorf("peso é obrigatório")
 			}
 		}
 
-		classID, err := strconv.ParseInt(classIDStr, 10, 64)
+		classID, err := parseIDArg(classIDStr, "turma")
 		if err != nil {
-			fmt.Println("Error parsing Class ID:", err)
-			return
+			return err
 		}
-		term, err := strconv.Atoi(termStr)
-		if err != nil {
-			fmt.Println("Error parsing Term:", err)
-			return
+		term := 0 // Com --data e sem --term, o bimestre vem do calendário escolar.
+		if termStr != "" {
+			if term, err = strconv.Atoi(termStr); err != nil {
+				return service.ValidationErrorf("bimestre inválido '%s': informe um número inteiro", termStr)
+			}
 		}
 		weight, err := strconv.ParseFloat(weightStr, 64)
 		if err != nil {
-			fmt.Println("Error parsing Weight:", err)
-			return
+			return service.ValidationErrorf("peso inválido '%s': informe um número (ex: 4.0)", weightStr)
 		}
 
-		assessment, err := assessmentService.CreateAssessment(context.Background(), name, classID, term, weight)
-		if err != nil {
-			fmt.Println("Error creating assessment:", err)
-			return
+		var assessment models.Assessment
+		if dateStr != "" {
+			date, err := parseDateFlag("data", dateStr)
+			if err != nil {
+				return err
+			}
+			assessment, err = assessmentService.CreateAssessmentOnDate(context.Background(), name, classID, term, weight, date)
+			if err != nil {
+				return fmt.Errorf("erro ao criar avaliação: %w", err)
+			}
+		} else if assessment, err = assessmentService.CreateAssessment(context.Background(), name, classID, term, weight); err != nil {
+			return fmt.Errorf("erro ao criar avaliação: %w", err)
+		}
+		if strings.TrimSpace(category) != "" {
+			if assessment, err = assessmentService.SetAssessmentCategory(context.Background(), assessment.ID, category); err != nil {
+				// Desfaz a criação para não deixar uma avaliação sem a categoria pedida.
+				_ = assessmentService.DeleteAssessment(context.Background(), assessment.ID)
+				return fmt.Errorf("erro ao definir a categoria da avaliação: %w", err)
+			}
 		}
-		fmt.Printf("Assessment '%s' (ID: %d) created for Class ID %d, Term %d, Weight %.1f.\n", assessment.Name, assessment.ID, classID, term, weight)
+		fmt.Printf("Assessment '%s' (ID: %d) created for Class ID %d, Term %d, Weight %.1f.\n", assessment.Name, assessment.ID, classID, assessment.Term, weight)
+		return nil
 	},
 }
 
 var assessmentEnterGradesCmd = &cobra.Command{
 	Use:   "lancar-notas [ID_da_avaliacao]",
 	Short: "Lança notas para os alunos de uma avaliação",
-	Long: `Inicia um processo interativo para lançar ou editar as notas dos alunos
-para uma avaliação específica. A lista de alunos da turma associada à avaliação
-será exibida, permitindo a inserção de cada nota.
+	Long: `Abre a lista dos alunos da turma da avaliação, com as notas já lançadas, para lançar ou
+editar as notas. Use as setas para escolher o aluno e 'enter' para editar a nota, digitada na escala
+da turma (número ou conceito) e validada ao confirmar; alunos transferidos ou inativos e, na
+recuperação, alunos acima da média de aprovação aparecem com "--". 'q' grava todas as notas
+alteradas de uma só vez e sai; 'esc' ou Ctrl+C sai sem gravar.
 O ID da avaliação é o identificador numérico único da avaliação.`,
 	Example: `  vigenda avaliacao lancar-notas 7
   vigenda avaliacao lancar-notas 2`,
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		assessmentID, err := strconv.ParseInt(args[0], 10, 64)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		assessmentID, err := parseIDArg(args[0], "avaliação")
 		if err != nil {
-			fmt.Println("Error parsing Assessment ID:", err)
-			return
-		}
-
-		// TODO: This is where the more complex TUI interaction for grade entry will go.
-		// 1. Fetch students for the class associated with the assessmentID.
-		//    (This might require adding a method to AssessmentService or ClassService,
-		//     or having AssessmentService return student info needed for grading).
-		// 2. Display students in a list/table, allowing navigation and grade input.
-		//    tui.GetInput can be used for each grade, or a more sophisticated BubbleTea model.
-		// 3. Collect all grades into a map[int64]float64 (studentID -> grade).
-		// 4. Call assessmentService.EnterGrades().
-
-		fmt.Printf("Interactive grade entry for Assessment ID %d is not yet fully implemented.\n", assessmentID)
-		fmt.Println("Simulating grade entry for now...")
-
-		// Placeholder for student grades map
-		studentGrades := make(map[int64]float64)
-		// Example: studentGrades[101] = 8.5
-		// Example: studentGrades[102] = 9.0
-		// In a real scenario, this map would be populated via TUI.
-
-		// For now, let's assume the user will input studentID:grade pairs via flags or prompts
-		// For a better UX as per Artefact 7 (golden_files/notas_lancar_interativo_output.txt),
-		// a full BubbleTea model would be needed here.
-		// We will simulate a simple input loop for now.
-
-		fmt.Println("Enter student grades (StudentID:Grade). Type 'done' when finished.")
-		for {
-			input, _ := tui.GetInput("Enter StudentID:Grade (or 'done'):", os.Stdout, os.Stdin)
-			if input == "done" {
-				break
-			}
-			parts := strings.Split(input, ":")
-			if len(parts) != 2 {
-				fmt.Println("Invalid format. Use StudentID:Grade.")
-				continue
-			}
-			studentID, errS := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
-			grade, errG := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-			if errS != nil || errG != nil {
-				fmt.Println("Invalid StudentID or Grade value.")
-				continue
-			}
-			studentGrades[studentID] = grade
+			return err
 		}
 
-
-		if len(studentGrades) > 0 {
-			err = assessmentService.EnterGrades(context.Background(), assessmentID, studentGrades)
-			if err != nil {
-				fmt.Println("Error entering grades:", err)
-				return
-			}
-			fmt.Println("Grades entered successfully for Assessment ID", assessmentID)
-		} else {
-			fmt.Println("No grades were entered.")
+		if !isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd()) {
+			return service.ValidationErrorf("o lançamento de notas é interativo: execute 'vigenda avaliacao lancar-notas %d' em um terminal", assessmentID)
+		}
+		model := grades.New(assessmentService, classService, assessmentID)
+		if _, err := tea.NewProgram(model).Run(); err != nil {
+			return fmt.Errorf("erro ao executar o lançamento de notas: %w", err)
 		}
+		if err := model.Err(); err != nil {
+			return err
+		}
+		switch {
+		case model.Cancelled():
+			fmt.Println("Lançamento cancelado: as notas alteradas não foram gravadas.")
+		case model.Saved() > 0:
+			fmt.Printf("%d nota(s) lançada(s) para a avaliação '%s' (ID: %d).\n", model.Saved(), model.Assessment().Name, assessmentID)
+		default:
+			fmt.Println("Nenhuma nota foi alterada.")
+		}
+		return nil
 	},
 }
 
 var assessmentClassAverageCmd = &cobra.Command{
 	Use:   "media-turma [ID_da_turma]",
 	Short: "Calcula a média geral das notas de uma turma",
-	Long: `Calcula e exibe a média geral ponderada das notas para uma turma específica,
-considerando todas as avaliações e seus respectivos pesos.
+	Long: `Calcula e exibe a média das notas de cada aluno de uma turma específica, considerando
+todas as avaliações, segundo a política de notas da turma (por padrão, a média ponderada
+pelos pesos; ver 'vigenda turma politica-notas'), já com as recuperações aplicadas. Se
+alguma recuperação alterou a média de um aluno, a média original também é exibida.
 O ID da turma é o identificador numérico único da turma.`,
 	Example: `  vigenda avaliacao media-turma 1
   vigenda avaliacao media-turma 5`,
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		classID, err := strconv.ParseInt(args[0], 10, 64)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		classID, err := parseIDArg(args[0], "turma")
 		if err != nil {
-			fmt.Println("Error parsing Class ID:", err)
-			return
+			return err
 		}
 
 		// Passing nil for terms to calculate the overall average
-		studentAverages, err := assessmentService.CalculateClassAverage(context.Background(), classID, nil)
+		detailedAverages, err := assessmentService.CalculateClassAverages(context.Background(), classID, nil)
 		if err != nil {
-			fmt.Println("Error calculating class average:", err)
-			return
+			return fmt.Errorf("erro ao calcular a média da turma: %w", err)
+		}
+		studentAverages := make(map[int64]float64, len(detailedAverages))
+		anyRecovered := false
+		for studentID, average := range detailedAverages {
+			studentAverages[studentID] = average.Adjusted
+			anyRecovered = anyRecovered || average.Recovered
+		}
+		policy, err := assessmentService.GetGradingPolicy(context.Background(), classID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar a política de notas: %w", err)
 		}
 
-		if len(studentAverages) == 0 {
+		if len(studentAverages) == 0 && isTableOutput() {
 			fmt.Println("No students with grades found to calculate an average.")
-			return
+			return nil
 		}
 
-		var totalAverage float64
+		var totalAverage, overallAverage float64
 		for _, avg := range studentAverages {
 			totalAverage += avg
 		}
-		overallAverage := totalAverage / float64(len(studentAverages))
+		if len(studentAverages) > 0 {
+			overallAverage = totalAverage / float64(len(studentAverages))
+		}
+
+		// Nomes dos alunos para o relatório; se a busca falhar, a listagem mostra apenas os IDs.
+		names := make(map[int64]string)
+		var studentIDs []int64
+		if students, err := classService.GetStudentsByClassID(context.Background(), classID); err == nil {
+			for _, student := range students {
+				names[student.ID] = student.FullName
+				if _, ok := studentAverages[student.ID]; ok {
+					studentIDs = append(studentIDs, student.ID)
+				}
+			}
+		}
+		for studentID := range studentAverages {
+			if _, ok := names[studentID]; !ok {
+				studentIDs = append(studentIDs, studentID)
+			}
+		}
+
+		// Nas escalas conceituais, o JSON traz também o conceito de cada média.
+		concept := func(value float64) string {
+			if service.IsConceptScale(policy.GradeScale) {
+				return service.GradeConcept(policy, value)
+			}
+			return ""
+		}
+		type studentAverageOutput struct {
+			StudentID       int64   `json:"student_id"`
+			FullName        string  `json:"full_name,omitempty"`
+			Average         float64 `json:"average"`
+			Concept         string  `json:"concept,omitempty"`
+			OriginalAverage float64 `json:"original_average"`
+			Recovered       bool    `json:"recovered"`
+		}
+		report := struct {
+			ClassID        int64                  `json:"class_id"`
+			GradeScale     string                 `json:"grade_scale"`
+			OverallAverage float64                `json:"overall_average"`
+			OverallConcept string                 `json:"overall_concept,omitempty"`
+			Students       []studentAverageOutput `json:"students"`
+		}{ClassID: classID, GradeScale: policy.GradeScale, OverallAverage: overallAverage, OverallConcept: concept(overallAverage), Students: []studentAverageOutput{}}
 
-		fmt.Printf("Overall average grade for Class ID %d: %.2f\n", classID, overallAverage)
+		columns := []table.Column{
+			{Title: "ID", Width: 4},
+			{Title: "ALUNO", Width: 35},
+			{Title: "MÉDIA", Width: 6},
+		}
+		if anyRecovered {
+			columns = append(columns, table.Column{Title: "MÉDIA ORIGINAL", Width: 14})
+		}
+		var rows []table.Row
+		for _, studentID := range studentIDs {
+			average := detailedAverages[studentID]
+			report.Students = append(report.Students, studentAverageOutput{
+				StudentID: studentID, FullName: names[studentID], Average: average.Adjusted, Concept: concept(average.Adjusted),
+				OriginalAverage: average.Original, Recovered: average.Recovered,
+			})
+			row := table.Row{fmt.Sprintf("%d", studentID), names[studentID], formatAverage(policy, average.Adjusted)}
+			if anyRecovered {
+				original := formatAverage(policy, average.Original)
+				if average.Recovered {
+					original += " (recuperação)"
+				}
+				row = append(row, original)
+			}
+			rows = append(rows, row)
+		}
+
+		if err := writeList(os.Stdout, listOutput{Columns: columns, Rows: rows, Data: report}); err != nil {
+			return err
+		}
+		if isTableOutput() {
+			fmt.Printf("\nOverall average grade for Class ID %d: %s\n", classID, formatAverage(policy, overallAverage))
+		}
+		return nil
 	},
 }
 
@@ -754,20 +981,19 @@ opções (para múltipla escolha) e resposta correta.`,
 	Example: `  vigenda bancoq add questoes_bimestre1.json
   vigenda bancoq add ../shared/questoes_revisao.json`,
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		jsonFilePath := args[0]
 		jsonData, err := os.ReadFile(jsonFilePath)
 		if err != nil {
-			fmt.Println("Error reading JSON file:", err)
-			return
+			return &service.Error{Category: service.CategoryValidation, Message: fmt.Sprintf("não foi possível ler o arquivo JSON '%s'", jsonFilePath), Err: err}
 		}
 
 		count, err := questionService.AddQuestionsFromJSON(context.Background(), jsonData)
 		if err != nil {
-			fmt.Println("Error adding questions from JSON:", err)
-			return
+			return fmt.Errorf("erro ao adicionar questões do JSON: %w", err)
 		}
 		fmt.Printf("%d questions added successfully to the bank.\n", count)
+		return nil
 	},
 }
 
@@ -788,10 +1014,11 @@ var proofGenerateCmd = &cobra.Command{
 	Long: `Gera uma prova selecionando questões do banco de questões.
 É obrigatório especificar o ID da disciplina. Opcionalmente, pode-se filtrar por tópico
 e definir o número de questões para cada nível de dificuldade (fácil, médio, difícil).
-A prova gerada será exibida no terminal.`,
+A prova gerada será exibida no terminal, sem as respostas; use --gabarito para incluir
+o gabarito separado ao final (ou na coluna/campo de resposta nos formatos csv e json).`,
 	Example: `  vigenda prova gerar --subjectid 1 --easy 5 --medium 3 --hard 2 --topic "Revolução Industrial"
   vigenda prova gerar --subjectid 3 --medium 10 --hard 5`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		subjectIDStr, _ := cmd.Flags().GetString("subjectid")
 		topic, _ := cmd.Flags().GetString("topic")
 		easyCountStr, _ := cmd.Flags().GetString("easy")
@@ -799,22 +1026,28 @@ A prova gerada será exibida no terminal.`,
 		hardCountStr, _ := cmd.Flags().GetString("hard")
 
 		if subjectIDStr == "" {
-			fmt.Println("Subject ID (--subjectid) is required.")
-			return
+			return service.ValidationErrorf("o ID da disciplina (--subjectid) é obrigatório")
 		}
-		subjectID, err := strconv.ParseInt(subjectIDStr, 10, 64)
+		subjectID, err := parseIDArg(subjectIDStr, "disciplina")
 		if err != nil {
-			fmt.Println("Invalid Subject ID:", err)
-			return
+			return err
 		}
 
-		easyCount, _ := strconv.Atoi(easyCountStr)   // Default to 0 if not provided or invalid
-		mediumCount, _ := strconv.Atoi(mediumCountStr) // Default to 0
-		hardCount, _ := strconv.Atoi(hardCountStr)     // Default to 0
+		easyCount, err := parseCountFlag("easy", easyCountStr)
+		if err != nil {
+			return err
+		}
+		mediumCount, err := parseCountFlag("medium", mediumCountStr)
+		if err != nil {
+			return err
+		}
+		hardCount, err := parseCountFlag("hard", hardCountStr)
+		if err != nil {
+			return err
+		}
 
 		if easyCount == 0 && mediumCount == 0 && hardCount == 0 {
-			fmt.Println("At least one difficulty count (--easy, --medium, --hard) must be greater than zero.")
-			return
+			return service.ValidationErrorf("pelo menos uma contagem de dificuldade (--easy, --medium, --hard) deve ser maior que zero")
 		}
 
 		criteria := service.ProofCriteria{
@@ -829,50 +1062,103 @@ A prova gerada será exibida no terminal.`,
 
 		questions, err := proofService.GenerateProof(context.Background(), criteria)
 		if err != nil {
-			fmt.Println("Error generating proof:", err)
-			return
+			return fmt.Errorf("erro ao gerar prova: %w", err)
 		}
 
-		if len(questions) == 0 {
+		if len(questions) == 0 && isTableOutput() {
 			fmt.Println("No questions matched the criteria to generate the proof.")
-			return
-		}
-
-		fmt.Printf("Proof generated successfully with %d questions:\n\n", len(questions))
-		// Display questions using TUI table or simple print
-		// For now, a simple print. Later, can use tui.ShowTable.
-		for i, q := range questions {
-			fmt.Printf("Q%d (%s, %s): %s\n", i+1, q.Difficulty, q.Type, q.Statement)
-			if q.Options != nil && *q.Options != "" && *q.Options != "null" {
-				var opts []string
-				if json.Unmarshal([]byte(*q.Options), &opts) == nil {
-					for j, opt := range opts {
-						fmt.Printf("  %c) %s\n", 'a'+j, opt)
-					}
-				}
-			}
-			fmt.Printf("   Answer: %s\n\n", q.CorrectAnswer)
+			return nil
 		}
+
+		showAnswers, _ := cmd.Flags().GetBool("gabarito")
+		return writeProof(os.Stdout, questions, showAnswers)
 	},
 }
 
+// parseCountFlag interpreta uma flag de quantidade de questões (--easy, --medium, --hard).
+func parseCountFlag(name, value string) (int, error) {
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return 0, service.ValidationErrorf("valor inválido para --%s '%s': informe um número inteiro não negativo", name, value)
+	}
+	return count, nil
+}
+
+// proofQuestionOutput é a representação de uma questão de prova nos formatos json e csv.
+// A resposta só é preenchida quando o gabarito é solicitado.
+type proofQuestionOutput struct {
+	Number     int      `json:"number"`
+	QuestionID int64    `json:"question_id"`
+	Difficulty string   `json:"difficulty"`
+	Type       string   `json:"type"`
+	Statement  string   `json:"statement"`
+	Options    []string `json:"options,omitempty"`
+	Answer     string   `json:"answer,omitempty"`
+}
+
+// writeProof escreve a prova gerada no formato de --formato. No formato tabela, as questões são
+// impressas como texto corrido e o gabarito, se pedido, vem separado no final.
+func writeProof(w io.Writer, questions []models.Question, showAnswers bool) error {
+	items := make([]proofQuestionOutput, 0, len(questions))
+	for i, q := range questions {
+		item := proofQuestionOutput{Number: i + 1, QuestionID: q.ID, Difficulty: q.Difficulty, Type: q.Type, Statement: q.Statement}
+		if q.Options != nil && *q.Options != "" && *q.Options != "null" {
+			_ = json.Unmarshal([]byte(*q.Options), &item.Options)
+		}
+		if showAnswers {
+			item.Answer = q.CorrectAnswer
+		}
+		items = append(items, item)
+	}
+
+	if !isTableOutput() {
+		columns := []table.Column{{Title: "N"}, {Title: "ID"}, {Title: "DIFICULDADE"}, {Title: "TIPO"}, {Title: "ENUNCIADO"}, {Title: "OPÇÕES"}}
+		if showAnswers {
+			columns = append(columns, table.Column{Title: "RESPOSTA"})
+		}
+		var rows []table.Row
+		for _, item := range items {
+			row := table.Row{fmt.Sprintf("%d", item.Number), fmt.Sprintf("%d", item.QuestionID), item.Difficulty, item.Type, item.Statement, strings.Join(item.Options, " | ")}
+			if showAnswers {
+				row = append(row, item.Answer)
+			}
+			rows = append(rows, row)
+		}
+		return writeList(w, listOutput{Columns: columns, Rows: rows, Data: items})
+	}
+
+	fmt.Fprintf(w, "Proof generated successfully with %d questions:\n\n", len(items))
+	for _, item := range items {
+		fmt.Fprintf(w, "Q%d (%s, %s): %s\n", item.Number, item.Difficulty, item.Type, item.Statement)
+		for j, opt := range item.Options {
+			fmt.Fprintf(w, "  %c) %s\n", 'a'+j, opt)
+		}
+		fmt.Fprintln(w)
+	}
+	if showAnswers {
+		fmt.Fprintln(w, "GABARITO:")
+		for _, item := range items {
+			fmt.Fprintf(w, "  Q%d: %s\n", item.Number, item.Answer)
+		}
+	}
+	return nil
+}
+
 func main() {
 	// PersistentPreRunE já chama setupLogging.
 	// Precisamos garantir que logFile seja fechado ao final da execução.
-	// rootCmd.Execute() é bloqueante.
-	// Uma forma de garantir o fechamento é adiar para main.
-	// No entanto, setupLogging pode falhar e logFile ser nil.
-
-	if err := rootCmd.Execute(); err != nil {
-		// Se Execute falhar, o log já deve ter sido configurado (ou tentado)
-		// e o erro de Execute pode ser logado no arquivo (se o log de arquivo estiver ok)
-		// ou no stderr (se o log de arquivo falhou).
-		log.Printf("CRITICAL: rootCmd.Execute failed: %v", err) // Vai para o arquivo de log se configurado
-		fmt.Fprintln(os.Stderr, "Error executing command:", err) // Também para stderr para visibilidade imediata
+	// Os erros são impressos por reportError (stderr) e o código de saída depende da categoria do erro.
+	wrapArgsValidation(rootCmd)
+
+	cmd, err := rootCmd.ExecuteC()
+	if err = normalizeCobraError(err); err != nil {
+		// A mensagem para o usuário vai para o stderr; o log só registra se o arquivo foi configurado.
+		reportError(os.Stderr, cmd, err)
 		if logFile != nil {
+			log.Printf("CRITICAL: rootCmd.Execute failed: %v", err)
 			logFile.Close()
 		}
-		os.Exit(1)
+		os.Exit(exitCodeFor(err))
 	}
 
 	// Se Execute for bem-sucedido e a aplicação terminar normalmente
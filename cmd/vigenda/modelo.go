@@ -0,0 +1,153 @@
+// Este arquivo (modelo.go) define o comando 'modelo', que mantém os modelos de plano de aula usados
+// para preencher o plano de novas aulas.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/tui/markdown"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "modelo",
+	Short: "Gerencia modelos de plano de aula (criar, listar, ver, remover)",
+	Long: `O comando 'modelo' mantém modelos reutilizáveis de plano de aula em Markdown (objetivos,
+habilidades, metodologia, recursos e avaliação). Um modelo preenche o plano de uma nova aula com
+'vigenda aula criar --modelo ID' ou com ctrl+t no formulário de aulas da TUI.`,
+	Example: `  vigenda modelo criar "Aula expositiva"
+  vigenda modelo criar "Laboratório" --plano laboratorio.md
+  vigenda aula criar "Densidade" --turma 1 --data "2025-06-20 08:00" --modelo 2`,
+}
+
+var templateCreateCmd = &cobra.Command{
+	Use:   "criar [nome]",
+	Short: "Cria um modelo de plano de aula",
+	Long: `Cria um modelo com o plano em Markdown lido de --plano (arquivo, ou - para a entrada padrão).
+Sem --plano, o modelo começa com as seções Objetivos, Habilidades (BNCC), Metodologia, Recursos e Avaliação.`,
+	Example: `  vigenda modelo criar "Aula expositiva"
+  vigenda modelo criar "Laboratório" --plano laboratorio.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		planPath, _ := cmd.Flags().GetString("plano")
+		content, err := readLessonPlan(planPath)
+		if err != nil {
+			return err
+		}
+		template, err := lessonTemplateService.CreateTemplate(context.Background(), args[0], content)
+		if err != nil {
+			return fmt.Errorf("erro ao criar modelo: %w", err)
+		}
+		fmt.Printf("Modelo '%s' (ID: %d) criado.\n", template.Name, template.ID)
+		return nil
+	},
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "listar",
+	Short: "Lista os modelos de plano de aula",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templates, err := lessonTemplateService.ListTemplates(context.Background())
+		if err != nil {
+			return fmt.Errorf("erro ao listar modelos: %w", err)
+		}
+		if len(templates) == 0 && isTableOutput() {
+			fmt.Println("Nenhum modelo de plano cadastrado.")
+			return nil
+		}
+		return writeTemplates(os.Stdout, "MODELOS DE PLANO DE AULA", templates)
+	},
+}
+
+var templateShowCmd = &cobra.Command{
+	Use:     "ver [ID_do_modelo]",
+	Short:   "Mostra um modelo de plano de aula formatado",
+	Example: `  vigenda modelo ver 2`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templateID, err := parseIDArg(args[0], "modelo")
+		if err != nil {
+			return err
+		}
+		template, err := lessonTemplateService.GetTemplate(context.Background(), templateID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar modelo: %w", err)
+		}
+		if !isTableOutput() {
+			return writeTemplates(os.Stdout, "", []models.LessonTemplate{template})
+		}
+		fmt.Println(template.Name)
+		fmt.Println(strings.Repeat("=", len([]rune(template.Name))))
+		fmt.Println(markdown.Render(template.Content, lessonPlanWidth))
+		return nil
+	},
+}
+
+var templateDeleteCmd = &cobra.Command{
+	Use:     "remover [ID_do_modelo]",
+	Short:   "Remove um modelo de plano de aula",
+	Long:    `Remove o modelo. As aulas criadas a partir dele não são alteradas.`,
+	Example: `  vigenda modelo remover 2`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templateID, err := parseIDArg(args[0], "modelo")
+		if err != nil {
+			return err
+		}
+		if err := lessonTemplateService.DeleteTemplate(context.Background(), templateID); err != nil {
+			return fmt.Errorf("erro ao remover modelo: %w", err)
+		}
+		fmt.Printf("Modelo ID %d removido.\n", templateID)
+		return nil
+	},
+}
+
+// writeTemplates escreve uma lista de modelos no formato de --formato.
+// No formato json, o conteúdo completo de cada modelo é incluído.
+func writeTemplates(w io.Writer, header string, templates []models.LessonTemplate) error {
+	columns := []table.Column{
+		{Title: "ID", Width: 4},
+		{Title: "NOME", Width: 30},
+		{Title: "SEÇÕES", Width: 60},
+	}
+	rows := []table.Row{}
+	for _, template := range templates {
+		rows = append(rows, table.Row{fmt.Sprintf("%d", template.ID), template.Name, planSections(template.Content)})
+	}
+	return writeList(w, listOutput{Header: header, Columns: columns, Rows: rows, Data: templates})
+}
+
+// planSections resume um plano em Markdown pelos seus títulos (ex: "Objetivos, Metodologia").
+func planSections(content string) string {
+	var sections []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			sections = append(sections, strings.TrimSpace(strings.TrimLeft(line, "#")))
+		}
+	}
+	return strings.Join(sections, ", ")
+}
+
+// templatePlan retorna o conteúdo do modelo 'templateID' para preencher o plano de uma aula.
+func templatePlan(ctx context.Context, templateID int64) (string, error) {
+	template, err := lessonTemplateService.GetTemplate(ctx, templateID)
+	if err != nil {
+		return "", fmt.Errorf("erro ao carregar modelo: %w", err)
+	}
+	return template.Content, nil
+}
+
+func init() {
+	templateCreateCmd.Flags().String("plano", "", "Arquivo Markdown com o plano do modelo, ou - para ler da entrada padrão.")
+
+	templateCmd.AddCommand(templateCreateCmd, templateListCmd, templateShowCmd, templateDeleteCmd)
+	rootCmd.AddCommand(templateCmd)
+}
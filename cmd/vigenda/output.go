@@ -0,0 +1,170 @@
+// Este arquivo (output.go) define a camada de saída compartilhada pelos comandos de listagem
+// e relatório da CLI. O formato é escolhido pela flag global --formato (tabela, json ou csv).
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"vigenda/internal/config"
+	"vigenda/internal/report"
+	"vigenda/internal/service"
+	"vigenda/internal/tui"
+)
+
+// Formatos aceitos pela flag --formato.
+const (
+	formatTable = "tabela"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// outputFormat guarda o valor da flag global --formato.
+var outputFormat = formatTable
+
+// validateOutputFormat normaliza e valida o valor de --formato.
+func validateOutputFormat() error {
+	outputFormat = strings.ToLower(strings.TrimSpace(outputFormat))
+	switch outputFormat {
+	case formatTable, formatJSON, formatCSV:
+		return nil
+	default:
+		return service.ValidationErrorf("formato de saída inválido '%s': use tabela, json ou csv", outputFormat)
+	}
+}
+
+// isTableOutput informa se a saída é para leitura humana, caso em que cabeçalhos,
+// mensagens informativas e prompts podem ser impressos junto com os dados.
+func isTableOutput() bool {
+	return outputFormat == formatTable
+}
+
+// listOutput descreve o resultado de um comando de listagem ou relatório.
+// Columns/Rows alimentam os formatos tabela e csv; Data é o valor serializado no formato json
+// (normalmente a slice de models correspondente, para preservar todos os campos).
+type listOutput struct {
+	Header  string // Header é impresso acima da tabela, apenas no formato tabela.
+	Columns []table.Column
+	Rows    []table.Row
+	Data    interface{}
+}
+
+// writeList escreve 'out' em 'w' no formato escolhido por --formato.
+func writeList(w io.Writer, out listOutput) error {
+	switch outputFormat {
+	case formatJSON:
+		return writeJSON(w, out.Data)
+	case formatCSV:
+		return writeCSV(w, out.Columns, out.Rows)
+	default:
+		if out.Header != "" {
+			fmt.Fprintf(w, "%s\n\n", out.Header)
+		}
+		tui.WriteTable(w, out.Columns, out.Rows)
+		return nil
+	}
+}
+
+// writeJSON serializa 'data' como JSON indentado.
+func writeJSON(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("erro ao gerar saída JSON: %w", err)
+	}
+	return nil
+}
+
+// writeCSV escreve os títulos das colunas seguidos das linhas, sem truncar valores.
+func writeCSV(w io.Writer, columns []table.Column, rows []table.Row) error {
+	writer := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Title
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("erro ao gerar saída CSV: %w", err)
+	}
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("erro ao gerar saída CSV: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("erro ao gerar saída CSV: %w", err)
+	}
+	return nil
+}
+
+// reportFormat retorna o formato em que writeReport grava o relatório: o da extensão de 'path' ou,
+// sem 'path', CSV com --formato csv e HTML nos demais casos.
+func reportFormat(path string) (string, error) {
+	if path == "" {
+		if outputFormat == formatCSV {
+			return report.FormatCSV, nil
+		}
+		return report.FormatHTML, nil
+	}
+	format, err := report.FormatFromPath(path)
+	if err != nil {
+		return "", service.ValidationErrorf("%v", err)
+	}
+	return format, nil
+}
+
+// schoolLogo lê o logotipo da escola de VIGENDA_LOGO, se definido.
+func schoolLogo() ([]byte, error) {
+	path := config.LogoPath()
+	if path == "" {
+		return nil, nil
+	}
+	logo, err := os.ReadFile(path)
+	if err != nil {
+		return nil, service.ValidationErrorf("não foi possível ler o logotipo de %s '%s': %v", config.LogoEnv, path, err)
+	}
+	if _, err := report.LogoType(logo); err != nil {
+		return nil, service.ValidationErrorf("logotipo de %s '%s' inválido: %v", config.LogoEnv, path, err)
+	}
+	return logo, nil
+}
+
+// writeReport grava os relatórios imprimíveis em 'path', no formato de reportFormat. Sem 'path', o
+// relatório é escrito na saída padrão. Em HTML e PDF, os documentos levam o logotipo da escola.
+func writeReport(path string, docs ...report.Document) error {
+	format, err := reportFormat(path)
+	if err != nil {
+		return err
+	}
+	if format != report.FormatCSV {
+		logo, err := schoolLogo()
+		if err != nil {
+			return err
+		}
+		for i := range docs {
+			docs[i].Logo = logo
+		}
+	}
+	if path == "" {
+		return report.Write(os.Stdout, format, docs...)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return service.StorageError(fmt.Sprintf("não foi possível criar o arquivo '%s'", path), err)
+	}
+	if err := report.Write(file, format, docs...); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return service.StorageError(fmt.Sprintf("não foi possível gravar o arquivo '%s'", path), err)
+	}
+	fmt.Printf("Relatório gravado em %s.\n", path)
+	return nil
+}
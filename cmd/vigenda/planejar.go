@@ -0,0 +1,240 @@
+// Este arquivo (planejar.go) define o comando 'planejar', que monta o plano diário
+// combinando as aulas do dia com as tarefas pendentes que cabem nos horários livres.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+	"vigenda/internal/tui"
+)
+
+var planningService service.PlanningService
+
+var planCmd = &cobra.Command{
+	Use:   "planejar",
+	Short: "Planeja o dia em blocos de horário (aulas + tarefas)",
+	Long: `Monta um plano do dia em blocos de horário.
+As aulas do dia ocupam blocos fixos; nos horários livres, o Vigenda sugere tarefas pendentes
+ordenadas por prazo, prioridade e duração estimada, e você escolhe com quais se compromete.
+O plano gravado aparece no Painel de Controle como "Agora / Próximo".`,
+	Example: `  vigenda planejar hoje
+  vigenda planejar hoje --inicio 13:00 --fim 18:00
+  vigenda planejar hoje --tarefas 4,7
+  vigenda planejar ver`,
+}
+
+var planTodayCmd = &cobra.Command{
+	Use:   "hoje",
+	Short: "Sugere e grava o plano de hoje",
+	Long: `Lista as aulas de hoje, os horários livres e as tarefas sugeridas, indicando em que horário
+cada uma caberia. Escolha as tarefas pelo ID (separados por vírgula) no prompt ou com --tarefas;
+use --sugeridas para aceitar todas as que cabem. Tarefas sem estimativa contam como 30 minutos.`,
+	Example: `  vigenda planejar hoje
+  vigenda planejar hoje --tarefas 4,7
+  vigenda planejar hoje --sugeridas --fim 17:00`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		start, _ := cmd.Flags().GetString("inicio")
+		end, _ := cmd.Flags().GetString("fim")
+		chosenStr, _ := cmd.Flags().GetString("tarefas")
+		acceptSuggested, _ := cmd.Flags().GetBool("sugeridas")
+
+		window, err := service.NewDayWindow(time.Now(), start, end)
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		proposal, err := planningService.ProposeDayPlan(ctx, window)
+		if err != nil {
+			return fmt.Errorf("erro ao montar sugestões do dia: %w", err)
+		}
+		if isTableOutput() {
+			printDayPlanProposal(proposal)
+		}
+
+		var taskIDs []int64
+		switch {
+		case acceptSuggested:
+			for _, suggestion := range proposal.Suggestions {
+				if suggestion.Slot != nil {
+					taskIDs = append(taskIDs, suggestion.Task.ID)
+				}
+			}
+		default:
+			if chosenStr == "" && len(proposal.Suggestions) > 0 && isTableOutput() {
+				chosenStr, err = tui.GetInput("IDs das tarefas para o plano (separados por vírgula, vazio para nenhuma):", os.Stdout, os.Stdin)
+				if err != nil {
+					return fmt.Errorf("erro ao ler escolha: %w", err)
+				}
+			}
+			taskIDs, err = parseIDList(chosenStr)
+			if err != nil {
+				return err
+			}
+		}
+
+		blocks, err := planningService.CommitDayPlan(ctx, window, taskIDs)
+		if err != nil {
+			return fmt.Errorf("erro ao gravar plano: %w", err)
+		}
+		if isTableOutput() {
+			fmt.Println()
+		}
+		return writeDayPlan(os.Stdout, time.Now(), blocks)
+	},
+}
+
+var planShowCmd = &cobra.Command{
+	Use:   "ver",
+	Short: "Mostra o plano gravado para hoje",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		blocks, err := planningService.GetDayPlan(context.Background(), now)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar plano: %w", err)
+		}
+		if len(blocks) == 0 && isTableOutput() {
+			fmt.Println("Nenhum plano gravado para hoje. Use 'vigenda planejar hoje'.")
+			return nil
+		}
+		return writeDayPlan(os.Stdout, now, blocks)
+	},
+}
+
+// printDayPlanProposal imprime aulas, horários livres e tarefas sugeridas de uma proposta de plano.
+func printDayPlanProposal(proposal service.DayPlanProposal) {
+	fmt.Printf("PLANEJAMENTO DE %s (%s–%s)\n\n", proposal.Window.Start.Format("02/01/2006"),
+		proposal.Window.Start.Format("15:04"), proposal.Window.End.Format("15:04"))
+
+	fmt.Println("AULAS:")
+	if len(proposal.Lessons) == 0 {
+		fmt.Println("  Nenhuma aula hoje.")
+	}
+	for _, lesson := range proposal.Lessons {
+		fmt.Printf("  %s  %s\n", formatBlockTime(lesson.StartAt, lesson.EndAt), lesson.Title)
+	}
+
+	fmt.Println("\nHORÁRIOS LIVRES:")
+	if len(proposal.FreeSlots) == 0 {
+		fmt.Println("  Nenhum horário livre.")
+	}
+	for _, slot := range proposal.FreeSlots {
+		fmt.Printf("  %s  (%d min)\n", formatBlockTime(slot.Start, slot.End), slot.Minutes())
+	}
+
+	fmt.Println("\nTAREFAS SUGERIDAS:")
+	if len(proposal.Suggestions) == 0 {
+		fmt.Println("  Nenhuma tarefa pendente.")
+		return
+	}
+	fmt.Printf("%-4s | %-35s | %-10s | %-7s | %-7s | %s\n", "ID", "TAREFA", "PRAZO", "PRIOR.", "ESTIM.", "HORÁRIO")
+	fmt.Printf("%s\n", strings.Repeat("-", 4+3+35+3+10+3+7+3+7+3+11))
+	for _, suggestion := range proposal.Suggestions {
+		dueDate := "N/A"
+		if suggestion.Task.DueDate != nil {
+			dueDate = suggestion.Task.DueDate.Format("02/01/2006")
+		}
+		estimate := fmt.Sprintf("%dmin", suggestion.Minutes)
+		if suggestion.DefaultEstimate {
+			estimate = "~" + estimate
+		}
+		slot := "não cabe"
+		if suggestion.Slot != nil {
+			slot = formatBlockTime(suggestion.Slot.Start, suggestion.Slot.End)
+		}
+		title := suggestion.Task.Title
+		if len([]rune(title)) > 35 {
+			title = string([]rune(title)[:32]) + "..."
+		}
+		fmt.Printf("%-4d | %-35s | %-10s | %-7s | %-7s | %s\n", suggestion.Task.ID, title, dueDate,
+			service.TaskPriorityLabel(suggestion.Task.Priority), estimate, slot)
+	}
+	fmt.Println()
+}
+
+// writeDayPlan escreve os blocos de um plano gravado no formato de --formato.
+// No formato tabela, o bloco em andamento é marcado com "▶".
+func writeDayPlan(w io.Writer, now time.Time, blocks []models.PlanBlock) error {
+	columns := []table.Column{
+		{Title: "", Width: 1},
+		{Title: "HORÁRIO", Width: 11},
+		{Title: "TIPO", Width: 6},
+		{Title: "TÍTULO", Width: 40},
+	}
+	rows := []table.Row{}
+	for _, block := range blocks {
+		marker := ""
+		if !now.Before(block.StartAt) && now.Before(block.EndAt) {
+			marker = "▶"
+		}
+		rows = append(rows, table.Row{marker, formatBlockTime(block.StartAt, block.EndAt), block.Kind, block.Title})
+	}
+	if !isTableOutput() {
+		columns = []table.Column{{Title: "INICIO"}, {Title: "FIM"}, {Title: "TIPO"}, {Title: "TITULO"}, {Title: "TAREFA_ID"}, {Title: "AULA_ID"}}
+		rows = []table.Row{}
+		for _, block := range blocks {
+			rows = append(rows, table.Row{block.StartAt.Format(time.RFC3339), block.EndAt.Format(time.RFC3339), block.Kind, block.Title,
+				formatOptionalID(block.TaskID), formatOptionalID(block.LessonID)})
+		}
+	}
+	if blocks == nil {
+		blocks = []models.PlanBlock{}
+	}
+	return writeList(w, listOutput{
+		Header:  fmt.Sprintf("PLANO DO DIA %s", now.Format("02/01/2006")),
+		Columns: columns,
+		Rows:    rows,
+		Data:    blocks,
+	})
+}
+
+// formatOptionalID formata um ID opcional, usando string vazia para nil.
+func formatOptionalID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
+
+// formatBlockTime formata um intervalo de horário como "HH:MM–HH:MM".
+func formatBlockTime(start, end time.Time) string {
+	return start.Format("15:04") + "–" + end.Format("15:04")
+}
+
+// parseIDList interpreta uma lista de IDs separados por vírgula (ex: "4, 7,12").
+func parseIDList(input string) ([]int64, error) {
+	var ids []int64
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, service.ValidationErrorf("ID de tarefa inválido '%s'", part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func init() {
+	planTodayCmd.Flags().String("inicio", service.DefaultDayStart, "Início da janela de trabalho (HH:MM).")
+	planTodayCmd.Flags().String("fim", service.DefaultDayEnd, "Fim da janela de trabalho (HH:MM).")
+	planTodayCmd.Flags().String("tarefas", "", "IDs das tarefas escolhidas, separados por vírgula (dispensa o prompt).")
+	planTodayCmd.Flags().Bool("sugeridas", false, "Aceita todas as tarefas sugeridas que cabem no dia.")
+
+	planCmd.AddCommand(planTodayCmd, planShowCmd)
+	rootCmd.AddCommand(planCmd)
+}
@@ -0,0 +1,362 @@
+// Este arquivo (planilha.go) define os comandos de planilhas de notas: 'avaliacao importar-notas',
+// que lança as notas de uma avaliação a partir de uma planilha CSV ou XLSX, mostrando antes como cada
+// linha foi associada aos alunos, e 'avaliacao exportar', que grava as notas e médias da turma em
+// XLSX, ODS ou CSV.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/config"
+	"vigenda/internal/models"
+	"vigenda/internal/report"
+	"vigenda/internal/service"
+	"vigenda/internal/tui"
+)
+
+var assessmentImportGradesCmd = &cobra.Command{
+	Use:   "importar-notas [ID_da_avaliacao] [arquivo]",
+	Short: "Importa as notas de uma avaliação de uma planilha CSV ou XLSX",
+	Long: `Lê uma planilha de notas (.csv, separada por vírgula ou ponto e vírgula, ou a primeira aba de um
+.xlsx) e lança as notas na avaliação. Cada linha tem o aluno e a nota, na escala da turma.
+
+O aluno é encontrado pela matrícula (ou número de chamada) ou pelo nome. Pelo nome, a comparação
+ignora acentos e maiúsculas e aceita nomes incompletos ("Ana Souza" para "Ana Beatriz Souza") e
+pequenos erros de digitação. As colunas são deduzidas dos cabeçalhos da primeira linha (Matrícula,
+Nº ou Nome; Nota ou o nome da avaliação) ou informadas com --coluna-aluno e --coluna-nota, pelo
+cabeçalho, pela letra (B) ou pelo número (2).
+
+Antes de gravar, a prévia mostra cada linha: encontrada, não encontrada, ambígua (mais de um
+aluno possível) ou inválida (nota fora da escala, aluno repetido, transferido ou fora da
+recuperação). Se houver linhas com problemas, nada é importado, a menos que --ignorar-pendentes
+seja informado. As notas são gravadas todas de uma vez: ou todas entram, ou nenhuma. Notas já
+lançadas para os alunos da planilha são substituídas.`,
+	Example: `  vigenda avaliacao importar-notas 7 notas_prova1.csv
+  vigenda avaliacao importar-notas 7 notas.xlsx --coluna-aluno B --coluna-nota D --chave nome
+  vigenda avaliacao importar-notas 7 notas.csv --previa
+  vigenda avaliacao importar-notas 7 notas.csv --ignorar-pendentes --sim`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		assessmentID, err := parseIDArg(args[0], "avaliação")
+		if err != nil {
+			return err
+		}
+		path := args[1]
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return service.ValidationErrorf("não foi possível ler o arquivo '%s': %v", path, err)
+		}
+		var mapping service.GradeImportMapping
+		mapping.StudentColumn, _ = cmd.Flags().GetString("coluna-aluno")
+		mapping.GradeColumn, _ = cmd.Flags().GetString("coluna-nota")
+		mapping.Key, _ = cmd.Flags().GetString("chave")
+
+		preview, err := assessmentService.PreviewGradeImport(ctx, assessmentID, data, filepath.Ext(path), mapping)
+		if err != nil {
+			return fmt.Errorf("erro ao ler a planilha: %w", err)
+		}
+		if err := writeGradeImportPreview(os.Stdout, preview); err != nil {
+			return err
+		}
+		// Com --formato json ou csv a saída é só a prévia; as mensagens vão para a saída de erros.
+		messages := io.Writer(os.Stdout)
+		if !isTableOutput() {
+			messages = os.Stderr
+		}
+		fmt.Fprintf(messages, "\n%d encontrada(s), %d não encontrada(s), %d ambígua(s), %d inválida(s), %d sem nota.\n",
+			preview.Count(service.GradeImportMatched), preview.Count(service.GradeImportUnmatched),
+			preview.Count(service.GradeImportAmbiguous), preview.Count(service.GradeImportInvalid), preview.Count(service.GradeImportSkipped))
+
+		if dryRun, _ := cmd.Flags().GetBool("previa"); dryRun {
+			fmt.Fprintln(messages, "Prévia apenas: nenhuma nota foi importada.")
+			return nil
+		}
+		if skip, _ := cmd.Flags().GetBool("ignorar-pendentes"); preview.Problems() > 0 && !skip {
+			return service.ValidationErrorf("%d linha(s) da planilha com problemas: corrija-as ou use --ignorar-pendentes para importar só as encontradas; nenhuma nota foi importada", preview.Problems())
+		}
+		grades := preview.Grades()
+		if len(grades) == 0 {
+			return service.ValidationErrorf("nenhuma nota da planilha corresponde a um aluno da avaliação; nada foi importado")
+		}
+		if confirmed, _ := cmd.Flags().GetBool("sim"); !confirmed {
+			answer, err := tui.GetInput(fmt.Sprintf("Importar %d nota(s) para a avaliação '%s'? (s/N)", len(grades), preview.Assessment.Name), messages, os.Stdin)
+			if err != nil {
+				return fmt.Errorf("erro ao ler confirmação: %w", err)
+			}
+			if answer := strings.ToLower(strings.TrimSpace(answer)); answer != "s" && answer != "sim" {
+				fmt.Fprintln(messages, "Importação cancelada: nenhuma nota foi importada.")
+				return nil
+			}
+		}
+		if err := assessmentService.EnterGrades(ctx, assessmentID, grades); err != nil {
+			return fmt.Errorf("erro ao importar as notas: %w", err)
+		}
+		fmt.Fprintf(messages, "%d nota(s) importada(s) para a avaliação '%s' (ID: %d).\n", len(grades), preview.Assessment.Name, assessmentID)
+		return nil
+	},
+}
+
+// writeGradeImportPreview escreve a prévia da importação: uma linha por linha da planilha, com o
+// aluno encontrado e a situação.
+func writeGradeImportPreview(w io.Writer, preview service.GradeImportPreview) error {
+	keyTitle := "NOME NA PLANILHA"
+	if preview.Key == service.GradeImportKeyEnrollment {
+		keyTitle = "MATRÍCULA"
+	}
+	columns := []table.Column{
+		{Title: "LINHA", Width: 5},
+		{Title: keyTitle, Width: 28},
+		{Title: "NOTA", Width: 6},
+		{Title: "ALUNO", Width: 28},
+		{Title: "SITUAÇÃO", Width: 14},
+		{Title: "OBSERVAÇÃO", Width: 40},
+	}
+	var rows []table.Row
+	for _, row := range preview.Rows {
+		student := "-"
+		if row.Student != nil {
+			student = row.Student.FullName
+		}
+		grade := row.GradeText
+		if row.Status == service.GradeImportMatched {
+			grade = service.FormatGrade(preview.Policy, row.Grade)
+		}
+		rows = append(rows, table.Row{fmt.Sprintf("%d", row.Line), row.Key, grade, student, service.GradeImportStatusLabel(row.Status), row.Message})
+	}
+	header := fmt.Sprintf("Prévia da importação para a avaliação '%s' (ID: %d)", preview.Assessment.Name, preview.Assessment.ID)
+	return writeList(w, listOutput{Header: header, Columns: columns, Rows: rows, Data: preview})
+}
+
+var assessmentExportCmd = &cobra.Command{
+	Use:   "exportar",
+	Short: "Exporta as notas e médias de uma turma para uma planilha XLSX, ODS ou CSV",
+	Long: `Grava a planilha de notas da turma para a coordenação ou a secretaria: uma aba por bimestre,
+com uma coluna por avaliação (o peso no título) e a média do bimestre, e uma aba "Resultado final"
+com as médias de cada bimestre, a média anual, a nota final ('vigenda avaliacao' > Notas Finais) e
+o resultado (aprovado ou reprovado pela média de aprovação da turma). As médias seguem a política
+de notas da turma, com as recuperações aplicadas. Alunos transferidos ou inativos aparecem com a
+situação e sem médias.
+
+O formato vem de --formato (xlsx, ods ou csv) ou da extensão de --saida; sem nenhum dos dois, é
+xlsx. Sem --saida, o arquivo se chama notas_<turma>.<formato>; com --saida -, é escrito na saída
+padrão. No XLSX e no ODS as notas são números, exibidos com o separador decimal do idioma da
+planilha; o CSV usa ponto e vírgula entre as colunas e vírgula decimal.`,
+	Example: `  vigenda avaliacao exportar --turma "Turma 9A"
+  vigenda avaliacao exportar --turma 1 --formato ods
+  vigenda avaliacao exportar --turma 1 --saida notas-9a.csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		classArg, _ := cmd.Flags().GetString("turma")
+		class, err := resolveClass(ctx, classArg)
+		if err != nil {
+			return err
+		}
+		output, _ := cmd.Flags().GetString("saida")
+		format, _ := cmd.Flags().GetString("formato")
+		switch format = strings.ToLower(strings.TrimSpace(format)); {
+		case format == "" && output != "" && output != "-":
+			if format, err = report.SpreadsheetFormatFromPath(output); err != nil {
+				return service.ValidationErrorf("%v", err)
+			}
+		case format == "":
+			format = report.FormatXLSX
+		case format != report.FormatXLSX && format != report.FormatODS && format != report.FormatCSV:
+			return service.ValidationErrorf("formato de planilha inválido '%s': use xlsx, ods ou csv", format)
+		}
+		if output == "" {
+			output = fmt.Sprintf("notas_%s.%s", fileNamePart(class.Name), format)
+		}
+
+		book, err := gradebookWorkbook(ctx, class)
+		if err != nil {
+			return err
+		}
+		if output == "-" {
+			return report.WriteWorkbook(os.Stdout, format, book)
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			return service.StorageError(fmt.Sprintf("não foi possível criar o arquivo '%s'", output), err)
+		}
+		if err := report.WriteWorkbook(file, format, book); err != nil {
+			file.Close()
+			os.Remove(output)
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return service.StorageError(fmt.Sprintf("não foi possível gravar o arquivo '%s'", output), err)
+		}
+		fmt.Printf("Notas da turma %s exportadas para %s.\n", class.Name, output)
+		return nil
+	},
+}
+
+// gradebookWorkbook monta a planilha de notas da turma: uma aba por bimestre e o resultado final.
+func gradebookWorkbook(ctx context.Context, class models.Class) (report.Workbook, error) {
+	book, err := assessmentService.GetGradebook(ctx, class.ID)
+	if err != nil {
+		return report.Workbook{}, fmt.Errorf("erro ao carregar as notas da turma: %w", err)
+	}
+	_, finals, err := assessmentService.GetFinalGradesByClassID(ctx, class.ID)
+	if err != nil {
+		return report.Workbook{}, fmt.Errorf("erro ao carregar as notas finais: %w", err)
+	}
+	policy := book.Policy
+	students := append([]models.Student(nil), book.Students...)
+	service.SortStudentsByEnrollment(students)
+
+	info := []string{"Turma: " + class.Name}
+	if school := config.SchoolName(); school != "" {
+		info = append([]string{school}, info...)
+	}
+	if teacher := config.TeacherName(); teacher != "" {
+		info = append(info, "Professor(a): "+teacher)
+	}
+	grade := func(value float64) report.Cell {
+		if service.IsConceptScale(policy.GradeScale) {
+			return report.TextCell(service.FormatGrade(policy, value))
+		}
+		return report.NumberCell(value)
+	}
+	// studentCells inicia a linha do aluno: matrícula, nome e situação.
+	studentCells := func(student models.Student) report.SheetRow {
+		return report.SheetRow{
+			Cells: []report.Cell{report.TextCell(student.EnrollmentID), report.TextCell(student.FullName), report.TextCell(studentStatusLabel(student.Status))},
+			Muted: student.Status != "ativo",
+		}
+	}
+	notes := []string{fmt.Sprintf("Média: %s; escala: %s; média de aprovação: %s. As médias já incluem as recuperações.",
+		service.GradingSchemeLabel(policy.Scheme), service.GradeScaleLabel(policy.GradeScale), decimalComma(policy.PassingGrade))}
+
+	var workbook report.Workbook
+	for _, term := range book.Terms {
+		sheet := report.Sheet{Name: service.TermLabel(term), Info: append(append([]string(nil), info...), "Período: "+service.TermLabel(term)),
+			Columns: []string{"Nº", "ALUNO", "SITUAÇÃO"}, Notes: notes}
+		assessments := book.TermAssessments(term)
+		for _, a := range assessments {
+			title := fmt.Sprintf("%s (peso %s)", a.Name, decimalComma(a.Weight))
+			if service.IsRecovery(a) {
+				title = a.Name + " (recuperação)"
+			}
+			sheet.Columns = append(sheet.Columns, title)
+		}
+		sheet.Columns = append(sheet.Columns, "MÉDIA DO BIMESTRE")
+		for _, student := range students {
+			row := studentCells(student)
+			for _, a := range assessments {
+				if value, ok := book.Grade(a.ID, student.ID); ok {
+					row.Cells = append(row.Cells, grade(value))
+				} else {
+					row.Cells = append(row.Cells, report.TextCell(""))
+				}
+			}
+			if average, ok := book.TermAverages[term][student.ID]; ok && book.HasGrades(student.ID, term) {
+				row.Cells = append(row.Cells, grade(average.Adjusted))
+			} else {
+				row.Cells = append(row.Cells, report.TextCell(""))
+			}
+			sheet.Rows = append(sheet.Rows, row)
+		}
+		workbook.Sheets = append(workbook.Sheets, sheet)
+	}
+
+	final := report.Sheet{Name: "Resultado final", Info: info, Columns: []string{"Nº", "ALUNO", "SITUAÇÃO"}, Notes: notes}
+	for _, term := range book.Terms {
+		final.Columns = append(final.Columns, "MÉDIA "+strings.ToUpper(service.TermLabel(term)))
+	}
+	final.Columns = append(final.Columns, "MÉDIA ANUAL", "NOTA FINAL", "RESULTADO")
+	for _, student := range students {
+		row := studentCells(student)
+		if student.Status != "ativo" {
+			// Sem médias nem resultado, mas com todas as colunas, para o CSV não ficar irregular.
+			for len(row.Cells) < len(final.Columns) {
+				row.Cells = append(row.Cells, report.TextCell(""))
+			}
+			final.Rows = append(final.Rows, row)
+			continue
+		}
+		for _, term := range book.Terms {
+			if average, ok := book.TermAverages[term][student.ID]; ok && book.HasGrades(student.ID, term) {
+				row.Cells = append(row.Cells, grade(average.Adjusted))
+			} else {
+				row.Cells = append(row.Cells, report.TextCell(""))
+			}
+		}
+		// Um aluno sem notas lançadas tem média zero na planilha, mas nenhuma média apurada.
+		average, hasAverage := book.Averages[student.ID]
+		hasAverage = hasAverage && book.HasGrades(student.ID)
+		if hasAverage {
+			row.Cells = append(row.Cells, grade(average.Adjusted))
+		} else {
+			row.Cells = append(row.Cells, report.TextCell(""))
+		}
+		// O resultado vem da nota final, se lançada, ou da média anual.
+		result, hasResult := average.Adjusted, hasAverage
+		if value, ok := finals[student.ID]; ok {
+			row.Cells = append(row.Cells, grade(value))
+			result, hasResult = value, true
+		} else {
+			row.Cells = append(row.Cells, report.TextCell(""))
+		}
+		switch {
+		case !hasResult:
+			row.Cells = append(row.Cells, report.TextCell(""))
+		case result >= policy.PassingGrade:
+			row.Cells = append(row.Cells, report.TextCell("Aprovado"))
+		default:
+			row.Cells = append(row.Cells, report.TextCell("Reprovado"))
+		}
+		final.Rows = append(final.Rows, row)
+	}
+	workbook.Sheets = append(workbook.Sheets, final)
+	return workbook, nil
+}
+
+// studentStatusLabel exibe a situação do aluno com inicial maiúscula ("Transferido").
+func studentStatusLabel(status string) string {
+	if status == "" {
+		return ""
+	}
+	return strings.ToUpper(status[:1]) + status[1:]
+}
+
+// decimalComma escreve um número com vírgula decimal e sem zeros à direita (2 → "2", 1,5 → "1,5").
+func decimalComma(value float64) string {
+	return strings.Replace(strconv.FormatFloat(value, 'f', -1, 64), ".", ",", 1)
+}
+
+// fileNamePattern são os trechos de um nome que não entram em nomes de arquivo.
+var fileNamePattern = regexp.MustCompile(`[^\pL\pN]+`)
+
+// fileNamePart converte um nome ("Turma 9A") num trecho de nome de arquivo ("turma_9a").
+func fileNamePart(name string) string {
+	return strings.Trim(fileNamePattern.ReplaceAllString(strings.ToLower(name), "_"), "_")
+}
+
+func init() {
+	assessmentImportGradesCmd.Flags().String("coluna-aluno", "", "Coluna do aluno: cabeçalho, letra ou número (padrão: deduzida do cabeçalho).")
+	assessmentImportGradesCmd.Flags().String("coluna-nota", "", "Coluna da nota: cabeçalho, letra ou número (padrão: deduzida do cabeçalho).")
+	assessmentImportGradesCmd.Flags().String("chave", "", "Como encontrar os alunos: matricula ou nome (padrão: deduzida do cabeçalho).")
+	assessmentImportGradesCmd.Flags().Bool("previa", false, "Só mostra a prévia, sem importar.")
+	assessmentImportGradesCmd.Flags().Bool("ignorar-pendentes", false, "Importa as linhas encontradas mesmo que outras tenham problemas.")
+	assessmentImportGradesCmd.Flags().Bool("sim", false, "Importa sem pedir confirmação.")
+
+	assessmentExportCmd.Flags().String("turma", "", "ID ou nome da turma (obrigatório).")
+	_ = assessmentExportCmd.MarkFlagRequired("turma")
+	// Substitui, só neste comando, a flag global --formato (tabela, json ou csv).
+	assessmentExportCmd.Flags().String("formato", "", "Formato da planilha: xlsx, ods ou csv (padrão: a extensão de --saida, ou xlsx).")
+	assessmentExportCmd.Flags().String("saida", "", "Arquivo da planilha (padrão: notas_<turma>.<formato>; '-' para a saída padrão).")
+
+	assessmentCmd.AddCommand(assessmentImportGradesCmd, assessmentExportCmd)
+}
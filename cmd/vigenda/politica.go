@@ -0,0 +1,272 @@
+// Este arquivo (politica.go) define o comando 'turma politica-notas', que configura a escala das
+// notas de cada turma, como a sua média é calculada e como as recuperações são aplicadas, e o comando 'avaliacao categoria', que classifica as avaliações para o
+// esquema de média por categorias.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+var classGradingPolicyCmd = &cobra.Command{
+	Use:   "politica-notas [turma]",
+	Short: "Mostra ou altera a política de cálculo das médias de uma turma",
+	Long: `Mostra ou altera como as notas da turma (ID ou nome) são lançadas e exibidas e como a média dos
+alunos é calculada em 'avaliacao media-turma', nos relatórios e na interface interativa. Sem flags,
+mostra a política atual.
+
+Escalas (--escala):
+  0-10       notas de 0 a 10 (padrão)
+  0-100      notas de 0 a 100
+  letras     conceitos A, B, C, D e E
+  conceitos  conceitos MB, B, R e I
+
+Nas escalas conceituais, cada conceito cobre uma faixa de notas de 0 a 10, dada pela sua nota mínima
+(--conceitos, padrão "A=9,B=7,C=5,D=3,E=0" e "MB=9,B=7,R=5,I=0"). O conceito lançado é guardado como
+o meio da sua faixa e as médias são exibidas pelo conceito da faixa em que caem. A escala não pode
+passar de 0 a 10 (ou conceitos) para 0 a 100, ou o contrário, depois que a turma tiver notas.
+
+Recuperação: os alunos abaixo da média de aprovação (--media-aprovacao, na escala da turma; padrão
+60% da nota máxima) podem fazer as recuperações criadas com 'avaliacao criar-recuperacao'. A nota da
+recuperação é aplicada segundo --recuperacao, sem nunca reduzir a média:
+  substitui-media  substitui a média das avaliações recuperadas, se for maior (padrão)
+  substitui-menor  substitui a menor nota entre as avaliações recuperadas, se for maior
+  media            a média das avaliações recuperadas passa a ser a média entre ela e a recuperação
+
+Esquemas (--esquema):
+  ponderada        média ponderada pelos pesos das avaliações (padrão)
+  aritmetica       média aritmética das notas, ignorando os pesos
+  categorias       média ponderada das médias de cada categoria de avaliação (--categorias);
+                   categorias sem notas são ignoradas e o peso das demais é redistribuído
+  media-bimestres  média aritmética das médias ponderadas de cada bimestre
+  melhores         média aritmética das N maiores notas (--melhores)
+
+--arredondamento arredonda a média para o múltiplo mais próximo do passo (ex: 0.5 leva 6.3 a 6.5
+e 0.1 leva 7.35 a 7.4); "nenhum" mantém a média sem arredondar. A categoria de cada avaliação é
+definida em 'avaliacao criar --categoria' ou 'avaliacao categoria'.`,
+	Example: `  vigenda turma politica-notas "Turma 9A"
+  vigenda turma politica-notas 1 --esquema categorias --categorias "prova=60,trabalho=40" --arredondamento 0.5
+  vigenda turma politica-notas 1 --esquema melhores --melhores 3 --arredondamento 0.1
+  vigenda turma politica-notas 1 --esquema ponderada --arredondamento nenhum
+  vigenda turma politica-notas 1 --escala conceitos
+  vigenda turma politica-notas 1 --escala letras --conceitos "A=8.5,B=7,C=5,D=3,E=0"
+  vigenda turma politica-notas 1 --media-aprovacao 5 --recuperacao substitui-menor`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		class, err := resolveClass(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		policy, err := assessmentService.GetGradingPolicy(ctx, class.ID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar a política de notas: %w", err)
+		}
+
+		flags := cmd.Flags()
+		changed := false
+		for _, name := range []string{"esquema", "categorias", "melhores", "arredondamento", "escala", "conceitos", "media-aprovacao", "recuperacao"} {
+			changed = changed || flags.Changed(name)
+		}
+		if !changed {
+			return writeGradingPolicy(os.Stdout, fmt.Sprintf("Política de notas da turma %s", class.Name), policy)
+		}
+		if flags.Changed("esquema") {
+			policy.Scheme, _ = flags.GetString("esquema")
+		}
+		if flags.Changed("categorias") {
+			value, _ := flags.GetString("categorias")
+			if policy.CategoryWeights, err = service.ParseCategoryWeights(value); err != nil {
+				return err
+			}
+		}
+		if flags.Changed("melhores") {
+			policy.BestOf, _ = flags.GetInt("melhores")
+		}
+		if flags.Changed("arredondamento") {
+			value, _ := flags.GetString("arredondamento")
+			if policy.RoundingStep, err = parseRoundingStep(value); err != nil {
+				return err
+			}
+		}
+		if flags.Changed("escala") {
+			scale, _ := flags.GetString("escala")
+			if scale != policy.GradeScale {
+				policy.ConceptRanges = nil // As faixas da escala anterior não servem para a nova.
+			}
+			if service.GradeScaleMax(scale) != service.GradeScaleMax(policy.GradeScale) {
+				policy.PassingGrade = 0 // Volta à média de aprovação padrão da nova escala.
+			}
+			policy.GradeScale = scale
+		}
+		if flags.Changed("conceitos") {
+			value, _ := flags.GetString("conceitos")
+			if policy.ConceptRanges, err = service.ParseConceptRanges(value); err != nil {
+				return err
+			}
+		}
+		if flags.Changed("media-aprovacao") {
+			value, _ := flags.GetString("media-aprovacao")
+			if policy.PassingGrade, err = strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(value), ",", "."), 64); err != nil {
+				return service.ValidationErrorf("média de aprovação inválida '%s': informe um número na escala da turma", value)
+			}
+		}
+		if flags.Changed("recuperacao") {
+			policy.RecoveryRule, _ = flags.GetString("recuperacao")
+		}
+
+		policy, err = assessmentService.SetGradingPolicy(ctx, policy)
+		if err != nil {
+			return fmt.Errorf("erro ao gravar a política de notas: %w", err)
+		}
+		if !isTableOutput() {
+			return writeGradingPolicy(os.Stdout, "", policy)
+		}
+		fmt.Printf("Política de notas da turma %s atualizada.\n\n", class.Name)
+		if err := writeGradingPolicy(os.Stdout, "", policy); err != nil {
+			return err
+		}
+		if policy.Scheme == models.GradingSchemeCategories {
+			return warnUncategorizedAssessments(ctx, os.Stdout, class.ID, policy)
+		}
+		return nil
+	},
+}
+
+var assessmentCategoryCmd = &cobra.Command{
+	Use:   "categoria [ID_da_avaliacao] [categoria]",
+	Short: "Define a categoria de uma avaliação (ex: prova, trabalho)",
+	Long: `Define a categoria de uma avaliação, usada pela política de notas "categorias" da turma
+('vigenda turma politica-notas'). Uma categoria vazia ("") remove a avaliação das categorias.`,
+	Example: `  vigenda avaliacao categoria 3 prova
+  vigenda avaliacao categoria 4 trabalho
+  vigenda avaliacao categoria 4 ""`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		assessmentID, err := parseIDArg(args[0], "avaliação")
+		if err != nil {
+			return err
+		}
+		assessment, err := assessmentService.SetAssessmentCategory(context.Background(), assessmentID, args[1])
+		if err != nil {
+			return fmt.Errorf("erro ao definir a categoria da avaliação: %w", err)
+		}
+		if !isTableOutput() {
+			return writeJSON(os.Stdout, assessment)
+		}
+		if assessment.Category == "" {
+			fmt.Printf("Avaliação '%s' (ID: %d) sem categoria.\n", assessment.Name, assessment.ID)
+		} else {
+			fmt.Printf("Avaliação '%s' (ID: %d) na categoria '%s'.\n", assessment.Name, assessment.ID, assessment.Category)
+		}
+		return nil
+	},
+}
+
+// parseRoundingStep interpreta o passo de arredondamento de --arredondamento ("nenhum", "0.5", "0,1", "1").
+func parseRoundingStep(value string) (float64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || value == "nenhum" {
+		return 0, nil
+	}
+	step, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil {
+		return 0, service.ValidationErrorf("arredondamento inválido '%s': use nenhum ou um passo como 0.5, 0.1 ou 1", value)
+	}
+	return step, nil
+}
+
+// formatRoundingStep descreve o passo de arredondamento de uma política.
+func formatRoundingStep(step float64) string {
+	if step <= 0 {
+		return "nenhum"
+	}
+	return strconv.FormatFloat(step, 'f', -1, 64)
+}
+
+// formatAverage exibe uma média na escala da turma: pelo conceito nas escalas conceituais e com duas
+// casas decimais nas numéricas.
+func formatAverage(policy models.GradingPolicy, value float64) string {
+	if service.IsConceptScale(policy.GradeScale) {
+		return service.GradeConcept(policy, value)
+	}
+	return fmt.Sprintf("%.2f", value)
+}
+
+func writeGradingPolicy(w io.Writer, header string, policy models.GradingPolicy) error {
+	// Na tabela, só as opções usadas pelo esquema e pela escala; as demais ficam guardadas para quando
+	// eles mudarem.
+	rows := []table.Row{
+		{"Escala", policy.GradeScale + " (" + service.GradeScaleLabel(policy.GradeScale) + ")"},
+	}
+	if service.IsConceptScale(policy.GradeScale) {
+		rows = append(rows, table.Row{"Conceitos", service.FormatConceptRanges(policy.ConceptRanges)})
+	}
+	rows = append(rows, table.Row{"Esquema", policy.Scheme + " (" + service.GradingSchemeLabel(policy.Scheme) + ")"})
+	if policy.Scheme == models.GradingSchemeCategories {
+		rows = append(rows, table.Row{"Categorias", service.FormatCategoryWeights(policy.CategoryWeights)})
+	}
+	if policy.Scheme == models.GradingSchemeBestOf {
+		rows = append(rows, table.Row{"Melhores", strconv.Itoa(policy.BestOf)})
+	}
+	passing := strconv.FormatFloat(policy.PassingGrade, 'f', -1, 64)
+	if service.IsConceptScale(policy.GradeScale) {
+		passing += " (" + service.GradeConcept(policy, policy.PassingGrade) + ")"
+	}
+	rows = append(rows,
+		table.Row{"Arredondamento", formatRoundingStep(policy.RoundingStep)},
+		table.Row{"Média de aprovação", passing},
+		table.Row{"Recuperação", policy.RecoveryRule + " (" + service.RecoveryRuleLabel(policy.RecoveryRule) + ")"},
+	)
+	columns := []table.Column{
+		{Title: "OPÇÃO", Width: 18},
+		{Title: "VALOR", Width: 60},
+	}
+	return writeList(w, listOutput{Header: header, Columns: columns, Rows: rows, Data: policy})
+}
+
+// warnUncategorizedAssessments avisa quais avaliações da turma ficam fora da média por não estarem
+// em nenhuma categoria da política.
+func warnUncategorizedAssessments(ctx context.Context, w io.Writer, classID int64, policy models.GradingPolicy) error {
+	assessments, err := assessmentService.ListAllAssessments(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar avaliações: %w", err)
+	}
+	var names []string
+	for _, assessment := range assessments {
+		if assessment.ClassID != classID || assessment.Name == service.FinalGradeAssessmentName || service.IsRecovery(assessment) {
+			continue
+		}
+		if _, ok := policy.CategoryWeights[assessment.Category]; !ok {
+			names = append(names, fmt.Sprintf("%s (ID %d)", assessment.Name, assessment.ID))
+		}
+	}
+	if len(names) > 0 {
+		fmt.Fprintf(w, "\nAtenção: estas avaliações não estão em nenhuma categoria da política e não contam na média: %s.\n", strings.Join(names, ", "))
+		fmt.Fprintln(w, "Use 'vigenda avaliacao categoria [ID_da_avaliacao] [categoria]' para classificá-las.")
+	}
+	return nil
+}
+
+func init() {
+	classGradingPolicyCmd.Flags().String("esquema", "", "Esquema da média: ponderada, aritmetica, categorias, media-bimestres ou melhores.")
+	classGradingPolicyCmd.Flags().String("categorias", "", "Pesos das categorias do esquema categorias (ex: \"prova=60,trabalho=40\").")
+	classGradingPolicyCmd.Flags().Int("melhores", 0, "Número de maiores notas consideradas no esquema melhores.")
+	classGradingPolicyCmd.Flags().String("escala", "", "Escala das notas: 0-10, 0-100, letras (A a E) ou conceitos (MB, B, R, I).")
+	classGradingPolicyCmd.Flags().String("conceitos", "", "Nota mínima de cada conceito das escalas conceituais (ex: \"A=9,B=7,C=5,D=3,E=0\").")
+	classGradingPolicyCmd.Flags().String("media-aprovacao", "", "Média de aprovação, na escala da turma (padrão: 60% da nota máxima).")
+	classGradingPolicyCmd.Flags().String("recuperacao", "", "Regra de recuperação: substitui-media, substitui-menor ou media.")
+	classGradingPolicyCmd.Flags().String("arredondamento", "", "Passo de arredondamento da média (ex: 0.5, 0.1, 1) ou nenhum.")
+
+	classCmd.AddCommand(classGradingPolicyCmd)
+	assessmentCmd.AddCommand(assessmentCategoryCmd)
+}
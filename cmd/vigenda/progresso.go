@@ -0,0 +1,260 @@
+// Este arquivo (progresso.go) define o comando 'relatorio progresso-turma', que resume o andamento
+// de uma turma: médias, distribuição das notas, alunos que precisam de atenção, notas pendentes e
+// aulas dadas e por dar, no terminal ou em Markdown.
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// progressBannerWidth é a largura da moldura do título do relatório de progresso no terminal.
+const progressBannerWidth = 49
+
+var reportClassProgressCmd = &cobra.Command{
+	Use:   "progresso-turma",
+	Short: "Resume o progresso de uma turma: médias, distribuição das notas, pendências e aulas",
+	Long: `Resume o andamento de uma turma a partir das notas e das aulas registradas:
+
+  - a média geral da turma, a média de cada bimestre e a de cada avaliação;
+  - a distribuição das médias dos alunos (por faixa da escala ou por conceito);
+  - os alunos de maior desempenho e os abaixo da média da turma, com a tendência entre os dois
+    últimos bimestres (em alta, em queda ou estável);
+  - as avaliações já aplicadas com alunos ainda sem nota;
+  - as aulas planejadas, ministradas e restantes.
+
+As médias seguem a política de notas da turma, com as recuperações aplicadas, e só consideram os
+alunos ativos. O relatório é escrito no terminal ou, com --markdown (ou --saida arquivo.md), em
+Markdown; com --formato json, os dados completos.`,
+	Example: `  vigenda relatorio progresso-turma --turma "Turma 9A"
+  vigenda relatorio progresso-turma --turma 1 --saida progresso-9a.md
+  vigenda relatorio progresso-turma --turma 1 --formato json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		classArg, _ := cmd.Flags().GetString("turma")
+		markdown, _ := cmd.Flags().GetBool("markdown")
+		output, _ := cmd.Flags().GetString("saida")
+		if outputFormat == formatCSV {
+			return service.ValidationErrorf("o relatório de progresso não tem saída csv: use tabela, json ou --markdown")
+		}
+		if ext := strings.ToLower(filepath.Ext(output)); ext == ".md" || ext == ".markdown" {
+			markdown = true
+		}
+
+		class, err := resolveClass(ctx, classArg)
+		if err != nil {
+			return err
+		}
+		book, err := assessmentService.GetGradebook(ctx, class.ID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar as notas da turma: %w", err)
+		}
+		lessons, err := lessonService.GetLessonsByClassID(ctx, class.ID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar as aulas da turma: %w", err)
+		}
+		progress := service.BuildClassProgress(book, lessons, time.Now())
+
+		var buf bytes.Buffer
+		switch {
+		case outputFormat == formatJSON:
+			err = writeJSON(&buf, progress)
+		case markdown:
+			writeClassProgressMarkdown(&buf, class, progress)
+		default:
+			writeClassProgressText(&buf, class, progress)
+		}
+		if err != nil {
+			return err
+		}
+		if output == "" {
+			_, err = os.Stdout.Write(buf.Bytes())
+			return err
+		}
+		if err := os.WriteFile(output, buf.Bytes(), 0o644); err != nil {
+			return service.StorageError(fmt.Sprintf("não foi possível gravar o arquivo '%s'", output), err)
+		}
+		fmt.Printf("Relatório gravado em %s.\n", output)
+		return nil
+	},
+}
+
+// progressGrade formata uma média do relatório na escala da turma, ou "-" se não houver.
+func progressGrade(policy models.GradingPolicy, value *float64) string {
+	if value == nil {
+		return "-"
+	}
+	return service.FormatGrade(policy, *value)
+}
+
+// progressTrend descreve a tendência do aluno com as médias dos dois últimos bimestres
+// ("em queda: 7.5 → 6.0").
+func progressTrend(policy models.GradingPolicy, student service.StudentProgress) string {
+	n := len(student.TermAverages)
+	if student.Trend == "" || n < 2 {
+		return "sem bimestres para comparar"
+	}
+	return fmt.Sprintf("%s: %s → %s", student.Trend, service.FormatGrade(policy, student.TermAverages[n-2]), service.FormatGrade(policy, student.TermAverages[n-1]))
+}
+
+// progressAssessmentName identifica a avaliação com o peso, ou como recuperação.
+func progressAssessmentName(a models.Assessment) string {
+	if service.IsRecovery(a) {
+		return a.Name + " (recuperação)"
+	}
+	return fmt.Sprintf("%s (Peso %g)", a.Name, a.Weight)
+}
+
+// progressMissing lista os alunos sem nota em uma avaliação.
+func progressMissing(item service.AssessmentProgress) string {
+	names := make([]string, len(item.Missing))
+	for i, student := range item.Missing {
+		names[i] = student.FullName
+	}
+	return fmt.Sprintf("%d aluno(s) sem nota (%s)", len(item.Missing), strings.Join(names, ", "))
+}
+
+// writeClassProgressText escreve o relatório de progresso para o terminal.
+func writeClassProgressText(w io.Writer, class models.Class, p service.ClassProgress) {
+	policy := p.Policy
+	title := strings.ToUpper("Relatório de progresso - " + class.Name)
+	inner := progressBannerWidth - 4
+	pad := max(inner-utf8.RuneCountInString(title), 0)
+	banner := strings.Repeat("=", progressBannerWidth)
+	fmt.Fprintf(w, "%s\n==%s%s%s==\n%s\n\n", banner, strings.Repeat(" ", pad-pad/2), title, strings.Repeat(" ", pad/2), banner)
+
+	fmt.Fprintf(w, "MÉDIA GERAL DA TURMA (Alunos Ativos): %s\n", progressGrade(policy, p.Average))
+
+	fmt.Fprintln(w, "\nDESEMPENHO POR BIMESTRE:")
+	if len(p.Terms) == 0 {
+		fmt.Fprintln(w, "- Nenhuma avaliação registrada.")
+	}
+	for _, term := range p.Terms {
+		fmt.Fprintf(w, "- %s: Média %s\n", service.TermLabel(term.Term), progressGrade(policy, term.Average))
+	}
+
+	fmt.Fprintln(w, "\nDESEMPENHO POR AVALIAÇÃO:")
+	if len(p.Assessments) == 0 {
+		fmt.Fprintln(w, "- Nenhuma avaliação registrada.")
+	}
+	for _, item := range p.Assessments {
+		fmt.Fprintf(w, "- %s: Média %s\n", progressAssessmentName(item.Assessment), progressGrade(policy, item.Average))
+	}
+
+	fmt.Fprintln(w, "\nDISTRIBUIÇÃO DAS MÉDIAS:")
+	for _, band := range p.Distribution {
+		fmt.Fprintf(w, "- %-10s %s %d\n", band.Label+":", strings.Repeat("#", band.Count), band.Count)
+	}
+
+	fmt.Fprintln(w, "\nALUNOS COM MAIOR DESEMPENHO:")
+	if len(p.Top) == 0 {
+		fmt.Fprintln(w, "- Nenhum aluno com média.")
+	}
+	for i, student := range p.Top {
+		fmt.Fprintf(w, "%d. %s (%s)\n", i+1, student.Student.FullName, service.FormatGrade(policy, student.Average))
+	}
+
+	fmt.Fprintln(w, "\nALUNOS QUE NECESSITAM DE ATENÇÃO:")
+	if len(p.Attention) == 0 {
+		fmt.Fprintln(w, "- Nenhum aluno abaixo da média da turma.")
+	}
+	for i, student := range p.Attention {
+		fmt.Fprintf(w, "%d. %s (%s) - %s\n", i+1, student.Student.FullName, service.FormatGrade(policy, student.Average), progressTrend(policy, student))
+	}
+
+	fmt.Fprintln(w, "\nAVALIAÇÕES COM NOTAS PENDENTES:")
+	if len(p.Pending) == 0 {
+		fmt.Fprintln(w, "- Nenhuma.")
+	}
+	for _, item := range p.Pending {
+		fmt.Fprintf(w, "- %s: %s\n", item.Assessment.Name, progressMissing(item))
+	}
+
+	l := p.Lessons
+	fmt.Fprintln(w, "\nAULAS:")
+	fmt.Fprintf(w, "- Planejadas: %d | Ministradas: %d | Restantes: %d | Sem registro: %d | Canceladas: %d\n",
+		l.Planned, l.Taught, l.Remaining, l.Overdue, l.Cancelled)
+}
+
+// writeClassProgressMarkdown escreve o relatório de progresso em Markdown.
+func writeClassProgressMarkdown(w io.Writer, class models.Class, p service.ClassProgress) {
+	policy := p.Policy
+	fmt.Fprintf(w, "# Relatório de progresso – %s\n\n", class.Name)
+	fmt.Fprintf(w, "**Média geral da turma (alunos ativos):** %s\n", progressGrade(policy, p.Average))
+
+	fmt.Fprint(w, "\n## Desempenho por bimestre\n\n| Bimestre | Média |\n| --- | ---: |\n")
+	for _, term := range p.Terms {
+		fmt.Fprintf(w, "| %s | %s |\n", service.TermLabel(term.Term), progressGrade(policy, term.Average))
+	}
+
+	fmt.Fprint(w, "\n## Desempenho por avaliação\n\n| Avaliação | Bimestre | Peso | Média | Notas lançadas |\n| --- | --- | ---: | ---: | ---: |\n")
+	for _, item := range p.Assessments {
+		weight := fmt.Sprintf("%g", item.Assessment.Weight)
+		if service.IsRecovery(item.Assessment) {
+			weight = "recuperação"
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %d de %d |\n", markdownCell(item.Assessment.Name), service.TermLabel(item.Assessment.Term),
+			weight, progressGrade(policy, item.Average), item.Graded, item.Graded+len(item.Missing))
+	}
+
+	fmt.Fprint(w, "\n## Distribuição das médias\n\n| Faixa | Alunos |\n| --- | ---: |\n")
+	for _, band := range p.Distribution {
+		fmt.Fprintf(w, "| %s | %d |\n", band.Label, band.Count)
+	}
+
+	fmt.Fprint(w, "\n## Alunos com maior desempenho\n\n")
+	if len(p.Top) == 0 {
+		fmt.Fprintln(w, "Nenhum aluno com média.")
+	}
+	for i, student := range p.Top {
+		fmt.Fprintf(w, "%d. %s (%s)\n", i+1, student.Student.FullName, service.FormatGrade(policy, student.Average))
+	}
+
+	fmt.Fprint(w, "\n## Alunos que necessitam de atenção\n\n")
+	if len(p.Attention) == 0 {
+		fmt.Fprintln(w, "Nenhum aluno abaixo da média da turma.")
+	} else {
+		fmt.Fprint(w, "| Aluno | Média | Tendência |\n| --- | ---: | --- |\n")
+	}
+	for _, student := range p.Attention {
+		fmt.Fprintf(w, "| %s | %s | %s |\n", markdownCell(student.Student.FullName), service.FormatGrade(policy, student.Average), progressTrend(policy, student))
+	}
+
+	fmt.Fprint(w, "\n## Avaliações com notas pendentes\n\n")
+	if len(p.Pending) == 0 {
+		fmt.Fprintln(w, "Nenhuma.")
+	}
+	for _, item := range p.Pending {
+		fmt.Fprintf(w, "- **%s**: %s\n", item.Assessment.Name, progressMissing(item))
+	}
+
+	l := p.Lessons
+	fmt.Fprint(w, "\n## Aulas\n\n| Planejadas | Ministradas | Restantes | Sem registro | Canceladas |\n| ---: | ---: | ---: | ---: | ---: |\n")
+	fmt.Fprintf(w, "| %d | %d | %d | %d | %d |\n", l.Planned, l.Taught, l.Remaining, l.Overdue, l.Cancelled)
+}
+
+// markdownCell escapa as barras verticais, que separariam as colunas de uma tabela Markdown.
+func markdownCell(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+func init() {
+	reportClassProgressCmd.Flags().String("turma", "", "ID ou nome da turma (obrigatório).")
+	_ = reportClassProgressCmd.MarkFlagRequired("turma")
+	reportClassProgressCmd.Flags().Bool("markdown", false, "Escreve o relatório em Markdown.")
+	reportClassProgressCmd.Flags().String("saida", "", "Arquivo de saída (.md grava em Markdown; padrão: a saída padrão).")
+
+	reportCmd.AddCommand(reportClassProgressCmd)
+}
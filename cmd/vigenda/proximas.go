@@ -0,0 +1,73 @@
+// Este arquivo (proximas.go) define 'avaliacao proximas', que lista as avaliações agendadas de todas
+// as turmas nos próximos dias, com quanto falta para cada uma e um aviso quando uma turma tem mais de
+// uma avaliação no mesmo dia.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/service"
+)
+
+var assessmentUpcomingCmd = &cobra.Command{
+	Use:   "proximas",
+	Short: "Lista as avaliações agendadas para os próximos dias, de todas as turmas",
+	Long: `Lista as avaliações com data de aplicação (ver 'vigenda avaliacao criar --data') de hoje até
+--dias dias à frente, da mais próxima para a mais distante, com quanto falta para cada uma. A
+coluna AVISO aponta as turmas com mais de uma avaliação no mesmo dia.`,
+	Example: `  vigenda avaliacao proximas
+  vigenda avaliacao proximas --dias 30
+  vigenda avaliacao proximas --formato json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, _ := cmd.Flags().GetInt("dias")
+		if days < 0 {
+			return service.ValidationErrorf("--dias deve ser zero ou positivo")
+		}
+		now := time.Now()
+		upcoming, err := assessmentService.GetUpcomingAssessments(context.Background(), now, now.AddDate(0, 0, days))
+		if err != nil {
+			return fmt.Errorf("erro ao listar as próximas avaliações: %w", err)
+		}
+		if len(upcoming) == 0 && isTableOutput() {
+			fmt.Printf("Nenhuma avaliação agendada nos próximos %d dias.\n", days)
+			return nil
+		}
+
+		columns := []table.Column{
+			{Title: "DATA", Width: 10},
+			{Title: "FALTA", Width: 10},
+			{Title: "TURMA", Width: 20},
+			{Title: "ID", Width: 5},
+			{Title: "AVALIAÇÃO", Width: 30},
+			{Title: "AVISO", Width: 40},
+		}
+		var rows []table.Row
+		for _, item := range upcoming {
+			warning := ""
+			if len(item.SameDay) > 0 {
+				names := make([]string, len(item.SameDay))
+				for i, other := range item.SameDay {
+					names[i] = other.Name
+				}
+				warning = "mesmo dia: " + strings.Join(names, ", ")
+			}
+			rows = append(rows, table.Row{item.Assessment.AssessmentDate.Format("02/01/2006"), item.Countdown(), item.ClassName,
+				fmt.Sprintf("%d", item.Assessment.ID), item.Assessment.Name, warning})
+		}
+		header := fmt.Sprintf("Avaliações dos próximos %d dias", days)
+		return writeList(os.Stdout, listOutput{Header: header, Columns: columns, Rows: rows, Data: upcoming})
+	},
+}
+
+func init() {
+	assessmentUpcomingCmd.Flags().Int("dias", 14, "Quantos dias à frente procurar avaliações.")
+
+	assessmentCmd.AddCommand(assessmentUpcomingCmd)
+}
@@ -0,0 +1,145 @@
+// Este arquivo (recuperacao.go) define os comandos das avaliações de recuperação: 'avaliacao
+// criar-recuperacao', que cria a recuperação de um bimestre ou de avaliações específicas, e
+// 'avaliacao recuperacao', que lista os alunos que podem fazê-la.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/service"
+)
+
+var assessmentCreateRecoveryCmd = &cobra.Command{
+	Use:   "criar-recuperacao [nome_da_avaliacao]",
+	Short: "Cria uma avaliação de recuperação de um bimestre ou de avaliações específicas",
+	Long: `Cria uma avaliação de recuperação para a turma. Sem --avaliacoes, a recuperação vale para todas
+as avaliações do bimestre (--bimestre); com --avaliacoes, só para as avaliações informadas (o bimestre,
+se omitido, é o delas).
+
+Só os alunos abaixo da média de aprovação nas avaliações recuperadas podem ter nota na recuperação
+(ver 'vigenda avaliacao recuperacao'). A nota é lançada com 'vigenda avaliacao lancar-notas' e
+aplicada às médias segundo a regra de recuperação da turma ('vigenda turma politica-notas
+--recuperacao'); a recuperação nunca reduz a média do aluno.`,
+	Example: `  vigenda avaliacao criar-recuperacao "Recuperação do 1º bimestre" --turma "Turma 9A" --bimestre 1
+  vigenda avaliacao criar-recuperacao "Recuperação da Prova 1" --turma 1 --avaliacoes 3
+  vigenda avaliacao criar-recuperacao "Recuperação das provas" --turma 1 --avaliacoes 3,5 --data 2025-05-02`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		classValue, _ := cmd.Flags().GetString("turma")
+		class, err := resolveClass(ctx, classValue)
+		if err != nil {
+			return err
+		}
+		term, _ := cmd.Flags().GetInt("bimestre")
+		recovered, _ := cmd.Flags().GetInt64Slice("avaliacoes")
+		if term == 0 && len(recovered) == 0 {
+			return service.ValidationErrorf("informe o bimestre (--bimestre) ou as avaliações recuperadas (--avaliacoes)")
+		}
+		var date *time.Time
+		if cmd.Flags().Changed("data") {
+			value, _ := cmd.Flags().GetString("data")
+			day, err := parseDateFlag("data", value)
+			if err != nil {
+				return err
+			}
+			date = &day
+		}
+
+		assessment, err := assessmentService.CreateRecoveryAssessment(ctx, args[0], class.ID, term, recovered, date)
+		if err != nil {
+			return fmt.Errorf("erro ao criar a recuperação: %w", err)
+		}
+		if !isTableOutput() {
+			return writeJSON(os.Stdout, assessment)
+		}
+		scope := fmt.Sprintf("todas as avaliações do %s", service.TermLabel(assessment.Term))
+		if len(assessment.RecoveryOf) > 0 {
+			scope = fmt.Sprintf("avaliações ID %s", formatIDList(assessment.RecoveryOf))
+		}
+		fmt.Printf("Recuperação '%s' (ID: %d) criada para a turma %s: recupera %s.\n", assessment.Name, assessment.ID, class.Name, scope)
+		return nil
+	},
+}
+
+var assessmentRecoveryStudentsCmd = &cobra.Command{
+	Use:   "recuperacao [ID_da_recuperacao]",
+	Short: "Lista os alunos que podem fazer uma recuperação",
+	Long: `Lista os alunos ativos abaixo da média de aprovação da turma nas avaliações recuperadas por uma
+recuperação, com a média deles nessas avaliações e a nota da recuperação, se já lançada.`,
+	Example: `  vigenda avaliacao recuperacao 7
+  vigenda avaliacao recuperacao 7 --formato csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		recoveryID, err := parseIDArg(args[0], "avaliação")
+		if err != nil {
+			return err
+		}
+		students, err := assessmentService.GetRecoveryStudents(ctx, recoveryID)
+		if err != nil {
+			return fmt.Errorf("erro ao listar os alunos da recuperação: %w", err)
+		}
+		_, recovery, err := assessmentService.GetStudentsForGrading(ctx, recoveryID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar a recuperação: %w", err)
+		}
+		policy, err := assessmentService.GetGradingPolicy(ctx, recovery.ClassID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar a política de notas: %w", err)
+		}
+
+		if len(students) == 0 && isTableOutput() {
+			fmt.Printf("Nenhum aluno abaixo da média de aprovação (%s) nas avaliações da recuperação '%s'.\n",
+				service.FormatGrade(policy, policy.PassingGrade), recovery.Name)
+			return nil
+		}
+		columns := []table.Column{
+			{Title: "ID", Width: 4},
+			{Title: "ALUNO", Width: 35},
+			{Title: "MÉDIA", Width: 6},
+			{Title: "RECUPERAÇÃO", Width: 11},
+		}
+		var rows []table.Row
+		for _, entry := range students {
+			grade := "-"
+			if entry.Grade != nil {
+				grade = service.FormatGrade(policy, *entry.Grade)
+			}
+			rows = append(rows, table.Row{fmt.Sprintf("%d", entry.Student.ID), entry.Student.FullName, formatAverage(policy, entry.Average), grade})
+		}
+		header := fmt.Sprintf("Alunos da recuperação '%s' (média de aprovação: %s)", recovery.Name, service.FormatGrade(policy, policy.PassingGrade))
+		if students == nil {
+			students = []service.RecoveryStudent{}
+		}
+		return writeList(os.Stdout, listOutput{Header: header, Columns: columns, Rows: rows, Data: students})
+	},
+}
+
+// formatIDList escreve uma lista de IDs separados por vírgula.
+func formatIDList(ids []int64) string {
+	text := ""
+	for i, id := range ids {
+		if i > 0 {
+			text += ", "
+		}
+		text += fmt.Sprintf("%d", id)
+	}
+	return text
+}
+
+func init() {
+	assessmentCreateRecoveryCmd.Flags().String("turma", "", "ID ou nome da turma (obrigatório).")
+	_ = assessmentCreateRecoveryCmd.MarkFlagRequired("turma")
+	assessmentCreateRecoveryCmd.Flags().Int("bimestre", 0, "Bimestre recuperado (todas as suas avaliações, se --avaliacoes não for informado).")
+	assessmentCreateRecoveryCmd.Flags().Int64Slice("avaliacoes", nil, "IDs das avaliações recuperadas, separados por vírgula.")
+	assessmentCreateRecoveryCmd.Flags().String("data", "", "Data de aplicação da recuperação (AAAA-MM-DD).")
+
+	assessmentCmd.AddCommand(assessmentCreateRecoveryCmd)
+	assessmentCmd.AddCommand(assessmentRecoveryStudentsCmd)
+}
@@ -0,0 +1,156 @@
+// Este arquivo (relatorio.go) define o comando 'relatorio', que reúne os relatórios das turmas
+// usados para preencher o diário de classe oficial.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "relatorio",
+	Short: "Gera relatórios das turmas (conteudo-ministrado, lista, diario, boletim, progresso-turma)",
+	Long: `O comando 'relatorio' gera relatórios de uma turma a partir dos dados registrados no Vigenda.
+A turma pode ser informada pelo ID ou pelo nome (ex: --turma "Turma 9A").`,
+	Example: `  vigenda relatorio conteudo-ministrado --turma "Turma 9A" --bimestre 2
+  vigenda relatorio lista --turma "Turma 9A" --modelo chamada --saida chamada.pdf
+  vigenda relatorio diario --turma "Turma 9A" --bimestre 2 --saida diario.pdf
+  vigenda relatorio boletim --turma "Turma 9A" --saida boletins.pdf`,
+}
+
+var reportDeliveredContentCmd = &cobra.Command{
+	Use:   "conteudo-ministrado",
+	Short: "Lista o conteúdo ministrado em uma turma num bimestre ou período",
+	Long: `Lista, em ordem de data, as aulas ministradas (inteira ou parcialmente) de uma turma e o conteúdo
+registrado em cada uma com 'vigenda aula registrar', pronto para ser copiado para o diário de classe.
+O período é o bimestre informado em --bimestre (do ano de --ano, padrão o ano atual) ou as datas
+de --de e --ate.`,
+	Example: `  vigenda relatorio conteudo-ministrado --turma "Turma 9A" --bimestre 2
+  vigenda relatorio conteudo-ministrado --turma 1 --de 2025-08-01 --ate 2025-08-31 --formato csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		classArg, _ := cmd.Flags().GetString("turma")
+		ctx := context.Background()
+		class, err := resolveClass(ctx, classArg)
+		if err != nil {
+			return err
+		}
+		from, to, period, err := reportPeriod(ctx, cmd)
+		if err != nil {
+			return err
+		}
+
+		lessons, err := lessonService.GetDeliveredContent(ctx, class.ID, from, to)
+		if err != nil {
+			return fmt.Errorf("erro ao gerar relatório de conteúdo ministrado: %w", err)
+		}
+		if len(lessons) == 0 && isTableOutput() {
+			fmt.Printf("Nenhuma aula ministrada registrada para %s em %s.\n", class.Name, period)
+			return nil
+		}
+		return writeDeliveredContent(os.Stdout, fmt.Sprintf("CONTEÚDO MINISTRADO: %s · %s", class.Name, period), lessons)
+	},
+}
+
+// writeDeliveredContent escreve o relatório de conteúdo ministrado no formato de --formato.
+func writeDeliveredContent(w io.Writer, header string, lessons []models.Lesson) error {
+	columns := []table.Column{
+		{Title: "DATA", Width: 14},
+		{Title: "AULA", Width: 30},
+		{Title: "SITUAÇÃO", Width: 10},
+		{Title: "CONTEÚDO MINISTRADO", Width: 50},
+	}
+	rows := []table.Row{}
+	for _, lesson := range lessons {
+		rows = append(rows, table.Row{
+			formatLessonDay(lesson.ScheduledAt),
+			lesson.Title,
+			lesson.Status,
+			lesson.CoveredContent,
+		})
+	}
+	return writeList(w, listOutput{Header: header, Columns: columns, Rows: rows, Data: lessons})
+}
+
+// resolveClass encontra a turma pelo ID ou, se o valor não for numérico, pelo nome (sem diferenciar
+// maiúsculas de minúsculas).
+func resolveClass(ctx context.Context, value string) (models.Class, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return models.Class{}, service.ValidationErrorf("informe a turma (--turma) pelo ID ou pelo nome")
+	}
+	if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+		class, err := classService.GetClassByID(ctx, id)
+		if err != nil {
+			return models.Class{}, fmt.Errorf("erro ao carregar turma: %w", err)
+		}
+		return class, nil
+	}
+	classes, err := classService.ListAllClasses(ctx)
+	if err != nil {
+		return models.Class{}, fmt.Errorf("erro ao listar turmas: %w", err)
+	}
+	for _, class := range classes {
+		if strings.EqualFold(strings.TrimSpace(class.Name), value) {
+			return class, nil
+		}
+	}
+	return models.Class{}, service.NotFoundErrorf("turma '%s' não encontrada", value)
+}
+
+// reportPeriod lê o período de um relatório das flags --bimestre/--ano ou --de/--ate e retorna
+// também a sua descrição para o cabeçalho.
+func reportPeriod(ctx context.Context, cmd *cobra.Command) (time.Time, time.Time, string, error) {
+	flags := cmd.Flags()
+	termNumber, _ := flags.GetInt("bimestre")
+	fromStr, _ := flags.GetString("de")
+	toStr, _ := flags.GetString("ate")
+	if termNumber != 0 {
+		if fromStr != "" || toStr != "" {
+			return time.Time{}, time.Time{}, "", service.ValidationErrorf("use --bimestre ou --de/--ate, não ambos")
+		}
+		year, _ := flags.GetInt("ano")
+		term, err := findTerm(ctx, termNumber, year)
+		if err != nil {
+			return time.Time{}, time.Time{}, "", err
+		}
+		return term.StartDate, term.EndDate, fmt.Sprintf("%s de %d", service.TermLabel(term.Number), term.Year), nil
+	}
+	if fromStr == "" && toStr == "" {
+		return time.Time{}, time.Time{}, "", service.ValidationErrorf("informe o período: --bimestre N ou --de e --ate")
+	}
+	from, err := parseDateFlag("de", fromStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, "", err
+	}
+	to, err := parseDateFlag("ate", toStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, "", err
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, "", service.ValidationErrorf("--ate (%s) é anterior a --de (%s)", toStr, fromStr)
+	}
+	return from, to, formatCalendarPeriod(from, to), nil
+}
+
+func init() {
+	reportDeliveredContentCmd.Flags().String("turma", "", "ID ou nome da turma (obrigatório).")
+	_ = reportDeliveredContentCmd.MarkFlagRequired("turma")
+	reportDeliveredContentCmd.Flags().Int("bimestre", 0, "Número do bimestre (1 a 4) do calendário escolar.")
+	reportDeliveredContentCmd.Flags().Int("ano", 0, "Ano do bimestre (padrão: o ano atual).")
+	reportDeliveredContentCmd.Flags().String("de", "", "Início do período, no formato AAAA-MM-DD.")
+	reportDeliveredContentCmd.Flags().String("ate", "", "Fim do período, no formato AAAA-MM-DD.")
+
+	reportCmd.AddCommand(reportDeliveredContentCmd)
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,260 @@
+// Este arquivo (sequencia.go) define o comando 'sequencia', que mantém as sequências didáticas:
+// unidades com vários planos de aula em ordem, aplicáveis às próximas aulas de uma turma e
+// compartilháveis como arquivo entre professores.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/spf13/cobra"
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+var sequenceCmd = &cobra.Command{
+	Use:   "sequencia",
+	Short: "Gerencia sequências didáticas (criar, listar, ver, aplicar, exportar, importar, remover)",
+	Long: `O comando 'sequencia' mantém sequências didáticas: unidades formadas por vários planos de aula
+em ordem. Aplicar uma sequência a uma turma copia título e plano de cada aula da sequência para as
+próximas aulas planejadas da turma (ver 'vigenda horario gerar'). Uma sequência pode ser exportada
+para um arquivo e importada por colegas que lecionam para a mesma série.`,
+	Example: `  vigenda sequencia criar "Frações" aula1.md aula2.md aula3.md --descricao "6º ano, unidade 2"
+  vigenda sequencia aplicar 1 --turma "Turma 6A" --a-partir 2025-05-05
+  vigenda sequencia exportar 1 --saida fracoes.json
+  vigenda sequencia importar fracoes.json`,
+}
+
+var sequenceCreateCmd = &cobra.Command{
+	Use:   "criar [nome] [plano.md]...",
+	Short: "Cria uma sequência didática a partir de arquivos Markdown",
+	Long: `Cria uma sequência com uma aula para cada arquivo Markdown, na ordem informada. O título de cada
+aula é o primeiro título (# ...) do arquivo ou, se não houver, o nome do arquivo.`,
+	Example: `  vigenda sequencia criar "Frações" aula1.md aula2.md aula3.md --descricao "6º ano, unidade 2"`,
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		description, _ := cmd.Flags().GetString("descricao")
+		items := make([]models.LessonSequenceItem, 0, len(args)-1)
+		for _, path := range args[1:] {
+			content, err := readLessonPlan(path)
+			if err != nil {
+				return err
+			}
+			items = append(items, models.LessonSequenceItem{Title: planTitle(content, path), PlanContent: content})
+		}
+		sequence, err := lessonTemplateService.CreateSequence(context.Background(), args[0], description, items)
+		if err != nil {
+			return fmt.Errorf("erro ao criar sequência: %w", err)
+		}
+		fmt.Printf("Sequência '%s' (ID: %d) criada com %d aulas.\n", sequence.Name, sequence.ID, len(sequence.Items))
+		return nil
+	},
+}
+
+var sequenceListCmd = &cobra.Command{
+	Use:   "listar",
+	Short: "Lista as sequências didáticas",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sequences, err := lessonTemplateService.ListSequences(context.Background())
+		if err != nil {
+			return fmt.Errorf("erro ao listar sequências: %w", err)
+		}
+		if len(sequences) == 0 && isTableOutput() {
+			fmt.Println("Nenhuma sequência didática cadastrada.")
+			return nil
+		}
+		columns := []table.Column{
+			{Title: "ID", Width: 4},
+			{Title: "NOME", Width: 30},
+			{Title: "AULAS", Width: 5},
+			{Title: "DESCRIÇÃO", Width: 40},
+		}
+		rows := []table.Row{}
+		for _, sequence := range sequences {
+			rows = append(rows, table.Row{fmt.Sprintf("%d", sequence.ID), sequence.Name, fmt.Sprintf("%d", len(sequence.Items)), sequence.Description})
+		}
+		return writeList(os.Stdout, listOutput{Header: "SEQUÊNCIAS DIDÁTICAS", Columns: columns, Rows: rows, Data: sequences})
+	},
+}
+
+var sequenceShowCmd = &cobra.Command{
+	Use:     "ver [ID_da_sequencia]",
+	Short:   "Mostra as aulas de uma sequência didática",
+	Example: `  vigenda sequencia ver 1`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sequenceID, err := parseIDArg(args[0], "sequência")
+		if err != nil {
+			return err
+		}
+		sequence, err := lessonTemplateService.GetSequence(context.Background(), sequenceID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar sequência: %w", err)
+		}
+		header := "SEQUÊNCIA: " + sequence.Name
+		if sequence.Description != "" {
+			header += " (" + sequence.Description + ")"
+		}
+		columns := []table.Column{
+			{Title: "Nº", Width: 3},
+			{Title: "TÍTULO", Width: 40},
+			{Title: "SEÇÕES DO PLANO", Width: 50},
+		}
+		rows := []table.Row{}
+		for _, item := range sequence.Items {
+			rows = append(rows, table.Row{fmt.Sprintf("%d", item.Position), item.Title, planSections(item.PlanContent)})
+		}
+		items := sequence.Items
+		if items == nil {
+			items = []models.LessonSequenceItem{}
+		}
+		return writeList(os.Stdout, listOutput{Header: header, Columns: columns, Rows: rows, Data: items})
+	},
+}
+
+var sequenceApplyCmd = &cobra.Command{
+	Use:   "aplicar [ID_da_sequencia]",
+	Short: "Aplica uma sequência didática às próximas aulas de uma turma",
+	Long: `Copia título e plano de cada aula da sequência, em ordem, para as próximas aulas planejadas da turma
+a partir da data de --a-partir (padrão: hoje). A turma precisa ter aulas suficientes cadastradas
+(gere-as com 'vigenda horario gerar'). Se alguma dessas aulas já tiver plano, a sequência só é
+aplicada com --substituir.`,
+	Example: `  vigenda sequencia aplicar 1 --turma "Turma 6A"
+  vigenda sequencia aplicar 1 --turma 3 --a-partir 2025-05-05 --substituir`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sequenceID, err := parseIDArg(args[0], "sequência")
+		if err != nil {
+			return err
+		}
+		classArg, _ := cmd.Flags().GetString("turma")
+		fromStr, _ := cmd.Flags().GetString("a-partir")
+		replace, _ := cmd.Flags().GetBool("substituir")
+
+		ctx := context.Background()
+		class, err := resolveClass(ctx, classArg)
+		if err != nil {
+			return err
+		}
+		from := time.Now()
+		if fromStr != "" {
+			if from, err = parseDateFlag("a-partir", fromStr); err != nil {
+				return err
+			}
+		}
+
+		lessons, err := lessonTemplateService.ApplySequence(ctx, sequenceID, class.ID, from, replace)
+		if err != nil {
+			return fmt.Errorf("erro ao aplicar sequência: %w", err)
+		}
+		header := fmt.Sprintf("SEQUÊNCIA APLICADA A %s (%d aulas)", class.Name, len(lessons))
+		return writeLessons(os.Stdout, header, lessons)
+	},
+}
+
+var sequenceExportCmd = &cobra.Command{
+	Use:   "exportar [ID_da_sequencia]",
+	Short: "Exporta uma sequência didática para um arquivo",
+	Long: `Grava a sequência em um arquivo JSON que pode ser importado em outro Vigenda com
+'vigenda sequencia importar'. Sem --saida, o arquivo é escrito na saída padrão.`,
+	Example: `  vigenda sequencia exportar 1 --saida fracoes.json`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sequenceID, err := parseIDArg(args[0], "sequência")
+		if err != nil {
+			return err
+		}
+		data, err := lessonTemplateService.ExportSequence(context.Background(), sequenceID)
+		if err != nil {
+			return fmt.Errorf("erro ao exportar sequência: %w", err)
+		}
+		output, _ := cmd.Flags().GetString("saida")
+		if output == "" || output == "-" {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(output, data, 0o644); err != nil {
+			return &service.Error{Category: service.CategoryStorage, Message: fmt.Sprintf("não foi possível gravar '%s'", output), Err: err}
+		}
+		fmt.Printf("Sequência ID %d exportada para %s.\n", sequenceID, output)
+		return nil
+	},
+}
+
+var sequenceImportCmd = &cobra.Command{
+	Use:   "importar [arquivo]",
+	Short: "Importa uma sequência didática exportada por outro Vigenda",
+	Long: `Cadastra a sequência do arquivo gerado por 'vigenda sequencia exportar'. Use --nome para
+gravá-la com outro nome (por exemplo, se já existir uma sequência com o mesmo nome).`,
+	Example: `  vigenda sequencia importar fracoes.json
+  vigenda sequencia importar fracoes.json --nome "Frações (Profa. Ana)"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return &service.Error{Category: service.CategoryValidation, Message: fmt.Sprintf("não foi possível ler '%s'", args[0]), Err: err}
+		}
+		name, _ := cmd.Flags().GetString("nome")
+		sequence, err := lessonTemplateService.ImportSequence(context.Background(), data, name)
+		if err != nil {
+			return fmt.Errorf("erro ao importar sequência: %w", err)
+		}
+		fmt.Printf("Sequência '%s' (ID: %d) importada com %d aulas.\n", sequence.Name, sequence.ID, len(sequence.Items))
+		return nil
+	},
+}
+
+var sequenceDeleteCmd = &cobra.Command{
+	Use:     "remover [ID_da_sequencia]",
+	Short:   "Remove uma sequência didática",
+	Long:    `Remove a sequência. As aulas às quais ela já foi aplicada não são alteradas.`,
+	Example: `  vigenda sequencia remover 1`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sequenceID, err := parseIDArg(args[0], "sequência")
+		if err != nil {
+			return err
+		}
+		if err := lessonTemplateService.DeleteSequence(context.Background(), sequenceID); err != nil {
+			return fmt.Errorf("erro ao remover sequência: %w", err)
+		}
+		fmt.Printf("Sequência ID %d removida.\n", sequenceID)
+		return nil
+	},
+}
+
+// planTitle retorna o primeiro título (# ...) do plano ou, se não houver, o nome do arquivo sem extensão.
+func planTitle(content, path string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			if title := strings.TrimSpace(strings.TrimLeft(line, "#")); title != "" {
+				return title
+			}
+		}
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return strings.NewReplacer("_", " ", "-", " ").Replace(name)
+}
+
+func init() {
+	sequenceCreateCmd.Flags().String("descricao", "", "Descrição da sequência (ex: série e unidade).")
+
+	sequenceApplyCmd.Flags().String("turma", "", "ID ou nome da turma (obrigatório).")
+	_ = sequenceApplyCmd.MarkFlagRequired("turma")
+	sequenceApplyCmd.Flags().String("a-partir", "", "Primeiro dia considerado, no formato AAAA-MM-DD (padrão: hoje).")
+	sequenceApplyCmd.Flags().Bool("substituir", false, "Substitui os planos que as aulas já tiverem.")
+
+	sequenceExportCmd.Flags().String("saida", "", "Arquivo de destino (padrão: saída padrão).")
+
+	sequenceImportCmd.Flags().String("nome", "", "Nome com que a sequência será gravada (padrão: o nome no arquivo).")
+
+	sequenceCmd.AddCommand(sequenceCreateCmd, sequenceListCmd, sequenceShowCmd, sequenceApplyCmd, sequenceExportCmd, sequenceImportCmd, sequenceDeleteCmd)
+	rootCmd.AddCommand(sequenceCmd)
+}
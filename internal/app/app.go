@@ -15,11 +15,15 @@ import (
 
 	// Importações dos submódulos de visualização da TUI.
 	"vigenda/internal/app/assessments"
+	"vigenda/internal/app/attendance"
 	"vigenda/internal/app/classes"
 	"vigenda/internal/app/dashboard"
+	"vigenda/internal/app/lessons"
+	"vigenda/internal/app/planning"
 	"vigenda/internal/app/proofs"
 	"vigenda/internal/app/questions"
 	"vigenda/internal/app/tasks"
+	"vigenda/internal/app/timetable"
 	"vigenda/internal/service" // Importa as interfaces de serviço.
 )
 
@@ -44,7 +48,11 @@ type Model struct {
 	assessmentsModel *assessments.Model
 	questionsModel   *questions.Model
 	proofsModel      *proofs.Model
-	dashboardModel   *dashboard.Model // Modelo para o painel de controle.
+	dashboardModel   *dashboard.Model  // Modelo para o painel de controle.
+	planningModel    *planning.Model   // Modelo para o planejamento do dia.
+	lessonsModel     *lessons.Model    // Modelo para as aulas e planos de aula.
+	timetableModel   *timetable.Model  // Modelo para o horário semanal.
+	attendanceModel  *attendance.Model // Modelo para a chamada.
 
 	width    int  // width da janela do terminal.
 	height   int  // height da janela do terminal.
@@ -58,6 +66,12 @@ type Model struct {
 	questionService   service.QuestionService
 	proofService      service.ProofService
 	lessonService     service.LessonService
+	planningService   service.PlanningService
+	timetableService  service.TimetableService
+	calendarService   service.CalendarService
+	templateService   service.LessonTemplateService
+	attachmentService service.AttachmentService
+	attendanceService service.AttendanceService
 }
 
 // Init é o método de inicialização para o Model principal da aplicação.
@@ -80,10 +94,17 @@ func New(
 	ts service.TaskService, cs service.ClassService,
 	as service.AssessmentService, qs service.QuestionService,
 	ps service.ProofService, ls service.LessonService,
+	pls service.PlanningService, tts service.TimetableService,
+	cals service.CalendarService, lts service.LessonTemplateService,
+	ats service.AttachmentService, atts service.AttendanceService,
 ) *Model {
 	// Define os itens do menu principal. Cada item tem um título e uma View associada.
 	menuItems := []list.Item{
 		menuItem{title: ConcreteDashboardView.String(), view: ConcreteDashboardView},
+		menuItem{title: DailyPlanningView.String(), view: DailyPlanningView},
+		menuItem{title: LessonPlanningView.String(), view: LessonPlanningView},
+		menuItem{title: TimetableView.String(), view: TimetableView},
+		menuItem{title: AttendanceView.String(), view: AttendanceView},
 		menuItem{title: TaskManagementView.String(), view: TaskManagementView},
 		menuItem{title: ClassManagementView.String(), view: ClassManagementView},
 		menuItem{title: AssessmentManagementView.String(), view: AssessmentManagementView},
@@ -112,7 +133,11 @@ func New(
 	am := assessments.New(as, cs) // Passa ClassService aqui
 	qm := questions.New(qs)
 	pm := proofs.New(ps)
-	dshModel := dashboard.New(ts, cs, as, ls)
+	dshModel := dashboard.New(ts, cs, as, ls, pls, cals)
+	plm := planning.New(pls, ts)
+	lm := lessons.New(ls, cs, lts, ats)
+	ttm := timetable.New(tts, cs)
+	atm := attendance.New(ls, atts, cs)
 
 	// Retorna a instância do Model principal.
 	return &Model{
@@ -130,6 +155,16 @@ func New(
 		proofService:      ps,
 		lessonService:     ls,
 		dashboardModel:    dshModel,
+		planningModel:     plm,
+		lessonsModel:      lm,
+		timetableModel:    ttm,
+		timetableService:  tts,
+		planningService:   pls,
+		calendarService:   cals,
+		templateService:   lts,
+		attachmentService: ats,
+		attendanceModel:   atm,
+		attendanceService: atts,
 	}
 }
 
@@ -194,6 +229,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.proofsModel = tempModel.(*proofs.Model)
 		cmds = append(cmds, subCmd)
 
+		tempModel, subCmd = m.planningModel.Update(msg)
+		m.planningModel = tempModel.(*planning.Model)
+		cmds = append(cmds, subCmd)
+
+		tempModel, subCmd = m.lessonsModel.Update(msg)
+		m.lessonsModel = tempModel.(*lessons.Model)
+		cmds = append(cmds, subCmd)
+
+		tempModel, subCmd = m.timetableModel.Update(msg)
+		m.timetableModel = tempModel.(*timetable.Model)
+		cmds = append(cmds, subCmd)
+
+		tempModel, subCmd = m.attendanceModel.Update(msg)
+		m.attendanceModel = tempModel.(*attendance.Model)
+		cmds = append(cmds, subCmd)
+
 		return m, tea.Batch(cmds...)
 
 	case tea.KeyMsg: // Mensagem de tecla pressionada.
@@ -229,6 +280,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						cmds = append(cmds, m.questionsModel.Init())
 					case ProofGenerationView:
 						cmds = append(cmds, m.proofsModel.Init())
+					case DailyPlanningView:
+						cmds = append(cmds, m.planningModel.Init())
+					case LessonPlanningView:
+						cmds = append(cmds, m.lessonsModel.Init())
+					case TimetableView:
+						cmds = append(cmds, m.timetableModel.Init())
+					case AttendanceView:
+						cmds = append(cmds, m.attendanceModel.Init())
 					}
 				}
 			} else if key.Matches(msg, key.NewBinding(key.WithKeys("q"))) { // Sair do menu principal.
@@ -306,6 +365,34 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.currentView = DashboardView
 			}
 		}
+	case DailyPlanningView:
+		updatedSubModel, submodelCmd = m.planningModel.Update(msg)
+		m.planningModel = updatedSubModel.(*planning.Model)
+		if km, ok := msg.(tea.KeyMsg); ok && key.Matches(km, key.NewBinding(key.WithKeys("esc"))) {
+			if m.planningModel.CanGoBack() {
+				m.currentView = DashboardView
+			}
+		}
+	case LessonPlanningView:
+		updatedSubModel, submodelCmd = m.lessonsModel.Update(msg)
+		m.lessonsModel = updatedSubModel.(*lessons.Model)
+		// O 'esc' também fecha o plano e o formulário; só volta ao menu quando pressionado na lista.
+		if m.lessonsModel.CanGoBack() {
+			m.currentView = DashboardView
+		}
+	case TimetableView:
+		updatedSubModel, submodelCmd = m.timetableModel.Update(msg)
+		m.timetableModel = updatedSubModel.(*timetable.Model)
+		if m.timetableModel.CanGoBack() {
+			m.currentView = DashboardView
+		}
+	case AttendanceView:
+		updatedSubModel, submodelCmd = m.attendanceModel.Update(msg)
+		m.attendanceModel = updatedSubModel.(*attendance.Model)
+		// O 'esc' na lista de chamada descarta a chamada; só volta ao menu quando pressionado na lista de aulas.
+		if m.attendanceModel.CanGoBack() {
+			m.currentView = DashboardView
+		}
 	}
 	cmds = append(cmds, submodelCmd) // Adiciona comando do sub-modelo.
 
@@ -350,6 +437,18 @@ func (m *Model) View() string {
 	case ProofGenerationView:
 		viewContent = m.proofsModel.View()
 		help = "\nPressione 'esc' para voltar ao menu principal."
+	case DailyPlanningView:
+		viewContent = m.planningModel.View()
+		help = "\nPressione 'esc' para voltar ao menu principal."
+	case LessonPlanningView:
+		viewContent = m.lessonsModel.View()
+		help = "\nPressione 'esc' na lista de aulas para voltar ao menu principal."
+	case TimetableView:
+		viewContent = m.timetableModel.View()
+		help = "\nPressione 'esc' na lista de horários para voltar ao menu principal."
+	case AttendanceView:
+		viewContent = m.attendanceModel.View()
+		help = "\nPressione 'esc' na lista de aulas para voltar ao menu principal."
 	default: // Caso uma view desconhecida seja definida.
 		viewContent = fmt.Sprintf("Visão desconhecida: %s (%d)", m.currentView.String(), m.currentView)
 		help = "\nPressione 'esc' ou 'q' para tentar voltar ao menu principal."
@@ -371,12 +470,17 @@ func StartApp(
 	ts service.TaskService, cs service.ClassService,
 	as service.AssessmentService, qs service.QuestionService,
 	ps service.ProofService, ls service.LessonService,
-) {
-	model := New(ts, cs, as, qs, ps, ls)
+	pls service.PlanningService, tts service.TimetableService,
+	cals service.CalendarService, lts service.LessonTemplateService,
+	ats service.AttachmentService, atts service.AttendanceService,
+) error {
+	model := New(ts, cs, as, qs, ps, ls, pls, tts, cals, lts, ats, atts)
 	// tea.WithAltScreen() usa o buffer alternativo do terminal, preservando o histórico do shell.
 	p := tea.NewProgram(model, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
-		// Usa log.Fatalf para registrar o erro e sair, garantindo que o erro seja logado no arquivo.
-		log.Fatalf("Erro ao executar o programa BubbleTea: %v", err)
+		// Registra no arquivo de log; a CLI reporta o erro ao usuário e define o código de saída.
+		log.Printf("Erro ao executar o programa BubbleTea: %v", err)
+		return fmt.Errorf("erro ao executar a interface interativa: %w", err)
 	}
+	return nil
 }
@@ -0,0 +1,499 @@
+package assessments
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// Larguras da planilha de notas: a coluna dos nomes fica fixa e as demais rolam na horizontal.
+const (
+	gradebookNameWidth = 24
+	gradebookColWidth  = 8
+)
+
+var (
+	gradebookHeaderStyle  = lipgloss.NewStyle().Bold(true)
+	gradebookCursorStyle  = lipgloss.NewStyle().Reverse(true)
+	gradebookMissingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	gradebookFailStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	gradebookChangedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
+	gradebookAverageStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("75"))
+)
+
+type gradebookLoadedMsg struct {
+	book      service.Gradebook
+	className string
+	err       error
+}
+
+type gradebookSavedMsg struct {
+	saved map[int64]int // ID da avaliação -> notas gravadas
+	err   error
+}
+
+// gradebookColumn é uma coluna da planilha: uma avaliação, a média de um bimestre ou a média do ano.
+type gradebookColumn struct {
+	assessment *models.Assessment // nil nas colunas de média
+	term       int                // 0 na média do ano
+	title      string
+}
+
+func (c gradebookColumn) groupLabel() string {
+	if c.term == 0 {
+		return "Ano"
+	}
+	return service.TermLabel(c.term)
+}
+
+// gradebookEditor é a planilha de notas de uma turma: os alunos nas linhas, as avaliações de cada
+// bimestre nas colunas, seguidas da média do bimestre, e a média do ano à direita. As notas são
+// editadas célula a célula e gravadas juntas com Ctrl+S.
+type gradebookEditor struct {
+	book      service.Gradebook
+	className string
+	columns   []gradebookColumn
+
+	row, col int // Célula selecionada (col é sempre uma coluna de avaliação).
+	offset   int // Primeira coluna visível depois da coluna dos nomes.
+
+	editing bool
+	input   string
+	err     error // Erro da nota em edição, mostrado ao lado da célula.
+
+	changes        map[int64]map[int64]float64 // ID da avaliação -> ID do aluno -> nota ainda não gravada
+	confirmDiscard bool                        // Esc com notas não gravadas: o próximo Esc as descarta.
+}
+
+func newGradebookEditor(book service.Gradebook, className string) *gradebookEditor {
+	e := &gradebookEditor{book: book, className: className, changes: make(map[int64]map[int64]float64)}
+	for _, term := range book.Terms {
+		for _, a := range book.TermAssessments(term) {
+			assessment := a
+			title := a.Name
+			if service.IsRecovery(a) {
+				title = "Rec. " + title
+			}
+			e.columns = append(e.columns, gradebookColumn{assessment: &assessment, term: term, title: title})
+		}
+		e.columns = append(e.columns, gradebookColumn{term: term, title: "Média"})
+	}
+	e.columns = append(e.columns, gradebookColumn{title: "Final"})
+	e.col = e.nextAssessmentColumn(-1, 1)
+	if e.col < 0 {
+		e.col = 0
+	}
+	return e
+}
+
+// reload troca os dados da planilha pelos recém-carregados, mantendo a célula selecionada.
+func (e *gradebookEditor) reload(book service.Gradebook) {
+	row, col, offset, changes := e.row, e.col, e.offset, e.changes
+	*e = *newGradebookEditor(book, e.className)
+	e.changes = changes
+	if row < len(e.book.Students) {
+		e.row = row
+	}
+	if col < len(e.columns) && e.columns[col].assessment != nil {
+		e.col, e.offset = col, offset
+	}
+}
+
+func (e *gradebookEditor) nextAssessmentColumn(from, step int) int {
+	for i := from + step; i >= 0 && i < len(e.columns); i += step {
+		if e.columns[i].assessment != nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// pending conta as notas alteradas e ainda não gravadas.
+func (e *gradebookEditor) pending() int {
+	count := 0
+	for _, grades := range e.changes {
+		count += len(grades)
+	}
+	return count
+}
+
+// grade retorna a nota exibida na célula: a alterada, se houver, ou a gravada.
+func (e *gradebookEditor) grade(assessmentID, studentID int64) (value float64, ok, changed bool) {
+	if value, ok := e.changes[assessmentID][studentID]; ok {
+		return value, true, true
+	}
+	value, ok = e.book.Grade(assessmentID, studentID)
+	return value, ok, false
+}
+
+// handlesEsc informa se o Esc é da planilha (cancelar a edição ou confirmar o descarte das notas
+// alteradas) e não deve voltar ao menu.
+func (e *gradebookEditor) handlesEsc() bool {
+	return e.editing || (e.pending() > 0 && !e.confirmDiscard)
+}
+
+// update trata as teclas da planilha (menos Ctrl+S, tratado pelo Model).
+func (e *gradebookEditor) update(msg tea.KeyMsg) {
+	if e.editing {
+		e.updateEditing(msg)
+		return
+	}
+	if msg.Type == tea.KeyEsc {
+		e.confirmDiscard = true
+		return
+	}
+	e.confirmDiscard = false
+	e.err = nil
+	if len(e.book.Students) == 0 || e.columns[e.col].assessment == nil {
+		return
+	}
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+		if e.row > 0 {
+			e.row--
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+		if e.row < len(e.book.Students)-1 {
+			e.row++
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h", "shift+tab"))):
+		if i := e.nextAssessmentColumn(e.col, -1); i >= 0 {
+			e.col = i
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l", "tab"))):
+		if i := e.nextAssessmentColumn(e.col, 1); i >= 0 {
+			e.col = i
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("home"))):
+		e.col = e.nextAssessmentColumn(-1, 1)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("end"))):
+		e.col = e.nextAssessmentColumn(len(e.columns), -1)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+		e.startEditing("")
+	default:
+		// Um número começa a edição da célula selecionada.
+		if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && unicode.IsDigit(msg.Runes[0]) {
+			e.startEditing(string(msg.Runes))
+		}
+	}
+}
+
+func (e *gradebookEditor) startEditing(input string) {
+	student := e.book.Students[e.row]
+	if student.Status != "" && student.Status != "ativo" {
+		e.err = fmt.Errorf("%s está %s e não recebe notas", student.FullName, student.Status)
+		return
+	}
+	e.err = nil
+	e.editing = true
+	e.input = input
+	if value, ok, _ := e.grade(e.columns[e.col].assessment.ID, student.ID); ok && input == "" {
+		e.input = service.FormatGrade(e.book.Policy, value)
+	}
+}
+
+// updateEditing trata as teclas da célula em edição; Enter valida a nota e desce para o próximo aluno.
+func (e *gradebookEditor) updateEditing(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		e.editing = false
+		e.err = nil
+	case tea.KeyBackspace:
+		if runes := []rune(e.input); len(runes) > 0 {
+			e.input = string(runes[:len(runes)-1])
+		}
+	case tea.KeyEnter:
+		if strings.TrimSpace(e.input) == "" {
+			e.editing = false
+			e.err = nil
+			return
+		}
+		value, err := service.ParseGrade(e.book.Policy, e.input)
+		if err != nil {
+			e.err = err
+			return
+		}
+		assessmentID, studentID := e.columns[e.col].assessment.ID, e.book.Students[e.row].ID
+		if saved, ok := e.book.Grade(assessmentID, studentID); ok && saved == value {
+			delete(e.changes[assessmentID], studentID)
+		} else {
+			if e.changes[assessmentID] == nil {
+				e.changes[assessmentID] = make(map[int64]float64)
+			}
+			e.changes[assessmentID][studentID] = value
+		}
+		e.editing = false
+		e.err = nil
+		if e.row < len(e.book.Students)-1 {
+			e.row++
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		if len([]rune(e.input))+len(msg.Runes) <= gradebookColWidth-2 {
+			e.input += string(msg.Runes)
+		}
+	}
+}
+
+// view renderiza a planilha na largura e altura disponíveis.
+func (e *gradebookEditor) view(width, height int) string {
+	var b strings.Builder
+	b.WriteString(gradebookHeaderStyle.Render("Planilha de Notas — "+e.className) + "  " +
+		helpStyle.Render(fmt.Sprintf("(%s; média de aprovação %s)", service.GradeScaleLabel(e.book.Policy.GradeScale),
+			service.FormatGrade(e.book.Policy, e.book.Policy.PassingGrade))) + "\n\n")
+	if len(e.book.Students) == 0 || len(e.book.Assessments) == 0 {
+		b.WriteString("A turma ainda não tem alunos e avaliações para montar a planilha.\n")
+		return b.String()
+	}
+
+	// Colunas visíveis: a coluna dos nomes fica fixa e as demais rolam com a célula selecionada.
+	visibleCols := len(e.columns)
+	if width > 0 {
+		visibleCols = max((width-gradebookNameWidth-6)/gradebookColWidth, 1)
+	}
+	if e.col < e.offset {
+		e.offset = e.col
+	} else if e.col >= e.offset+visibleCols {
+		e.offset = e.col - visibleCols + 1
+	}
+	end := min(e.offset+visibleCols, len(e.columns))
+
+	// Cabeçalho: o bimestre de cada grupo de colunas e o nome de cada coluna.
+	var groups, titles strings.Builder
+	groups.WriteString(strings.Repeat(" ", gradebookNameWidth))
+	titles.WriteString(padCell("Aluno", gradebookNameWidth))
+	for i := e.offset; i < end; {
+		span := 1
+		for i+span < end && e.columns[i+span].term == e.columns[i].term {
+			span++
+		}
+		groups.WriteString(padCell(e.columns[i].groupLabel(), span*gradebookColWidth))
+		i += span
+	}
+	for i := e.offset; i < end; i++ {
+		titles.WriteString(padCell(e.columns[i].title, gradebookColWidth))
+	}
+	scroll := ""
+	if e.offset > 0 {
+		scroll += "◀ "
+	}
+	if end < len(e.columns) {
+		scroll += "▶"
+	}
+	b.WriteString(gradebookHeaderStyle.Render(strings.TrimRight(groups.String(), " ")) + "  " + helpStyle.Render(scroll) + "\n")
+	b.WriteString(gradebookHeaderStyle.Render(titles.String()) + "\n")
+
+	// Linhas visíveis: mantém o aluno selecionado na janela.
+	visibleRows := len(e.book.Students)
+	if height > 0 {
+		visibleRows = max(height-14, 5)
+	}
+	start := 0
+	if e.row >= visibleRows {
+		start = e.row - visibleRows + 1
+	}
+	for r := start; r < min(start+visibleRows, len(e.book.Students)); r++ {
+		student := e.book.Students[r]
+		name := student.FullName
+		if student.Status != "" && student.Status != "ativo" {
+			name += " (" + student.Status + ")"
+		}
+		line := padCell(name, gradebookNameWidth)
+		if r == e.row {
+			line = gradebookHeaderStyle.Render(line)
+		} else if student.Status != "" && student.Status != "ativo" {
+			line = helpStyle.Render(line)
+		}
+		for c := e.offset; c < end; c++ {
+			line += e.cell(r, c)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if e.editing && e.err != nil {
+		b.WriteString("\n" + gradebookFailStyle.Render(e.err.Error()) + "\n")
+	} else if e.err != nil {
+		b.WriteString("\n" + gradebookFailStyle.Render("Erro: "+e.err.Error()) + "\n")
+	}
+	if pending := e.pending(); pending > 0 {
+		note := fmt.Sprintf("%d nota(s) alterada(s), ainda não gravada(s); as médias são recalculadas ao gravar.", pending)
+		if e.confirmDiscard {
+			note = fmt.Sprintf("Esc de novo descarta %d nota(s) alterada(s); Ctrl+S grava.", pending)
+		}
+		b.WriteString("\n" + gradebookChangedStyle.Render(note) + "\n")
+	}
+	b.WriteString("\n" + helpStyle.Render("Setas: célula | Enter ou número: editar | Ctrl+S: gravar | Esc: voltar") + "\n")
+	b.WriteString(helpStyle.Render("Legenda: ") + gradebookMissingStyle.Render("-- sem nota") + "  " +
+		gradebookFailStyle.Render("abaixo da média") + "  " + gradebookChangedStyle.Render("* não gravada"))
+	return b.String()
+}
+
+// cell renderiza a célula da linha r e coluna c, já com a largura da coluna.
+func (e *gradebookEditor) cell(r, c int) string {
+	student := e.book.Students[r]
+	column := e.columns[c]
+	active := student.Status == "" || student.Status == "ativo"
+	policy := e.book.Policy
+	selected := r == e.row && c == e.col
+
+	if selected && e.editing {
+		return gradebookCursorStyle.Render(padCell(e.input+"_", gradebookColWidth-1)) + " "
+	}
+
+	var text string
+	style := lipgloss.NewStyle()
+	switch {
+	case column.assessment != nil:
+		value, ok, changed := e.grade(column.assessment.ID, student.ID)
+		switch {
+		case ok:
+			text = service.FormatGrade(policy, value)
+			if value < policy.PassingGrade {
+				style = gradebookFailStyle
+			}
+			if changed {
+				text += "*"
+				style = gradebookChangedStyle
+			}
+		case active && !service.IsRecovery(*column.assessment):
+			text, style = "--", gradebookMissingStyle
+		default:
+			text, style = "·", helpStyle
+		}
+	default:
+		averages := e.book.Averages
+		if column.term != 0 {
+			averages = e.book.TermAverages[column.term]
+		}
+		if average, ok := averages[student.ID]; ok {
+			text, style = service.FormatGrade(policy, average.Adjusted), gradebookAverageStyle
+			if average.Adjusted < policy.PassingGrade {
+				style = gradebookFailStyle
+			}
+		}
+	}
+	if selected {
+		style = style.Inherit(gradebookCursorStyle)
+	}
+	return style.Render(padCell(text, gradebookColWidth-1)) + " "
+}
+
+// padCell corta ou completa o texto até a largura da coluna.
+func padCell(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return text + strings.Repeat(" ", width-len(runes))
+}
+
+func (m *Model) loadGradebookCmd(classID int64) tea.Cmd {
+	return func() tea.Msg {
+		book, err := m.assessmentService.GetGradebook(context.Background(), classID)
+		if err != nil {
+			return gradebookLoadedMsg{err: err}
+		}
+		className := fmt.Sprintf("Turma ID %d", classID)
+		if m.classService != nil {
+			if class, err := m.classService.GetClassByID(context.Background(), classID); err == nil {
+				className = class.Name
+			}
+		}
+		return gradebookLoadedMsg{book: book, className: className}
+	}
+}
+
+// saveGradebookCmd grava as notas alteradas, uma chamada de EnterGrades por avaliação.
+func (m *Model) saveGradebookCmd() tea.Cmd {
+	changes := make(map[int64]map[int64]float64)
+	var ids []int64
+	for assessmentID, grades := range m.gradebook.changes {
+		if len(grades) > 0 {
+			changes[assessmentID] = grades
+			ids = append(ids, assessmentID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return func() tea.Msg {
+		saved := make(map[int64]int)
+		for _, assessmentID := range ids {
+			if err := m.assessmentService.EnterGrades(context.Background(), assessmentID, changes[assessmentID]); err != nil {
+				return gradebookSavedMsg{saved: saved, err: fmt.Errorf("avaliação ID %d: %w", assessmentID, err)}
+			}
+			saved[assessmentID] = len(changes[assessmentID])
+		}
+		return gradebookSavedMsg{saved: saved}
+	}
+}
+
+// updateGradebook trata as teclas da planilha de notas: primeiro o ID da turma, depois a planilha.
+func (m *Model) updateGradebook(msg tea.KeyMsg) tea.Cmd {
+	if m.gradebook == nil {
+		if key.Matches(msg, key.NewBinding(key.WithKeys("enter"))) {
+			classID, err := strconv.ParseInt(strings.TrimSpace(m.textInputs[0].Value()), 10, 64)
+			if err != nil {
+				m.err = fmt.Errorf("ID da Turma inválido: %w", err)
+				return nil
+			}
+			m.err = nil
+			m.isLoading = true
+			m.currentClassID = &classID
+			return m.loadGradebookCmd(classID)
+		}
+		var cmd tea.Cmd
+		m.textInputs[0], cmd = m.textInputs[0].Update(msg)
+		return cmd
+	}
+
+	if key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+s"))) && !m.gradebook.editing {
+		if m.gradebook.pending() == 0 {
+			m.message = "Nenhuma nota alterada."
+			return nil
+		}
+		m.err = nil
+		m.message = ""
+		m.isLoading = true
+		return m.saveGradebookCmd()
+	}
+	m.message = ""
+	m.gradebook.update(msg)
+	return nil
+}
+
+// handleGradebookSaved tira das alterações pendentes as notas gravadas e recarrega a planilha, para
+// recalcular as médias.
+func (m *Model) handleGradebookSaved(msg gradebookSavedMsg) tea.Cmd {
+	count := 0
+	for assessmentID, n := range msg.saved {
+		delete(m.gradebook.changes, assessmentID)
+		count += n
+	}
+	if msg.err != nil {
+		m.isLoading = false
+		m.err = msg.err
+		if count == 0 {
+			return nil
+		}
+	}
+	if msg.err == nil {
+		m.message = fmt.Sprintf("%d nota(s) gravada(s).", count)
+	}
+	return m.loadGradebookCmd(m.gradebook.book.ClassID)
+}
+
+func (m *Model) gradebookView() string {
+	if m.gradebook == nil {
+		return "Planilha de Notas da Turma\n\n" + m.textInputs[0].View() + "\n\n" +
+			helpStyle.Render("Enter: abrir a planilha | Esc: voltar")
+	}
+	return m.gradebook.view(m.width, m.height)
+}
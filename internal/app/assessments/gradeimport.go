@@ -0,0 +1,196 @@
+package assessments
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vigenda/internal/service"
+)
+
+var (
+	gradeImportMatchedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	gradeImportProblemStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+)
+
+type gradeImportPreviewMsg struct {
+	preview service.GradeImportPreview
+	err     error
+}
+
+type gradesImportedMsg struct {
+	count int
+	err   error
+}
+
+// setupGradeImportForm prepara os campos da importação: o ID da avaliação e o caminho da planilha.
+func (m *Model) setupGradeImportForm() {
+	m.focusIndex = 0
+	m.gradeImport = nil
+	m.gradeImportOffset = 0
+	m.textInputs = make([]textinput.Model, 2)
+	for i, placeholder := range []string{"ID da Avaliação", "Arquivo da planilha (.csv ou .xlsx)"} {
+		m.textInputs[i] = textinput.New()
+		m.textInputs[i].Placeholder = placeholder
+		m.textInputs[i].Width = m.width / 2
+	}
+	m.textInputs[0].CharLimit = 10
+	m.textInputs[0].Validate = isNumber
+	m.textInputs[1].CharLimit = 256
+	m.textInputs[0].Focus()
+}
+
+func (m *Model) previewGradeImportCmd(assessmentID int64, path string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return gradeImportPreviewMsg{err: fmt.Errorf("não foi possível ler o arquivo '%s': %w", path, err)}
+		}
+		preview, err := m.assessmentService.PreviewGradeImport(context.Background(), assessmentID, data, filepath.Ext(path), service.GradeImportMapping{})
+		return gradeImportPreviewMsg{preview: preview, err: err}
+	}
+}
+
+// importGradesCmd grava de uma vez as notas das linhas encontradas na prévia.
+func (m *Model) importGradesCmd() tea.Cmd {
+	preview := *m.gradeImport
+	return func() tea.Msg {
+		grades := preview.Grades()
+		if err := m.assessmentService.EnterGrades(context.Background(), preview.Assessment.ID, grades); err != nil {
+			return gradesImportedMsg{err: err}
+		}
+		return gradesImportedMsg{count: len(grades)}
+	}
+}
+
+// updateGradeImport trata as teclas da importação: primeiro o formulário, depois a prévia, em que
+// 'enter' importa quando todas as linhas foram encontradas e 'i' importa só as encontradas.
+func (m *Model) updateGradeImport(msg tea.KeyMsg) tea.Cmd {
+	if m.gradeImport == nil {
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("tab", "shift+tab", "up", "down"))):
+			m.focusIndex = 1 - m.focusIndex
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))) && m.focusIndex == 0:
+			m.focusIndex = 1
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			assessmentID, err := strconv.ParseInt(strings.TrimSpace(m.textInputs[0].Value()), 10, 64)
+			if err != nil {
+				m.err = fmt.Errorf("ID da Avaliação inválido: %w", err)
+				return nil
+			}
+			path := strings.TrimSpace(m.textInputs[1].Value())
+			if path == "" {
+				m.err = fmt.Errorf("informe o arquivo da planilha")
+				return nil
+			}
+			m.err = nil
+			m.isLoading = true
+			m.currentAssessmentID = &assessmentID
+			return m.previewGradeImportCmd(assessmentID, path)
+		default:
+			var cmd tea.Cmd
+			m.textInputs[m.focusIndex], cmd = m.textInputs[m.focusIndex].Update(msg)
+			return cmd
+		}
+		for i := range m.textInputs {
+			if i == m.focusIndex {
+				m.textInputs[i].Focus()
+			} else {
+				m.textInputs[i].Blur()
+			}
+		}
+		return nil
+	}
+
+	preview := m.gradeImport
+	matched := preview.Count(service.GradeImportMatched)
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+		m.gradeImportOffset = max(m.gradeImportOffset-1, 0)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+		m.gradeImportOffset = min(m.gradeImportOffset+1, max(len(preview.Rows)-1, 0))
+	case key.Matches(msg, key.NewBinding(key.WithKeys("enter", "i"))):
+		if matched == 0 {
+			m.err = fmt.Errorf("nenhuma linha da planilha corresponde a um aluno da avaliação")
+			return nil
+		}
+		if preview.Problems() > 0 && msg.String() != "i" {
+			m.err = fmt.Errorf("%d linha(s) com problemas: corrija a planilha ou pressione 'i' para importar só as encontradas", preview.Problems())
+			return nil
+		}
+		m.err = nil
+		m.isLoading = true
+		return m.importGradesCmd()
+	}
+	return nil
+}
+
+func (m *Model) gradeImportView() string {
+	var b strings.Builder
+	if m.gradeImport == nil {
+		b.WriteString("Importar Planilha de Notas\n\n")
+		for i := range m.textInputs {
+			b.WriteString(m.textInputs[i].View() + "\n")
+		}
+		b.WriteString("\nA planilha tem uma linha por aluno, com a matrícula ou o nome e a nota; as colunas são\n")
+		b.WriteString("deduzidas dos cabeçalhos (Matrícula ou Nome; Nota).\n\n")
+		b.WriteString(helpStyle.Render("Tab: trocar de campo | Enter: ver a prévia | Esc: voltar"))
+		return b.String()
+	}
+
+	preview := m.gradeImport
+	b.WriteString(gradebookHeaderStyle.Render(fmt.Sprintf("Prévia da importação — %s (ID: %d)", preview.Assessment.Name, preview.Assessment.ID)) + "\n\n")
+	keyTitle := "Nome na planilha"
+	if preview.Key == service.GradeImportKeyEnrollment {
+		keyTitle = "Matrícula"
+	}
+	b.WriteString(gradebookHeaderStyle.Render(fmt.Sprintf("%5s  %s %s %s %s", "Linha", padCell(keyTitle, 22), padCell("Nota", 6), padCell("Aluno", 22), "Situação")) + "\n")
+
+	visible := max(m.height-12, 5)
+	end := min(m.gradeImportOffset+visible, len(preview.Rows))
+	for _, row := range preview.Rows[m.gradeImportOffset:end] {
+		student := "-"
+		if row.Student != nil {
+			student = row.Student.FullName
+		}
+		grade := row.GradeText
+		if row.Status == service.GradeImportMatched {
+			grade = service.FormatGrade(preview.Policy, row.Grade)
+		}
+		status := service.GradeImportStatusLabel(row.Status)
+		if row.Message != "" {
+			status += ": " + row.Message
+		}
+		switch row.Status {
+		case service.GradeImportMatched:
+			status = gradeImportMatchedStyle.Render(status)
+		case service.GradeImportSkipped:
+			status = helpStyle.Render(status)
+		default:
+			status = gradeImportProblemStyle.Render(status)
+		}
+		b.WriteString(fmt.Sprintf("%5d  %s %s %s %s\n", row.Line, padCell(row.Key, 22), padCell(grade, 6), padCell(student, 22), status))
+	}
+	if end < len(preview.Rows) || m.gradeImportOffset > 0 {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("(linhas %d a %d de %d)", m.gradeImportOffset+1, end, len(preview.Rows))) + "\n")
+	}
+
+	matched := preview.Count(service.GradeImportMatched)
+	b.WriteString(fmt.Sprintf("\n%d encontrada(s), %d não encontrada(s), %d ambígua(s), %d inválida(s), %d sem nota.\n",
+		matched, preview.Count(service.GradeImportUnmatched), preview.Count(service.GradeImportAmbiguous),
+		preview.Count(service.GradeImportInvalid), preview.Count(service.GradeImportSkipped)))
+	help := fmt.Sprintf("Enter: importar %d nota(s) | ↑/↓: rolar | Esc: cancelar", matched)
+	if preview.Problems() > 0 {
+		help = fmt.Sprintf("i: importar só as %d encontrada(s) | ↑/↓: rolar | Esc: cancelar", matched)
+	}
+	b.WriteString(helpStyle.Render(help))
+	return b.String()
+}
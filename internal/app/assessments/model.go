@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -33,6 +34,9 @@ const (
 	FinalGradesView
 	ViewFinalGradesView
 	ListAssessmentsView // For showing assessments in a table
+	GradingPolicyView   // Editor da política de notas de uma turma
+	GradebookView       // Planilha com todas as notas de uma turma
+	GradeImportView     // Importação das notas de uma avaliação de uma planilha CSV/XLSX
 )
 
 // Model represents the assessments management model.
@@ -53,6 +57,11 @@ type Model struct {
 	studentsForGrading []models.Student // For EnterGradesView
 	gradesInput        map[int64]textinput.Model // studentID -> textinput for grade
 	gradeFocusIndex int // New: To track focus on grade inputs
+	policyEditor    *policyEditor // Editor da política de notas (GradingPolicyView), após carregar a turma
+	gradePolicy     models.GradingPolicy // Política da turma das notas em edição ou exibição (escala das notas)
+	gradebook       *gradebookEditor // Planilha de notas (GradebookView), após carregar a turma
+	gradeImport       *service.GradeImportPreview // Prévia da importação (GradeImportView), após ler a planilha
+	gradeImportOffset int                         // Primeira linha visível da prévia
 
 	// Popup state
 	isPopupVisible bool
@@ -84,6 +93,7 @@ type assessmentCreatedMsg struct {
 type studentsForGradingLoadedMsg struct {
 	students []models.Student
 	assessmentName string
+	policy   models.GradingPolicy
 	err      error
 }
 type gradesEnteredMsg struct {
@@ -97,6 +107,7 @@ type assessmentDeletedMsg struct {
 
 type studentsForFinalGradesLoadedMsg struct {
 	students []models.Student
+	policy   models.GradingPolicy
 	err      error
 }
 
@@ -107,11 +118,12 @@ type finalGradesEnteredMsg struct {
 type finalGradesLoadedMsg struct {
 	students []models.Student
 	grades   map[int64]float64
+	policy   models.GradingPolicy
 	err      error
 }
 
 type classAverageCalculatedMsg struct {
-	averages map[int64]float64
+	averages map[int64]service.StudentAverage
 	err      error
 }
 
@@ -131,7 +143,7 @@ func (m *Model) calculateAverageCmd(classID int64, termsStr string) tea.Cmd {
 			}
 		}
 
-		averages, err := m.assessmentService.CalculateClassAverage(context.Background(), classID, terms)
+		averages, err := m.assessmentService.CalculateClassAverages(context.Background(), classID, terms)
 		if err != nil {
 			return classAverageCalculatedMsg{err: err}
 		}
@@ -160,8 +172,11 @@ func New(assessmentService service.AssessmentService, classService service.Class
 		actionItem{title: "Listar Avaliações", description: "Visualizar todas as avaliações (pode pedir turma)."},
 		actionItem{title: "Criar Nova Avaliação", description: "Adicionar uma nova avaliação para uma turma."},
 		actionItem{title: "Lançar Notas", description: "Lançar/editar notas de alunos para uma avaliação."},
+		actionItem{title: "Planilha de Notas", description: "Todas as notas da turma por bimestre, com as médias, editáveis célula a célula."},
+		actionItem{title: "Importar Planilha", description: "Lançar as notas de uma avaliação a partir de um arquivo CSV ou XLSX."},
 		actionItem{title: "Lançar/Calcular Notas Finais", description: "Lançar manualmente ou calcular a média final de uma turma."},
 		actionItem{title: "Visualizar Notas Finais", description: "Visualizar as notas finais de uma turma."},
+		actionItem{title: "Política de Notas da Turma", description: "Definir como a média da turma é calculada e arredondada."},
 	}
 	l := list.New(actionItems, list.NewDefaultDelegate(), 0, 0)
 	l.Title = "Gerenciar Avaliações e Notas"
@@ -181,6 +196,7 @@ func New(assessmentService service.AssessmentService, classService service.Class
 		{Title: "ID Turma", Width: 10},
 		{Title: "Período", Width: 10},
 		{Title: "Peso", Width: 8},
+		{Title: "Data", Width: 12},
 	}
 	tbl := table.New(table.WithColumns(cols), table.WithFocused(true), table.WithHeight(10))
 	s := table.DefaultStyles()
@@ -188,7 +204,7 @@ func New(assessmentService service.AssessmentService, classService service.Class
 	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Bold(false)
 	tbl.SetStyles(s)
 
-	inputs := make([]textinput.Model, 4) // Max 4 inputs for CreateAssessment
+	inputs := make([]textinput.Model, 5) // Max 5 inputs for CreateAssessment
 	for i := range inputs {
 		ti := textinput.New()
 		ti.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
@@ -214,7 +230,11 @@ func (m *Model) loadStudentsForFinalGradesCmd(classID int64) tea.Cmd {
 		if err != nil {
 			return studentsForFinalGradesLoadedMsg{err: err}
 		}
-		return studentsForFinalGradesLoadedMsg{students: students, err: nil}
+		policy, err := m.assessmentService.GetGradingPolicy(context.Background(), classID)
+		if err != nil {
+			return studentsForFinalGradesLoadedMsg{err: err}
+		}
+		return studentsForFinalGradesLoadedMsg{students: students, policy: policy, err: nil}
 	}
 }
 
@@ -229,9 +249,9 @@ func (m *Model) submitFinalGradesCmd() tea.Cmd {
 		if gradeStr == "" {
 			continue
 		}
-		grade, err := strconv.ParseFloat(gradeStr, 64)
+		grade, err := service.ParseGrade(m.gradePolicy, gradeStr)
 		if err != nil {
-			return func() tea.Msg { return finalGradesEnteredMsg{err: fmt.Errorf("nota inválida para aluno ID %d: '%s'", studentID, gradeStr)} }
+			return func() tea.Msg { return finalGradesEnteredMsg{err: fmt.Errorf("nota inválida para aluno ID %d: %w", studentID, err)} }
 		}
 		grades[studentID] = grade
 	}
@@ -249,7 +269,11 @@ func (m *Model) submitFinalGradesCmd() tea.Cmd {
 func (m *Model) loadFinalGradesCmd(classID int64) tea.Cmd {
 	return func() tea.Msg {
 		students, grades, err := m.assessmentService.GetFinalGradesByClassID(context.Background(), classID)
-		return finalGradesLoadedMsg{students: students, grades: grades, err: err}
+		if err != nil {
+			return finalGradesLoadedMsg{err: err}
+		}
+		policy, err := m.assessmentService.GetGradingPolicy(context.Background(), classID)
+		return finalGradesLoadedMsg{students: students, grades: grades, policy: policy, err: err}
 	}
 }
 
@@ -285,6 +309,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.state == ListView {
 				return m, nil // Let parent model handle 'esc' from main action list
 			}
+			if m.state == GradebookView && m.gradebook != nil && m.gradebook.handlesEsc() {
+				m.gradebook.update(msg)
+				return m, nil
+			}
 			// Go back to previous state or main action list
 			if m.state == EnterGradesView && len(m.studentsForGrading) > 0 { // If in grade entry, Esc might go to assessment selection or main list
 				m.state = ListView // Simplified: back to main action list
@@ -329,6 +357,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					case "Visualizar Notas Finais":
 						m.state = ViewFinalGradesView
 						m.setupEnterClassIDForm("ID da Turma para Visualizar as Notas Finais:")
+					case "Política de Notas da Turma":
+						m.state = GradingPolicyView
+						m.setupEnterClassIDForm("ID da Turma:")
+					case "Planilha de Notas":
+						m.state = GradebookView
+						m.setupEnterClassIDForm("ID da Turma:")
+					case "Importar Planilha":
+						m.state = GradeImportView
+						m.setupGradeImportForm()
 					}
 				}
 			}
@@ -427,6 +464,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.textInputs[0], cmd = m.textInputs[0].Update(msg)
 				cmds = append(cmds, cmd)
 			}
+
+		case GradingPolicyView:
+			cmds = append(cmds, m.updateGradingPolicy(msg))
+
+		case GradebookView:
+			cmds = append(cmds, m.updateGradebook(msg))
+
+		case GradeImportView:
+			cmds = append(cmds, m.updateGradeImport(msg))
 		}
 
 	if m.isPopupVisible {
@@ -443,12 +489,21 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.assessments = msg.assessments
 			rows := make([]table.Row, len(m.assessments))
 			for i, asm := range m.assessments {
+				name, weight := asm.Name, fmt.Sprintf("%.1f", asm.Weight)
+				date := "-"
+				if asm.AssessmentDate != nil {
+					date = asm.AssessmentDate.Format("02/01/2006")
+				}
+				if service.IsRecovery(asm) {
+					name, weight = name+" (recuperação)", "-"
+				}
 				rows[i] = table.Row{
 					fmt.Sprintf("%d", asm.ID),
-					asm.Name,
+					name,
 					fmt.Sprintf("%d", asm.ClassID),
 					fmt.Sprintf("%d", asm.Term),
-					fmt.Sprintf("%.1f", asm.Weight),
+					weight,
+					date,
 				}
 			}
 			m.table.SetRows(rows)
@@ -470,11 +525,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 		} else {
 			m.studentsForGrading = msg.students
-			m.message = fmt.Sprintf("Alunos carregados para avaliação: %s. Insira as notas.", msg.assessmentName)
+			m.gradePolicy = msg.policy
+			m.message = fmt.Sprintf("Alunos carregados para avaliação: %s. Insira as notas (%s).", msg.assessmentName, service.GradeScaleLabel(msg.policy.GradeScale))
 			m.gradesInput = make(map[int64]textinput.Model)
 			for i, s := range msg.students { // Use index for focus logic if needed
 				ti := textinput.New()
-				ti.Placeholder = "Nota (ex: 7.5)"
+				ti.Placeholder = gradePlaceholder(msg.policy)
 				ti.CharLimit = 5
 				ti.Width = 10
 				m.gradesInput[s.ID] = ti
@@ -490,12 +546,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 		} else {
 			m.studentsForGrading = msg.students
+			m.gradePolicy = msg.policy
 			rows := make([]table.Row, len(m.studentsForGrading))
 			for i, s := range m.studentsForGrading {
 				grade, ok := msg.grades[s.ID]
 				gradeStr := "N/A"
 				if ok {
-					gradeStr = fmt.Sprintf("%.1f", grade)
+					gradeStr = service.FormatGrade(msg.policy, grade)
 				}
 				rows[i] = table.Row{s.FullName, gradeStr}
 			}
@@ -547,13 +604,72 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 		} else {
 			m.message = "Médias calculadas com sucesso!"
+			recovered := 0
 			for studentID, avg := range msg.averages {
+				if avg.Recovered {
+					recovered++
+				}
 				if ti, ok := m.gradesInput[studentID]; ok {
-					ti.SetValue(fmt.Sprintf("%.2f", avg))
+					ti.SetValue(formatGradeInput(m.gradePolicy, avg.Adjusted))
 					ti.Blur() // Disable editing after calculation
 					m.gradesInput[studentID] = ti
 				}
 			}
+			if recovered > 0 {
+				m.message += fmt.Sprintf(" %d aluno(s) com a média ajustada pela recuperação.", recovered)
+			}
+		}
+
+	case gradingPolicyLoadedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.policyEditor = newPolicyEditor(msg.policy, msg.className)
+		}
+
+	case gradingPolicySavedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.message = fmt.Sprintf("Política de notas salva: %s, %s.", service.GradingSchemeLabel(msg.policy.Scheme), service.GradeScaleLabel(msg.policy.GradeScale))
+			m.state = ListView
+			m.list.Select(-1)
+			m.policyEditor = nil
+		}
+
+	case gradebookLoadedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+		} else if m.gradebook != nil {
+			m.gradebook.reload(msg.book)
+		} else {
+			m.gradebook = newGradebookEditor(msg.book, msg.className)
+		}
+
+	case gradebookSavedMsg:
+		cmds = append(cmds, m.handleGradebookSaved(msg))
+
+	case gradeImportPreviewMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.gradeImport = &msg.preview
+			m.gradeImportOffset = 0
+		}
+
+	case gradesImportedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.message = fmt.Sprintf("%d nota(s) importada(s) para a avaliação '%s'.", msg.count, m.gradeImport.Assessment.Name)
+			m.state = ListView
+			m.list.Select(-1)
+			m.gradeImport = nil
 		}
 
 	case studentsForFinalGradesLoadedMsg:
@@ -562,7 +678,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 		} else {
 			m.studentsForGrading = msg.students
-			m.message = "Insira as notas finais."
+			m.gradePolicy = msg.policy
+			m.message = fmt.Sprintf("Insira as notas finais (%s).", service.GradeScaleLabel(msg.policy.GradeScale))
 			m.gradesInput = make(map[int64]textinput.Model)
 			for i, s := range msg.students {
 				ti := textinput.New()
@@ -721,6 +838,15 @@ func (m *Model) View() string {
 			b.WriteString("\n(Pressione 'esc' para voltar)")
 		}
 
+	case GradingPolicyView:
+		b.WriteString(m.gradingPolicyView())
+
+	case GradebookView:
+		b.WriteString(m.gradebookView())
+
+	case GradeImportView:
+		b.WriteString(m.gradeImportView())
+
 	default:
 		b.WriteString("Visualização de Avaliações Desconhecida")
 	}
@@ -755,6 +881,10 @@ func (m *Model) resetForms() {
 	}
 	m.gradesInput = make(map[int64]textinput.Model)
 	m.studentsForGrading = nil
+	m.policyEditor = nil
+	m.gradebook = nil
+	m.gradeImport = nil
+	m.gradePolicy = models.GradingPolicy{}
 	m.focusIndex = 0
 	m.err = nil
 	m.message = ""
@@ -762,10 +892,10 @@ func (m *Model) resetForms() {
 
 func (m *Model) setupCreateAssessmentForm() {
 	m.focusIndex = 0
-	m.textInputs = make([]textinput.Model, 4) // Name, ClassID, Term, Weight
+	m.textInputs = make([]textinput.Model, 5) // Name, ClassID, Term, Weight, Date
 
-	placeholders := []string{"Nome da Avaliação", "ID da Turma", "Período (ex: 1)", "Peso (ex: 3.0)"}
-	validators := []func(string)error{nil, isNumber, isNumber, isFloatOrEmpty}
+	placeholders := []string{"Nome da Avaliação", "ID da Turma", "Período (ex: 1; com data, opcional)", "Peso (ex: 3.0)", "Data (DD/MM/AAAA, opcional)"}
+	validators := []func(string)error{nil, isNumber, isNumber, isFloatOrEmpty, nil}
 
 	for i, p := range placeholders {
 		m.textInputs[i] = textinput.New()
@@ -810,7 +940,7 @@ func (m *Model) updateFocus() tea.Cmd {
 	// Determine number of active inputs for current form
 	numInputs := 0
 	if m.state == CreateAssessmentView {
-		numInputs = 4
+		numInputs = 5
 	} else if (m.state == EnterGradesView && len(m.studentsForGrading) == 0) || m.state == FinalGradesView {
 		numInputs = 1 // Single ID input
 	} else if m.state == EnterGradesView && len(m.studentsForGrading) > 0 {
@@ -830,7 +960,7 @@ func (m *Model) updateInputFocusStyle() tea.Cmd {
 	numInputs := 0
 	// Determine active inputs based on state
 	if m.state == CreateAssessmentView {
-		numInputs = 4
+		numInputs = 5
 	} else if (m.state == EnterGradesView && len(m.studentsForGrading) == 0) || m.state == FinalGradesView {
 		numInputs = 1
 	} // Other states might not use these textInputs directly or have their own focus logic
@@ -855,7 +985,7 @@ func (m *Model) updateFormInputs(msg tea.Msg) tea.Cmd {
 	// Update focused text input
 	activeInputs := 0
 	if m.state == CreateAssessmentView {
-		activeInputs = 4
+		activeInputs = 5
 	}
 	if (m.state == EnterGradesView && len(m.studentsForGrading) == 0) || m.state == FinalGradesView {
 		activeInputs = 1
@@ -876,9 +1006,11 @@ func (m *Model) submitCreateAssessmentFormCmd() tea.Cmd {
 		classIDStr := m.textInputs[1].Value()
 		termStr := m.textInputs[2].Value()
 		weightStr := m.textInputs[3].Value()
+		dateStr := strings.TrimSpace(m.textInputs[4].Value())
 
-		if name == "" || classIDStr == "" || termStr == "" || weightStr == "" {
-			return assessmentCreatedMsg{err: fmt.Errorf("todos os campos são obrigatórios")}
+		// Com data, o período pode ficar vazio: vem do calendário escolar.
+		if name == "" || classIDStr == "" || (termStr == "" && dateStr == "") || weightStr == "" {
+			return assessmentCreatedMsg{err: fmt.Errorf("nome, turma, peso e período (ou data) são obrigatórios")}
 		}
 
 		classID, err := strconv.ParseInt(classIDStr, 10, 64)
@@ -886,9 +1018,11 @@ func (m *Model) submitCreateAssessmentFormCmd() tea.Cmd {
 			return assessmentCreatedMsg{err: fmt.Errorf("ID da turma inválido: '%s'", classIDStr)}
 		}
 
-		term, err := strconv.Atoi(termStr)
-		if err != nil {
-			return assessmentCreatedMsg{err: fmt.Errorf("período inválido: '%s'", termStr)}
+		term := 0
+		if termStr != "" {
+			if term, err = strconv.Atoi(termStr); err != nil {
+				return assessmentCreatedMsg{err: fmt.Errorf("período inválido: '%s'", termStr)}
+			}
 		}
 
 		weight, err := strconv.ParseFloat(weightStr, 64)
@@ -896,6 +1030,14 @@ func (m *Model) submitCreateAssessmentFormCmd() tea.Cmd {
 			return assessmentCreatedMsg{err: fmt.Errorf("peso inválido: '%s'", weightStr)}
 		}
 
+		if dateStr != "" {
+			date, err := time.ParseInLocation("02/01/2006", dateStr, time.Local)
+			if err != nil {
+				return assessmentCreatedMsg{err: fmt.Errorf("data inválida: '%s' (use DD/MM/AAAA)", dateStr)}
+			}
+			asm, err := m.assessmentService.CreateAssessmentOnDate(context.Background(), name, classID, term, weight, date)
+			return assessmentCreatedMsg{assessment: asm, err: err}
+		}
 		asm, err := m.assessmentService.CreateAssessment(context.Background(), name, classID, term, weight)
 		return assessmentCreatedMsg{assessment: asm, err: err}
 	}
@@ -907,9 +1049,14 @@ func (m *Model) loadStudentsForGradingCmd(assessmentID int64) tea.Cmd {
 		if err != nil {
 			return studentsForGradingLoadedMsg{err: err}
 		}
+		policy, err := m.assessmentService.GetGradingPolicy(context.Background(), assessment.ClassID)
+		if err != nil {
+			return studentsForGradingLoadedMsg{err: err}
+		}
 		return studentsForGradingLoadedMsg{
 			students:       students,
 			assessmentName: assessment.Name,
+			policy:         policy,
 			err:            nil,
 		}
 	}
@@ -924,9 +1071,9 @@ func (m *Model) submitGradesCmd() tea.Cmd {
 	for studentID, ti := range m.gradesInput {
 		gradeStr := ti.Value()
 		if gradeStr == "" { continue } // Skip empty grades or handle as 0?
-		grade, err := strconv.ParseFloat(gradeStr, 64)
+		grade, err := service.ParseGrade(m.gradePolicy, gradeStr)
 		if err != nil {
-			return func() tea.Msg { return gradesEnteredMsg{err: fmt.Errorf("nota inválida para aluno ID %d: '%s'", studentID, gradeStr)} }
+			return func() tea.Msg { return gradesEnteredMsg{err: fmt.Errorf("nota inválida para aluno ID %d: %w", studentID, err)} }
 		}
 		grades[studentID] = grade
 	}
@@ -0,0 +1,406 @@
+package assessments
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// fakeAssessmentService guarda a política de notas da turma em memória.
+type fakeAssessmentService struct {
+	service.AssessmentService
+	policy  models.GradingPolicy
+	saved   *models.GradingPolicy
+	book    service.Gradebook
+	entered map[int64]map[int64]float64 // ID da avaliação -> notas gravadas por EnterGrades
+	created *models.Assessment          // Última avaliação criada
+}
+
+// CreateAssessmentOnDate cria a avaliação no 3º bimestre quando o bimestre não é informado, como
+// se a data estivesse nele no calendário escolar.
+func (f *fakeAssessmentService) CreateAssessmentOnDate(ctx context.Context, name string, classID int64, term int, weight float64, date time.Time) (models.Assessment, error) {
+	if term == 0 {
+		term = 3
+	}
+	f.created = &models.Assessment{ID: 9, ClassID: classID, Name: name, Term: term, Weight: weight, AssessmentDate: &date}
+	return *f.created, nil
+}
+
+func (f *fakeAssessmentService) GetGradingPolicy(ctx context.Context, classID int64) (models.GradingPolicy, error) {
+	if classID != f.policy.ClassID {
+		return models.GradingPolicy{}, service.NotFoundErrorf("turma com ID %d não encontrada", classID)
+	}
+	return f.policy, nil
+}
+
+func (f *fakeAssessmentService) SetGradingPolicy(ctx context.Context, policy models.GradingPolicy) (models.GradingPolicy, error) {
+	f.saved = &policy
+	return policy, nil
+}
+
+// PreviewGradeImport associa cada linha "nome;nota" a Ana (ID 1) ou Bruno (ID 2); as demais não
+// são encontradas.
+func (f *fakeAssessmentService) PreviewGradeImport(ctx context.Context, assessmentID int64, data []byte, format string, mapping service.GradeImportMapping) (service.GradeImportPreview, error) {
+	preview := service.GradeImportPreview{Assessment: models.Assessment{ID: assessmentID, Name: "Prova 1"}, Policy: f.policy, Key: service.GradeImportKeyName}
+	students := map[string]models.Student{"Ana": {ID: 1, FullName: "Ana Souza"}, "Bruno": {ID: 2, FullName: "Bruno Lima"}}
+	for i, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		name, grade, _ := strings.Cut(line, ";")
+		row := service.GradeImportRow{Line: i + 1, Key: name, GradeText: grade, Status: service.GradeImportUnmatched}
+		if student, ok := students[name]; ok {
+			row.Student, row.Status = &student, service.GradeImportMatched
+			row.Grade, _ = service.ParseGrade(f.policy, grade)
+		}
+		preview.Rows = append(preview.Rows, row)
+	}
+	return preview, nil
+}
+
+func (f *fakeAssessmentService) GetGradebook(ctx context.Context, classID int64) (service.Gradebook, error) {
+	return f.book, nil
+}
+
+func (f *fakeAssessmentService) EnterGrades(ctx context.Context, assessmentID int64, studentGrades map[int64]float64) error {
+	if f.entered == nil {
+		f.entered = make(map[int64]map[int64]float64)
+	}
+	f.entered[assessmentID] = studentGrades
+	if f.book.Grades == nil {
+		return nil
+	}
+	for studentID, grade := range studentGrades {
+		if f.book.Grades[assessmentID] == nil {
+			f.book.Grades[assessmentID] = make(map[int64]float64)
+		}
+		f.book.Grades[assessmentID][studentID] = grade
+	}
+	return nil
+}
+
+type fakeClassService struct {
+	service.ClassService
+}
+
+func (f *fakeClassService) GetClassByID(ctx context.Context, classID int64) (models.Class, error) {
+	return models.Class{ID: classID, Name: "Turma 9A"}, nil
+}
+
+func typeText(m *Model, text string) {
+	for _, r := range text {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// update envia a tecla e entrega ao modelo a mensagem do comando resultante, se houver.
+func update(m *Model, msg tea.KeyMsg, run bool) {
+	_, cmd := m.Update(msg)
+	if run && cmd != nil {
+		m.Update(cmd())
+	}
+}
+
+func openPolicyEditor(t *testing.T, m *Model) {
+	t.Helper()
+	m.SetSize(100, 40)
+	for i, item := range m.list.Items() {
+		if item.(actionItem).title == "Política de Notas da Turma" {
+			m.list.Select(i)
+		}
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, GradingPolicyView, m.state)
+	typeText(m, "1")
+	update(m, tea.KeyMsg{Type: tea.KeyEnter}, true)
+	require.NoError(t, m.err)
+	require.NotNil(t, m.policyEditor)
+}
+
+func TestGradingPolicyEditor(t *testing.T) {
+	assessments := &fakeAssessmentService{policy: models.GradingPolicy{ClassID: 1, Scheme: models.GradingSchemeWeighted}}
+	m := New(assessments, &fakeClassService{})
+	openPolicyEditor(t, m)
+	assert.Contains(t, m.View(), "Turma 9A")
+	assert.Contains(t, m.View(), "ponderada")
+
+	// Esquema: ponderada → aritmetica → categorias.
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, "prova=60,trabalho=40")
+	// Arredondamento: sem arredondamento → 0,1 → 0,5.
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Contains(t, m.View(), "para 0,5")
+
+	update(m, tea.KeyMsg{Type: tea.KeyCtrlS}, true)
+	require.NoError(t, m.err)
+	require.NotNil(t, assessments.saved)
+	assert.Equal(t, models.GradingPolicy{
+		ClassID: 1, Scheme: models.GradingSchemeCategories, GradeScale: models.GradeScaleTen, RoundingStep: 0.5,
+		RecoveryRule: models.RecoveryRuleReplaceAverage, CategoryWeights: map[string]float64{"prova": 60, "trabalho": 40},
+	}, *assessments.saved)
+	assert.Equal(t, ListView, m.state)
+	assert.Contains(t, m.View(), "Política de notas salva")
+}
+
+func TestGradingPolicyEditor_KeepsUnlistedRoundingAndRejectsBadCategories(t *testing.T) {
+	assessments := &fakeAssessmentService{policy: models.GradingPolicy{ClassID: 1, Scheme: models.GradingSchemeBestOf, BestOf: 3, RoundingStep: 0.25}}
+	m := New(assessments, &fakeClassService{})
+	openPolicyEditor(t, m)
+	view := m.View()
+	assert.Contains(t, view, "melhores")
+	assert.Contains(t, view, "para 0,25", "um passo gravado pela linha de comando continua disponível")
+	assert.True(t, strings.Contains(view, "não usado por este esquema"))
+
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, "prova")
+	update(m, tea.KeyMsg{Type: tea.KeyCtrlS}, true)
+	assert.Error(t, m.err)
+	assert.Nil(t, assessments.saved)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, ListView, m.state)
+	assert.Nil(t, m.policyEditor)
+}
+
+func TestGradingPolicyEditor_GradeScale(t *testing.T) {
+	assessments := &fakeAssessmentService{policy: models.GradingPolicy{ClassID: 1, Scheme: models.GradingSchemeWeighted, GradeScale: models.GradeScaleTen}}
+	m := New(assessments, &fakeClassService{})
+	openPolicyEditor(t, m)
+	assert.Contains(t, m.View(), "só nas escalas conceituais")
+
+	// Escala (quarto campo a partir do fim): 0-10 → 0-100 → letras, com as faixas usuais dos conceitos.
+	for i := 0; i < 4; i++ {
+		m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Contains(t, m.View(), "conceitos A a E")
+	assert.Equal(t, "A=9,B=7,C=5,D=3,E=0", m.policyEditor.concepts.Value())
+
+	update(m, tea.KeyMsg{Type: tea.KeyCtrlS}, true)
+	require.NoError(t, m.err)
+	require.NotNil(t, assessments.saved)
+	assert.Equal(t, models.GradeScaleLetters, assessments.saved.GradeScale)
+	assert.Equal(t, service.DefaultConceptRanges(models.GradeScaleLetters), assessments.saved.ConceptRanges)
+	assert.Contains(t, m.View(), "conceitos A a E")
+}
+
+func TestGradingPolicyEditor_Recovery(t *testing.T) {
+	assessments := &fakeAssessmentService{policy: models.GradingPolicy{
+		ClassID: 1, Scheme: models.GradingSchemeWeighted, GradeScale: models.GradeScaleTen,
+		PassingGrade: 6, RecoveryRule: models.RecoveryRuleReplaceAverage,
+	}}
+	m := New(assessments, &fakeClassService{})
+	openPolicyEditor(t, m)
+	assert.Contains(t, m.View(), "substitui-media")
+
+	// Recuperação (último campo): substitui-media → substitui-menor.
+	m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Contains(t, m.View(), "substitui a menor nota")
+	// Média de aprovação: 6 → 5,5 (vírgula decimal aceita).
+	m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText(m, "5,5")
+
+	update(m, tea.KeyMsg{Type: tea.KeyCtrlS}, true)
+	require.NoError(t, m.err)
+	require.NotNil(t, assessments.saved)
+	assert.Equal(t, 5.5, assessments.saved.PassingGrade)
+	assert.Equal(t, models.RecoveryRuleReplaceLowest, assessments.saved.RecoveryRule)
+}
+
+// newGradebookFake monta a planilha de uma turma com duas provas no 1º bimestre e uma no 2º:
+// Bruno está sem nota na P2 e abaixo da média de aprovação; Carla foi transferida.
+func newGradebookFake() *fakeAssessmentService {
+	policy := models.GradingPolicy{ClassID: 1, Scheme: models.GradingSchemeWeighted, GradeScale: models.GradeScaleTen, PassingGrade: 6}
+	return &fakeAssessmentService{policy: policy, book: service.Gradebook{
+		ClassID: 1,
+		Policy:  policy,
+		Students: []models.Student{
+			{ID: 1, FullName: "Ana Souza", Status: "ativo"},
+			{ID: 2, FullName: "Bruno Lima", Status: "ativo"},
+			{ID: 3, FullName: "Carla Dias", Status: "transferido"},
+		},
+		Assessments: []models.Assessment{
+			{ID: 1, ClassID: 1, Name: "P1", Term: 1, Weight: 1},
+			{ID: 2, ClassID: 1, Name: "P2", Term: 1, Weight: 1},
+			{ID: 3, ClassID: 1, Name: "P3", Term: 2, Weight: 1},
+		},
+		Terms:  []int{1, 2},
+		Grades: map[int64]map[int64]float64{1: {1: 8, 2: 4}, 2: {1: 9}, 3: {1: 7, 2: 5}},
+		TermAverages: map[int]map[int64]service.StudentAverage{
+			1: {1: {Original: 8.5, Adjusted: 8.5}, 2: {Original: 4, Adjusted: 4}},
+			2: {1: {Original: 7, Adjusted: 7}, 2: {Original: 5, Adjusted: 5}},
+		},
+		Averages: map[int64]service.StudentAverage{1: {Original: 8, Adjusted: 8}, 2: {Original: 4.5, Adjusted: 4.5}},
+	}}
+}
+
+func openGradebook(t *testing.T, m *Model) {
+	t.Helper()
+	m.SetSize(120, 40)
+	for i, item := range m.list.Items() {
+		if item.(actionItem).title == "Planilha de Notas" {
+			m.list.Select(i)
+		}
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, GradebookView, m.state)
+	typeText(m, "1")
+	update(m, tea.KeyMsg{Type: tea.KeyEnter}, true)
+	require.NoError(t, m.err)
+	require.NotNil(t, m.gradebook)
+}
+
+func TestGradebook_ShowsGradesGroupedByTermWithAverages(t *testing.T) {
+	m := New(newGradebookFake(), &fakeClassService{})
+	openGradebook(t, m)
+	view := m.View()
+	assert.Contains(t, view, "Planilha de Notas — Turma 9A")
+	assert.Contains(t, view, "1º bimestre")
+	assert.Contains(t, view, "2º bimestre")
+	assert.Regexp(t, `Ana Souza\s+8\.0\s+9\.0\s+8\.5\s+7\.0\s+7\.0\s+8\.0`, view)
+	assert.Regexp(t, `Bruno Lima\s+4\.0\s+--\s+4\.0\s+5\.0\s+5\.0\s+4\.5`, view, "nota faltando marcada com --")
+	assert.Contains(t, view, "Carla Dias (transferido)")
+}
+
+func TestGradebook_EditsCellsAndSavesThroughEnterGrades(t *testing.T) {
+	assessments := newGradebookFake()
+	m := New(assessments, &fakeClassService{})
+	openGradebook(t, m)
+
+	// Bruno, P2: a nota fora da escala é recusada na própria célula.
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	typeText(m, "11")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, m.gradebook.editing)
+	assert.Contains(t, m.View(), "fora da escala")
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText(m, "6,5")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.gradebook.editing)
+	assert.Contains(t, m.View(), "6.5*")
+
+	// Carla (transferida) não recebe notas.
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.gradebook.editing)
+	assert.Contains(t, m.View(), "não recebe notas")
+
+	// Ana, P3 (2º bimestre).
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "7.0", m.gradebook.input, "a edição começa pela nota gravada")
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText(m, "10")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, 2, m.gradebook.pending())
+
+	// Esc com notas alteradas pede confirmação antes de descartá-las.
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, GradebookView, m.state)
+	assert.Contains(t, m.View(), "Esc de novo descarta 2 nota(s)")
+
+	update(m, tea.KeyMsg{Type: tea.KeyCtrlS}, true)
+	require.NoError(t, m.err)
+	assert.Equal(t, map[int64]map[int64]float64{2: {2: 6.5}, 3: {1: 10}}, assessments.entered)
+	_, cmd := m.Update(gradebookSavedMsg{saved: map[int64]int{}})
+	require.NotNil(t, cmd, "a planilha é recarregada para recalcular as médias")
+	m.Update(cmd())
+	assert.Equal(t, 0, m.gradebook.pending())
+	assert.Regexp(t, `Bruno Lima\s+4\.0\s+6\.5`, m.View())
+}
+
+func TestGradebook_FreezesNameColumnWhenScrolling(t *testing.T) {
+	m := New(newGradebookFake(), &fakeClassService{})
+	openGradebook(t, m)
+	m.SetSize(50, 40) // Espaço para só duas colunas depois dos nomes.
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	view := m.View()
+	assert.Contains(t, view, "Ana Souza")
+	assert.Contains(t, view, "◀")
+	assert.NotContains(t, view, "P1 ")
+	assert.Contains(t, view, "P3")
+}
+
+func TestGradeImport_PreviewsAndImportsMatchedRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notas.csv")
+	require.NoError(t, os.WriteFile(path, []byte("Ana;8,5\nBruno;7\nZeca;5\n"), 0o644))
+	assessments := &fakeAssessmentService{policy: models.GradingPolicy{ClassID: 1, GradeScale: models.GradeScaleTen}}
+	m := New(assessments, &fakeClassService{})
+	m.SetSize(120, 40)
+	for i, item := range m.list.Items() {
+		if item.(actionItem).title == "Importar Planilha" {
+			m.list.Select(i)
+		}
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, GradeImportView, m.state)
+
+	typeText(m, "3")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, path)
+	update(m, tea.KeyMsg{Type: tea.KeyEnter}, true)
+	require.NoError(t, m.err)
+	require.NotNil(t, m.gradeImport)
+	view := m.View()
+	assert.Contains(t, view, "Prévia da importação — Prova 1 (ID: 3)")
+	assert.Regexp(t, `Ana\s+8\.5\s+Ana Souza\s+encontrado`, view)
+	assert.Contains(t, view, "não encontrado")
+	assert.Contains(t, view, "i: importar só as 2 encontrada(s)")
+
+	// Com linhas não encontradas, Enter não importa nada.
+	update(m, tea.KeyMsg{Type: tea.KeyEnter}, true)
+	assert.Error(t, m.err)
+	assert.Empty(t, assessments.entered)
+
+	update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}}, true)
+	require.NoError(t, m.err)
+	assert.Equal(t, map[int64]map[int64]float64{3: {1: 8.5, 2: 7}}, assessments.entered)
+	assert.Equal(t, ListView, m.state)
+	assert.Contains(t, m.View(), "2 nota(s) importada(s) para a avaliação 'Prova 1'.")
+}
+
+func TestCreateAssessment_WithDateTakesTermFromCalendar(t *testing.T) {
+	assessments := &fakeAssessmentService{}
+	m := New(assessments, &fakeClassService{})
+	m.SetSize(120, 40)
+	for i, item := range m.list.Items() {
+		if item.(actionItem).title == "Criar Nova Avaliação" {
+			m.list.Select(i)
+		}
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, CreateAssessmentView, m.state)
+
+	// Nome, turma, período (vazio), peso e data.
+	for _, value := range []string{"Prova 2", "1", "", "2", "23/10/2026"} {
+		typeText(m, value)
+		m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	update(m, tea.KeyMsg{Type: tea.KeyEnter}, true)
+	require.NoError(t, m.err)
+	require.NotNil(t, assessments.created)
+	assert.Equal(t, 3, assessments.created.Term)
+	assert.Equal(t, "23/10/2026", assessments.created.AssessmentDate.Format("02/01/2006"))
+	assert.Equal(t, ListView, m.state)
+}
@@ -0,0 +1,363 @@
+package assessments
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// Campos do editor da política de notas, na ordem de navegação.
+const (
+	policyFieldScheme = iota
+	policyFieldCategories
+	policyFieldBestOf
+	policyFieldRounding
+	policyFieldScale
+	policyFieldConcepts
+	policyFieldPassing
+	policyFieldRecovery
+	policyFieldCount
+)
+
+// roundingSteps são os passos de arredondamento oferecidos pelo editor (0 = sem arredondamento).
+var roundingSteps = []float64{0, 0.1, 0.5, 1}
+
+var (
+	policyLabelStyle = lipgloss.NewStyle().Width(16)
+	policyFocusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
+	policyMutedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+)
+
+type gradingPolicyLoadedMsg struct {
+	policy    models.GradingPolicy
+	className string
+	err       error
+}
+
+type gradingPolicySavedMsg struct {
+	policy models.GradingPolicy
+	err    error
+}
+
+// policyEditor edita a política de notas de uma turma: o esquema, o arredondamento, a escala e a
+// regra da recuperação são escolhidos com ←/→ e os pesos das categorias, o número de melhores notas,
+// as faixas dos conceitos e a média de aprovação são digitados.
+type policyEditor struct {
+	policy     models.GradingPolicy
+	className  string
+	scheme     int // índice em service.GradingSchemes
+	rounding   int // índice em steps
+	steps      []float64
+	scale      int // índice em service.GradeScales
+	recovery   int // índice em service.RecoveryRules
+	categories textinput.Model
+	bestOf     textinput.Model
+	concepts   textinput.Model
+	passing    textinput.Model
+	focus      int
+}
+
+func newPolicyEditor(policy models.GradingPolicy, className string) *policyEditor {
+	e := &policyEditor{policy: policy, className: className, steps: append([]float64{}, roundingSteps...)}
+	for i, scheme := range service.GradingSchemes {
+		if scheme == policy.Scheme {
+			e.scheme = i
+		}
+	}
+	for i, scale := range service.GradeScales {
+		if scale == policy.GradeScale {
+			e.scale = i
+		}
+	}
+	for i, rule := range service.RecoveryRules {
+		if rule == policy.RecoveryRule {
+			e.recovery = i
+		}
+	}
+	e.rounding = -1
+	for i, step := range e.steps {
+		if step == policy.RoundingStep {
+			e.rounding = i
+		}
+	}
+	if e.rounding < 0 { // Passo gravado pela linha de comando que não está entre os oferecidos.
+		e.steps = append(e.steps, policy.RoundingStep)
+		e.rounding = len(e.steps) - 1
+	}
+
+	e.categories = textinput.New()
+	e.categories.Placeholder = "prova=60,trabalho=40"
+	e.categories.CharLimit = 120
+	e.categories.Width = 40
+	e.categories.SetValue(service.FormatCategoryWeights(policy.CategoryWeights))
+
+	e.bestOf = textinput.New()
+	e.bestOf.Placeholder = "ex: 3"
+	e.bestOf.CharLimit = 3
+	e.bestOf.Width = 5
+	e.bestOf.Validate = isNumber
+	if policy.BestOf > 0 {
+		e.bestOf.SetValue(strconv.Itoa(policy.BestOf))
+	}
+
+	e.concepts = textinput.New()
+	e.concepts.Placeholder = "A=9,B=7,C=5,D=3,E=0"
+	e.concepts.CharLimit = 80
+	e.concepts.Width = 40
+	e.concepts.SetValue(service.FormatConceptRanges(policy.ConceptRanges))
+
+	e.passing = textinput.New()
+	e.passing.Placeholder = "ex: 6"
+	e.passing.CharLimit = 6
+	e.passing.Width = 8
+	if policy.PassingGrade > 0 {
+		e.passing.SetValue(strconv.FormatFloat(policy.PassingGrade, 'f', -1, 64))
+	}
+	return e
+}
+
+// result monta a política a partir dos campos do editor.
+func (e *policyEditor) result() (models.GradingPolicy, error) {
+	policy := e.policy
+	policy.Scheme = service.GradingSchemes[e.scheme]
+	policy.RoundingStep = e.steps[e.rounding]
+	weights, err := service.ParseCategoryWeights(e.categories.Value())
+	if err != nil {
+		return models.GradingPolicy{}, err
+	}
+	policy.CategoryWeights = weights
+	policy.GradeScale = service.GradeScales[e.scale]
+	policy.ConceptRanges = nil
+	if service.IsConceptScale(policy.GradeScale) {
+		if policy.ConceptRanges, err = service.ParseConceptRanges(e.concepts.Value()); err != nil {
+			return models.GradingPolicy{}, err
+		}
+	}
+	policy.RecoveryRule = service.RecoveryRules[e.recovery]
+	policy.PassingGrade = 0
+	if value := strings.TrimSpace(e.passing.Value()); value != "" {
+		if policy.PassingGrade, err = strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64); err != nil {
+			return models.GradingPolicy{}, fmt.Errorf("média de aprovação inválida: '%s'", value)
+		}
+	}
+	policy.BestOf = 0
+	if value := strings.TrimSpace(e.bestOf.Value()); value != "" {
+		if policy.BestOf, err = strconv.Atoi(value); err != nil {
+			return models.GradingPolicy{}, fmt.Errorf("número de melhores notas inválido: '%s'", value)
+		}
+	}
+	return policy, nil
+}
+
+// update trata as teclas do editor (menos ctrl+s e esc, tratadas pelo Model).
+func (e *policyEditor) update(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("tab", "down"))):
+		return e.setFocus((e.focus + 1) % policyFieldCount)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("shift+tab", "up"))):
+		return e.setFocus((e.focus + policyFieldCount - 1) % policyFieldCount)
+	}
+
+	switch e.focus {
+	case policyFieldScheme:
+		e.scheme = cycle(e.scheme, len(service.GradingSchemes), msg)
+	case policyFieldRounding:
+		e.rounding = cycle(e.rounding, len(e.steps), msg)
+	case policyFieldScale:
+		if scale := cycle(e.scale, len(service.GradeScales), msg); scale != e.scale {
+			// As faixas da escala anterior não servem para a nova: começa pelas faixas usuais.
+			e.scale = scale
+			e.concepts.SetValue(service.FormatConceptRanges(service.DefaultConceptRanges(service.GradeScales[scale])))
+			// A média de aprovação também: em branco, vale a média usual da nova escala.
+			e.passing.SetValue("")
+		}
+	case policyFieldRecovery:
+		e.recovery = cycle(e.recovery, len(service.RecoveryRules), msg)
+	case policyFieldPassing:
+		var cmd tea.Cmd
+		e.passing, cmd = e.passing.Update(msg)
+		return cmd
+	case policyFieldConcepts:
+		var cmd tea.Cmd
+		e.concepts, cmd = e.concepts.Update(msg)
+		return cmd
+	case policyFieldCategories:
+		var cmd tea.Cmd
+		e.categories, cmd = e.categories.Update(msg)
+		return cmd
+	case policyFieldBestOf:
+		var cmd tea.Cmd
+		e.bestOf, cmd = e.bestOf.Update(msg)
+		return cmd
+	}
+	return nil
+}
+
+// cycle avança (→/l) ou recua (←/h) o índice 'i' entre 'n' opções.
+func cycle(i, n int, msg tea.KeyMsg) int {
+	switch msg.String() {
+	case "right", "l", " ":
+		return (i + 1) % n
+	case "left", "h":
+		return (i + n - 1) % n
+	}
+	return i
+}
+
+func (e *policyEditor) setFocus(focus int) tea.Cmd {
+	e.focus = focus
+	e.categories.Blur()
+	e.bestOf.Blur()
+	e.concepts.Blur()
+	e.passing.Blur()
+	switch focus {
+	case policyFieldCategories:
+		return e.categories.Focus()
+	case policyFieldBestOf:
+		return e.bestOf.Focus()
+	case policyFieldConcepts:
+		return e.concepts.Focus()
+	case policyFieldPassing:
+		return e.passing.Focus()
+	}
+	return nil
+}
+
+func (e *policyEditor) view() string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Política de Notas — "+e.className) + "\n\n")
+
+	scheme := service.GradingSchemes[e.scheme]
+	scale := service.GradeScales[e.scale]
+	rule := service.RecoveryRules[e.recovery]
+	rows := []struct {
+		label, value string
+		used         bool
+		unusedNote   string
+	}{
+		{"Esquema", fmt.Sprintf("◀ %s ▶  %s", scheme, service.GradingSchemeLabel(scheme)), true, ""},
+		{"Categorias", e.categories.View(), scheme == models.GradingSchemeCategories, "não usado por este esquema"},
+		{"Melhores notas", e.bestOf.View(), scheme == models.GradingSchemeBestOf, "não usado por este esquema"},
+		{"Arredondamento", "◀ " + roundingLabel(e.steps[e.rounding]) + " ▶", true, ""},
+		{"Escala", fmt.Sprintf("◀ %s ▶  %s", scale, service.GradeScaleLabel(scale)), true, ""},
+		{"Conceitos", e.concepts.View(), service.IsConceptScale(scale), "só nas escalas conceituais"},
+		{"Média aprovação", e.passing.View(), true, ""},
+		{"Recuperação", fmt.Sprintf("◀ %s ▶  %s", rule, service.RecoveryRuleLabel(rule)), true, ""},
+	}
+	for i, row := range rows {
+		label := policyLabelStyle.Render(row.label)
+		if i == e.focus {
+			label = policyFocusStyle.Render("> ") + policyFocusStyle.Inherit(policyLabelStyle).Render(row.label)
+		} else {
+			label = "  " + label
+		}
+		value := row.value
+		if !row.used {
+			value = policyMutedStyle.Render(value + "  (" + row.unusedNote + ")")
+		}
+		b.WriteString(label + value + "\n")
+	}
+	b.WriteString("\n" + helpStyle.Render("↑/↓ ou Tab: campo | ←/→: alterar opção | Ctrl+S: salvar | Esc: cancelar"))
+	return b.String()
+}
+
+// gradePlaceholder sugere o formato da nota na escala da turma.
+func gradePlaceholder(policy models.GradingPolicy) string {
+	switch {
+	case service.IsConceptScale(policy.GradeScale) && len(policy.ConceptRanges) > 0:
+		return "Conceito (ex: " + policy.ConceptRanges[0].Concept + ")"
+	case policy.GradeScale == models.GradeScaleHundred:
+		return "Nota (ex: 75)"
+	}
+	return "Nota (ex: 7.5)"
+}
+
+// formatGradeInput escreve uma média no campo de nota, na escala da turma, para ser lançada como nota.
+func formatGradeInput(policy models.GradingPolicy, value float64) string {
+	if service.IsConceptScale(policy.GradeScale) {
+		return service.FormatGrade(policy, value)
+	}
+	return fmt.Sprintf("%.2f", value)
+}
+
+func roundingLabel(step float64) string {
+	if step <= 0 {
+		return "sem arredondamento"
+	}
+	return "para " + strings.ReplaceAll(strconv.FormatFloat(step, 'f', -1, 64), ".", ",")
+}
+
+func (m *Model) loadGradingPolicyCmd(classID int64) tea.Cmd {
+	return func() tea.Msg {
+		policy, err := m.assessmentService.GetGradingPolicy(context.Background(), classID)
+		if err != nil {
+			return gradingPolicyLoadedMsg{err: err}
+		}
+		className := fmt.Sprintf("Turma ID %d", classID)
+		if m.classService != nil {
+			class, err := m.classService.GetClassByID(context.Background(), classID)
+			if err != nil {
+				return gradingPolicyLoadedMsg{err: err}
+			}
+			className = class.Name
+		}
+		return gradingPolicyLoadedMsg{policy: policy, className: className}
+	}
+}
+
+func (m *Model) saveGradingPolicyCmd(policy models.GradingPolicy) tea.Cmd {
+	return func() tea.Msg {
+		saved, err := m.assessmentService.SetGradingPolicy(context.Background(), policy)
+		return gradingPolicySavedMsg{policy: saved, err: err}
+	}
+}
+
+// updateGradingPolicy trata as teclas da tela da política de notas: primeiro o ID da turma, depois
+// o editor.
+func (m *Model) updateGradingPolicy(msg tea.KeyMsg) tea.Cmd {
+	if m.policyEditor == nil {
+		if key.Matches(msg, key.NewBinding(key.WithKeys("enter"))) {
+			classID, err := strconv.ParseInt(strings.TrimSpace(m.textInputs[0].Value()), 10, 64)
+			if err != nil {
+				m.err = fmt.Errorf("ID da Turma inválido: %w", err)
+				return nil
+			}
+			m.err = nil
+			m.isLoading = true
+			m.currentClassID = &classID
+			return m.loadGradingPolicyCmd(classID)
+		}
+		var cmd tea.Cmd
+		m.textInputs[0], cmd = m.textInputs[0].Update(msg)
+		return cmd
+	}
+
+	if key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+s"))) {
+		policy, err := m.policyEditor.result()
+		if err != nil {
+			m.err = err
+			return nil
+		}
+		m.err = nil
+		m.isLoading = true
+		return m.saveGradingPolicyCmd(policy)
+	}
+	return m.policyEditor.update(msg)
+}
+
+func (m *Model) gradingPolicyView() string {
+	if m.policyEditor == nil {
+		return "Política de Notas da Turma\n\n" + m.textInputs[0].View() + "\n\n" +
+			helpStyle.Render("Enter: carregar a política | Esc: voltar")
+	}
+	return m.policyEditor.view()
+}
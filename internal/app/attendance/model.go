@@ -0,0 +1,445 @@
+// Package attendance implementa a tela "Chamada" da TUI: lista as aulas de um dia e, para a aula
+// escolhida, percorre a lista de chamada marcando cada aluno com uma única tecla (p, f, a ou j).
+package attendance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// ViewState define o estado atual da tela de chamada.
+type ViewState int
+
+const (
+	ListView     ViewState = iota // Aulas do dia.
+	RollCallView                  // Lista de chamada da aula escolhida.
+)
+
+// userID é o usuário das consultas por dia; o Vigenda ainda é monousuário.
+const userID = 1
+
+// statusKeys associa as teclas da chamada às situações de frequência.
+var statusKeys = map[string]string{
+	"p": models.AttendancePresent,
+	"f": models.AttendanceAbsent,
+	"a": models.AttendanceLate,
+	"j": models.AttendanceJustified,
+}
+
+// statusCycle é a ordem em que 'espaço' percorre as situações.
+var statusCycle = []string{models.AttendancePresent, models.AttendanceAbsent, models.AttendanceLate, models.AttendanceJustified}
+
+var weekdayNames = [...]string{"Domingo", "Segunda", "Terça", "Quarta", "Quinta", "Sexta", "Sábado"}
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).MarginBottom(1)
+	itemStyle     = lipgloss.NewStyle().PaddingLeft(2)
+	selectedStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	faintStyle    = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	helpStyle     = lipgloss.NewStyle().Faint(true).MarginTop(1)
+
+	statusStyles = map[string]lipgloss.Style{
+		models.AttendancePresent:   lipgloss.NewStyle().Foreground(lipgloss.Color("42")),
+		models.AttendanceAbsent:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("9")),
+		models.AttendanceLate:      lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+		models.AttendanceJustified: lipgloss.NewStyle().Foreground(lipgloss.Color("75")),
+	}
+)
+
+// Model é o modelo BubbleTea da tela de chamada.
+type Model struct {
+	lessonService     service.LessonService
+	attendanceService service.AttendanceService
+	classService      service.ClassService
+	state             ViewState
+
+	day        time.Time
+	lessons    []models.Lesson
+	classNames map[int64]string
+	cursor     int
+
+	lesson   models.Lesson
+	entries  []service.RollCallEntry
+	statuses []string // Situação marcada para cada aluno de 'entries'; "" se ainda não marcado.
+	row      int      // Aluno selecionado na lista de chamada.
+	changed  bool
+
+	isLoading     bool
+	backRequested bool // 'esc' na lista: o app.Model deve voltar ao menu principal.
+	err           error
+	statusMessage string
+
+	width  int
+	height int
+}
+
+// --- Mensagens ---
+
+type lessonsLoadedMsg struct {
+	lessons    []models.Lesson
+	classNames map[int64]string
+	err        error
+}
+
+type rollCallLoadedMsg struct {
+	lesson  models.Lesson
+	entries []service.RollCallEntry
+	err     error
+}
+
+type attendanceSavedMsg struct {
+	lesson models.Lesson
+	counts map[string]int
+	err    error
+}
+
+// New cria o modelo da tela de chamada.
+func New(lessonService service.LessonService, attendanceService service.AttendanceService, classService service.ClassService) *Model {
+	return &Model{
+		lessonService:     lessonService,
+		attendanceService: attendanceService,
+		classService:      classService,
+		day:               time.Now(),
+	}
+}
+
+// --- Comandos ---
+
+func (m *Model) loadLessonsCmd() tea.Cmd {
+	day := m.day
+	return func() tea.Msg {
+		ctx := context.Background()
+		lessons, err := m.lessonService.GetLessonsForDate(ctx, userID, day)
+		if err != nil {
+			return lessonsLoadedMsg{err: err}
+		}
+		names := make(map[int64]string)
+		if classes, err := m.classService.ListAllClasses(ctx); err == nil {
+			for _, class := range classes {
+				names[class.ID] = class.Name
+			}
+		}
+		return lessonsLoadedMsg{lessons: lessons, classNames: names}
+	}
+}
+
+func (m *Model) loadRollCallCmd(lessonID int64) tea.Cmd {
+	return func() tea.Msg {
+		lesson, entries, err := m.attendanceService.GetRollCall(context.Background(), lessonID)
+		return rollCallLoadedMsg{lesson: lesson, entries: entries, err: err}
+	}
+}
+
+func (m *Model) saveCmd() tea.Cmd {
+	lesson := m.lesson
+	statuses := make(map[int64]string)
+	counts := make(map[string]int)
+	for i, entry := range m.entries {
+		if m.statuses[i] != "" {
+			statuses[entry.Student.ID] = m.statuses[i]
+			counts[m.statuses[i]]++
+		}
+	}
+	return func() tea.Msg {
+		err := m.attendanceService.RecordAttendance(context.Background(), lesson.ID, statuses)
+		return attendanceSavedMsg{lesson: lesson, counts: counts, err: err}
+	}
+}
+
+// Init volta para a lista das aulas de hoje.
+func (m *Model) Init() tea.Cmd {
+	m.state = ListView
+	m.day = time.Now()
+	m.cursor = 0
+	m.isLoading = true
+	m.backRequested = false
+	m.err = nil
+	m.statusMessage = ""
+	return m.loadLessonsCmd()
+}
+
+// Update processa teclas e o resultado dos comandos assíncronos.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case lessonsLoadedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.lessons = msg.lessons
+		m.classNames = msg.classNames
+		if m.cursor >= len(m.lessons) {
+			m.cursor = max(len(m.lessons)-1, 0)
+		}
+		return m, nil
+
+	case rollCallLoadedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if len(msg.entries) == 0 {
+			m.err = fmt.Errorf("a turma da aula '%s' não tem alunos ativos", msg.lesson.Title)
+			return m, nil
+		}
+		m.lesson = msg.lesson
+		m.entries = msg.entries
+		m.statuses = make([]string, len(msg.entries))
+		m.row = len(msg.entries)
+		for i, entry := range msg.entries {
+			m.statuses[i] = entry.Status
+			if entry.Status == "" && m.row == len(msg.entries) {
+				m.row = i // Começa pelo primeiro aluno ainda sem registro.
+			}
+		}
+		if m.row == len(msg.entries) {
+			m.row = 0
+		}
+		m.changed = false
+		m.err = nil
+		m.state = RollCallView
+		return m, nil
+
+	case attendanceSavedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.state = ListView
+		m.statusMessage = fmt.Sprintf("Chamada de '%s' registrada: %d presente(s), %d falta(s), %d atraso(s), %d justificada(s).",
+			msg.lesson.Title, msg.counts[models.AttendancePresent], msg.counts[models.AttendanceAbsent],
+			msg.counts[models.AttendanceLate], msg.counts[models.AttendanceJustified])
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.isLoading {
+			return m, nil
+		}
+		if m.state == RollCallView {
+			return m.updateRollCallView(msg)
+		}
+		return m.updateListView(msg)
+	}
+	return m, nil
+}
+
+func (m *Model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		m.backRequested = true
+	case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+		if m.cursor < len(m.lessons)-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h"))):
+		return m, m.shiftDay(-1)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l"))):
+		return m, m.shiftDay(1)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
+		m.day = time.Now()
+		return m, m.shiftDay(0)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+		if m.cursor < len(m.lessons) {
+			lesson := m.lessons[m.cursor]
+			if lesson.Status == models.LessonStatusCancelled {
+				m.err = fmt.Errorf("a aula '%s' foi cancelada: não há chamada a registrar", lesson.Title)
+				return m, nil
+			}
+			m.isLoading = true
+			m.err = nil
+			m.statusMessage = ""
+			return m, m.loadRollCallCmd(lesson.ID)
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) updateRollCallView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if status, ok := statusKeys[msg.String()]; ok {
+		m.mark(status)
+		return m, nil
+	}
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		m.state = ListView
+		if m.changed {
+			m.statusMessage = "Chamada descartada."
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+		if m.row > 0 {
+			m.row--
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("down"))):
+		if m.row < len(m.entries)-1 {
+			m.row++
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys(" "))):
+		next := statusCycle[0]
+		for i, status := range statusCycle {
+			if status == m.statuses[m.row] {
+				next = statusCycle[(i+1)%len(statusCycle)]
+			}
+		}
+		m.statuses[m.row] = next
+		m.changed = true
+	case key.Matches(msg, key.NewBinding(key.WithKeys("P"))):
+		// Todos os alunos ainda sem marcação ficam presentes.
+		for i := range m.statuses {
+			if m.statuses[i] == "" {
+				m.statuses[i] = models.AttendancePresent
+				m.changed = true
+			}
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+s", "enter"))):
+		if missing := m.unmarked(); missing > 0 {
+			m.err = fmt.Errorf("%d aluno(s) sem marcação: marque-os ou use P para marcar os restantes como presentes", missing)
+			return m, nil
+		}
+		return m, m.saveCmd()
+	}
+	return m, nil
+}
+
+// mark registra a situação do aluno selecionado e avança para o próximo.
+func (m *Model) mark(status string) {
+	m.statuses[m.row] = status
+	m.changed = true
+	m.err = nil
+	if m.row < len(m.entries)-1 {
+		m.row++
+	}
+}
+
+func (m *Model) unmarked() int {
+	missing := 0
+	for _, status := range m.statuses {
+		if status == "" {
+			missing++
+		}
+	}
+	return missing
+}
+
+func (m *Model) shiftDay(days int) tea.Cmd {
+	m.day = m.day.AddDate(0, 0, days)
+	m.cursor = 0
+	m.isLoading = true
+	m.err = nil
+	m.statusMessage = ""
+	return m.loadLessonsCmd()
+}
+
+// CanGoBack informa ao app.Model que 'esc' foi pressionado na lista e a tela pode voltar ao menu.
+func (m *Model) CanGoBack() bool {
+	back := m.backRequested
+	m.backRequested = false
+	return back
+}
+
+// View renderiza a tela de acordo com o estado atual.
+func (m *Model) View() string {
+	if m.isLoading {
+		return "Carregando chamada..."
+	}
+	var b strings.Builder
+	if m.state == RollCallView {
+		m.viewRollCall(&b)
+	} else {
+		m.viewList(&b)
+	}
+	if m.err != nil {
+		b.WriteString("\n" + errorStyle.Render("Erro: "+m.err.Error()) + "\n")
+	}
+	if m.statusMessage != "" {
+		b.WriteString("\n" + faintStyle.Render(m.statusMessage) + "\n")
+	}
+	return b.String()
+}
+
+func (m *Model) viewList(b *strings.Builder) {
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Chamada - Aulas de %s %s", weekdayNames[m.day.Weekday()], m.day.Format("02/01/2006"))) + "\n")
+	if len(m.lessons) == 0 {
+		b.WriteString(itemStyle.Render("Nenhuma aula neste dia.") + "\n")
+	}
+	for i, lesson := range m.lessons {
+		line := fmt.Sprintf("%s  %-36s %s", lesson.ScheduledAt.Format("15:04"), truncate(lesson.Title, 36), m.classNames[lesson.ClassID])
+		if lesson.Status == models.LessonStatusCancelled {
+			line += "  [cancelada]"
+		}
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render(line) + "\n")
+		} else {
+			b.WriteString(itemStyle.Render(line) + "\n")
+		}
+	}
+	b.WriteString(helpStyle.Render("enter: fazer chamada • ←/→: dia • t: hoje • esc: voltar") + "\n")
+}
+
+func (m *Model) viewRollCall(b *strings.Builder) {
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Chamada - %s (%s, %s)", m.lesson.Title,
+		m.classNames[m.lesson.ClassID], m.lesson.ScheduledAt.Format("02/01/2006 15:04"))) + "\n")
+
+	// Mantém o aluno selecionado visível em turmas maiores que a janela.
+	visible := len(m.entries)
+	if m.height > 0 {
+		visible = max(m.height-12, 5)
+	}
+	start := 0
+	if m.row >= visible {
+		start = m.row - visible + 1
+	}
+	end := min(start+visible, len(m.entries))
+
+	counts := make(map[string]int)
+	for _, status := range m.statuses {
+		counts[status]++
+	}
+	for i := start; i < end; i++ {
+		entry := m.entries[i]
+		status := m.statuses[i]
+		label := "-"
+		if status != "" {
+			label = statusStyles[status].Render(status)
+		}
+		line := fmt.Sprintf("%2d. %-35s ", i+1, truncate(entry.Student.FullName, 35))
+		if i == m.row {
+			b.WriteString(selectedStyle.Render(line) + " " + label + "\n")
+		} else {
+			b.WriteString(itemStyle.Render(line) + " " + label + "\n")
+		}
+	}
+	b.WriteString(fmt.Sprintf("\nPresentes: %d   Faltas: %d   Atrasos: %d   Justificadas: %d   Sem marcação: %d\n",
+		counts[models.AttendancePresent], counts[models.AttendanceAbsent], counts[models.AttendanceLate],
+		counts[models.AttendanceJustified], counts[""]))
+	b.WriteString(helpStyle.Render("p: presente • f: falta • a: atraso • j: justificada • espaço: alternar • P: restantes presentes • ↑/↓: aluno • enter/ctrl+s: salvar • esc: cancelar") + "\n")
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
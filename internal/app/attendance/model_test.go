@@ -0,0 +1,151 @@
+package attendance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// fakeLessonService devolve as mesmas aulas para qualquer dia.
+type fakeLessonService struct {
+	service.LessonService
+	lessons []models.Lesson
+}
+
+func (f *fakeLessonService) GetLessonsForDate(ctx context.Context, userID int64, date time.Time) ([]models.Lesson, error) {
+	return f.lessons, nil
+}
+
+// fakeClassService devolve uma lista fixa de turmas.
+type fakeClassService struct {
+	service.ClassService
+	classes []models.Class
+}
+
+func (f *fakeClassService) ListAllClasses(ctx context.Context) ([]models.Class, error) {
+	return f.classes, nil
+}
+
+// fakeAttendanceService devolve uma lista de chamada fixa e guarda a última chamada registrada.
+type fakeAttendanceService struct {
+	service.AttendanceService
+	lessons  []models.Lesson
+	entries  []service.RollCallEntry
+	recorded map[int64]string
+}
+
+func (f *fakeAttendanceService) GetRollCall(ctx context.Context, lessonID int64) (models.Lesson, []service.RollCallEntry, error) {
+	for _, lesson := range f.lessons {
+		if lesson.ID == lessonID {
+			return lesson, f.entries, nil
+		}
+	}
+	return models.Lesson{}, nil, service.NotFoundErrorf("aula com ID %d não encontrada", lessonID)
+}
+
+func (f *fakeAttendanceService) RecordAttendance(ctx context.Context, lessonID int64, statuses map[int64]string) error {
+	f.recorded = statuses
+	return nil
+}
+
+// runCmd executa 'cmd' e os comandos encadeados pelas mensagens resultantes.
+func runCmd(m *Model, cmd tea.Cmd) {
+	for cmd != nil {
+		msg := cmd()
+		if msg == nil {
+			return
+		}
+		_, cmd = m.Update(msg)
+	}
+}
+
+func typeText(m *Model, text string) {
+	for _, r := range text {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func newTestModel(entries []service.RollCallEntry) (*Model, *fakeAttendanceService) {
+	scheduledAt := time.Date(2025, 3, 10, 7, 30, 0, 0, time.Local)
+	lessons := []models.Lesson{
+		{ID: 1, ClassID: 1, Title: "Frações", ScheduledAt: scheduledAt},
+		{ID: 2, ClassID: 1, Title: "Conselho de classe", ScheduledAt: scheduledAt.Add(time.Hour), Status: models.LessonStatusCancelled},
+	}
+	attendanceService := &fakeAttendanceService{lessons: lessons, entries: entries}
+	model := New(&fakeLessonService{lessons: lessons}, attendanceService, &fakeClassService{classes: []models.Class{{ID: 1, Name: "Turma 9A"}}})
+	runCmd(model, model.Init())
+	return model, attendanceService
+}
+
+func TestAttendanceModel_RollCall(t *testing.T) {
+	model, attendanceService := newTestModel([]service.RollCallEntry{
+		{Student: models.Student{ID: 10, FullName: "Ana Souza"}},
+		{Student: models.Student{ID: 11, FullName: "Bruno Lima"}},
+		{Student: models.Student{ID: 12, FullName: "Carla Dias"}},
+		{Student: models.Student{ID: 13, FullName: "Diego Alves"}},
+	})
+	view := model.View()
+	assert.Contains(t, view, "Frações")
+	assert.Contains(t, view, "Turma 9A")
+	assert.Contains(t, view, "[cancelada]")
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runCmd(model, cmd)
+	require.Equal(t, RollCallView, model.state)
+	assert.Contains(t, model.View(), "Ana Souza")
+
+	// Uma tecla por aluno, descendo a lista.
+	typeText(model, "pf")
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd, "não deve salvar com alunos sem marcação")
+	assert.Contains(t, model.View(), "2 aluno(s) sem marcação")
+
+	typeText(model, "j")
+	model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	model.Update(tea.KeyMsg{Type: tea.KeySpace}) // justificada -> presente
+	typeText(model, "P")
+	assert.Contains(t, model.View(), "Presentes: 3   Faltas: 1")
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	runCmd(model, cmd)
+	assert.Equal(t, map[int64]string{
+		10: models.AttendancePresent, 11: models.AttendanceAbsent,
+		12: models.AttendancePresent, 13: models.AttendancePresent,
+	}, attendanceService.recorded)
+	assert.Equal(t, ListView, model.state)
+	assert.Contains(t, model.View(), "3 presente(s), 1 falta(s)")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, model.CanGoBack())
+}
+
+func TestAttendanceModel_ResumesExistingRollCall(t *testing.T) {
+	model, attendanceService := newTestModel([]service.RollCallEntry{
+		{Student: models.Student{ID: 10, FullName: "Ana Souza"}, Status: models.AttendanceLate},
+		{Student: models.Student{ID: 11, FullName: "Bruno Lima"}},
+	})
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runCmd(model, cmd)
+	assert.Equal(t, 1, model.row, "começa pelo primeiro aluno sem registro")
+	typeText(model, "f")
+
+	// 'esc' descarta a chamada sem gravar e volta à lista, sem sair da tela.
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, attendanceService.recorded)
+	assert.Equal(t, ListView, model.state)
+	assert.False(t, model.CanGoBack())
+
+	// Aulas canceladas não abrem a chamada.
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Contains(t, model.View(), "foi cancelada")
+}
@@ -26,12 +26,18 @@ type Model struct {
 	classService      service.ClassService       // Para buscar aulas, por exemplo (pode ser removido se LessonService for suficiente)
 	assessmentService service.AssessmentService  // Para buscar avaliações
 	lessonService     service.LessonService      // Adicionado para buscar lições
+	planningService   service.PlanningService    // Para exibir o bloco atual e o próximo do plano do dia
+	calendarService   service.CalendarService    // Para exibir o bimestre e a semana atuais do calendário escolar
 
 	// Dados a serem exibidos no Dashboard
 	// Estes campos serão populados pelas respostas dos serviços.
 	upcomingTasks       []models.Task        // Tarefas com prazos futuros
 	todaysLessons       []models.Lesson      // Lições agendadas para o dia atual
-	upcomingAssessments []models.Assessment  // Avaliações agendadas futuramente
+	upcomingAssessments []service.UpcomingAssessment // Avaliações dos próximos dias, de todas as turmas
+	currentBlock        *models.PlanBlock    // Bloco do plano do dia em andamento ("agora")
+	nextBlock           *models.PlanBlock    // Próximo bloco do plano do dia
+	hasDayPlan          bool                 // True se existe plano gravado para hoje
+	calendarLabel       string               // Posição de hoje no calendário escolar (ex: "3º bimestre, semana 5")
 	// Poderíamos adicionar mais, como:
 	// recentGrades      []models.GradeSummary // Resumo de notas recentes lançadas
 	// systemMessages    []string              // Mensagens importantes do sistema ou lembretes
@@ -48,12 +54,16 @@ type Model struct {
 //   cs: Instância de ClassService (pode ser removido se não for mais usado diretamente pelo dashboard).
 //   as: Instância de AssessmentService para buscar dados de avaliações.
 //   ls: Instância de LessonService para buscar dados de lições.
-func New(ts service.TaskService, cs service.ClassService, as service.AssessmentService, ls service.LessonService) *Model {
+//   ps: Instância de PlanningService para a seção "Agora / Próximo" (pode ser nil).
+//   cals: Instância de CalendarService para o bimestre e a semana atuais (pode ser nil).
+func New(ts service.TaskService, cs service.ClassService, as service.AssessmentService, ls service.LessonService, ps service.PlanningService, cals service.CalendarService) *Model {
 	return &Model{
 		taskService:       ts,
 		classService:      cs, // Manter por enquanto, pode ser removido se não usado
 		assessmentService: as,
 		lessonService:     ls,
+		planningService:   ps,
+		calendarService:   cals,
 		isLoading:         true, // Inicia em estado de carregamento por padrão
 		// upcomingTasks, todaysLessons, upcomingAssessments são inicializados como slices vazios (nil)
 	}
@@ -68,7 +78,16 @@ type upcomingTasksLoadedMsg struct{ tasks []models.Task }
 type todaysLessonsLoadedMsg struct{ lessons []models.Lesson }
 
 // upcomingAssessmentsLoadedMsg é enviada quando as próximas avaliações são carregadas.
-type upcomingAssessmentsLoadedMsg struct{ assessments []models.Assessment }
+type upcomingAssessmentsLoadedMsg struct{ assessments []service.UpcomingAssessment }
+
+// dayPlanLoadedMsg é enviada com o bloco atual e o próximo do plano do dia.
+type dayPlanLoadedMsg struct {
+	current, next *models.PlanBlock
+	hasPlan       bool
+}
+
+// calendarLabelLoadedMsg é enviada com a posição de hoje no calendário escolar.
+type calendarLabelLoadedMsg struct{ label string }
 
 // dashboardErrorMsg é enviada quando ocorre um erro ao buscar dados para o dashboard.
 type dashboardErrorMsg struct{ err error }
@@ -111,27 +130,42 @@ func (m *Model) fetchTodaysLessons() tea.Cmd {
 	}
 }
 
+// upcomingAssessmentsDays é quantos dias à frente o dashboard procura avaliações.
+const upcomingAssessmentsDays = 14
+
 func (m *Model) fetchUpcomingAssessments() tea.Cmd {
 	return func() tea.Msg {
-		// TODO: Implementar a lógica real de busca no AssessmentService.
-		// Exemplo: assessments, err := m.assessmentService.GetUpcomingAssessments(context.Background(), time.Now(), 7*24*time.Hour) // Próximos 7 dias
-		// Por enquanto, retorna dados mockados ou vazios.
-		// Supondo que AssessmentService precise de um método específico.
-		// Por agora, vamos simular.
-		// assessments, err := m.assessmentService.ListAllAssessments(context.Background()) // Se tal método existir
-		// if err != nil {
-		// 	return dashboardErrorMsg{fmt.Errorf("buscar todas as avaliações: %w", err)}
-		// }
-		// Placeholder: Filtro manual
-		// var upcoming []models.Assessment
-		// now := time.Now()
-		// for _, assessment := range assessments {
-		// 	if assessment.Date.After(now) { // Supondo que assessment.Date exista e seja time.Time
-		// 		upcoming = append(upcoming, assessment)
-		// 	}
-		// }
-		// Retornando vazio por enquanto
-		return upcomingAssessmentsLoadedMsg{assessments: []models.Assessment{}}
+		now := time.Now()
+		assessments, err := m.assessmentService.GetUpcomingAssessments(context.Background(), now, now.AddDate(0, 0, upcomingAssessmentsDays))
+		if err != nil {
+			return dashboardErrorMsg{fmt.Errorf("buscar próximas avaliações: %w", err)}
+		}
+		return upcomingAssessmentsLoadedMsg{assessments: assessments}
+	}
+}
+
+func (m *Model) fetchDayPlan() tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		blocks, err := m.planningService.GetDayPlan(context.Background(), now)
+		if err != nil {
+			return dashboardErrorMsg{fmt.Errorf("buscar plano do dia: %w", err)}
+		}
+		current, next, err := m.planningService.CurrentAndNextBlocks(context.Background(), now)
+		if err != nil {
+			return dashboardErrorMsg{fmt.Errorf("buscar plano do dia: %w", err)}
+		}
+		return dayPlanLoadedMsg{current: current, next: next, hasPlan: len(blocks) > 0}
+	}
+}
+
+func (m *Model) fetchCalendarLabel() tea.Cmd {
+	return func() tea.Msg {
+		label, err := m.calendarService.DescribeDate(context.Background(), time.Now())
+		if err != nil {
+			return dashboardErrorMsg{fmt.Errorf("buscar calendário escolar: %w", err)}
+		}
+		return calendarLabelLoadedMsg{label: label}
 	}
 }
 
@@ -140,11 +174,18 @@ func (m *Model) fetchUpcomingAssessments() tea.Cmd {
 func (m *Model) Init() tea.Cmd {
 	m.isLoading = true
 	m.err = nil // Limpar erros anteriores
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.fetchUpcomingTasks(),
 		m.fetchTodaysLessons(), // Corrigido
 		m.fetchUpcomingAssessments(),
-	)
+	}
+	if m.planningService != nil {
+		cmds = append(cmds, m.fetchDayPlan())
+	}
+	if m.calendarService != nil {
+		cmds = append(cmds, m.fetchCalendarLabel())
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update lida com mensagens e atualiza o modelo.
@@ -172,6 +213,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.isLoading = false
 		m.err = nil // Limpar erro se a última carga foi bem-sucedida
 
+	case dayPlanLoadedMsg:
+		m.currentBlock = msg.current
+		m.nextBlock = msg.next
+		m.hasDayPlan = msg.hasPlan
+
+	case calendarLabelLoadedMsg:
+		m.calendarLabel = msg.label
+
 	case dashboardErrorMsg:
 		m.err = msg.err
 		m.isLoading = false // Parar o carregamento em caso de erro
@@ -208,11 +257,27 @@ func (m *Model) View() string {
 	listItemStyle := lipgloss.NewStyle().PaddingLeft(2)
 	noDataStyle := lipgloss.NewStyle().Italic(true).PaddingLeft(2)
 	helpStyle := lipgloss.NewStyle().Faint(true).MarginTop(1)
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).PaddingLeft(4)
 
 	// Construtor de String para a View
 	var sb strings.Builder
 
-	sb.WriteString(titleStyle.Render("Painel de Controle Vigenda") + "\n")
+	title := "Painel de Controle Vigenda"
+	if m.calendarLabel != "" {
+		title += " · " + m.calendarLabel
+	}
+	sb.WriteString(titleStyle.Render(title) + "\n")
+
+	// Seção: Agora / Próximo (plano do dia)
+	if m.planningService != nil {
+		sb.WriteString(sectionTitleStyle.Render("Agora / Próximo") + "\n")
+		if !m.hasDayPlan {
+			sb.WriteString(noDataStyle.Render("Nenhum plano para hoje. Use 'Planejar o Dia' ou 'vigenda planejar hoje'.") + "\n")
+		} else {
+			sb.WriteString(listItemStyle.Render("Agora:   "+formatPlanBlock(m.currentBlock, "livre")) + "\n")
+			sb.WriteString(listItemStyle.Render("Próximo: "+formatPlanBlock(m.nextBlock, "nada mais planejado hoje")) + "\n")
+		}
+	}
 
 	// Seção: Tarefas Próximas
 	sb.WriteString(sectionTitleStyle.Render("Tarefas Próximas") + "\n")
@@ -244,14 +309,19 @@ func (m *Model) View() string {
 	// Seção: Próximas Avaliações
 	sb.WriteString(sectionTitleStyle.Render("Próximas Avaliações") + "\n")
 	if len(m.upcomingAssessments) == 0 {
-		sb.WriteString(noDataStyle.Render("Nenhuma avaliação próxima encontrada.") + "\n")
+		sb.WriteString(noDataStyle.Render(fmt.Sprintf("Nenhuma avaliação nos próximos %d dias.", upcomingAssessmentsDays)) + "\n")
 	} else {
-		for _, assessment := range m.upcomingAssessments {
-			assessmentDateStr := "N/D"
-			if assessment.AssessmentDate != nil {
-				assessmentDateStr = assessment.AssessmentDate.Format("02/01/2006")
+		// Avaliações da mesma turma no mesmo dia vêm juntas; o aviso vai depois da última delas.
+		listed := make(map[string]int)
+		for _, upcoming := range m.upcomingAssessments {
+			date := upcoming.Assessment.AssessmentDate.Format("02/01")
+			sb.WriteString(listItemStyle.Render(fmt.Sprintf("• %s – %s – %s (%s)", date, upcoming.ClassName, upcoming.Assessment.Name, upcoming.Countdown())) + "\n")
+			key := fmt.Sprintf("%d %s", upcoming.Assessment.ClassID, date)
+			listed[key]++
+			if len(upcoming.SameDay) > 0 && listed[key] == len(upcoming.SameDay)+1 {
+				warning := fmt.Sprintf("⚠ %s tem %d avaliações em %s", upcoming.ClassName, len(upcoming.SameDay)+1, date)
+				sb.WriteString(warningStyle.Render(warning) + "\n")
 			}
-			sb.WriteString(listItemStyle.Render(fmt.Sprintf("• %s (Turma ID: %d, Data: %s)", assessment.Name, assessment.ClassID, assessmentDateStr)) + "\n")
 		}
 	}
 
@@ -264,6 +334,14 @@ func (m *Model) View() string {
 	// return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, sb.String(), lipgloss.WithMaxHeight(m.height), lipgloss.WithMaxWidth(m.width))
 }
 
+// formatPlanBlock formata um bloco do plano como "HH:MM–HH:MM Título (tipo)", ou 'empty' se não houver bloco.
+func formatPlanBlock(block *models.PlanBlock, empty string) string {
+	if block == nil {
+		return empty
+	}
+	return fmt.Sprintf("%s–%s %s (%s)", block.StartAt.Format("15:04"), block.EndAt.Format("15:04"), block.Title, block.Kind)
+}
+
 // IsFocused indica se o dashboard tem algum componente interno focado (como um input de texto).
 // Isso é usado pelo app.Model para decidir se 'esc' deve voltar ao menu ou ser tratado pelo dashboard.
 // Para um dashboard que apenas exibe dados, isso geralmente será false.
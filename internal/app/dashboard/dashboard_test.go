@@ -0,0 +1,30 @@
+package dashboard
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+func TestView_UpcomingAssessmentsWithCountdownAndSameDayWarning(t *testing.T) {
+	date := time.Date(2026, time.October, 23, 0, 0, 0, 0, time.Local)
+	prova := models.Assessment{ID: 1, ClassID: 1, Name: "Prova 2", AssessmentDate: &date}
+	seminario := models.Assessment{ID: 2, ClassID: 1, Name: "Seminário", AssessmentDate: &date}
+
+	m := New(nil, nil, nil, nil, nil, nil)
+	m.Update(upcomingAssessmentsLoadedMsg{assessments: []service.UpcomingAssessment{
+		{Assessment: prova, ClassName: "Turma 9A", DaysLeft: 4, SameDay: []models.Assessment{seminario}},
+		{Assessment: seminario, ClassName: "Turma 9A", DaysLeft: 4, SameDay: []models.Assessment{prova}},
+	}})
+	view := m.View()
+
+	assert.Contains(t, view, "23/10 – Turma 9A – Prova 2 (em 4 dias)")
+	assert.Contains(t, view, "23/10 – Turma 9A – Seminário (em 4 dias)")
+	assert.Equal(t, 1, strings.Count(view, "⚠ Turma 9A tem 2 avaliações em 23/10"))
+	assert.Less(t, strings.Index(view, "Seminário"), strings.Index(view, "⚠"), "o aviso vem depois das avaliações do dia")
+}
@@ -0,0 +1,403 @@
+// Package grades implementa a tela de lançamento de notas de uma avaliação, aberta por
+// 'vigenda avaliacao lancar-notas': a lista de alunos da turma com a nota de cada um, editada
+// aluno a aluno e gravada de uma só vez ao sair.
+package grades
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// nameWidth é a largura da coluna ALUNO, sem o marcador do aluno selecionado.
+const nameWidth = 22
+
+// maxInput limita o tamanho da nota digitada.
+const maxInput = 8
+
+var (
+	errorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	faintStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// rosterRow é um aluno da lista de lançamento.
+type rosterRow struct {
+	student  models.Student
+	note     string   // Motivo de o aluno não receber nota (ex: "Transferido"); "" se recebe.
+	original *float64 // Nota já gravada.
+	grade    *float64 // Nota atual, com as alterações ainda não gravadas.
+}
+
+func (r rosterRow) editable() bool { return r.note == "" }
+
+func (r rosterRow) changed() bool {
+	return r.grade != nil && (r.original == nil || *r.original != *r.grade)
+}
+
+// Model é o modelo BubbleTea da tela de lançamento de notas.
+type Model struct {
+	assessmentService service.AssessmentService
+	classService      service.ClassService
+	assessmentID      int64
+
+	assessment models.Assessment
+	className  string
+	policy     models.GradingPolicy
+	rows       []rosterRow
+	cursor     int
+
+	editing bool
+	input   string
+
+	isLoading bool
+	err       error
+	saved     int  // Notas gravadas ao sair com 'q'.
+	cancelled bool // Saiu sem gravar as alterações.
+	height    int
+}
+
+// --- Mensagens ---
+
+type rosterLoadedMsg struct {
+	assessment models.Assessment
+	className  string
+	policy     models.GradingPolicy
+	rows       []rosterRow
+	err        error
+}
+
+type gradesSavedMsg struct {
+	count int
+	err   error
+}
+
+// New cria a tela de lançamento de notas da avaliação 'assessmentID'.
+func New(assessmentService service.AssessmentService, classService service.ClassService, assessmentID int64) *Model {
+	return &Model{
+		assessmentService: assessmentService,
+		classService:      classService,
+		assessmentID:      assessmentID,
+		isLoading:         true,
+	}
+}
+
+// --- Comandos ---
+
+func (m *Model) loadCmd() tea.Cmd {
+	assessmentID := m.assessmentID
+	return func() tea.Msg {
+		ctx := context.Background()
+		students, assessment, err := m.assessmentService.GetStudentsForGrading(ctx, assessmentID)
+		if err != nil {
+			return rosterLoadedMsg{err: fmt.Errorf("erro ao carregar a avaliação: %w", err)}
+		}
+		policy, err := m.assessmentService.GetGradingPolicy(ctx, assessment.ClassID)
+		if err != nil {
+			return rosterLoadedMsg{err: fmt.Errorf("erro ao carregar a política de notas: %w", err)}
+		}
+		grades, err := m.assessmentService.GetGradesByAssessmentID(ctx, assessmentID)
+		if err != nil {
+			return rosterLoadedMsg{err: fmt.Errorf("erro ao carregar as notas: %w", err)}
+		}
+		className := fmt.Sprintf("Turma ID %d", assessment.ClassID)
+		if class, err := m.classService.GetClassByID(ctx, assessment.ClassID); err == nil {
+			className = class.Name
+		}
+		// Na recuperação, só os alunos abaixo da média de aprovação recebem nota.
+		var eligible map[int64]bool
+		if service.IsRecovery(*assessment) {
+			recoveryStudents, err := m.assessmentService.GetRecoveryStudents(ctx, assessmentID)
+			if err != nil {
+				return rosterLoadedMsg{err: fmt.Errorf("erro ao carregar os alunos da recuperação: %w", err)}
+			}
+			eligible = make(map[int64]bool)
+			for _, rs := range recoveryStudents {
+				eligible[rs.Student.ID] = true
+			}
+		}
+
+		existing := make(map[int64]float64)
+		for _, grade := range grades {
+			existing[grade.StudentID] = grade.Grade
+		}
+		rows := make([]rosterRow, 0, len(students))
+		for _, student := range students {
+			row := rosterRow{student: student}
+			if grade, ok := existing[student.ID]; ok {
+				row.original, row.grade = &grade, &grade
+			}
+			switch {
+			case student.Status != "" && student.Status != "ativo":
+				row.note = capitalize(student.Status)
+			case eligible != nil && !eligible[student.ID]:
+				row.note = "Acima da média"
+			}
+			rows = append(rows, row)
+		}
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].student.FullName < rows[j].student.FullName })
+		return rosterLoadedMsg{assessment: *assessment, className: className, policy: policy, rows: rows}
+	}
+}
+
+func (m *Model) saveCmd() tea.Cmd {
+	grades := make(map[int64]float64)
+	for _, row := range m.rows {
+		if row.changed() {
+			grades[row.student.ID] = *row.grade
+		}
+	}
+	assessmentID := m.assessmentID
+	return func() tea.Msg {
+		err := m.assessmentService.EnterGrades(context.Background(), assessmentID, grades)
+		return gradesSavedMsg{count: len(grades), err: err}
+	}
+}
+
+// Init carrega os alunos e as notas já lançadas.
+func (m *Model) Init() tea.Cmd {
+	return m.loadCmd()
+}
+
+// Update processa teclas e o resultado dos comandos assíncronos.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+
+	case rosterLoadedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, tea.Quit
+		}
+		m.assessment = msg.assessment
+		m.className = msg.className
+		m.policy = msg.policy
+		m.rows = msg.rows
+		m.cursor = m.nextEditable(-1, 1)
+		if m.cursor < 0 {
+			m.err = fmt.Errorf("nenhum aluno da turma %s pode receber nota na avaliação '%s'", m.className, m.assessment.Name)
+			return m, tea.Quit
+		}
+		return m, nil
+
+	case gradesSavedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.saved = msg.count
+		for i := range m.rows {
+			m.rows[i].original = m.rows[i].grade
+		}
+		return m, tea.Quit
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			m.cancelled = true
+			return m, tea.Quit
+		}
+		if m.isLoading || len(m.rows) == 0 {
+			return m, nil
+		}
+		if m.editing {
+			m.updateEditing(msg)
+			return m, nil
+		}
+		return m.updateRoster(msg)
+	}
+	return m, nil
+}
+
+func (m *Model) updateRoster(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("q"))):
+		if m.pending() == 0 {
+			return m, tea.Quit
+		}
+		m.isLoading = true
+		return m, m.saveCmd()
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		m.cancelled = m.pending() > 0
+		return m, tea.Quit
+	case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+		if i := m.nextEditable(m.cursor, -1); i >= 0 {
+			m.cursor = i
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+		if i := m.nextEditable(m.cursor, 1); i >= 0 {
+			m.cursor = i
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+		m.startEditing("")
+	default:
+		// Um número começa a edição da nota do aluno selecionado.
+		if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && unicode.IsDigit(msg.Runes[0]) {
+			m.startEditing(string(msg.Runes))
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) startEditing(input string) {
+	m.err = nil
+	m.editing = true
+	m.input = input
+	if row := m.rows[m.cursor]; input == "" && row.grade != nil {
+		m.input = service.FormatGrade(m.policy, *row.grade)
+	}
+}
+
+// updateEditing trata as teclas da nota em edição; 'enter' a valida e passa ao próximo aluno.
+func (m *Model) updateEditing(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.editing = false
+		m.err = nil
+	case tea.KeyBackspace:
+		if runes := []rune(m.input); len(runes) > 0 {
+			m.input = string(runes[:len(runes)-1])
+		}
+	case tea.KeyEnter:
+		if strings.TrimSpace(m.input) == "" {
+			m.editing = false
+			m.err = nil
+			return
+		}
+		grade, err := service.ParseGrade(m.policy, m.input)
+		if err != nil {
+			m.err = err
+			return
+		}
+		m.rows[m.cursor].grade = &grade
+		m.editing = false
+		m.err = nil
+		if i := m.nextEditable(m.cursor, 1); i >= 0 {
+			m.cursor = i
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		if len([]rune(m.input))+len(msg.Runes) <= maxInput {
+			m.input += string(msg.Runes)
+		}
+	}
+}
+
+// nextEditable retorna o próximo aluno que pode receber nota a partir de 'from', na direção 'step',
+// ou -1 se não houver.
+func (m *Model) nextEditable(from, step int) int {
+	for i := from + step; i >= 0 && i < len(m.rows); i += step {
+		if m.rows[i].editable() {
+			return i
+		}
+	}
+	return -1
+}
+
+// pending conta as notas alteradas e ainda não gravadas.
+func (m *Model) pending() int {
+	count := 0
+	for _, row := range m.rows {
+		if row.changed() {
+			count++
+		}
+	}
+	return count
+}
+
+// Saved retorna quantas notas foram gravadas ao sair.
+func (m *Model) Saved() int { return m.saved }
+
+// Cancelled informa se a tela foi fechada descartando notas alteradas.
+func (m *Model) Cancelled() bool { return m.cancelled }
+
+// Err retorna o erro que encerrou a tela, se houver.
+func (m *Model) Err() error { return m.err }
+
+// Assessment retorna a avaliação carregada.
+func (m *Model) Assessment() models.Assessment { return m.assessment }
+
+// View renderiza a lista de alunos com as notas.
+func (m *Model) View() string {
+	if m.isLoading && m.rows == nil {
+		return "Carregando alunos..."
+	}
+	if m.rows == nil {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Lançamento de notas para a avaliação %q - %s\n", m.assessment.Name, m.className)
+	b.WriteString("Use as setas para navegar, 'enter' para editar a nota. Digite 'q' para sair.\n\n")
+	fmt.Fprintf(&b, "%-*s%s\n", nameWidth+2, "ALUNO", "NOTA")
+	fmt.Fprintf(&b, "%-*s%s\n", nameWidth+2, strings.Repeat("-", 19), "----")
+
+	// Mantém o aluno selecionado visível em turmas maiores que a janela.
+	start, end := 0, len(m.rows)
+	if visible := m.height - 10; m.height > 0 && visible < len(m.rows) {
+		visible = max(visible, 5)
+		if m.cursor >= visible {
+			start = m.cursor - visible + 1
+		}
+		end = min(start+visible, len(m.rows))
+	}
+	for i := start; i < end; i++ {
+		row := m.rows[i]
+		marker := "  "
+		if i == m.cursor {
+			marker = "› "
+		}
+		name := row.student.FullName
+		if row.note != "" {
+			name += " (" + row.note + ")"
+		}
+		fmt.Fprintf(&b, "%s%-*s %s\n", marker, nameWidth-1, name, m.gradeCell(i))
+	}
+
+	if m.err != nil && !m.editing {
+		b.WriteString("\n" + errorStyle.Render("Erro: "+m.err.Error()) + "\n")
+	}
+	if pending := m.pending(); pending > 0 && !m.isLoading {
+		b.WriteString("\n" + faintStyle.Render(fmt.Sprintf("%d nota(s) alterada(s): 'q' grava e sai, 'esc' sai sem gravar.", pending)) + "\n")
+	}
+	return b.String()
+}
+
+// gradeCell renderiza a nota do aluno da linha 'i': "--" se ele não recebe nota, o campo de edição
+// (com o erro da validação ao lado) ou a nota, marcada com '*' se ainda não foi gravada.
+func (m *Model) gradeCell(i int) string {
+	row := m.rows[i]
+	switch {
+	case !row.editable():
+		return "--"
+	case m.editing && i == m.cursor:
+		cell := "› " + m.input + "_"
+		if m.err != nil {
+			cell += "  " + errorStyle.Render(m.err.Error())
+		}
+		return cell
+	case row.grade == nil:
+		return "[Pendente]"
+	case row.changed():
+		return service.FormatGrade(m.policy, *row.grade) + " *"
+	}
+	return service.FormatGrade(m.policy, *row.grade)
+}
+
+func capitalize(s string) string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return s
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,197 @@
+package grades
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// fakeAssessmentService guarda a avaliação, os alunos e as notas em memória.
+type fakeAssessmentService struct {
+	service.AssessmentService
+	assessment models.Assessment
+	students   []models.Student
+	grades     map[int64]float64
+	policy     models.GradingPolicy
+	recovery   []int64 // Alunos da recuperação, se a avaliação for uma.
+	entered    []map[int64]float64
+}
+
+func (f *fakeAssessmentService) GetStudentsForGrading(ctx context.Context, assessmentID int64) ([]models.Student, *models.Assessment, error) {
+	if assessmentID != f.assessment.ID {
+		return nil, nil, service.NotFoundErrorf("avaliação com ID %d não encontrada", assessmentID)
+	}
+	assessment := f.assessment
+	return f.students, &assessment, nil
+}
+
+func (f *fakeAssessmentService) GetGradingPolicy(ctx context.Context, classID int64) (models.GradingPolicy, error) {
+	return f.policy, nil
+}
+
+func (f *fakeAssessmentService) GetGradesByAssessmentID(ctx context.Context, assessmentID int64) ([]models.Grade, error) {
+	var grades []models.Grade
+	for studentID, grade := range f.grades {
+		grades = append(grades, models.Grade{AssessmentID: assessmentID, StudentID: studentID, Grade: grade})
+	}
+	return grades, nil
+}
+
+func (f *fakeAssessmentService) GetRecoveryStudents(ctx context.Context, recoveryID int64) ([]service.RecoveryStudent, error) {
+	var students []service.RecoveryStudent
+	for _, id := range f.recovery {
+		students = append(students, service.RecoveryStudent{Student: models.Student{ID: id}})
+	}
+	return students, nil
+}
+
+func (f *fakeAssessmentService) EnterGrades(ctx context.Context, assessmentID int64, studentGrades map[int64]float64) error {
+	f.entered = append(f.entered, studentGrades)
+	for studentID, grade := range studentGrades {
+		f.grades[studentID] = grade
+	}
+	return nil
+}
+
+type fakeClassService struct {
+	service.ClassService
+}
+
+func (f *fakeClassService) GetClassByID(ctx context.Context, classID int64) (models.Class, error) {
+	return models.Class{ID: classID, Name: "Turma 9A"}, nil
+}
+
+// newRoster monta a turma do arquivo golden: Carla ainda sem nota e Daniel transferido.
+func newRoster(t *testing.T) (*Model, *fakeAssessmentService) {
+	t.Helper()
+	assessments := &fakeAssessmentService{
+		assessment: models.Assessment{ID: 7, ClassID: 1, Name: "Prova Bimestral 1", Term: 1, Weight: 4},
+		students: []models.Student{
+			{ID: 2, FullName: "Bruno Dias", Status: "ativo"},
+			{ID: 1, FullName: "Ana Beatriz Costa", Status: "ativo"},
+			{ID: 4, FullName: "Daniel Mendes", Status: "transferido"},
+			{ID: 3, FullName: "Carla Esteves", Status: "ativo"},
+		},
+		grades: map[int64]float64{1: 8.5, 2: 7},
+		policy: models.GradingPolicy{ClassID: 1, GradeScale: models.GradeScaleTen},
+	}
+	m := New(assessments, &fakeClassService{}, 7)
+	m.Update(m.Init()())
+	require.NoError(t, m.Err())
+	return m, assessments
+}
+
+func press(m *Model, keys ...tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		_, cmd = m.Update(k)
+	}
+	return cmd
+}
+
+func typeText(m *Model, text string) {
+	for _, r := range text {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+var (
+	down  = tea.KeyMsg{Type: tea.KeyDown}
+	enter = tea.KeyMsg{Type: tea.KeyEnter}
+	quit  = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}
+)
+
+func TestRoster_MatchesGoldenFile(t *testing.T) {
+	golden, err := os.ReadFile(filepath.Join("..", "..", "..", "tests", "integration", "golden_files", "notas_lancar_interativo_output.txt"))
+	require.NoError(t, err)
+	screens := strings.Split(string(golden), "\n---\n")
+	require.Len(t, screens, 3, "tela inicial, legenda e tela de edição")
+
+	m, _ := newRoster(t)
+	assert.Equal(t, strings.TrimSpace(screens[0]), strings.TrimSpace(m.View()))
+
+	press(m, down, down, enter)
+	typeText(m, "9.0")
+	assert.Equal(t, strings.TrimSpace(screens[2]), strings.TrimSpace(m.View()))
+}
+
+func TestRoster_EditsValidatesAndSavesInOneBatch(t *testing.T) {
+	m, assessments := newRoster(t)
+
+	// Nota fora da escala: o erro aparece na linha e a edição continua.
+	press(m, down, down, enter)
+	typeText(m, "11")
+	press(m, enter)
+	require.Error(t, m.Err())
+	assert.True(t, m.editing)
+	assert.Contains(t, m.View(), "› 11_  nota 11 fora da escala")
+
+	// Vírgula decimal é aceita; a nota fica marcada até ser gravada.
+	press(m, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText(m, "9,5")
+	press(m, enter)
+	require.NoError(t, m.Err())
+	assert.Contains(t, m.View(), "Carla Esteves         9.5 *")
+
+	// Daniel (transferido) é pulado: o cursor não sai de Carla.
+	press(m, down)
+	assert.Equal(t, "Carla Esteves", m.rows[m.cursor].student.FullName)
+
+	// A nota de Ana é editada a partir do valor já lançado.
+	press(m, tea.KeyMsg{Type: tea.KeyUp}, tea.KeyMsg{Type: tea.KeyUp}, enter)
+	assert.Equal(t, "8.5", m.input)
+	press(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText(m, "0")
+	press(m, enter)
+	assert.Contains(t, m.View(), "2 nota(s) alterada(s)")
+
+	cmd := press(m, quit)
+	require.NotNil(t, cmd)
+	_, cmd = m.Update(cmd())
+	require.NotNil(t, cmd)
+	assert.Equal(t, 2, m.Saved())
+	assert.False(t, m.Cancelled())
+	require.Len(t, assessments.entered, 1, "as notas são gravadas de uma só vez")
+	assert.Equal(t, map[int64]float64{1: 8.0, 3: 9.5}, assessments.entered[0])
+}
+
+func TestRoster_QuitWithoutChangesAndCancel(t *testing.T) {
+	m, assessments := newRoster(t)
+	assert.NotNil(t, press(m, quit))
+	assert.Empty(t, assessments.entered)
+	assert.Equal(t, 0, m.Saved())
+
+	m, assessments = newRoster(t)
+	press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'6'}}, enter)
+	assert.NotNil(t, press(m, tea.KeyMsg{Type: tea.KeyEsc}))
+	assert.True(t, m.Cancelled())
+	assert.Empty(t, assessments.entered)
+}
+
+func TestRoster_RecoveryOnlyForStudentsBelowPassingGrade(t *testing.T) {
+	assessments := &fakeAssessmentService{
+		assessment: models.Assessment{ID: 9, ClassID: 1, Name: "Recuperação", Term: 1, Kind: models.AssessmentKindRecovery},
+		students: []models.Student{
+			{ID: 1, FullName: "Ana Beatriz Costa", Status: "ativo"},
+			{ID: 2, FullName: "Bruno Dias", Status: "ativo"},
+		},
+		grades:   map[int64]float64{},
+		policy:   models.GradingPolicy{ClassID: 1, GradeScale: models.GradeScaleTen},
+		recovery: []int64{2},
+	}
+	m := New(assessments, &fakeClassService{}, 9)
+	m.Update(m.Init()())
+	require.NoError(t, m.Err())
+	view := m.View()
+	assert.Contains(t, view, "  Ana Beatriz Costa (Acima da média) --")
+	assert.Contains(t, view, "› Bruno Dias            [Pendente]")
+}
@@ -0,0 +1,1065 @@
+// Package lessons implementa a tela "Planejar Aulas" da TUI: lista as aulas da semana ou de uma
+// turma, cria e edita aulas com um editor de plano de várias linhas (que pode partir de um modelo
+// de plano de aula), mostra o plano de aula
+// (Markdown) formatado em uma área com rolagem, com os anexos da aula, e registra a situação e o
+// conteúdo ministrado.
+package lessons
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vigenda/internal/config"
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+	"vigenda/internal/tui/markdown"
+)
+
+// ViewState define o estado atual da tela de aulas.
+type ViewState int
+
+const (
+	ListView          ViewState = iota // Lista de aulas (da semana ou da turma).
+	DetailView                         // Plano de aula formatado, com rolagem.
+	FormView                           // Criação ou edição de uma aula.
+	DeleteConfirmView                  // Confirmação de remoção.
+	DeliveryView                       // Registro da situação e do conteúdo ministrado.
+)
+
+// ListMode define quais aulas a lista mostra.
+type ListMode int
+
+const (
+	WeekMode  ListMode = iota // Aulas da semana (segunda a domingo) de todas as turmas.
+	ClassMode                 // Todas as aulas de uma turma.
+)
+
+// dateTimeLayout é o formato do campo de data e hora do formulário.
+const dateTimeLayout = "2006-01-02 15:04"
+
+// userID é o usuário das consultas por semana; o Vigenda ainda é monousuário.
+const userID = 1
+
+// Campos do formulário, na ordem de navegação com tab.
+const (
+	titleField = iota
+	dateField
+	classField
+	planField
+	fieldCount
+)
+
+// Campos do registro de aula, na ordem de navegação com tab.
+const (
+	statusField = iota
+	coveredField
+	reflectionField
+	pendingField
+	deliveryFieldCount
+)
+
+// lessonStatuses são as situações de aula, na ordem em que ←/→ as percorrem.
+var lessonStatuses = []string{models.LessonStatusPlanned, models.LessonStatusTaught, models.LessonStatusPartial, models.LessonStatusCancelled}
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).MarginBottom(1)
+	dayStyle      = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("208")).MarginTop(1)
+	itemStyle     = lipgloss.NewStyle().PaddingLeft(2)
+	selectedStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	labelStyle    = lipgloss.NewStyle().Bold(true)
+	focusedStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	faintStyle    = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	helpStyle     = lipgloss.NewStyle().Faint(true).MarginTop(1)
+)
+
+var weekdayNames = [...]string{"Domingo", "Segunda", "Terça", "Quarta", "Quinta", "Sexta", "Sábado"}
+
+// Model é o modelo BubbleTea da tela de aulas.
+type Model struct {
+	lessonService   service.LessonService
+	classService    service.ClassService
+	templateService service.LessonTemplateService
+	attachmentService service.AttachmentService
+	state         ViewState
+	mode          ListMode
+
+	classes    []models.Class
+	classIndex int       // Turma exibida em ClassMode e sugerida ao criar uma aula.
+	weekOf     time.Time // Qualquer dia da semana exibida em WeekMode.
+	lessons    []models.Lesson
+	cursor     int
+
+	editing      *models.Lesson // Aula em edição; nil ao criar.
+	formClass    int            // Índice em 'classes' da turma escolhida no formulário.
+	focus        int
+	titleInput   textinput.Model
+	dateInput    textinput.Model
+	planInput    textarea.Model
+	planViewport viewport.Model
+
+	templates     []models.LessonTemplate // Modelos de plano, carregados no primeiro ctrl+t.
+	templateIndex int                     // Índice em 'templates' do último modelo aplicado; -1 se nenhum.
+
+	attachments []models.LessonAttachment // Anexos da aula aberta em DetailView.
+
+	deliveryStatus  int // Índice em lessonStatuses.
+	deliveryFocus   int
+	coveredInput    textinput.Model
+	reflectionInput textinput.Model
+	pendingInput    textinput.Model
+
+	isLoading     bool
+	backRequested bool // 'esc' na lista: o app.Model deve voltar ao menu principal.
+	err           error
+	statusMessage string
+
+	width  int
+	height int
+}
+
+// --- Mensagens ---
+
+type classesLoadedMsg struct {
+	classes []models.Class
+	err     error
+}
+
+type lessonsLoadedMsg struct {
+	lessons []models.Lesson
+	err     error
+}
+
+type lessonSavedMsg struct {
+	lesson  models.Lesson
+	created bool
+	err     error
+}
+
+type templatesLoadedMsg struct {
+	templates []models.LessonTemplate
+	err       error
+}
+
+type deliveryRecordedMsg struct {
+	lesson models.Lesson
+	next   *models.Lesson // Aula que recebeu o conteúdo pendente, se houve.
+	err    error
+}
+
+type attachmentsLoadedMsg struct {
+	lessonID    int64
+	attachments []models.LessonAttachment
+	err         error
+}
+
+type attachmentOpenedMsg struct {
+	name string
+	err  error
+}
+
+type lessonDeletedMsg struct {
+	lesson models.Lesson
+	err    error
+}
+
+// New cria o modelo da tela de aulas.
+func New(lessonService service.LessonService, classService service.ClassService, templateService service.LessonTemplateService, attachmentService service.AttachmentService) *Model {
+	titleInput := textinput.New()
+	titleInput.Placeholder = "Título da aula"
+	titleInput.CharLimit = 120
+
+	dateInput := textinput.New()
+	dateInput.Placeholder = "AAAA-MM-DD HH:MM"
+	dateInput.CharLimit = len(dateTimeLayout)
+
+	planInput := textarea.New()
+	planInput.Placeholder = "Plano de aula em Markdown (# títulos, - listas, | tabelas |)..."
+	planInput.CharLimit = 0
+	planInput.SetHeight(10)
+
+	coveredInput := textinput.New()
+	coveredInput.Placeholder = "O que foi efetivamente trabalhado"
+	coveredInput.CharLimit = 500
+
+	reflectionInput := textinput.New()
+	reflectionInput.Placeholder = "Como foi a aula (opcional)"
+	reflectionInput.CharLimit = 500
+
+	pendingInput := textinput.New()
+	pendingInput.Placeholder = "Conteúdo a levar para a próxima aula (opcional)"
+	pendingInput.CharLimit = 500
+
+	return &Model{
+		lessonService:   lessonService,
+		classService:    classService,
+		templateService: templateService,
+		attachmentService: attachmentService,
+		weekOf:        time.Now(),
+		titleInput:    titleInput,
+		dateInput:     dateInput,
+		planInput:     planInput,
+		planViewport:  viewport.New(80, 15),
+
+		coveredInput:    coveredInput,
+		reflectionInput: reflectionInput,
+		pendingInput:    pendingInput,
+	}
+}
+
+// --- Comandos ---
+
+func (m *Model) loadClassesCmd() tea.Msg {
+	classes, err := m.classService.ListAllClasses(context.Background())
+	return classesLoadedMsg{classes: classes, err: err}
+}
+
+func (m *Model) loadTemplatesCmd() tea.Msg {
+	templates, err := m.templateService.ListTemplates(context.Background())
+	return templatesLoadedMsg{templates: templates, err: err}
+}
+
+func (m *Model) loadLessonsCmd() tea.Cmd {
+	mode, weekOf := m.mode, m.weekOf
+	var classID int64
+	if class := m.currentClass(); class != nil {
+		classID = class.ID
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		if mode == ClassMode {
+			if classID == 0 {
+				return lessonsLoadedMsg{}
+			}
+			lessons, err := m.lessonService.GetLessonsByClassID(ctx, classID)
+			return lessonsLoadedMsg{lessons: lessons, err: err}
+		}
+		lessons, err := m.lessonService.GetLessonsForWeek(ctx, userID, weekOf)
+		return lessonsLoadedMsg{lessons: lessons, err: err}
+	}
+}
+
+func (m *Model) saveLessonCmd(editing *models.Lesson, classID int64, title, plan string, scheduledAt time.Time) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if editing == nil {
+			lesson, err := m.lessonService.CreateLesson(ctx, classID, title, plan, scheduledAt)
+			return lessonSavedMsg{lesson: lesson, created: true, err: err}
+		}
+		lesson, err := m.lessonService.UpdateLesson(ctx, editing.ID, title, plan, scheduledAt)
+		return lessonSavedMsg{lesson: lesson, err: err}
+	}
+}
+
+// recordDeliveryCmd grava o registro da aula e, se houver conteúdo pendente (ou a aula for
+// cancelada com plano), leva-o para a próxima aula da turma.
+func (m *Model) recordDeliveryCmd(lessonID int64, status, covered, reflection, pending string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		lesson, err := m.lessonService.RecordDelivery(ctx, lessonID, status, covered, reflection)
+		if err != nil {
+			return deliveryRecordedMsg{err: err}
+		}
+		carry := strings.TrimSpace(pending) != "" ||
+			(status == models.LessonStatusCancelled && strings.TrimSpace(lesson.PlanContent) != "")
+		if !carry {
+			return deliveryRecordedMsg{lesson: lesson}
+		}
+		next, err := m.lessonService.CarryOverContent(ctx, lessonID, pending)
+		if err != nil {
+			return deliveryRecordedMsg{lesson: lesson, err: err}
+		}
+		return deliveryRecordedMsg{lesson: lesson, next: &next}
+	}
+}
+
+func (m *Model) loadAttachmentsCmd(lessonID int64) tea.Cmd {
+	return func() tea.Msg {
+		attachments, err := m.attachmentService.ListAttachments(context.Background(), lessonID)
+		return attachmentsLoadedMsg{lessonID: lessonID, attachments: attachments, err: err}
+	}
+}
+
+// openAttachmentCmd abre o anexo com o programa configurado (config.Opener), suspendendo a TUI
+// enquanto ele executa.
+func (m *Model) openAttachmentCmd(attachment models.LessonAttachment) tea.Cmd {
+	opener := config.OpenCommand(m.attachmentService.Target(attachment))
+	return tea.ExecProcess(opener, func(err error) tea.Msg {
+		return attachmentOpenedMsg{name: attachment.Name, err: err}
+	})
+}
+
+func (m *Model) deleteLessonCmd(lesson models.Lesson) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if err := m.lessonService.DeleteLesson(ctx, lesson.ID); err != nil {
+			return lessonDeletedMsg{lesson: lesson, err: err}
+		}
+		if m.attachmentService == nil {
+			return lessonDeletedMsg{lesson: lesson}
+		}
+		return lessonDeletedMsg{lesson: lesson, err: m.attachmentService.DeleteLessonAttachments(ctx, lesson.ID)}
+	}
+}
+
+// Init volta para a lista da semana atual e recarrega turmas e aulas.
+func (m *Model) Init() tea.Cmd {
+	m.state = ListView
+	m.weekOf = time.Now()
+	m.cursor = 0
+	m.isLoading = true
+	m.backRequested = false
+	m.err = nil
+	m.statusMessage = ""
+	m.templates = nil // Recarregados no próximo ctrl+t.
+	return m.loadClassesCmd
+}
+
+// Update processa teclas e o resultado dos comandos assíncronos.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.resizeComponents()
+		return m, nil
+
+	case classesLoadedMsg:
+		if msg.err != nil {
+			m.isLoading = false
+			m.err = msg.err
+			return m, nil
+		}
+		m.classes = msg.classes
+		if m.classIndex >= len(m.classes) {
+			m.classIndex = 0
+		}
+		return m, m.loadLessonsCmd()
+
+	case lessonsLoadedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.lessons = msg.lessons
+		if m.cursor >= len(m.lessons) {
+			m.cursor = max(len(m.lessons)-1, 0)
+		}
+		return m, nil
+
+	case lessonSavedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		if msg.created {
+			m.statusMessage = fmt.Sprintf("Aula '%s' criada.", msg.lesson.Title)
+		} else {
+			m.statusMessage = fmt.Sprintf("Aula '%s' atualizada.", msg.lesson.Title)
+		}
+		m.editing = nil
+		m.state = ListView
+		m.isLoading = true
+		return m, m.loadLessonsCmd()
+
+	case templatesLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.templates = msg.templates
+		if m.templates == nil {
+			m.templates = []models.LessonTemplate{}
+		}
+		m.applyNextTemplate()
+		return m, nil
+
+	case deliveryRecordedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			if msg.lesson.ID == 0 {
+				return m, nil // O registro falhou: o formulário continua aberto.
+			}
+		} else {
+			m.err = nil
+		}
+		m.statusMessage = fmt.Sprintf("Aula '%s' registrada como %s.", msg.lesson.Title, msg.lesson.Status)
+		if msg.next != nil {
+			m.statusMessage += fmt.Sprintf(" Pendências levadas para '%s' (%s).", msg.next.Title, msg.next.ScheduledAt.Format("02/01 15:04"))
+		}
+		m.state = ListView
+		m.isLoading = true
+		return m, m.loadLessonsCmd()
+
+	case attachmentsLoadedMsg:
+		if lesson := m.selectedLesson(); lesson == nil || lesson.ID != msg.lessonID {
+			return m, nil // A aula mudou enquanto os anexos carregavam.
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.attachments = msg.attachments
+		return m, nil
+
+	case attachmentOpenedMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("não foi possível abrir '%s' (ajuste o programa em %s): %w", msg.name, config.OpenerEnv, msg.err)
+			return m, nil
+		}
+		m.err = nil
+		m.statusMessage = fmt.Sprintf("Anexo '%s' aberto.", msg.name)
+		return m, nil
+
+	case lessonDeletedMsg:
+		m.state = ListView
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.statusMessage = fmt.Sprintf("Aula '%s' removida.", msg.lesson.Title)
+		m.isLoading = true
+		return m, m.loadLessonsCmd()
+
+	case tea.KeyMsg:
+		if m.isLoading {
+			return m, nil
+		}
+		switch m.state {
+		case DetailView:
+			return m.updateDetailView(msg)
+		case FormView:
+			return m.updateFormView(msg)
+		case DeleteConfirmView:
+			return m.updateDeleteConfirmView(msg)
+		case DeliveryView:
+			return m.updateDeliveryView(msg)
+		default:
+			return m.updateListView(msg)
+		}
+	}
+
+	if m.state == FormView {
+		return m, m.updateFocusedInput(msg)
+	}
+	if m.state == DeliveryView {
+		return m, m.updateDeliveryInput(msg)
+	}
+	return m, nil
+}
+
+func (m *Model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		m.backRequested = true
+	case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+		if m.cursor < len(m.lessons)-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("w"))):
+		return m, m.switchMode(WeekMode)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
+		if m.mode == ClassMode && len(m.classes) > 0 {
+			m.classIndex = (m.classIndex + 1) % len(m.classes)
+		}
+		return m, m.switchMode(ClassMode)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h"))):
+		return m, m.shift(-1)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l"))):
+		return m, m.shift(1)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+		if lesson := m.selectedLesson(); lesson != nil {
+			m.state = DetailView
+			m.statusMessage = ""
+			m.attachments = nil
+			m.planViewport.SetContent(m.renderPlan(*lesson))
+			m.planViewport.GotoTop()
+			if m.attachmentService != nil {
+				return m, m.loadAttachmentsCmd(lesson.ID)
+			}
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("n"))):
+		if len(m.classes) == 0 {
+			m.err = fmt.Errorf("cadastre uma turma antes de criar aulas")
+			return m, nil
+		}
+		return m, m.openForm(nil)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("e"))):
+		if lesson := m.selectedLesson(); lesson != nil {
+			return m, m.openForm(lesson)
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("d"))):
+		if m.selectedLesson() != nil {
+			m.state = DeleteConfirmView
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("m"))):
+		if lesson := m.selectedLesson(); lesson != nil {
+			return m, m.openDelivery(*lesson)
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		m.isLoading = true
+		m.err = nil
+		return m, m.loadClassesCmd
+	}
+	return m, nil
+}
+
+func (m *Model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "q"))):
+		m.state = ListView
+		return m, nil
+	case key.Matches(msg, key.NewBinding(key.WithKeys("e"))):
+		return m, m.openForm(m.selectedLesson())
+	case key.Matches(msg, key.NewBinding(key.WithKeys("d"))):
+		m.state = DeleteConfirmView
+		return m, nil
+	case key.Matches(msg, key.NewBinding(key.WithKeys("m"))):
+		if lesson := m.selectedLesson(); lesson != nil {
+			return m, m.openDelivery(*lesson)
+		}
+		return m, nil
+	case len(msg.Runes) == 1 && msg.Runes[0] >= '1' && msg.Runes[0] <= '9':
+		if index := int(msg.Runes[0] - '1'); index < len(m.attachments) {
+			return m, m.openAttachmentCmd(m.attachments[index])
+		}
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.planViewport, cmd = m.planViewport.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) updateFormView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		m.state = ListView
+		m.editing = nil
+		m.err = nil
+		return m, nil
+	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+s"))):
+		return m, m.submitForm()
+	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+t"))):
+		if m.templateService == nil {
+			return m, nil
+		}
+		if m.templates == nil {
+			return m, m.loadTemplatesCmd
+		}
+		m.applyNextTemplate()
+		return m, nil
+	case key.Matches(msg, key.NewBinding(key.WithKeys("tab"))):
+		return m, m.setFocus((m.focus + 1) % fieldCount)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("shift+tab"))):
+		return m, m.setFocus((m.focus + fieldCount - 1) % fieldCount)
+	case m.focus == classField:
+		// A turma de uma aula existente não muda; ao criar, ←/→ escolhem a turma.
+		if m.editing == nil && len(m.classes) > 0 {
+			switch {
+			case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h"))):
+				m.formClass = (m.formClass + len(m.classes) - 1) % len(m.classes)
+			case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l"))):
+				m.formClass = (m.formClass + 1) % len(m.classes)
+			}
+		}
+		return m, nil
+	}
+	return m, m.updateFocusedInput(msg)
+}
+
+func (m *Model) updateDeleteConfirmView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("s", "y"))):
+		if lesson := m.selectedLesson(); lesson != nil {
+			return m, m.deleteLessonCmd(*lesson)
+		}
+		m.state = ListView
+	case key.Matches(msg, key.NewBinding(key.WithKeys("n", "esc"))):
+		m.state = ListView
+	}
+	return m, nil
+}
+
+func (m *Model) updateDeliveryView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		m.state = ListView
+		m.err = nil
+		return m, nil
+	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+s", "enter"))):
+		return m, m.submitDelivery()
+	case key.Matches(msg, key.NewBinding(key.WithKeys("tab", "down"))):
+		return m, m.setDeliveryFocus((m.deliveryFocus + 1) % m.deliveryFields())
+	case key.Matches(msg, key.NewBinding(key.WithKeys("shift+tab", "up"))):
+		return m, m.setDeliveryFocus((m.deliveryFocus + m.deliveryFields() - 1) % m.deliveryFields())
+	case m.deliveryFocus == statusField:
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h"))):
+			m.deliveryStatus = (m.deliveryStatus + len(lessonStatuses) - 1) % len(lessonStatuses)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l", " "))):
+			m.deliveryStatus = (m.deliveryStatus + 1) % len(lessonStatuses)
+		}
+		return m, nil
+	}
+	return m, m.updateDeliveryInput(msg)
+}
+
+// openDelivery prepara o registro da situação e do conteúdo ministrado de uma aula. Uma aula
+// ainda planejada é sugerida como ministrada.
+func (m *Model) openDelivery(lesson models.Lesson) tea.Cmd {
+	m.state = DeliveryView
+	m.err = nil
+	m.statusMessage = ""
+	m.deliveryStatus = 1
+	for i, status := range lessonStatuses {
+		if status == lesson.Status && status != models.LessonStatusPlanned {
+			m.deliveryStatus = i
+		}
+	}
+	m.coveredInput.SetValue(lesson.CoveredContent)
+	m.reflectionInput.SetValue(lesson.Reflection)
+	m.pendingInput.SetValue("")
+	return m.setDeliveryFocus(statusField)
+}
+
+// deliveryFields é o número de campos do registro: o conteúdo pendente só aparece para aulas
+// parciais ou canceladas.
+func (m *Model) deliveryFields() int {
+	switch lessonStatuses[m.deliveryStatus] {
+	case models.LessonStatusPartial, models.LessonStatusCancelled:
+		return deliveryFieldCount
+	}
+	return pendingField
+}
+
+// submitDelivery dispara a gravação do registro da aula selecionada.
+func (m *Model) submitDelivery() tea.Cmd {
+	lesson := m.selectedLesson()
+	if lesson == nil {
+		m.state = ListView
+		return nil
+	}
+	status := lessonStatuses[m.deliveryStatus]
+	pending := ""
+	if m.deliveryFields() == deliveryFieldCount {
+		pending = m.pendingInput.Value()
+	}
+	return m.recordDeliveryCmd(lesson.ID, status, m.coveredInput.Value(), m.reflectionInput.Value(), pending)
+}
+
+// setDeliveryFocus move o foco do registro de aula para o campo 'field'.
+func (m *Model) setDeliveryFocus(field int) tea.Cmd {
+	m.deliveryFocus = field
+	m.coveredInput.Blur()
+	m.reflectionInput.Blur()
+	m.pendingInput.Blur()
+	switch field {
+	case coveredField:
+		return m.coveredInput.Focus()
+	case reflectionField:
+		return m.reflectionInput.Focus()
+	case pendingField:
+		return m.pendingInput.Focus()
+	}
+	return nil
+}
+
+// updateDeliveryInput repassa a mensagem ao campo de texto com foco no registro de aula.
+func (m *Model) updateDeliveryInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch m.deliveryFocus {
+	case coveredField:
+		m.coveredInput, cmd = m.coveredInput.Update(msg)
+	case reflectionField:
+		m.reflectionInput, cmd = m.reflectionInput.Update(msg)
+	case pendingField:
+		m.pendingInput, cmd = m.pendingInput.Update(msg)
+	}
+	return cmd
+}
+
+// switchMode troca o modo da lista e recarrega as aulas.
+func (m *Model) switchMode(mode ListMode) tea.Cmd {
+	m.mode = mode
+	m.cursor = 0
+	m.err = nil
+	m.isLoading = true
+	return m.loadLessonsCmd()
+}
+
+// shift avança ou recua a semana (WeekMode) ou a turma (ClassMode) exibida.
+func (m *Model) shift(delta int) tea.Cmd {
+	if m.mode == WeekMode {
+		m.weekOf = m.weekOf.AddDate(0, 0, 7*delta)
+	} else {
+		if len(m.classes) == 0 {
+			return nil
+		}
+		m.classIndex = (m.classIndex + len(m.classes) + delta) % len(m.classes)
+	}
+	return m.switchMode(m.mode)
+}
+
+// openForm prepara o formulário para criar (lesson == nil) ou editar uma aula.
+func (m *Model) openForm(lesson *models.Lesson) tea.Cmd {
+	m.state = FormView
+	m.err = nil
+	m.statusMessage = ""
+	m.editing = nil
+	m.formClass = m.classIndex
+	m.templateIndex = -1
+	if lesson == nil {
+		m.titleInput.SetValue("")
+		m.dateInput.SetValue(defaultLessonTime(m).Format(dateTimeLayout))
+		m.planInput.SetValue("")
+	} else {
+		editing := *lesson
+		m.editing = &editing
+		m.titleInput.SetValue(lesson.Title)
+		m.dateInput.SetValue(lesson.ScheduledAt.Format(dateTimeLayout))
+		m.planInput.SetValue(lesson.PlanContent)
+		for i, class := range m.classes {
+			if class.ID == lesson.ClassID {
+				m.formClass = i
+			}
+		}
+	}
+	return m.setFocus(titleField)
+}
+
+// applyNextTemplate preenche o plano com o próximo modelo de plano de aula. Para não perder o que
+// o professor escreveu, só substitui um plano vazio ou igual ao último modelo aplicado.
+func (m *Model) applyNextTemplate() {
+	if len(m.templates) == 0 {
+		m.err = fmt.Errorf("nenhum modelo de plano cadastrado: crie um com 'vigenda modelo criar'")
+		return
+	}
+	plan := strings.TrimSpace(m.planInput.Value())
+	if plan != "" && (m.templateIndex < 0 || plan != strings.TrimSpace(m.templates[m.templateIndex].Content)) {
+		m.err = fmt.Errorf("o plano já tem conteúdo: apague-o para usar um modelo")
+		return
+	}
+	m.templateIndex = (m.templateIndex + 1) % len(m.templates)
+	template := m.templates[m.templateIndex]
+	m.planInput.SetValue(template.Content)
+	m.err = nil
+	m.statusMessage = fmt.Sprintf("Modelo '%s' aplicado (%d/%d). ctrl+t: próximo modelo.", template.Name, m.templateIndex+1, len(m.templates))
+}
+
+// defaultLessonTime sugere a data de uma nova aula: hoje às 07:00 na semana atual,
+// ou a segunda-feira da semana exibida.
+func defaultLessonTime(m *Model) time.Time {
+	day := time.Now()
+	if m.mode == WeekMode && !service.WeekStart(m.weekOf).Equal(service.WeekStart(day)) {
+		day = service.WeekStart(m.weekOf)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), 7, 0, 0, 0, time.Local)
+}
+
+// submitForm valida o formulário e dispara a gravação da aula.
+func (m *Model) submitForm() tea.Cmd {
+	title := strings.TrimSpace(m.titleInput.Value())
+	if title == "" {
+		m.err = fmt.Errorf("o título da aula é obrigatório")
+		return nil
+	}
+	scheduledAt, err := time.ParseInLocation(dateTimeLayout, strings.TrimSpace(m.dateInput.Value()), time.Local)
+	if err != nil {
+		m.err = fmt.Errorf("data inválida '%s': use o formato AAAA-MM-DD HH:MM", m.dateInput.Value())
+		return nil
+	}
+	if m.formClass >= len(m.classes) {
+		m.err = fmt.Errorf("escolha uma turma para a aula")
+		return nil
+	}
+	m.err = nil
+	return m.saveLessonCmd(m.editing, m.classes[m.formClass].ID, title, m.planInput.Value(), scheduledAt)
+}
+
+// setFocus move o foco do formulário para o campo 'field'.
+func (m *Model) setFocus(field int) tea.Cmd {
+	m.focus = field
+	m.titleInput.Blur()
+	m.dateInput.Blur()
+	m.planInput.Blur()
+	switch field {
+	case titleField:
+		return m.titleInput.Focus()
+	case dateField:
+		return m.dateInput.Focus()
+	case planField:
+		return m.planInput.Focus()
+	}
+	return nil
+}
+
+// updateFocusedInput repassa a mensagem ao campo de texto com foco.
+func (m *Model) updateFocusedInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch m.focus {
+	case titleField:
+		m.titleInput, cmd = m.titleInput.Update(msg)
+	case dateField:
+		m.dateInput, cmd = m.dateInput.Update(msg)
+	case planField:
+		m.planInput, cmd = m.planInput.Update(msg)
+	}
+	return cmd
+}
+
+// resizeComponents ajusta o editor e a área de rolagem ao tamanho da janela.
+func (m *Model) resizeComponents() {
+	width := max(m.width-6, 20)
+	m.titleInput.Width = min(width, 80)
+	m.coveredInput.Width = min(width, 80)
+	m.reflectionInput.Width = min(width, 80)
+	m.pendingInput.Width = min(width, 80)
+	m.planInput.SetWidth(width)
+	m.planInput.SetHeight(max(m.height-18, 5))
+	m.planViewport.Width = width
+	m.planViewport.Height = max(m.height-14, 5)
+	if m.state == DetailView {
+		if lesson := m.selectedLesson(); lesson != nil {
+			m.planViewport.SetContent(m.renderPlan(*lesson))
+		}
+	}
+}
+
+func (m *Model) currentClass() *models.Class {
+	if m.classIndex < len(m.classes) {
+		return &m.classes[m.classIndex]
+	}
+	return nil
+}
+
+func (m *Model) selectedLesson() *models.Lesson {
+	if m.cursor < len(m.lessons) {
+		return &m.lessons[m.cursor]
+	}
+	return nil
+}
+
+func (m *Model) className(classID int64) string {
+	for _, class := range m.classes {
+		if class.ID == classID {
+			return class.Name
+		}
+	}
+	return fmt.Sprintf("Turma ID %d", classID)
+}
+
+// renderPlan formata o plano de aula para a área de rolagem.
+func (m *Model) renderPlan(lesson models.Lesson) string {
+	if strings.TrimSpace(lesson.PlanContent) == "" {
+		return faintStyle.Render("(sem plano de aula — pressione 'e' para escrever)")
+	}
+	return markdown.Render(lesson.PlanContent, m.planViewport.Width)
+}
+
+// CanGoBack informa ao app.Model que 'esc' foi pressionado na lista e a tela pode voltar ao menu.
+func (m *Model) CanGoBack() bool {
+	back := m.backRequested
+	m.backRequested = false
+	return back
+}
+
+// View renderiza a tela de acordo com o estado atual.
+func (m *Model) View() string {
+	if m.isLoading {
+		return "Carregando aulas..."
+	}
+	var b strings.Builder
+	switch m.state {
+	case DetailView:
+		m.viewDetail(&b)
+	case FormView:
+		m.viewForm(&b)
+	case DeleteConfirmView:
+		m.viewDeleteConfirm(&b)
+	case DeliveryView:
+		m.viewDelivery(&b)
+	default:
+		m.viewList(&b)
+	}
+	if m.err != nil {
+		b.WriteString("\n" + errorStyle.Render("Erro: "+m.err.Error()) + "\n")
+	}
+	if m.statusMessage != "" {
+		b.WriteString("\n" + faintStyle.Render(m.statusMessage) + "\n")
+	}
+	return b.String()
+}
+
+func (m *Model) viewList(b *strings.Builder) {
+	if m.mode == WeekMode {
+		start := service.WeekStart(m.weekOf)
+		end := start.AddDate(0, 0, 6)
+		b.WriteString(titleStyle.Render(fmt.Sprintf("Aulas da Semana - %s a %s", start.Format("02/01"), end.Format("02/01/2006"))) + "\n")
+	} else if class := m.currentClass(); class != nil {
+		b.WriteString(titleStyle.Render(fmt.Sprintf("Aulas da Turma - %s (%d/%d)", class.Name, m.classIndex+1, len(m.classes))) + "\n")
+	} else {
+		b.WriteString(titleStyle.Render("Aulas da Turma") + "\n")
+	}
+
+	if len(m.lessons) == 0 {
+		if m.mode == ClassMode && len(m.classes) == 0 {
+			b.WriteString(itemStyle.Render("Nenhuma turma cadastrada.") + "\n")
+		} else {
+			b.WriteString(itemStyle.Render("Nenhuma aula neste período.") + "\n")
+		}
+	}
+	var lastDay string
+	for i, lesson := range m.lessons {
+		var line string
+		if m.mode == WeekMode {
+			day := weekdayNames[lesson.ScheduledAt.Weekday()] + " " + lesson.ScheduledAt.Format("02/01")
+			if day != lastDay {
+				b.WriteString(dayStyle.Render(day) + "\n")
+				lastDay = day
+			}
+			line = fmt.Sprintf("%s  %-36s %s", lesson.ScheduledAt.Format("15:04"), truncate(lesson.Title, 36), m.className(lesson.ClassID))
+		} else {
+			line = fmt.Sprintf("%s  %s", lesson.ScheduledAt.Format("02/01/2006 15:04"), lesson.Title)
+		}
+		if lesson.Status != "" && lesson.Status != models.LessonStatusPlanned {
+			line += "  [" + lesson.Status + "]"
+		}
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render(line) + "\n")
+		} else {
+			b.WriteString(itemStyle.Render(line) + "\n")
+		}
+	}
+
+	navigation := "←/→: semana"
+	if m.mode == ClassMode {
+		navigation = "←/→ ou t: turma"
+	}
+	b.WriteString(helpStyle.Render(fmt.Sprintf("enter: ver plano • n: nova • e: editar • m: registrar • d: remover • w: semana • t: por turma • %s • esc: voltar", navigation)) + "\n")
+}
+
+func (m *Model) viewDetail(b *strings.Builder) {
+	lesson := m.selectedLesson()
+	if lesson == nil {
+		return
+	}
+	b.WriteString(titleStyle.Render(lesson.Title) + "\n")
+	b.WriteString(fmt.Sprintf("%s %s   %s %s\n\n", labelStyle.Render("Turma:"), m.className(lesson.ClassID),
+		labelStyle.Render("Data:"), weekdayNames[lesson.ScheduledAt.Weekday()]+" "+lesson.ScheduledAt.Format("02/01/2006 15:04")))
+	if lesson.Status != "" && lesson.Status != models.LessonStatusPlanned {
+		b.WriteString(fmt.Sprintf("%s %s", labelStyle.Render("Situação:"), lesson.Status))
+		if lesson.CoveredContent != "" {
+			b.WriteString(" — " + lesson.CoveredContent)
+		}
+		b.WriteString("\n")
+		if lesson.Reflection != "" {
+			b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Reflexão:"), lesson.Reflection))
+		}
+		b.WriteString("\n")
+	}
+	if len(m.attachments) > 0 {
+		b.WriteString(labelStyle.Render("Anexos:") + "\n")
+		for i, attachment := range m.attachments {
+			if i >= 9 {
+				b.WriteString(faintStyle.Render(fmt.Sprintf("  … e mais %d (veja 'vigenda aula anexos %d')", len(m.attachments)-9, lesson.ID)) + "\n")
+				break
+			}
+			b.WriteString(fmt.Sprintf("  %d. %s %s\n", i+1, attachment.Name, faintStyle.Render("("+attachment.Kind+")")))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(m.planViewport.View() + "\n")
+	help := "↑/↓ pgup/pgdn: rolar (%3.f%%) • e: editar • m: registrar • d: remover • esc: voltar"
+	if len(m.attachments) > 0 {
+		help = "↑/↓ pgup/pgdn: rolar (%3.f%%) • 1-9: abrir anexo • e: editar • m: registrar • d: remover • esc: voltar"
+	}
+	b.WriteString(helpStyle.Render(fmt.Sprintf(help, m.planViewport.ScrollPercent()*100)) + "\n")
+}
+
+func (m *Model) viewForm(b *strings.Builder) {
+	if m.editing == nil {
+		b.WriteString(titleStyle.Render("Nova Aula") + "\n")
+	} else {
+		b.WriteString(titleStyle.Render(fmt.Sprintf("Editar Aula (ID %d)", m.editing.ID)) + "\n")
+	}
+	label := func(field int, text string) string {
+		if m.focus == field {
+			return focusedStyle.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+
+	b.WriteString(label(titleField, "Título:") + " " + m.titleInput.View() + "\n")
+	b.WriteString(label(dateField, "Data:  ") + " " + m.dateInput.View() + "\n")
+	className := "-"
+	if m.formClass < len(m.classes) {
+		className = m.classes[m.formClass].Name
+	}
+	if m.editing == nil {
+		className = "‹ " + className + " ›"
+	}
+	b.WriteString(label(classField, "Turma: ") + " " + className + "\n\n")
+	b.WriteString(label(planField, "Plano de aula (Markdown):") + "\n")
+	b.WriteString(m.planInput.View() + "\n")
+	b.WriteString(helpStyle.Render("tab/shift+tab: próximo campo • ←/→: turma • ctrl+t: usar modelo • ctrl+s: salvar • esc: cancelar") + "\n")
+}
+
+func (m *Model) viewDelivery(b *strings.Builder) {
+	lesson := m.selectedLesson()
+	if lesson == nil {
+		return
+	}
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Registrar Aula - %s (%s)", lesson.Title, lesson.ScheduledAt.Format("02/01/2006 15:04"))) + "\n")
+	label := func(field int, text string) string {
+		if m.deliveryFocus == field {
+			return focusedStyle.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+	b.WriteString(label(statusField, "Situação:  ") + " ‹ " + lessonStatuses[m.deliveryStatus] + " ›\n")
+	b.WriteString(label(coveredField, "Conteúdo:  ") + " " + m.coveredInput.View() + "\n")
+	b.WriteString(label(reflectionField, "Reflexão:  ") + " " + m.reflectionInput.View() + "\n")
+	if m.deliveryFields() == deliveryFieldCount {
+		b.WriteString(label(pendingField, "Pendente:  ") + " " + m.pendingInput.View() + "\n")
+		if lessonStatuses[m.deliveryStatus] == models.LessonStatusCancelled {
+			b.WriteString(faintStyle.Render("Vazio: o plano inteiro vai para a próxima aula da turma.") + "\n")
+		} else {
+			b.WriteString(faintStyle.Render("O conteúdo pendente entra no início do plano da próxima aula da turma.") + "\n")
+		}
+	}
+	b.WriteString(helpStyle.Render("tab/↑/↓: campo • ←/→: situação • enter/ctrl+s: salvar • esc: cancelar") + "\n")
+}
+
+func (m *Model) viewDeleteConfirm(b *strings.Builder) {
+	lesson := m.selectedLesson()
+	if lesson == nil {
+		return
+	}
+	b.WriteString(titleStyle.Render("Remover Aula") + "\n")
+	b.WriteString(fmt.Sprintf("Remover a aula '%s' de %s (%s)?\n", lesson.Title,
+		lesson.ScheduledAt.Format("02/01/2006 15:04"), m.className(lesson.ClassID)))
+	b.WriteString(helpStyle.Render("s: remover • n/esc: cancelar") + "\n")
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
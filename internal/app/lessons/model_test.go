@@ -0,0 +1,290 @@
+package lessons
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// fakeLessonService guarda as aulas em memória e registra as aulas criadas.
+type fakeLessonService struct {
+	service.LessonService
+	lessons []models.Lesson
+	created []models.Lesson
+	carried []string // Conteúdos pendentes passados a CarryOverContent.
+}
+
+func (f *fakeLessonService) GetLessonsForWeek(ctx context.Context, userID int64, date time.Time) ([]models.Lesson, error) {
+	return f.lessons, nil
+}
+
+func (f *fakeLessonService) GetLessonsByClassID(ctx context.Context, classID int64) ([]models.Lesson, error) {
+	var lessons []models.Lesson
+	for _, lesson := range f.lessons {
+		if lesson.ClassID == classID {
+			lessons = append(lessons, lesson)
+		}
+	}
+	return lessons, nil
+}
+
+func (f *fakeLessonService) CreateLesson(ctx context.Context, classID int64, title string, planContent string, scheduledAt time.Time) (models.Lesson, error) {
+	lesson := models.Lesson{ID: int64(len(f.lessons) + 1), ClassID: classID, Title: title, PlanContent: planContent, ScheduledAt: scheduledAt}
+	f.lessons = append(f.lessons, lesson)
+	f.created = append(f.created, lesson)
+	return lesson, nil
+}
+
+func (f *fakeLessonService) RecordDelivery(ctx context.Context, lessonID int64, status string, coveredContent string, reflection string) (models.Lesson, error) {
+	for i := range f.lessons {
+		if f.lessons[i].ID == lessonID {
+			f.lessons[i].Status, f.lessons[i].CoveredContent, f.lessons[i].Reflection = status, coveredContent, reflection
+			return f.lessons[i], nil
+		}
+	}
+	return models.Lesson{}, service.NotFoundErrorf("aula com ID %d não encontrada", lessonID)
+}
+
+func (f *fakeLessonService) CarryOverContent(ctx context.Context, lessonID int64, pendingContent string) (models.Lesson, error) {
+	f.carried = append(f.carried, pendingContent)
+	next := f.lessons[len(f.lessons)-1]
+	return next, nil
+}
+
+// fakeClassService devolve uma lista fixa de turmas.
+type fakeClassService struct {
+	service.ClassService
+	classes []models.Class
+}
+
+func (f *fakeClassService) ListAllClasses(ctx context.Context) ([]models.Class, error) {
+	return f.classes, nil
+}
+
+// fakeTemplateService devolve uma lista fixa de modelos de plano.
+type fakeTemplateService struct {
+	service.LessonTemplateService
+	templates []models.LessonTemplate
+}
+
+func (f *fakeTemplateService) ListTemplates(ctx context.Context) ([]models.LessonTemplate, error) {
+	return f.templates, nil
+}
+
+// fakeAttachmentService devolve os anexos fixos de cada aula.
+type fakeAttachmentService struct {
+	service.AttachmentService
+	attachments map[int64][]models.LessonAttachment
+}
+
+func (f *fakeAttachmentService) ListAttachments(ctx context.Context, lessonID int64) ([]models.LessonAttachment, error) {
+	return f.attachments[lessonID], nil
+}
+
+func (f *fakeAttachmentService) Target(attachment models.LessonAttachment) string {
+	return attachment.Location
+}
+
+// runCmd executa 'cmd' e os comandos encadeados pelas mensagens resultantes.
+func runCmd(m *Model, cmd tea.Cmd) {
+	for cmd != nil {
+		msg := cmd()
+		if msg == nil {
+			return
+		}
+		_, cmd = m.Update(msg)
+	}
+}
+
+func typeText(m *Model, text string) {
+	for _, r := range text {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func newTestModel(lessons []models.Lesson) (*Model, *fakeLessonService) {
+	lessonService := &fakeLessonService{lessons: lessons}
+	classService := &fakeClassService{classes: []models.Class{{ID: 1, Name: "Turma 9A"}, {ID: 2, Name: "Turma 8B"}}}
+	templateService := &fakeTemplateService{templates: []models.LessonTemplate{
+		{ID: 1, Name: "Aula expositiva", Content: "# Objetivos\n-"},
+		{ID: 2, Name: "Aula prática", Content: "# Materiais\n-"},
+	}}
+	attachmentService := &fakeAttachmentService{attachments: map[int64][]models.LessonAttachment{
+		1: {
+			{ID: 1, LessonID: 1, Kind: models.AttachmentKindFile, Name: "slides.pdf", Location: "aula-1/slides.pdf"},
+			{ID: 2, LessonID: 1, Kind: models.AttachmentKindLink, Name: "Vídeo sobre frações", Location: "https://example.com/fracoes"},
+		},
+	}}
+	model := New(lessonService, classService, templateService, attachmentService)
+	model.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	runCmd(model, model.Init())
+	return model, lessonService
+}
+
+func TestLessonsModel_ListAndShowPlan(t *testing.T) {
+	scheduledAt := time.Date(2025, 6, 16, 8, 0, 0, 0, time.Local)
+	model, _ := newTestModel([]models.Lesson{
+		{ID: 1, ClassID: 1, Title: "Frações", ScheduledAt: scheduledAt, PlanContent: "# Objetivos\n- Comparar frações\n\n| Etapa | Tempo |\n|---|---|\n| Aquecimento | 10 min |"},
+		{ID: 2, ClassID: 2, Title: "Revolução Industrial", ScheduledAt: scheduledAt.Add(2 * time.Hour)},
+	})
+
+	view := model.View()
+	assert.Contains(t, view, "Segunda 16/06")
+	assert.Contains(t, view, "Frações")
+	assert.Contains(t, view, "Turma 8B")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, DetailView, model.state)
+	view = model.View()
+	assert.Contains(t, view, "OBJETIVOS")
+	assert.Contains(t, view, "• Comparar frações")
+	assert.Contains(t, view, "Aquecimento │ 10 min")
+
+	// 'esc' no plano volta à lista sem sair da tela; na lista, libera a volta ao menu.
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, ListView, model.state)
+	assert.False(t, model.CanGoBack())
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, model.CanGoBack())
+}
+
+func TestLessonsModel_ClassMode(t *testing.T) {
+	model, _ := newTestModel([]models.Lesson{
+		{ID: 1, ClassID: 1, Title: "Frações", ScheduledAt: time.Now()},
+		{ID: 2, ClassID: 2, Title: "Revolução Industrial", ScheduledAt: time.Now()},
+	})
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	runCmd(model, cmd)
+	assert.Contains(t, model.View(), "Aulas da Turma - Turma 9A")
+	assert.NotContains(t, model.View(), "Revolução Industrial")
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	runCmd(model, cmd)
+	assert.Contains(t, model.View(), "Aulas da Turma - Turma 8B")
+	assert.Contains(t, model.View(), "Revolução Industrial")
+}
+
+func TestLessonsModel_CreateLesson(t *testing.T) {
+	model, lessonService := newTestModel(nil)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	require.Equal(t, FormView, model.state)
+
+	typeText(model, "Equações")
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model.dateInput.SetValue("data errada")
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.Nil(t, cmd, "não deve gravar com data inválida")
+	assert.Contains(t, model.View(), "data inválida")
+
+	model.dateInput.SetValue("2025-06-18 10:00")
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model.Update(tea.KeyMsg{Type: tea.KeyRight}) // Escolhe a Turma 8B.
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(model, "# Plano")
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	typeText(model, "- Exercícios")
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	runCmd(model, cmd)
+
+	require.Len(t, lessonService.created, 1)
+	created := lessonService.created[0]
+	assert.Equal(t, "Equações", created.Title)
+	assert.Equal(t, int64(2), created.ClassID)
+	assert.Equal(t, "# Plano\n- Exercícios", created.PlanContent)
+	assert.Equal(t, time.Date(2025, 6, 18, 10, 0, 0, 0, time.Local), created.ScheduledAt)
+	assert.Equal(t, ListView, model.state)
+	assert.Contains(t, model.View(), "Aula 'Equações' criada.")
+}
+
+func TestLessonsModel_RecordDelivery(t *testing.T) {
+	scheduledAt := time.Date(2025, 6, 16, 8, 0, 0, 0, time.Local)
+	model, lessonService := newTestModel([]models.Lesson{
+		{ID: 1, ClassID: 1, Title: "Frações", ScheduledAt: scheduledAt, Status: models.LessonStatusPlanned},
+		{ID: 2, ClassID: 1, Title: "Soma de frações", ScheduledAt: scheduledAt.AddDate(0, 0, 2), Status: models.LessonStatusPlanned},
+	})
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	require.Equal(t, DeliveryView, model.state)
+	assert.Contains(t, model.View(), "‹ ministrada ›")
+	assert.NotContains(t, model.View(), "Pendente:", "aulas ministradas não têm conteúdo pendente")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRight}) // ministrada -> parcial
+	assert.Contains(t, model.View(), "Pendente:")
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(model, "Frações equivalentes")
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(model, "Simplificação")
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runCmd(model, cmd)
+
+	assert.Equal(t, ListView, model.state)
+	assert.Equal(t, models.LessonStatusPartial, lessonService.lessons[0].Status)
+	assert.Equal(t, "Frações equivalentes", lessonService.lessons[0].CoveredContent)
+	assert.Equal(t, []string{"Simplificação"}, lessonService.carried)
+	view := model.View()
+	assert.Contains(t, view, "Aula 'Frações' registrada como parcial. Pendências levadas para 'Soma de frações'")
+	assert.Contains(t, view, "[parcial]")
+}
+
+func TestLessonsModel_ApplyTemplate(t *testing.T) {
+	model, _ := newTestModel(nil)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	runCmd(model, cmd)
+	assert.Equal(t, "# Objetivos\n-", model.planInput.Value())
+	assert.Contains(t, model.View(), "Modelo 'Aula expositiva' aplicado (1/2)")
+
+	// Um novo ctrl+t troca o modelo ainda não editado pelo seguinte.
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	assert.Nil(t, cmd, "os modelos já foram carregados")
+	assert.Equal(t, "# Materiais\n-", model.planInput.Value())
+
+	// Depois de editado, o plano não é substituído.
+	model.planInput.SetValue("# Meu plano")
+	model.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	assert.Equal(t, "# Meu plano", model.planInput.Value())
+	assert.Contains(t, model.View(), "o plano já tem conteúdo")
+}
+
+func TestLessonsModel_ShowAttachments(t *testing.T) {
+	scheduledAt := time.Date(2025, 6, 16, 8, 0, 0, 0, time.Local)
+	model, _ := newTestModel([]models.Lesson{
+		{ID: 1, ClassID: 1, Title: "Frações", ScheduledAt: scheduledAt},
+		{ID: 2, ClassID: 2, Title: "Revolução Industrial", ScheduledAt: scheduledAt.Add(2 * time.Hour)},
+	})
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runCmd(model, cmd)
+	require.Equal(t, DetailView, model.state)
+	view := model.View()
+	assert.Contains(t, view, "Anexos:")
+	assert.Contains(t, view, "1. slides.pdf")
+	assert.Contains(t, view, "2. Vídeo sobre frações")
+	assert.Contains(t, view, "1-9: abrir anexo")
+
+	// Abrir um anexo suspende a TUI para executar o programa configurado; número sem anexo é ignorado.
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	assert.NotNil(t, cmd)
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'5'}})
+	assert.Nil(t, cmd)
+
+	// Uma aula sem anexos não mostra a seção.
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runCmd(model, cmd)
+	assert.NotContains(t, model.View(), "Anexos:")
+}
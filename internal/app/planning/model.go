@@ -0,0 +1,428 @@
+// Package planning implementa a tela "Planejar o Dia" da TUI: mostra as aulas de hoje,
+// os horários livres e as tarefas sugeridas, e grava o plano em blocos com as tarefas escolhidas.
+package planning
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// ViewState define o estado atual da tela de planejamento.
+type ViewState int
+
+const (
+	ProposalView ViewState = iota // Escolha das tarefas sugeridas para o dia.
+	PlanView                      // Plano gravado para o dia.
+)
+
+// estimateStep é o ajuste, em minutos, aplicado pelas teclas '+' e '-' à estimativa de uma tarefa.
+const estimateStep = 15
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).MarginBottom(1)
+	sectionStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("208")).MarginTop(1)
+	itemStyle     = lipgloss.NewStyle().PaddingLeft(2)
+	selectedStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	faintStyle    = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	helpStyle     = lipgloss.NewStyle().Faint(true).MarginTop(1)
+)
+
+// Model é o modelo BubbleTea da tela de planejamento diário.
+type Model struct {
+	planningService service.PlanningService
+	taskService     service.TaskService
+	state           ViewState
+
+	window   service.TimeSlot
+	proposal service.DayPlanProposal
+	plan     []models.PlanBlock
+	chosen   map[int64]bool // IDs das tarefas marcadas para entrar no plano.
+	cursor   int
+
+	isLoading     bool
+	freshLoad     bool // True quando a próxima carga vem de Init e deve definir estado e seleção iniciais.
+	err           error
+	statusMessage string
+
+	width  int
+	height int
+}
+
+// --- Mensagens ---
+
+type dayLoadedMsg struct {
+	proposal service.DayPlanProposal
+	plan     []models.PlanBlock
+	err      error
+}
+
+type planCommittedMsg struct {
+	plan []models.PlanBlock
+	err  error
+}
+
+type estimateUpdatedMsg struct {
+	task models.Task
+	err  error
+}
+
+// New cria o modelo da tela de planejamento.
+func New(planningService service.PlanningService, taskService service.TaskService) *Model {
+	return &Model{
+		planningService: planningService,
+		taskService:     taskService,
+		chosen:          make(map[int64]bool),
+	}
+}
+
+// --- Comandos ---
+
+func (m *Model) loadDayCmd() tea.Msg {
+	ctx := context.Background()
+	proposal, err := m.planningService.ProposeDayPlan(ctx, m.window)
+	if err != nil {
+		return dayLoadedMsg{err: err}
+	}
+	plan, err := m.planningService.GetDayPlan(ctx, m.window.Start)
+	return dayLoadedMsg{proposal: proposal, plan: plan, err: err}
+}
+
+func (m *Model) commitPlanCmd(taskIDs []int64) tea.Cmd {
+	window := m.window
+	return func() tea.Msg {
+		plan, err := m.planningService.CommitDayPlan(context.Background(), window, taskIDs)
+		return planCommittedMsg{plan: plan, err: err}
+	}
+}
+
+func (m *Model) updateEstimateCmd(task models.Task, minutes int) tea.Cmd {
+	return func() tea.Msg {
+		updated, err := m.taskService.SetTaskPlanning(context.Background(), task.ID, &minutes, 0)
+		return estimateUpdatedMsg{task: updated, err: err}
+	}
+}
+
+// Init recarrega aulas, horários livres, sugestões e o plano já gravado para hoje.
+func (m *Model) Init() tea.Cmd {
+	window, err := service.NewDayWindow(time.Now(), service.DefaultDayStart, service.DefaultDayEnd)
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	m.window = window
+	m.isLoading = true
+	m.freshLoad = true
+	m.err = nil
+	m.statusMessage = ""
+	return m.loadDayCmd
+}
+
+// Update processa teclas e o resultado dos comandos assíncronos.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case dayLoadedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.proposal = msg.proposal
+		m.plan = msg.plan
+		if m.cursor >= len(m.proposal.Suggestions) {
+			m.cursor = 0
+		}
+		if m.freshLoad {
+			m.freshLoad = false
+			m.syncChosenWithPlan()
+			m.state = ProposalView
+			if len(m.plan) > 0 {
+				m.state = PlanView
+			}
+		}
+		return m, nil
+
+	case planCommittedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.statusMessage = ""
+			m.err = msg.err
+			return m, nil
+		}
+		m.plan = msg.plan
+		m.state = PlanView
+		m.statusMessage = "Plano do dia gravado."
+		return m, nil
+
+	case estimateUpdatedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.statusMessage = fmt.Sprintf("Estimativa de '%s' ajustada para %d min.", msg.task.Title, *msg.task.EstimatedMinutes)
+		m.isLoading = true
+		return m, m.loadDayCmd
+
+	case tea.KeyMsg:
+		if m.isLoading {
+			return m, nil
+		}
+		if m.state == PlanView {
+			return m.updatePlanView(msg)
+		}
+		return m.updateProposalView(msg)
+	}
+	return m, nil
+}
+
+func (m *Model) updatePlanView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("p", "enter"))):
+		m.state = ProposalView
+		m.statusMessage = ""
+		m.err = nil
+	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		m.statusMessage = ""
+		return m, m.Init()
+	}
+	return m, nil
+}
+
+func (m *Model) updateProposalView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	suggestions := m.proposal.Suggestions
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+		if m.cursor < len(suggestions)-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys(" ", "x"))):
+		if m.cursor < len(suggestions) {
+			id := suggestions[m.cursor].Task.ID
+			m.chosen[id] = !m.chosen[id]
+			m.err = nil
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
+		m.chosen = make(map[int64]bool)
+		for _, suggestion := range suggestions {
+			if suggestion.Slot != nil {
+				m.chosen[suggestion.Task.ID] = true
+			}
+		}
+		m.err = nil
+	case key.Matches(msg, key.NewBinding(key.WithKeys("+", "="))):
+		return m, m.adjustEstimate(estimateStep)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("-"))):
+		return m, m.adjustEstimate(-estimateStep)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("v"))):
+		m.state = PlanView
+	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		return m, m.Init()
+	case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+		for _, suggestion := range m.preview() {
+			if suggestion.Slot == nil {
+				m.err = fmt.Errorf("'%s' não cabe nos horários livres; desmarque alguma tarefa", suggestion.Task.Title)
+				return m, nil
+			}
+		}
+		var taskIDs []int64
+		for _, suggestion := range suggestions {
+			if m.chosen[suggestion.Task.ID] {
+				taskIDs = append(taskIDs, suggestion.Task.ID)
+			}
+		}
+		m.isLoading = true
+		m.err = nil
+		return m, m.commitPlanCmd(taskIDs)
+	}
+	return m, nil
+}
+
+// adjustEstimate altera em 'delta' minutos a estimativa da tarefa sob o cursor (mínimo de estimateStep).
+func (m *Model) adjustEstimate(delta int) tea.Cmd {
+	if m.cursor >= len(m.proposal.Suggestions) || m.taskService == nil {
+		return nil
+	}
+	suggestion := m.proposal.Suggestions[m.cursor]
+	minutes := suggestion.Minutes + delta
+	if minutes < estimateStep {
+		minutes = estimateStep
+	}
+	if minutes == suggestion.Minutes && !suggestion.DefaultEstimate {
+		return nil
+	}
+	return m.updateEstimateCmd(suggestion.Task, minutes)
+}
+
+// syncChosenWithPlan marca como escolhidas as tarefas que já estão no plano gravado,
+// para que replanejar parta do compromisso anterior.
+func (m *Model) syncChosenWithPlan() {
+	m.chosen = make(map[int64]bool)
+	for _, block := range m.plan {
+		if block.TaskID != nil {
+			m.chosen[*block.TaskID] = true
+		}
+	}
+}
+
+// preview encaixa apenas as tarefas marcadas nos horários livres, na ordem das sugestões.
+func (m *Model) preview() []service.TaskSuggestion {
+	var selected []service.TaskSuggestion
+	for _, suggestion := range m.proposal.Suggestions {
+		if m.chosen[suggestion.Task.ID] {
+			selected = append(selected, suggestion)
+		}
+	}
+	return service.AllocateTasks(m.proposal.FreeSlots, selected)
+}
+
+// View renderiza a tela de acordo com o estado atual.
+func (m *Model) View() string {
+	if m.isLoading {
+		return "Carregando planejamento do dia..."
+	}
+	var b strings.Builder
+	if m.state == PlanView {
+		m.viewPlan(&b)
+	} else {
+		m.viewProposal(&b)
+	}
+	if m.err != nil {
+		b.WriteString("\n" + errorStyle.Render("Erro: "+m.err.Error()) + "\n")
+	}
+	if m.statusMessage != "" {
+		b.WriteString("\n" + faintStyle.Render(m.statusMessage) + "\n")
+	}
+	return b.String()
+}
+
+func (m *Model) viewPlan(b *strings.Builder) {
+	b.WriteString(titleStyle.Render("Plano do Dia - "+m.window.Start.Format("02/01/2006")) + "\n")
+	if len(m.plan) == 0 {
+		b.WriteString(itemStyle.Render("Nenhum plano gravado para hoje.") + "\n")
+	}
+	now := time.Now()
+	for _, block := range m.plan {
+		line := fmt.Sprintf("%s  [%s] %s", formatSlot(block.StartAt, block.EndAt), block.Kind, block.Title)
+		if !now.Before(block.StartAt) && now.Before(block.EndAt) {
+			b.WriteString(selectedStyle.Render("▶ "+line) + "\n")
+			continue
+		}
+		b.WriteString(itemStyle.Render("  "+line) + "\n")
+	}
+	b.WriteString(helpStyle.Render("p/enter: replanejar • r: recarregar • esc: voltar") + "\n")
+}
+
+func (m *Model) viewProposal(b *strings.Builder) {
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Planejar o Dia - %s (%s)", m.window.Start.Format("02/01/2006"),
+		formatSlot(m.window.Start, m.window.End))) + "\n")
+
+	b.WriteString(sectionStyle.Render("Aulas") + "\n")
+	if len(m.proposal.Lessons) == 0 {
+		b.WriteString(itemStyle.Render("Nenhuma aula hoje.") + "\n")
+	}
+	for _, lesson := range m.proposal.Lessons {
+		b.WriteString(itemStyle.Render(fmt.Sprintf("%s  %s", formatSlot(lesson.StartAt, lesson.EndAt), lesson.Title)) + "\n")
+	}
+
+	freeMinutes := 0
+	var freeParts []string
+	for _, slot := range m.proposal.FreeSlots {
+		freeMinutes += slot.Minutes()
+		freeParts = append(freeParts, formatSlot(slot.Start, slot.End))
+	}
+	b.WriteString(sectionStyle.Render(fmt.Sprintf("Horários livres (%s)", formatMinutes(freeMinutes))) + "\n")
+	if len(freeParts) == 0 {
+		b.WriteString(itemStyle.Render("Nenhum horário livre.") + "\n")
+	} else {
+		b.WriteString(itemStyle.Render(strings.Join(freeParts, "  ")) + "\n")
+	}
+
+	preview := make(map[int64]*service.TimeSlot)
+	plannedMinutes := 0
+	for _, suggestion := range m.preview() {
+		preview[suggestion.Task.ID] = suggestion.Slot
+		plannedMinutes += suggestion.Minutes
+	}
+
+	b.WriteString(sectionStyle.Render(fmt.Sprintf("Tarefas sugeridas (%s de %s escolhidos)", formatMinutes(plannedMinutes), formatMinutes(freeMinutes))) + "\n")
+	if len(m.proposal.Suggestions) == 0 {
+		b.WriteString(itemStyle.Render("Nenhuma tarefa pendente.") + "\n")
+	}
+	for i, suggestion := range m.proposal.Suggestions {
+		check := "[ ]"
+		slot := "—"
+		if !m.chosen[suggestion.Task.ID] && suggestion.Slot == nil {
+			slot = "não cabe"
+		}
+		if m.chosen[suggestion.Task.ID] {
+			check = "[x]"
+			if s := preview[suggestion.Task.ID]; s != nil {
+				slot = formatSlot(s.Start, s.End)
+			} else {
+				slot = "não cabe!"
+			}
+		}
+		due := "sem prazo"
+		if suggestion.Task.DueDate != nil {
+			due = suggestion.Task.DueDate.Format("02/01")
+		}
+		estimate := formatMinutes(suggestion.Minutes)
+		if suggestion.DefaultEstimate {
+			estimate = "~" + estimate
+		}
+		line := fmt.Sprintf("%s %-30s %-9s %-6s %-7s %s", check, truncate(suggestion.Task.Title, 30), due,
+			service.TaskPriorityLabel(suggestion.Task.Priority), estimate, slot)
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render(line) + "\n")
+		} else {
+			b.WriteString(itemStyle.Render(line) + "\n")
+		}
+	}
+	b.WriteString(helpStyle.Render("espaço: marcar • a: aceitar sugeridas • +/-: estimativa • enter: gravar plano • v: ver plano • esc: voltar") + "\n")
+}
+
+// CanGoBack informa ao app.Model que 'esc' pode voltar ao menu principal.
+func (m *Model) CanGoBack() bool {
+	return !m.isLoading
+}
+
+func formatSlot(start, end time.Time) string {
+	return start.Format("15:04") + "–" + end.Format("15:04")
+}
+
+// formatMinutes formata minutos como "45min" ou "1h30".
+func formatMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dmin", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh%02d", minutes/60, minutes%60)
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,86 @@
+package planning
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// MockPlanningService é um mock para service.PlanningService.
+type MockPlanningService struct {
+	mock.Mock
+}
+
+func (m *MockPlanningService) ProposeDayPlan(ctx context.Context, window service.TimeSlot) (service.DayPlanProposal, error) {
+	args := m.Called(ctx, window)
+	return args.Get(0).(service.DayPlanProposal), args.Error(1)
+}
+
+func (m *MockPlanningService) CommitDayPlan(ctx context.Context, window service.TimeSlot, taskIDs []int64) ([]models.PlanBlock, error) {
+	args := m.Called(ctx, window, taskIDs)
+	return args.Get(0).([]models.PlanBlock), args.Error(1)
+}
+
+func (m *MockPlanningService) GetDayPlan(ctx context.Context, day time.Time) ([]models.PlanBlock, error) {
+	args := m.Called(ctx, day)
+	return args.Get(0).([]models.PlanBlock), args.Error(1)
+}
+
+func (m *MockPlanningService) CurrentAndNextBlocks(ctx context.Context, at time.Time) (*models.PlanBlock, *models.PlanBlock, error) {
+	args := m.Called(ctx, at)
+	return args.Get(0).(*models.PlanBlock), args.Get(1).(*models.PlanBlock), args.Error(2)
+}
+
+var _ service.PlanningService = (*MockPlanningService)(nil)
+
+func TestPlanningModel_SelectAndCommitTasks(t *testing.T) {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 7, 0, 0, 0, now.Location())
+	free := []service.TimeSlot{{Start: start, End: start.Add(time.Hour)}}
+	proposal := service.DayPlanProposal{
+		FreeSlots: free,
+		Suggestions: service.AllocateTasks(free, []service.TaskSuggestion{
+			{Task: models.Task{ID: 1, Title: "Corrigir provas"}, Minutes: 45},
+			{Task: models.Task{ID: 2, Title: "Responder e-mails"}, Minutes: 30},
+		}),
+	}
+	committed := []models.PlanBlock{{Kind: models.PlanBlockKindTask, Title: "Responder e-mails", StartAt: start, EndAt: start.Add(30 * time.Minute)}}
+
+	mockService := new(MockPlanningService)
+	mockService.On("ProposeDayPlan", mock.Anything, mock.Anything).Return(proposal, nil)
+	mockService.On("GetDayPlan", mock.Anything, mock.Anything).Return([]models.PlanBlock{}, nil)
+	mockService.On("CommitDayPlan", mock.Anything, mock.Anything, []int64{2}).Return(committed, nil)
+
+	model := New(mockService, nil)
+	model.Update(model.Init()())
+	assert.Equal(t, ProposalView, model.state, "sem plano gravado, a tela começa na escolha de tarefas")
+
+	// Marca as duas tarefas: juntas (75 min) não cabem na hora livre.
+	model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd, "não deve gravar um plano que não cabe")
+	assert.Error(t, model.err)
+	assert.Contains(t, model.View(), "não cabe!")
+
+	// Desmarca a primeira e grava.
+	model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if assert.NotNil(t, cmd) {
+		model.Update(cmd())
+	}
+
+	assert.Equal(t, PlanView, model.state)
+	assert.True(t, strings.Contains(model.View(), "Responder e-mails"))
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,310 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vigenda/internal/models"
+)
+
+// boardColumnTitles mapeia cada status de tarefa ao título de sua coluna no quadro.
+var boardColumnTitles = map[string]string{
+	models.TaskStatusTodo:    "A fazer",
+	models.TaskStatusDoing:   "Fazendo",
+	models.TaskStatusWaiting: "Aguardando",
+	models.TaskStatusDone:    "Feito",
+}
+
+var (
+	boardColumnStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("240")).
+				Padding(0, 1)
+	boardFocusedColumnStyle = boardColumnStyle.Copy().BorderForeground(lipgloss.Color("63"))
+	boardSelectedCardStyle  = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("229")).
+				Background(lipgloss.Color("57"))
+)
+
+type taskStatusUpdatedMsg struct {
+	taskID int64
+	status string
+}
+type taskStatusUpdateFailedMsg struct{ err error }
+
+func (m *Model) updateTaskStatusCmd(taskID int64, status string) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.taskService.UpdateTaskStatus(context.Background(), taskID, status); err != nil {
+			return taskStatusUpdateFailedMsg{err}
+		}
+		return taskStatusUpdatedMsg{taskID: taskID, status: status}
+	}
+}
+
+// taskStatus retorna o status efetivo da tarefa para o quadro.
+// Tarefas antigas sem status caem em 'a_fazer' ou 'feito' conforme IsCompleted.
+func taskStatus(task models.Task) string {
+	if task.IsCompleted {
+		return models.TaskStatusDone
+	}
+	if task.Status == "" || task.Status == models.TaskStatusDone {
+		return models.TaskStatusTodo
+	}
+	return task.Status
+}
+
+// boardColumns agrupa as tarefas visíveis (respeitando o filtro de turma) por coluna,
+// na ordem de models.TaskStatuses. Dentro de cada coluna, as tarefas com prazo vêm primeiro.
+func (m *Model) boardColumns() [][]models.Task {
+	columns := make([][]models.Task, len(models.TaskStatuses))
+	for _, task := range m.allTasks {
+		if task.UserID == 0 { // Tarefas de sistema/bugs não aparecem no quadro.
+			continue
+		}
+		if m.boardClassFilter != nil && (task.ClassID == nil || *task.ClassID != *m.boardClassFilter) {
+			continue
+		}
+		status := taskStatus(task)
+		for i, s := range models.TaskStatuses {
+			if s == status {
+				columns[i] = append(columns[i], task)
+				break
+			}
+		}
+	}
+	for _, column := range columns {
+		sort.SliceStable(column, func(i, j int) bool {
+			a, b := column[i].DueDate, column[j].DueDate
+			if a == nil || b == nil {
+				return a != nil
+			}
+			return a.Before(*b)
+		})
+	}
+	return columns
+}
+
+// boardClassIDs retorna os IDs de turma presentes nas tarefas, em ordem crescente, para o filtro.
+func (m *Model) boardClassIDs() []int64 {
+	seen := map[int64]bool{}
+	var ids []int64
+	for _, task := range m.allTasks {
+		if task.ClassID != nil && !seen[*task.ClassID] {
+			seen[*task.ClassID] = true
+			ids = append(ids, *task.ClassID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// cycleBoardClassFilter avança o filtro de turma: todas -> cada turma -> todas.
+func (m *Model) cycleBoardClassFilter() {
+	ids := m.boardClassIDs()
+	if len(ids) == 0 {
+		m.boardClassFilter = nil
+		return
+	}
+	if m.boardClassFilter == nil {
+		m.boardClassFilter = &ids[0]
+		return
+	}
+	for i, id := range ids {
+		if id == *m.boardClassFilter {
+			if i+1 < len(ids) {
+				next := ids[i+1]
+				m.boardClassFilter = &next
+			} else {
+				m.boardClassFilter = nil
+			}
+			return
+		}
+	}
+	m.boardClassFilter = nil
+}
+
+// selectedBoardTask retorna a tarefa sob o cursor do quadro, se houver.
+func (m *Model) selectedBoardTask() (models.Task, bool) {
+	columns := m.boardColumns()
+	column := columns[m.boardColumn]
+	if m.boardRow < 0 || m.boardRow >= len(column) {
+		return models.Task{}, false
+	}
+	return column[m.boardRow], true
+}
+
+// clampBoardCursor mantém o cursor dentro da coluna atual após mudanças de dados ou filtro.
+func (m *Model) clampBoardCursor() {
+	column := m.boardColumns()[m.boardColumn]
+	if m.boardRow >= len(column) {
+		m.boardRow = len(column) - 1
+	}
+	if m.boardRow < 0 {
+		m.boardRow = 0
+	}
+}
+
+// focusBoardTask posiciona o cursor na tarefa indicada (usado após mover uma tarefa de coluna).
+func (m *Model) focusBoardTask(taskID int64) {
+	for c, column := range m.boardColumns() {
+		for r, task := range column {
+			if task.ID == taskID {
+				m.boardColumn, m.boardRow = c, r
+				return
+			}
+		}
+	}
+	m.clampBoardCursor()
+}
+
+// updateBoard trata as teclas do quadro Kanban.
+func (m *Model) updateBoard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.statusMessage = ""
+	switch msg.String() {
+	case "left", "h":
+		if m.boardColumn > 0 {
+			m.boardColumn--
+			m.clampBoardCursor()
+		}
+	case "right", "l":
+		if m.boardColumn < len(models.TaskStatuses)-1 {
+			m.boardColumn++
+			m.clampBoardCursor()
+		}
+	case "up", "k":
+		if m.boardRow > 0 {
+			m.boardRow--
+		}
+	case "down", "j":
+		if m.boardRow < len(m.boardColumns()[m.boardColumn])-1 {
+			m.boardRow++
+		}
+	case "shift+left", "<", "H":
+		return m.moveSelectedBoardTask(-1)
+	case "shift+right", ">", "L":
+		return m.moveSelectedBoardTask(1)
+	case "f":
+		m.cycleBoardClassFilter()
+		m.boardRow = 0
+		m.clampBoardCursor()
+	case "b":
+		m.preferBoard = false
+		m.currentView = TableView
+	case "a":
+		m.currentView = FormView
+		m.formSubState = CreatingTask
+		m.resetFormInputs()
+		m.err = nil
+		return m, nil
+	case "e", "v", "enter":
+		if task, ok := m.selectedBoardTask(); ok {
+			m.isLoading = true
+			return m, m.fetchTaskForDetailCmd(task.ID, msg.String() == "e")
+		}
+	case "z":
+		if task, ok := m.selectedBoardTask(); ok && !task.IsCompleted {
+			m.isLoading = true
+			m.err = nil
+			return m, m.postponeTaskCmd(task.ID, snoozeDelay)
+		}
+	case "d":
+		if task, ok := m.selectedBoardTask(); ok {
+			m.taskIDToDelete = task.ID
+			m.currentView = ConfirmDeleteView
+			m.err = nil
+		}
+	}
+	return m, nil
+}
+
+// moveSelectedBoardTask move a tarefa selecionada 'delta' colunas para a esquerda (-1) ou direita (+1).
+func (m *Model) moveSelectedBoardTask(delta int) (tea.Model, tea.Cmd) {
+	task, ok := m.selectedBoardTask()
+	if !ok {
+		return m, nil
+	}
+	target := m.boardColumn + delta
+	if target < 0 || target >= len(models.TaskStatuses) {
+		return m, nil
+	}
+	m.isLoading = true
+	m.err = nil
+	return m, m.updateTaskStatusCmd(task.ID, models.TaskStatuses[target])
+}
+
+// viewBoard renderiza o quadro Kanban com uma coluna por status.
+func (m *Model) viewBoard() string {
+	if m.isLoading && m.allTasks == nil {
+		return "Carregando tarefas..."
+	}
+
+	columnCount := len(models.TaskStatuses)
+	columnWidth := 22
+	if m.width > 0 {
+		columnWidth = (m.width - columnCount*boardColumnStyle.GetHorizontalFrameSize()) / columnCount
+		if columnWidth < 14 {
+			columnWidth = 14
+		}
+	}
+
+	rendered := make([]string, 0, columnCount)
+	for c, column := range m.boardColumns() {
+		var b strings.Builder
+		header := fmt.Sprintf("%s (%d)", boardColumnTitles[models.TaskStatuses[c]], len(column))
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render(header) + "\n")
+		if len(column) == 0 {
+			b.WriteString(lipgloss.NewStyle().Faint(true).Render("—"))
+		}
+		for r, task := range column {
+			card := task.Title
+			if task.DueDate != nil {
+				card += " · " + task.DueDate.Format("02/01")
+			}
+			card = truncate(card, columnWidth)
+			if c == m.boardColumn && r == m.boardRow {
+				card = boardSelectedCardStyle.Render(card)
+			}
+			b.WriteString(card)
+			if r < len(column)-1 {
+				b.WriteString("\n")
+			}
+		}
+		style := boardColumnStyle
+		if c == m.boardColumn {
+			style = boardFocusedColumnStyle
+		}
+		rendered = append(rendered, style.Width(columnWidth).Render(b.String()))
+	}
+
+	filter := "Turma: todas"
+	if m.boardClassFilter != nil {
+		filter = fmt.Sprintf("Turma: %d", *m.boardClassFilter)
+	}
+
+	var out strings.Builder
+	out.WriteString(filter + "\n")
+	out.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, rendered...))
+	if m.statusMessage != "" {
+		out.WriteString("\n" + m.statusMessage)
+	}
+	if m.err != nil {
+		out.WriteString(fmt.Sprintf("\nErro: %v", m.err))
+	}
+	out.WriteString("\n\n  ←/→: Coluna | ↑/↓: Tarefa | </>: Mover tarefa | 'f': Filtrar turma | 'z': Adiar 1 dia\n")
+	out.WriteString("  'a': Adicionar | 'e': Editar | 'd': Excluir | 'v'|Enter: Detalhes | 'b': Ver tabelas")
+	return out.String()
+}
+
+// truncate corta 's' para caber em 'width' colunas, terminando com reticências quando necessário.
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if width <= 1 || len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
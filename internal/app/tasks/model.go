@@ -30,8 +30,16 @@ const (
 	FormView
 	DetailView
 	ConfirmDeleteView
+	OverdueTriageView // Triagem das tarefas atrasadas, uma por vez.
+	BoardView         // Quadro Kanban com colunas por status.
 )
 
+// snoozeDelay é o adiamento aplicado pela tecla 'z' (soneca) na lista e na triagem.
+const snoozeDelay = 24 * time.Hour
+
+// weekDelay é o adiamento aplicado pela tecla 's' na triagem de atrasadas.
+const weekDelay = 7 * 24 * time.Hour
+
 // FormState represents the current state of the task form (creating or editing).
 // This is used when currentView is FormView.
 type FormState int
@@ -65,6 +73,17 @@ type Model struct {
 	selectedTaskForDetail *models.Task
 	editingTaskID         int64
 	taskIDToDelete        int64
+	statusMessage         string // Mensagem de feedback da última ação (ex: tarefa adiada).
+
+	allTasks         []models.Task // Última lista carregada, usada pelo quadro Kanban.
+	preferBoard      bool          // Lembra se o usuário escolheu o quadro em vez das tabelas.
+	boardColumn      int           // Coluna (índice em models.TaskStatuses) sob o cursor do quadro.
+	boardRow         int           // Tarefa sob o cursor dentro da coluna.
+	boardClassFilter *int64        // Turma filtrada no quadro (nil = todas).
+	boardFocusTaskID int64         // Tarefa a selecionar no quadro após o próximo recarregamento.
+
+	overdueTasks []models.Task // Fila da triagem de atrasadas.
+	triageIndex  int           // Posição da tarefa atual na fila de triagem.
 	// confirmingDelete      bool         // This state is now handled by currentView = ConfirmDeleteView
 
 	width  int
@@ -93,6 +112,18 @@ type taskDeletedMsg struct{}
 type taskDeleteFailedMsg struct{ err error }
 type taskMarkedCompletedMsg struct{}
 type taskMarkCompleteFailedMsg struct{ err error }
+type taskPostponedMsg struct{ task models.Task }
+type taskPostponeFailedMsg struct{ err error }
+type overdueTasksLoadedMsg struct {
+	tasks []models.Task
+	err   error
+}
+
+// triageActionDoneMsg é enviado quando uma ação da triagem (concluir, adiar, descartar) termina.
+type triageActionDoneMsg struct {
+	result string
+	err    error
+}
 
 func (m *Model) loadTasksCmd() tea.Msg {
 	tasks, err := m.taskService.ListAllTasks(context.Background())
@@ -147,12 +178,36 @@ func (m *Model) markTaskCompleteCmd(taskID int64) tea.Cmd {
 	}
 }
 
+func (m *Model) postponeTaskCmd(taskID int64, delay time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		task, err := m.taskService.PostponeTask(context.Background(), taskID, delay)
+		if err != nil {
+			return taskPostponeFailedMsg{err}
+		}
+		return taskPostponedMsg{task: task}
+	}
+}
+
+func (m *Model) loadOverdueTasksCmd() tea.Msg {
+	tasks, err := m.taskService.ListOverdueTasks(context.Background())
+	return overdueTasksLoadedMsg{tasks: tasks, err: err}
+}
+
+// triageActionCmd executa a ação escolhida para a tarefa atual da triagem.
+// 'result' descreve a ação para a mensagem de feedback.
+func (m *Model) triageActionCmd(result string, action func(ctx context.Context) error) tea.Cmd {
+	return func() tea.Msg {
+		return triageActionDoneMsg{result: result, err: action(context.Background())}
+	}
+}
+
 func New(taskService service.TaskService) *Model {
 	pendingColumns := []table.Column{
 		{Title: "ID", Width: 4},
 		{Title: "Título", Width: 30},
 		{Title: "Prazo", Width: 10},
 		{Title: "ID Turma", Width: 8},
+		{Title: "Adiada", Width: 6},
 	}
 	pendingTable := table.New(
 		table.WithColumns(pendingColumns),
@@ -176,6 +231,7 @@ func New(taskService service.TaskService) *Model {
 		{Title: "Título", Width: 30},
 		{Title: "Prazo", Width: 10},
 		{Title: "ID Turma", Width: 8},
+		{Title: "Adiada", Width: 6},
 	}
 	completedTable := table.New(
 		table.WithColumns(completedColumns),
@@ -230,11 +286,14 @@ func New(taskService service.TaskService) *Model {
 func (m *Model) Init() tea.Cmd {
 	m.isLoading = true
 	m.err = nil
-	m.currentView = TableView
+	m.currentView = m.listView()
 	m.focusedTable = PendingTableFocus
 	m.selectedTaskForDetail = nil
 	m.editingTaskID = 0
 	m.taskIDToDelete = 0
+	m.statusMessage = ""
+	m.overdueTasks = nil
+	m.triageIndex = 0
 	return m.loadTasksCmd
 }
 
@@ -247,6 +306,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.isLoading = false
 		m.err = msg.err
 		if msg.err == nil {
+			m.allTasks = msg.tasks
+			if m.allTasks == nil {
+				m.allTasks = []models.Task{}
+			}
 			pendingRows := []table.Row{}
 			completedRows := []table.Row{}
 			for _, task := range msg.tasks {
@@ -263,7 +326,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if task.IsCompleted {
 					titleCell = strikethroughStyle.Render(task.Title)
 				}
-				row := table.Row{fmt.Sprintf("%d", task.ID), titleCell, dueDate, classIDStr}
+				row := table.Row{fmt.Sprintf("%d", task.ID), titleCell, dueDate, classIDStr, formatPostponeCount(task.PostponeCount)}
 
 				if task.IsCompleted {
 					completedRows = append(completedRows, row)
@@ -273,6 +336,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.pendingTasksTable.SetRows(pendingRows)
 			m.completedTasksTable.SetRows(completedRows)
+			if m.boardFocusTaskID != 0 {
+				m.focusBoardTask(m.boardFocusTaskID)
+				m.boardFocusTaskID = 0
+			} else {
+				m.clampBoardCursor()
+			}
 		} else {
 			m.pendingTasksTable.SetRows([]table.Row{})
 			m.completedTasksTable.SetRows([]table.Row{})
@@ -280,7 +349,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case taskCreatedMsg:
-		m.currentView = TableView
+		m.currentView = m.listView()
 		m.resetFormInputs()
 		m.err = nil
 		return m, m.loadTasksCmd
@@ -290,7 +359,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case taskUpdatedMsg:
-		m.currentView = TableView
+		m.currentView = m.listView()
 		m.resetFormInputs()
 		m.editingTaskID = 0
 		m.err = nil
@@ -302,7 +371,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case taskDeletedMsg:
 		m.isLoading = false
-		m.currentView = TableView
+		m.currentView = m.listView()
 		m.taskIDToDelete = 0
 		m.err = nil
 		return m, m.loadTasksCmd
@@ -322,6 +391,49 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg.err
 		return m, nil
 
+	case taskPostponedMsg:
+		m.isLoading = false
+		m.err = nil
+		if msg.task.DueDate != nil {
+			m.statusMessage = fmt.Sprintf("Tarefa ID %d adiada para %s.", msg.task.ID, msg.task.DueDate.Format("02/01/2006"))
+		}
+		return m, m.loadTasksCmd
+
+	case taskStatusUpdatedMsg:
+		m.isLoading = false
+		m.err = nil
+		m.boardFocusTaskID = msg.taskID
+		m.statusMessage = fmt.Sprintf("Tarefa ID %d movida para '%s'.", msg.taskID, boardColumnTitles[msg.status])
+		return m, m.loadTasksCmd
+
+	case taskStatusUpdateFailedMsg:
+		m.isLoading = false
+		m.err = msg.err
+		return m, nil
+
+	case taskPostponeFailedMsg:
+		m.isLoading = false
+		m.err = msg.err
+		return m, nil
+
+	case overdueTasksLoadedMsg:
+		m.isLoading = false
+		m.err = msg.err
+		m.overdueTasks = msg.tasks
+		m.triageIndex = 0
+		return m, nil
+
+	case triageActionDoneMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.statusMessage = msg.result
+		m.triageIndex++
+		return m, nil
+
 	case tea.KeyMsg:
 		switch m.currentView {
 		case ConfirmDeleteView:
@@ -332,7 +444,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, m.deleteTaskCmd(m.taskIDToDelete))
 				return m, tea.Batch(cmds...)
 			case "n", "N", "esc":
-				m.currentView = TableView
+				m.currentView = m.listView()
 				m.taskIDToDelete = 0
 				m.err = nil
 				return m, nil
@@ -340,7 +452,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case FormView:
 			switch msg.String() {
 			case "ctrl+c", "esc":
-				m.currentView = TableView
+				m.currentView = m.listView()
 				m.resetFormInputs()
 				m.editingTaskID = 0
 				m.err = nil
@@ -392,6 +504,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							ClassID:     classID,
 							DueDate:     dueDate,
 							IsCompleted: m.selectedTaskForDetail.IsCompleted,
+							PostponeCount: m.selectedTaskForDetail.PostponeCount,
+							Status:        m.selectedTaskForDetail.Status,
 						}
 						submitCmd = m.updateTaskCmd(updatedTask)
 					}
@@ -417,10 +531,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, tmpCmds...)
 			return m, tea.Batch(cmds...)
 
+		case OverdueTriageView:
+			return m.updateTriage(msg)
+		case BoardView:
+			return m.updateBoard(msg)
 		case DetailView:
 			switch msg.String() {
 			case "ctrl+c", "esc", "q":
-				m.currentView = TableView
+				m.currentView = m.listView()
 				m.selectedTaskForDetail = nil
 				m.err = nil
 				return m, nil
@@ -431,6 +549,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				activeTable = &m.completedTasksTable
 			}
 
+			m.statusMessage = ""
 			switch msg.String() {
 			case "a":
 				m.currentView = FormView
@@ -463,6 +582,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						cmds = append(cmds, m.markTaskCompleteCmd(taskID))
 					}
 				}
+			case "z":
+				if m.focusedTable == PendingTableFocus && len(m.pendingTasksTable.Rows()) > 0 && m.pendingTasksTable.Cursor() < len(m.pendingTasksTable.Rows()) {
+					selectedRow := m.pendingTasksTable.SelectedRow()
+					taskID, errConv := strconv.ParseInt(selectedRow[0], 10, 64)
+					if errConv != nil {
+						m.err = fmt.Errorf("erro ao parsear ID da tarefa para adiar: %v", errConv)
+					} else {
+						m.isLoading = true
+						m.err = nil
+						cmds = append(cmds, m.postponeTaskCmd(taskID, snoozeDelay))
+					}
+				}
+			case "b":
+				m.preferBoard = true
+				m.currentView = BoardView
+				m.clampBoardCursor()
+				return m, nil
+			case "o":
+				m.currentView = OverdueTriageView
+				m.isLoading = true
+				m.err = nil
+				m.overdueTasks = nil
+				m.triageIndex = 0
+				return m, m.loadOverdueTasksCmd
 			case "d":
 				if len(activeTable.Rows()) > 0 && activeTable.Cursor() >= 0 && activeTable.Cursor() < len(activeTable.Rows()) {
 					selectedRow := activeTable.SelectedRow()
@@ -583,11 +726,15 @@ func (m *Model) View() string {
 		return fmt.Sprintf("Tem certeza que deseja excluir a tarefa ID %d? (s/n)", m.taskIDToDelete)
 	}
 
-	if m.err != nil && m.currentView != FormView && m.currentView != DetailView {
+	if m.err != nil && m.currentView != FormView && m.currentView != DetailView && m.currentView != OverdueTriageView && m.currentView != BoardView {
 		return fmt.Sprintf("Erro: %v\n\nPressione 'a' para adicionar, 'e' para editar, 'd' para excluir, 'v' para ver detalhes, 'esc' para sair desta tela.", m.err)
 	}
 
 	switch m.currentView {
+	case OverdueTriageView:
+		return m.viewTriage()
+	case BoardView:
+		return m.viewBoard()
 	case FormView:
 		return m.viewForm()
 	case DetailView:
@@ -619,8 +766,12 @@ func (m *Model) View() string {
 		)
 
 		var help strings.Builder
+		if m.statusMessage != "" {
+			help.WriteString("\n" + m.statusMessage)
+		}
 		help.WriteString("\n\n")
 		help.WriteString("  'a': Adicionar | 'e': Editar (pendentes) | 'd': Excluir | 'c': Concluir (pendentes)\n")
+		help.WriteString("  'z': Adiar 1 dia (pendentes) | 'o': Triagem de Atrasadas | 'b': Quadro Kanban\n")
 		help.WriteString("  'v'|Enter: Detalhes | Tab: Mudar Tabela Focada")
 		return tablesView + help.String()
 	}
@@ -708,6 +859,9 @@ func (m *Model) viewTaskDetail() string {
 	finalView += fmt.Sprintf("Prazo: %s\n", dueDateStr)
 	finalView += fmt.Sprintf("ID Turma: %s\n", classIDStr)
 	finalView += fmt.Sprintf("Status: %s\n", statusStr)
+	if task.PostponeCount > 0 {
+		finalView += fmt.Sprintf("Adiada: %d vez(es)\n", task.PostponeCount)
+	}
 	finalView += fmt.Sprintf("%s\n\nPressione Esc para voltar à lista.", strings.Repeat("-", 30))
 
 	return detailStyle.Render(finalView)
@@ -777,9 +931,120 @@ func (m *Model) SetSize(width, height int) {
 	}
 }
 
+// updateTriage trata as teclas da triagem de atrasadas. Cada ação avança para a próxima tarefa.
+func (m *Model) updateTriage(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" || msg.String() == "q" {
+		m.currentView = m.listView()
+		m.overdueTasks = nil
+		m.triageIndex = 0
+		m.err = nil
+		m.isLoading = true
+		return m, m.loadTasksCmd
+	}
+	if m.isLoading || m.triageIndex >= len(m.overdueTasks) {
+		return m, nil
+	}
+
+	task := m.overdueTasks[m.triageIndex]
+	switch msg.String() {
+	case "c":
+		m.isLoading = true
+		return m, m.triageActionCmd(fmt.Sprintf("'%s' concluída.", task.Title), func(ctx context.Context) error {
+			return m.taskService.MarkTaskAsCompleted(ctx, task.ID)
+		})
+	case "z":
+		m.isLoading = true
+		return m, m.triageActionCmd(fmt.Sprintf("'%s' adiada por 1 dia.", task.Title), func(ctx context.Context) error {
+			_, err := m.taskService.PostponeTask(ctx, task.ID, snoozeDelay)
+			return err
+		})
+	case "s":
+		m.isLoading = true
+		return m, m.triageActionCmd(fmt.Sprintf("'%s' adiada por 1 semana.", task.Title), func(ctx context.Context) error {
+			_, err := m.taskService.PostponeTask(ctx, task.ID, weekDelay)
+			return err
+		})
+	case "x":
+		m.isLoading = true
+		return m, m.triageActionCmd(fmt.Sprintf("'%s' descartada.", task.Title), func(ctx context.Context) error {
+			return m.taskService.DeleteTask(ctx, task.ID)
+		})
+	case "n", "right", "l":
+		m.statusMessage = fmt.Sprintf("'%s' mantida como está.", task.Title)
+		m.err = nil
+		m.triageIndex++
+	}
+	return m, nil
+}
+
+// viewTriage renderiza a triagem de atrasadas, mostrando uma tarefa por vez.
+func (m *Model) viewTriage() string {
+	if m.isLoading && m.overdueTasks == nil {
+		return "Carregando tarefas atrasadas..."
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Atrasadas") + "\n\n")
+
+	if m.triageIndex >= len(m.overdueTasks) {
+		if len(m.overdueTasks) == 0 {
+			b.WriteString("Nenhuma tarefa atrasada. Bom trabalho!\n")
+		} else {
+			b.WriteString(fmt.Sprintf("Triagem concluída: %d tarefa(s) revisada(s).\n", len(m.overdueTasks)))
+		}
+		if m.statusMessage != "" {
+			b.WriteString("\n" + m.statusMessage + "\n")
+		}
+		b.WriteString("\nPressione Esc para voltar à lista.")
+		return baseStyle.Render(b.String())
+	}
+
+	task := m.overdueTasks[m.triageIndex]
+	b.WriteString(fmt.Sprintf("Tarefa %d de %d\n\n", m.triageIndex+1, len(m.overdueTasks)))
+	b.WriteString(fmt.Sprintf("Título: %s\n", task.Title))
+	if task.Description != "" {
+		b.WriteString(fmt.Sprintf("Descrição: %s\n", task.Description))
+	}
+	if task.DueDate != nil {
+		daysLate := int(time.Since(*task.DueDate).Hours() / 24)
+		b.WriteString(fmt.Sprintf("Prazo: %s (%d dia(s) de atraso)\n", task.DueDate.Format("02/01/2006"), daysLate))
+	}
+	if task.ClassID != nil && *task.ClassID != 0 {
+		b.WriteString(fmt.Sprintf("ID Turma: %d\n", *task.ClassID))
+	}
+	if task.PostponeCount > 0 {
+		b.WriteString(fmt.Sprintf("Já adiada %d vez(es)\n", task.PostponeCount))
+	}
+	if m.statusMessage != "" {
+		b.WriteString("\n" + m.statusMessage + "\n")
+	}
+	if m.err != nil {
+		b.WriteString(fmt.Sprintf("\nErro: %v\n", m.err))
+	}
+	b.WriteString("\n'c': Concluir | 'z': Adiar 1 dia | 's': Adiar 1 semana | 'x': Descartar | 'n': Próxima | Esc: Voltar")
+	return baseStyle.Render(b.String())
+}
+
+// formatPostponeCount formata o contador de adiamentos para a coluna "Adiada" (vazio se nunca adiada).
+func formatPostponeCount(count int) string {
+	if count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dx", count)
+}
+
+// listView retorna a visão de lista preferida pelo usuário (quadro ou tabelas),
+// usada ao voltar de formulários, detalhes e da triagem.
+func (m *Model) listView() ViewState {
+	if m.preferBoard {
+		return BoardView
+	}
+	return TableView
+}
+
 // CanGoBack returns true if the model is in a state where 'esc' should return to the main menu.
 func (m *Model) CanGoBack() bool {
-	return m.currentView == TableView
+	return m.currentView == TableView || m.currentView == BoardView
 }
 
 // IsLoading returns true if the model is currently loading data.
@@ -85,6 +85,32 @@ func (m *MockTaskService) DeleteTask(ctx context.Context, taskID int64) error {
 	return args.Error(0)
 }
 
+func (m *MockTaskService) PostponeTask(ctx context.Context, taskID int64, delay time.Duration) (models.Task, error) {
+	args := m.Called(ctx, taskID, delay)
+	if task, ok := args.Get(0).(models.Task); ok {
+		return task, args.Error(1)
+	}
+	return models.Task{}, args.Error(1)
+}
+
+func (m *MockTaskService) ListOverdueTasks(ctx context.Context) ([]models.Task, error) {
+	args := m.Called(ctx)
+	if tasks, ok := args.Get(0).([]models.Task); ok {
+		return tasks, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTaskService) UpdateTaskStatus(ctx context.Context, taskID int64, status string) error {
+	args := m.Called(ctx, taskID, status)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) SetTaskPlanning(ctx context.Context, taskID int64, estimatedMinutes *int, priority int) (models.Task, error) {
+	args := m.Called(ctx, taskID, estimatedMinutes, priority)
+	return args.Get(0).(models.Task), args.Error(1)
+}
+
 var _ service.TaskService = (*MockTaskService)(nil)
 
 func TestTasksModel_Init(t *testing.T) {
@@ -432,3 +458,125 @@ func TestTasksModel_DeleteTask_ConfirmNo(t *testing.T) {
 	assert.Nil(t, cmd)
 	mockService.AssertNotCalled(t, "DeleteTask", mock.Anything, mock.Anything)
 }
+
+func TestTasksModel_SnoozeKey_PostponesSelectedTask(t *testing.T) {
+	mockService := new(MockTaskService)
+	due := time.Date(2025, 6, 23, 0, 0, 0, 0, time.UTC)
+	pendingTask := models.Task{ID: 4, Title: "Corrigir provas", DueDate: &due, UserID: 1}
+
+	mockService.On("ListAllTasks", mock.Anything).Return([]models.Task{pendingTask}, nil).Once()
+	model := New(mockService)
+	model.SetSize(80, 24)
+	model.Update(model.Init()())
+	model.pendingTasksTable.SetCursor(0)
+
+	newDue := due.Add(24 * time.Hour)
+	postponed := pendingTask
+	postponed.DueDate = &newDue
+	postponed.PostponeCount = 1
+	mockService.On("PostponeTask", mock.Anything, pendingTask.ID, 24*time.Hour).Return(postponed, nil).Once()
+	mockService.On("ListAllTasks", mock.Anything).Return([]models.Task{postponed}, nil).Once()
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	assert.NotNil(t, cmd)
+	msg := cmd()
+	assert.IsType(t, taskPostponedMsg{}, msg)
+
+	_, refreshCmd := model.Update(msg)
+	assert.Contains(t, model.statusMessage, "24/06/2025")
+	model.Update(refreshCmd())
+
+	assert.Len(t, model.pendingTasksTable.Rows(), 1)
+	assert.Equal(t, "1x", model.pendingTasksTable.Rows()[0][4])
+	mockService.AssertExpectations(t)
+}
+
+func TestTasksModel_OverdueTriage_ActionsAdvanceQueue(t *testing.T) {
+	mockService := new(MockTaskService)
+	model := New(mockService)
+	model.SetSize(80, 24)
+
+	due := time.Now().Add(-72 * time.Hour)
+	overdue := []models.Task{
+		{ID: 1, Title: "Lançar notas", DueDate: &due},
+		{ID: 2, Title: "Devolver trabalhos", DueDate: &due},
+		{ID: 3, Title: "Ligar para a coordenação", DueDate: &due},
+	}
+	mockService.On("ListOverdueTasks", mock.Anything).Return(overdue, nil).Once()
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	assert.Equal(t, OverdueTriageView, model.currentView)
+	model.Update(cmd())
+	assert.Contains(t, model.View(), "Tarefa 1 de 3")
+
+	mockService.On("MarkTaskAsCompleted", mock.Anything, int64(1)).Return(nil).Once()
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	model.Update(cmd())
+	assert.Equal(t, 1, model.triageIndex)
+
+	mockService.On("PostponeTask", mock.Anything, int64(2), 7*24*time.Hour).Return(overdue[1], nil).Once()
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	model.Update(cmd())
+	assert.Equal(t, 2, model.triageIndex)
+
+	mockService.On("DeleteTask", mock.Anything, int64(3)).Return(nil).Once()
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	model.Update(cmd())
+	assert.Contains(t, model.View(), "Triagem concluída")
+
+	mockService.On("ListAllTasks", mock.Anything).Return([]models.Task{}, nil).Once()
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, TableView, model.currentView)
+	model.Update(cmd())
+	mockService.AssertExpectations(t)
+}
+
+func TestTasksModel_Board_MoveTaskBetweenColumnsAndFilter(t *testing.T) {
+	mockService := new(MockTaskService)
+	class1, class2 := int64(1), int64(2)
+	tasksList := []models.Task{
+		{ID: 1, UserID: 1, Title: "Preparar slides", ClassID: &class1, Status: models.TaskStatusTodo},
+		{ID: 2, UserID: 1, Title: "Corrigir redações", ClassID: &class2, Status: models.TaskStatusDoing},
+		{ID: 3, UserID: 1, Title: "Entregar diário", ClassID: &class1, IsCompleted: true, Status: models.TaskStatusDone},
+	}
+	mockService.On("ListAllTasks", mock.Anything).Return(tasksList, nil).Once()
+	model := New(mockService)
+	model.SetSize(120, 30)
+	model.Update(model.Init()())
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	assert.Equal(t, BoardView, model.currentView)
+	assert.True(t, model.CanGoBack())
+	columns := model.boardColumns()
+	assert.Len(t, columns[0], 1)
+	assert.Len(t, columns[1], 1)
+	assert.Len(t, columns[3], 1)
+
+	moved := tasksList[0]
+	moved.Status = models.TaskStatusDoing
+	mockService.On("UpdateTaskStatus", mock.Anything, int64(1), models.TaskStatusDoing).Return(nil).Once()
+	mockService.On("ListAllTasks", mock.Anything).Return([]models.Task{moved, tasksList[1], tasksList[2]}, nil).Once()
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	msg := cmd()
+	assert.IsType(t, taskStatusUpdatedMsg{}, msg)
+	_, reload := model.Update(msg)
+	model.Update(reload())
+
+	assert.Equal(t, 1, model.boardColumn, "cursor should follow the moved task")
+	selected, ok := model.selectedBoardTask()
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), selected.ID)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	assert.NotNil(t, model.boardClassFilter)
+	assert.Equal(t, class1, *model.boardClassFilter)
+	assert.Len(t, model.boardColumns()[1], 1, "only class 1 tasks should remain in 'Fazendo'")
+	assert.Contains(t, model.View(), "Turma: 1")
+
+	// Reentrar no módulo mantém o quadro como visão escolhida.
+	mockService.On("ListAllTasks", mock.Anything).Return(tasksList, nil).Once()
+	model.Update(model.Init()())
+	assert.Equal(t, BoardView, model.currentView)
+	mockService.AssertExpectations(t)
+}
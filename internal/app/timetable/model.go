@@ -0,0 +1,595 @@
+// Package timetable implementa a tela "Horário Semanal" da TUI: mostra os horários de aula das
+// turmas por dia da semana, permite incluir, alterar e remover horários e gerar as aulas de um
+// período com uma simulação antes de gravar.
+package timetable
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// ViewState define o estado atual da tela de horário.
+type ViewState int
+
+const (
+	ListView          ViewState = iota // Horários agrupados por dia da semana.
+	FormView                           // Inclusão ou alteração de um horário.
+	DeleteConfirmView                  // Confirmação de remoção.
+	GenerateView                       // Período para gerar aulas e resultado da simulação.
+)
+
+// Campos do formulário de horário, na ordem de navegação com tab.
+const (
+	classField = iota
+	weekdayField
+	startField
+	durationField
+	slotFieldCount
+)
+
+// dateLayout é o formato dos campos de data da geração de aulas.
+const dateLayout = "2006-01-02"
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).MarginBottom(1)
+	dayStyle      = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("208")).MarginTop(1)
+	itemStyle     = lipgloss.NewStyle().PaddingLeft(2)
+	selectedStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	labelStyle    = lipgloss.NewStyle().Bold(true)
+	focusedStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	warningStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	faintStyle    = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	helpStyle     = lipgloss.NewStyle().Faint(true).MarginTop(1)
+)
+
+// Model é o modelo BubbleTea da tela de horário semanal.
+type Model struct {
+	timetableService service.TimetableService
+	classService     service.ClassService
+	state            ViewState
+
+	slots      []models.TimetableSlot
+	overlapped map[int64]bool // IDs dos horários que se sobrepõem a outro.
+	classes    []models.Class
+	cursor     int
+
+	editing       *models.TimetableSlot // Horário em alteração; nil ao incluir.
+	formClass     int                   // Índice em 'classes' da turma escolhida no formulário.
+	formWeekday   time.Weekday
+	focus         int
+	startInput    textinput.Model
+	durationInput textinput.Model
+
+	fromInput textinput.Model
+	toInput   textinput.Model
+	preview   *service.LessonGenerationResult // Resultado da simulação, aguardando confirmação.
+
+	isLoading     bool
+	backRequested bool // 'esc' na lista: o app.Model deve voltar ao menu principal.
+	err           error
+	statusMessage string
+
+	width  int
+	height int
+}
+
+// --- Mensagens ---
+
+type dataLoadedMsg struct {
+	slots    []models.TimetableSlot
+	overlaps []service.SlotOverlap
+	classes  []models.Class
+	err      error
+}
+
+type slotSavedMsg struct {
+	slot models.TimetableSlot
+	err  error
+}
+
+type slotDeletedMsg struct {
+	slotID int64
+	err    error
+}
+
+type lessonsGeneratedMsg struct {
+	result service.LessonGenerationResult
+	dryRun bool
+	err    error
+}
+
+// New cria o modelo da tela de horário semanal.
+func New(timetableService service.TimetableService, classService service.ClassService) *Model {
+	newInput := func(placeholder string, limit int) textinput.Model {
+		input := textinput.New()
+		input.Placeholder = placeholder
+		input.CharLimit = limit
+		input.Width = 12
+		return input
+	}
+	return &Model{
+		timetableService: timetableService,
+		classService:     classService,
+		overlapped:       make(map[int64]bool),
+		startInput:       newInput("HH:MM", 5),
+		durationInput:    newInput("minutos", 4),
+		fromInput:        newInput("AAAA-MM-DD", 10),
+		toInput:          newInput("AAAA-MM-DD", 10),
+	}
+}
+
+// --- Comandos ---
+
+func (m *Model) loadDataCmd() tea.Msg {
+	ctx := context.Background()
+	slots, err := m.timetableService.ListSlots(ctx)
+	if err != nil {
+		return dataLoadedMsg{err: err}
+	}
+	overlaps, err := m.timetableService.FindOverlaps(ctx)
+	if err != nil {
+		return dataLoadedMsg{err: err}
+	}
+	classes, err := m.classService.ListAllClasses(ctx)
+	return dataLoadedMsg{slots: slots, overlaps: overlaps, classes: classes, err: err}
+}
+
+func (m *Model) saveSlotCmd(editing *models.TimetableSlot, classID int64, weekday time.Weekday, start string, duration int) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if editing == nil {
+			slot, err := m.timetableService.AddSlot(ctx, classID, weekday, start, duration)
+			return slotSavedMsg{slot: slot, err: err}
+		}
+		slot, err := m.timetableService.UpdateSlot(ctx, editing.ID, weekday, start, duration)
+		return slotSavedMsg{slot: slot, err: err}
+	}
+}
+
+func (m *Model) deleteSlotCmd(slotID int64) tea.Cmd {
+	return func() tea.Msg {
+		return slotDeletedMsg{slotID: slotID, err: m.timetableService.DeleteSlot(context.Background(), slotID)}
+	}
+}
+
+func (m *Model) generateCmd(from, to time.Time, dryRun bool) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.timetableService.GenerateLessons(context.Background(), service.GenerateLessonsOptions{From: from, To: to, DryRun: dryRun})
+		return lessonsGeneratedMsg{result: result, dryRun: dryRun, err: err}
+	}
+}
+
+// Init volta para a lista e recarrega horários e turmas.
+func (m *Model) Init() tea.Cmd {
+	m.state = ListView
+	m.isLoading = true
+	m.backRequested = false
+	m.err = nil
+	m.statusMessage = ""
+	return m.loadDataCmd
+}
+
+// reload volta para a lista e recarrega os dados, mantendo 'status' como mensagem.
+func (m *Model) reload(status string) tea.Cmd {
+	cmd := m.Init()
+	m.statusMessage = status
+	return cmd
+}
+
+// Update processa teclas e o resultado dos comandos assíncronos.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case dataLoadedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.slots = msg.slots
+		m.classes = msg.classes
+		m.overlapped = make(map[int64]bool)
+		for _, overlap := range msg.overlaps {
+			m.overlapped[overlap.First.ID] = true
+			m.overlapped[overlap.Second.ID] = true
+		}
+		if m.cursor >= len(m.slots) {
+			m.cursor = max(len(m.slots)-1, 0)
+		}
+		return m, nil
+
+	case slotSavedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m, m.reload(fmt.Sprintf("Horário de %s %s salvo.", service.WeekdayName(msg.slot.Weekday), msg.slot.StartTime))
+
+	case slotDeletedMsg:
+		if msg.err != nil {
+			m.isLoading = false
+			m.state = ListView
+			m.err = msg.err
+			return m, nil
+		}
+		return m, m.reload(fmt.Sprintf("Horário ID %d removido.", msg.slotID))
+
+	case lessonsGeneratedMsg:
+		m.isLoading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if msg.dryRun {
+			m.preview = &msg.result
+			return m, nil
+		}
+		m.preview = nil
+		m.state = ListView
+		m.statusMessage = fmt.Sprintf("%d aulas criadas, %d horários pulados.", len(msg.result.Created), len(msg.result.Skipped))
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.isLoading {
+			return m, nil
+		}
+		switch m.state {
+		case FormView:
+			return m.updateFormView(msg)
+		case DeleteConfirmView:
+			return m.updateDeleteConfirmView(msg)
+		case GenerateView:
+			return m.updateGenerateView(msg)
+		default:
+			return m.updateListView(msg)
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		m.backRequested = true
+	case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+		if m.cursor < len(m.slots)-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("n"))):
+		if len(m.classes) == 0 {
+			m.err = fmt.Errorf("cadastre uma turma antes de montar o horário")
+			return m, nil
+		}
+		return m, m.openForm(nil)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("e", "enter"))):
+		if m.cursor < len(m.slots) {
+			return m, m.openForm(&m.slots[m.cursor])
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("d"))):
+		if m.cursor < len(m.slots) {
+			m.state = DeleteConfirmView
+		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("g"))):
+		return m, m.openGenerate()
+	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		return m, m.Init()
+	}
+	return m, nil
+}
+
+func (m *Model) updateFormView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		m.state = ListView
+		m.err = nil
+		return m, nil
+	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+s"))),
+		key.Matches(msg, key.NewBinding(key.WithKeys("enter"))) && m.focus == durationField:
+		return m, m.submitForm()
+	case key.Matches(msg, key.NewBinding(key.WithKeys("tab", "down", "enter"))):
+		return m, m.setFocus((m.focus + 1) % slotFieldCount)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("shift+tab", "up"))):
+		return m, m.setFocus((m.focus + slotFieldCount - 1) % slotFieldCount)
+	case m.focus == classField || m.focus == weekdayField:
+		delta := 0
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h"))):
+			delta = -1
+		case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l"))):
+			delta = 1
+		}
+		if m.focus == weekdayField {
+			m.formWeekday = time.Weekday((int(m.formWeekday) + 7 + delta) % 7)
+		} else if m.editing == nil && len(m.classes) > 0 {
+			// A turma de um horário existente não muda; remova e inclua outro.
+			m.formClass = (m.formClass + len(m.classes) + delta) % len(m.classes)
+		}
+		return m, nil
+	}
+	var cmd tea.Cmd
+	if m.focus == startField {
+		m.startInput, cmd = m.startInput.Update(msg)
+	} else {
+		m.durationInput, cmd = m.durationInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *Model) updateDeleteConfirmView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("s", "y"))):
+		m.isLoading = true
+		return m, m.deleteSlotCmd(m.slots[m.cursor].ID)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("n", "esc"))):
+		m.state = ListView
+	}
+	return m, nil
+}
+
+func (m *Model) updateGenerateView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		if m.preview != nil {
+			m.preview = nil // Volta à edição do período.
+			return m, nil
+		}
+		m.state = ListView
+		m.err = nil
+		return m, nil
+	case m.preview != nil:
+		if key.Matches(msg, key.NewBinding(key.WithKeys("c"))) {
+			from, to, _ := m.generationPeriod()
+			m.isLoading = true
+			return m, m.generateCmd(from, to, false)
+		}
+		return m, nil
+	case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+		from, to, err := m.generationPeriod()
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.err = nil
+		m.isLoading = true
+		return m, m.generateCmd(from, to, true)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("tab", "shift+tab", "up", "down"))):
+		if m.fromInput.Focused() {
+			m.fromInput.Blur()
+			return m, m.toInput.Focus()
+		}
+		m.toInput.Blur()
+		return m, m.fromInput.Focus()
+	}
+	var cmd tea.Cmd
+	if m.fromInput.Focused() {
+		m.fromInput, cmd = m.fromInput.Update(msg)
+	} else {
+		m.toInput, cmd = m.toInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// openForm prepara o formulário para incluir (slot == nil) ou alterar um horário.
+func (m *Model) openForm(slot *models.TimetableSlot) tea.Cmd {
+	m.state = FormView
+	m.err = nil
+	m.statusMessage = ""
+	m.editing = nil
+	if slot == nil {
+		m.formWeekday = time.Monday
+		m.startInput.SetValue("07:00")
+		m.durationInput.SetValue(strconv.Itoa(int(service.DefaultLessonDuration / time.Minute)))
+		return m.setFocus(classField)
+	}
+	editing := *slot
+	m.editing = &editing
+	m.formWeekday = slot.Weekday
+	m.startInput.SetValue(slot.StartTime)
+	m.durationInput.SetValue(strconv.Itoa(slot.DurationMinutes))
+	for i, class := range m.classes {
+		if class.ID == slot.ClassID {
+			m.formClass = i
+		}
+	}
+	return m.setFocus(weekdayField)
+}
+
+// openGenerate prepara o período da geração: de hoje até o fim do mês seguinte.
+func (m *Model) openGenerate() tea.Cmd {
+	m.state = GenerateView
+	m.err = nil
+	m.statusMessage = ""
+	m.preview = nil
+	today := time.Now()
+	endOfNextMonth := time.Date(today.Year(), today.Month()+2, 0, 0, 0, 0, 0, time.Local)
+	m.fromInput.SetValue(today.Format(dateLayout))
+	m.toInput.SetValue(endOfNextMonth.Format(dateLayout))
+	m.toInput.Blur()
+	return m.fromInput.Focus()
+}
+
+func (m *Model) generationPeriod() (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation(dateLayout, strings.TrimSpace(m.fromInput.Value()), time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("data inicial inválida '%s': use AAAA-MM-DD", m.fromInput.Value())
+	}
+	to, err := time.ParseInLocation(dateLayout, strings.TrimSpace(m.toInput.Value()), time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("data final inválida '%s': use AAAA-MM-DD", m.toInput.Value())
+	}
+	return from, to, nil
+}
+
+// submitForm valida a duração e dispara a gravação; o restante é validado pelo serviço.
+func (m *Model) submitForm() tea.Cmd {
+	duration, err := strconv.Atoi(strings.TrimSpace(m.durationInput.Value()))
+	if err != nil || duration <= 0 {
+		m.err = fmt.Errorf("duração inválida '%s': informe os minutos", m.durationInput.Value())
+		return nil
+	}
+	if m.formClass >= len(m.classes) {
+		m.err = fmt.Errorf("escolha uma turma para o horário")
+		return nil
+	}
+	m.err = nil
+	return m.saveSlotCmd(m.editing, m.classes[m.formClass].ID, m.formWeekday, m.startInput.Value(), duration)
+}
+
+func (m *Model) setFocus(field int) tea.Cmd {
+	m.focus = field
+	m.startInput.Blur()
+	m.durationInput.Blur()
+	switch field {
+	case startField:
+		return m.startInput.Focus()
+	case durationField:
+		return m.durationInput.Focus()
+	}
+	return nil
+}
+
+func (m *Model) className(classID int64) string {
+	for _, class := range m.classes {
+		if class.ID == classID {
+			return class.Name
+		}
+	}
+	return fmt.Sprintf("Turma ID %d", classID)
+}
+
+// CanGoBack informa ao app.Model que 'esc' foi pressionado na lista e a tela pode voltar ao menu.
+func (m *Model) CanGoBack() bool {
+	back := m.backRequested
+	m.backRequested = false
+	return back
+}
+
+// View renderiza a tela de acordo com o estado atual.
+func (m *Model) View() string {
+	if m.isLoading {
+		return "Carregando horário..."
+	}
+	var b strings.Builder
+	switch m.state {
+	case FormView:
+		m.viewForm(&b)
+	case DeleteConfirmView:
+		slot := m.slots[m.cursor]
+		b.WriteString(titleStyle.Render("Remover Horário") + "\n")
+		b.WriteString(fmt.Sprintf("Remover o horário de %s %s–%s (%s)? As aulas já geradas são mantidas.\n",
+			service.WeekdayName(slot.Weekday), slot.StartTime, slotEnd(slot), m.className(slot.ClassID)))
+		b.WriteString(helpStyle.Render("s: remover • n/esc: cancelar") + "\n")
+	case GenerateView:
+		m.viewGenerate(&b)
+	default:
+		m.viewList(&b)
+	}
+	if m.err != nil {
+		b.WriteString("\n" + errorStyle.Render("Erro: "+m.err.Error()) + "\n")
+	}
+	if m.statusMessage != "" {
+		b.WriteString("\n" + faintStyle.Render(m.statusMessage) + "\n")
+	}
+	return b.String()
+}
+
+func (m *Model) viewList(b *strings.Builder) {
+	b.WriteString(titleStyle.Render("Horário Semanal") + "\n")
+	if len(m.slots) == 0 {
+		b.WriteString(itemStyle.Render("Nenhum horário cadastrado. Pressione 'n' para incluir.") + "\n")
+	}
+	lastDay := time.Weekday(-1)
+	for i, slot := range m.slots {
+		if slot.Weekday != lastDay {
+			b.WriteString(dayStyle.Render(service.WeekdayName(slot.Weekday)) + "\n")
+			lastDay = slot.Weekday
+		}
+		line := fmt.Sprintf("%s–%s  %-20s %d min", slot.StartTime, slotEnd(slot), m.className(slot.ClassID), slot.DurationMinutes)
+		if m.overlapped[slot.ID] {
+			line += "  " + warningStyle.Render("⚠ sobreposto")
+		}
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render(line) + "\n")
+		} else {
+			b.WriteString(itemStyle.Render(line) + "\n")
+		}
+	}
+	b.WriteString(helpStyle.Render("n: incluir • e/enter: alterar • d: remover • g: gerar aulas • r: recarregar • esc: voltar") + "\n")
+}
+
+func (m *Model) viewForm(b *strings.Builder) {
+	if m.editing == nil {
+		b.WriteString(titleStyle.Render("Novo Horário") + "\n")
+	} else {
+		b.WriteString(titleStyle.Render(fmt.Sprintf("Alterar Horário (ID %d)", m.editing.ID)) + "\n")
+	}
+	label := func(field int, text string) string {
+		if m.focus == field {
+			return focusedStyle.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+	className := "-"
+	if m.formClass < len(m.classes) {
+		className = m.classes[m.formClass].Name
+	}
+	if m.editing == nil {
+		className = "‹ " + className + " ›"
+	}
+	b.WriteString(label(classField, "Turma:   ") + " " + className + "\n")
+	b.WriteString(label(weekdayField, "Dia:     ") + " ‹ " + service.WeekdayName(m.formWeekday) + " ›\n")
+	b.WriteString(label(startField, "Início:  ") + " " + m.startInput.View() + "\n")
+	b.WriteString(label(durationField, "Duração: ") + " " + m.durationInput.View() + "\n")
+	b.WriteString(helpStyle.Render("tab/↑/↓: campo • ←/→: turma e dia • ctrl+s: salvar • esc: cancelar") + "\n")
+}
+
+func (m *Model) viewGenerate(b *strings.Builder) {
+	b.WriteString(titleStyle.Render("Gerar Aulas do Período") + "\n")
+	b.WriteString(labelStyle.Render("De:  ") + " " + m.fromInput.View() + "\n")
+	b.WriteString(labelStyle.Render("Até: ") + " " + m.toInput.View() + "\n")
+	if m.preview == nil {
+		b.WriteString(helpStyle.Render("Feriados nacionais e horários com aula já marcada são pulados.\nenter: simular • tab: campo • esc: voltar") + "\n")
+		return
+	}
+
+	b.WriteString(dayStyle.Render(fmt.Sprintf("Simulação: %d aulas serão criadas, %d horários pulados", len(m.preview.Created), len(m.preview.Skipped))) + "\n")
+	const maxSkippedShown = 10
+	for i, skipped := range m.preview.Skipped {
+		if i == maxSkippedShown {
+			b.WriteString(itemStyle.Render(fmt.Sprintf("... e mais %d", len(m.preview.Skipped)-maxSkippedShown)) + "\n")
+			break
+		}
+		b.WriteString(itemStyle.Render(fmt.Sprintf("%s %s  %-15s %s", skipped.Start.Format("02/01"), skipped.Start.Format("15:04"),
+			m.className(skipped.Slot.ClassID), skipped.Reason)) + "\n")
+	}
+	b.WriteString(helpStyle.Render("c: confirmar e criar as aulas • esc: alterar o período") + "\n")
+}
+
+// slotEnd calcula a hora de término de um horário (HH:MM).
+func slotEnd(slot models.TimetableSlot) string {
+	start, err := time.Parse("15:04", slot.StartTime)
+	if err != nil {
+		return ""
+	}
+	return start.Add(time.Duration(slot.DurationMinutes) * time.Minute).Format("15:04")
+}
@@ -0,0 +1,116 @@
+package timetable
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+	"vigenda/internal/service"
+)
+
+// fakeTimetableService guarda os horários em memória e registra as gerações pedidas.
+type fakeTimetableService struct {
+	service.TimetableService
+	slots       []models.TimetableSlot
+	generations []service.GenerateLessonsOptions
+}
+
+func (f *fakeTimetableService) ListSlots(ctx context.Context) ([]models.TimetableSlot, error) {
+	return f.slots, nil
+}
+
+func (f *fakeTimetableService) FindOverlaps(ctx context.Context) ([]service.SlotOverlap, error) {
+	return nil, nil
+}
+
+func (f *fakeTimetableService) AddSlot(ctx context.Context, classID int64, weekday time.Weekday, startTime string, durationMinutes int) (models.TimetableSlot, error) {
+	slot := models.TimetableSlot{ID: int64(len(f.slots) + 1), ClassID: classID, Weekday: weekday, StartTime: startTime, DurationMinutes: durationMinutes}
+	f.slots = append(f.slots, slot)
+	return slot, nil
+}
+
+func (f *fakeTimetableService) GenerateLessons(ctx context.Context, opts service.GenerateLessonsOptions) (service.LessonGenerationResult, error) {
+	f.generations = append(f.generations, opts)
+	return service.LessonGenerationResult{
+		Created: []models.Lesson{{ClassID: 1}, {ClassID: 1}},
+		Skipped: []service.SkippedLesson{{Slot: f.slots[0], Start: time.Date(2025, 4, 21, 7, 0, 0, 0, time.Local), Reason: "feriado: Tiradentes"}},
+	}, nil
+}
+
+type fakeClassService struct {
+	service.ClassService
+}
+
+func (f *fakeClassService) ListAllClasses(ctx context.Context) ([]models.Class, error) {
+	return []models.Class{{ID: 1, Name: "Turma 9A"}, {ID: 2, Name: "Turma 8B"}}, nil
+}
+
+// runCmd executa 'cmd' e os comandos encadeados pelas mensagens resultantes.
+func runCmd(m *Model, cmd tea.Cmd) {
+	for cmd != nil {
+		msg := cmd()
+		if msg == nil {
+			return
+		}
+		_, cmd = m.Update(msg)
+	}
+}
+
+func TestTimetableModel_AddSlot(t *testing.T) {
+	timetableService := &fakeTimetableService{}
+	model := New(timetableService, &fakeClassService{})
+	runCmd(model, model.Init())
+	assert.Contains(t, model.View(), "Nenhum horário cadastrado")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	require.Equal(t, FormView, model.state)
+	model.Update(tea.KeyMsg{Type: tea.KeyRight}) // Turma 8B.
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model.Update(tea.KeyMsg{Type: tea.KeyRight}) // Terça.
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	runCmd(model, cmd)
+
+	require.Len(t, timetableService.slots, 1)
+	slot := timetableService.slots[0]
+	assert.Equal(t, int64(2), slot.ClassID)
+	assert.Equal(t, time.Tuesday, slot.Weekday)
+	assert.Equal(t, "07:00", slot.StartTime)
+	assert.Equal(t, 50, slot.DurationMinutes)
+
+	view := model.View()
+	assert.Contains(t, view, "Terça")
+	assert.Contains(t, view, "07:00–07:50  Turma 8B")
+	assert.Contains(t, view, "Horário de Terça 07:00 salvo.")
+}
+
+func TestTimetableModel_GenerateWithPreview(t *testing.T) {
+	timetableService := &fakeTimetableService{slots: []models.TimetableSlot{{ID: 1, ClassID: 1, Weekday: time.Monday, StartTime: "07:00", DurationMinutes: 50}}}
+	model := New(timetableService, &fakeClassService{})
+	runCmd(model, model.Init())
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	require.Equal(t, GenerateView, model.state)
+	model.fromInput.SetValue("2025-04-14")
+	model.toInput.SetValue("2025-04-30")
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runCmd(model, cmd)
+	require.Len(t, timetableService.generations, 1)
+	assert.True(t, timetableService.generations[0].DryRun, "enter deve apenas simular")
+	view := model.View()
+	assert.Contains(t, view, "2 aulas serão criadas, 1 horários pulados")
+	assert.Contains(t, view, "feriado: Tiradentes")
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	runCmd(model, cmd)
+	require.Len(t, timetableService.generations, 2)
+	assert.False(t, timetableService.generations[1].DryRun)
+	assert.Equal(t, time.Date(2025, 4, 30, 0, 0, 0, 0, time.Local), timetableService.generations[1].To)
+	assert.Equal(t, ListView, model.state)
+	assert.Contains(t, model.View(), "2 aulas criadas, 1 horários pulados.")
+}
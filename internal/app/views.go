@@ -32,6 +32,18 @@ const (
 	// Distingue-se de DashboardView (menu principal) para permitir uma navegação clara.
 	ConcreteDashboardView
 
+	// DailyPlanningView representa a tela de planejamento do dia (aulas + tarefas em blocos de horário).
+	DailyPlanningView
+
+	// LessonPlanningView representa a tela de aulas: lista por semana ou por turma e edita os planos de aula.
+	LessonPlanningView
+
+	// TimetableView representa a tela do horário semanal das turmas e da geração de aulas.
+	TimetableView
+
+	// AttendanceView representa a tela de chamada: aulas do dia e lista de chamada de cada aula.
+	AttendanceView
+
 	// StudentView é um exemplo de uma sub-visualização, possivelmente para listar ou editar alunos.
 	// O seu uso e contexto exato podem depender de como o ClassManagementView é implementado.
 	// NOTA: Este valor (99) está fora da sequência iota e foi usado em tui.go;
@@ -60,6 +72,14 @@ func (v View) String() string {
 		return "Gerar Provas"
 	case ConcreteDashboardView:
 		return "Painel de Controle"
+	case DailyPlanningView:
+		return "Planejar o Dia"
+	case LessonPlanningView:
+		return "Planejar Aulas"
+	case TimetableView:
+		return "Horário Semanal"
+	case AttendanceView:
+		return "Chamada"
 	case StudentView: // Caso para o valor explícito
 		return "Visualizar Alunos" // Ou um nome mais apropriado
 	default:
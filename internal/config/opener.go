@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// OpenerEnv é a variável de ambiente com o comando usado para abrir anexos (arquivos e links).
+// Pode conter argumentos, por exemplo VIGENDA_OPENER="firefox --new-tab".
+const OpenerEnv = "VIGENDA_OPENER"
+
+// Opener retorna o comando (e os argumentos) usado para abrir anexos: o valor de VIGENDA_OPENER ou,
+// se não estiver definido, o abridor padrão do sistema (xdg-open no Linux, open no macOS).
+func Opener() []string {
+	if fields := strings.Fields(os.Getenv(OpenerEnv)); len(fields) > 0 {
+		return fields
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"open"}
+	case "windows":
+		return []string{"rundll32", "url.dll,FileProtocolHandler"}
+	default:
+		return []string{"xdg-open"}
+	}
+}
+
+// OpenCommand monta o comando que abre 'target' (um caminho de arquivo ou uma URL) com o Opener.
+func OpenCommand(target string) *exec.Cmd {
+	opener := Opener()
+	args := append(append([]string{}, opener[1:]...), target)
+	return exec.Command(opener[0], args...)
+}
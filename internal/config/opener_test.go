@@ -0,0 +1,22 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOpenCommand_UsesConfiguredOpener(t *testing.T) {
+	t.Setenv(OpenerEnv, "firefox --new-tab")
+	cmd := OpenCommand("https://example.com")
+	want := []string{"firefox", "--new-tab", "https://example.com"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("OpenCommand().Args = %v, want %v", cmd.Args, want)
+	}
+}
+
+func TestOpener_DefaultWhenUnset(t *testing.T) {
+	t.Setenv(OpenerEnv, "  ")
+	if opener := Opener(); len(opener) == 0 || opener[0] == "" {
+		t.Errorf("Opener() = %v, want a default command", opener)
+	}
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// Variáveis de ambiente com a identificação impressa no cabeçalho dos relatórios em HTML e PDF.
+const (
+	SchoolEnv  = "VIGENDA_ESCOLA"    // SchoolEnv é o nome da escola (ex: "E. E. Machado de Assis").
+	TeacherEnv = "VIGENDA_PROFESSOR" // TeacherEnv é o nome do(a) professor(a).
+	LogoEnv    = "VIGENDA_LOGO"      // LogoEnv é o caminho do logotipo da escola, uma imagem PNG ou JPEG.
+)
+
+// SchoolName retorna o nome da escola de VIGENDA_ESCOLA, ou "" se não estiver definido.
+func SchoolName() string {
+	return strings.TrimSpace(os.Getenv(SchoolEnv))
+}
+
+// TeacherName retorna o nome do(a) professor(a) de VIGENDA_PROFESSOR, ou "" se não estiver definido.
+func TeacherName() string {
+	return strings.TrimSpace(os.Getenv(TeacherEnv))
+}
+
+// LogoPath retorna o caminho do logotipo da escola de VIGENDA_LOGO, ou "" se não estiver definido.
+func LogoPath() string {
+	return strings.TrimSpace(os.Getenv(LogoEnv))
+}
@@ -3,8 +3,11 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	_ "github.com/lib/pq" // PostgreSQL driver
 	_ "github.com/mattn/go-sqlite3" // SQLite driver
@@ -69,6 +72,11 @@ func GetDBConnection(config DBConfig) (*sql.DB, error) {
 			db.Close()
 			return nil, fmt.Errorf("failed to check for existing SQLite tables: %w", err)
 		}
+		// Apply the incremental migrations (002 onwards) that are not yet recorded.
+		if err := applyPendingSQLiteMigrations(db); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to apply SQLite migrations: %w", err)
+		}
 	}
 	// For PostgreSQL, schema migrations are assumed to be handled by external tools
 	// like goose, migrate, or flyway.
@@ -92,6 +100,68 @@ func applySQLiteSchema(db *sql.DB) error {
 	return nil
 }
 
+// initialSchemaFile is the migration applied by applySQLiteSchema on new databases.
+const initialSchemaFile = "001_initial_schema.sql"
+
+// applyPendingSQLiteMigrations applies, in file name order, every embedded migration
+// not yet recorded in the schema_migrations table. Databases created before this
+// table existed already contain the initial schema, so it is recorded as applied.
+func applyPendingSQLiteMigrations(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version TEXT PRIMARY KEY,
+		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	if _, err := db.Exec("INSERT OR IGNORE INTO schema_migrations (version) VALUES (?)", initialSchemaFile); err != nil {
+		return fmt.Errorf("failed to record initial schema migration: %w", err)
+	}
+
+	entries, err := fs.ReadDir(migrationsFS, "migrations")
+	if err != nil {
+		return fmt.Errorf("failed to read embedded migrations directory: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".sql") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var applied string
+		err := db.QueryRow("SELECT version FROM schema_migrations WHERE version = ?", name).Scan(&applied)
+		if err == nil {
+			continue
+		}
+		if err != sql.ErrNoRows {
+			return fmt.Errorf("failed to check migration %s: %w", name, err)
+		}
+
+		content, err := migrationsFS.ReadFile("migrations/" + name)
+		if err != nil {
+			return fmt.Errorf("failed to read embedded migration %s: %w", name, err)
+		}
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction for migration %s: %w", name, err)
+		}
+		if _, err := tx.Exec(string(content)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %s: %w", name, err)
+		}
+		if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", name); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %s: %w", name, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
 // DefaultSQLitePath returns the default path for the SQLite database file.
 // It places it in the user's config directory or defaults to "vigenda.db" in CWD.
 // This function is now correctly named and used.
@@ -127,3 +197,33 @@ func DefaultSQLitePath() string {
 func DefaultDbPath() string {
 	return DefaultSQLitePath()
 }
+
+// SQLiteFilePath returns the database file behind a SQLite configuration, ignoring a "file:" prefix
+// and URI parameters. It returns false for PostgreSQL and in-memory databases.
+func SQLiteFilePath(config DBConfig) (string, bool) {
+	if config.DBType != "sqlite" && config.DBType != "" {
+		return "", false
+	}
+	path := config.DSN
+	if path == "" {
+		path = DefaultSQLitePath()
+	}
+	path = strings.TrimPrefix(path, "file:")
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	if path == "" || path == ":memory:" {
+		return "", false
+	}
+	return path, true
+}
+
+// AttachmentsDir returns the directory where lesson attachments are stored: "anexos" next to the
+// SQLite database file or, for other databases, next to the default database path.
+func AttachmentsDir(config DBConfig) string {
+	path, ok := SQLiteFilePath(config)
+	if !ok {
+		path = DefaultSQLitePath()
+	}
+	return filepath.Join(filepath.Dir(path), "anexos")
+}
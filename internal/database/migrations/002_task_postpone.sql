@@ -0,0 +1,4 @@
+-- Migration 002: Adiamento de tarefas
+-- Registra quantas vezes cada tarefa foi adiada (soneca / triagem de atrasadas).
+
+ALTER TABLE tasks ADD COLUMN postpone_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+-- Migration 003: Status de tarefas para o quadro Kanban
+-- Valores: 'a_fazer', 'fazendo', 'aguardando', 'feito'. is_completed continua
+-- sincronizado com o status 'feito' para manter as consultas existentes.
+
+ALTER TABLE tasks ADD COLUMN status TEXT NOT NULL DEFAULT 'a_fazer';
+UPDATE tasks SET status = 'feito' WHERE is_completed = 1;
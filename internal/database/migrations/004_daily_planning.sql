@@ -0,0 +1,23 @@
+-- Migration 004: Planejamento diário
+-- Tarefas ganham duração estimada (minutos) e prioridade (1 baixa, 2 média, 3 alta).
+-- day_plan_blocks guarda o plano em blocos de horário confirmado pelo professor para cada dia.
+
+ALTER TABLE tasks ADD COLUMN estimated_minutes INTEGER;
+ALTER TABLE tasks ADD COLUMN priority INTEGER NOT NULL DEFAULT 2;
+
+CREATE TABLE IF NOT EXISTS day_plan_blocks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    plan_date TEXT NOT NULL, -- Dia do plano no formato YYYY-MM-DD
+    start_at TIMESTAMP NOT NULL,
+    end_at TIMESTAMP NOT NULL,
+    kind TEXT NOT NULL, -- 'aula' ou 'tarefa'
+    task_id INTEGER,
+    lesson_id INTEGER,
+    title TEXT NOT NULL,
+    FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY(task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY(lesson_id) REFERENCES lessons(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_day_plan_blocks_user_date ON day_plan_blocks(user_id, plan_date);
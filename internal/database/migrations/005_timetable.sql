@@ -0,0 +1,15 @@
+-- Migration 005: Horário semanal
+-- Cada linha é um horário fixo de aula de uma turma: dia da semana (0 = domingo ... 6 = sábado,
+-- como time.Weekday), hora de início (HH:MM) e duração em minutos. O gerador de aulas usa
+-- estes horários para criar as aulas ('lessons') de um período.
+
+CREATE TABLE IF NOT EXISTS timetable_slots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    class_id INTEGER NOT NULL,
+    weekday INTEGER NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    start_time TEXT NOT NULL,
+    duration_minutes INTEGER NOT NULL CHECK (duration_minutes > 0),
+    FOREIGN KEY(class_id) REFERENCES classes(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_timetable_slots_weekday ON timetable_slots(weekday, start_time);
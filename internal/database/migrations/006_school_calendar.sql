@@ -0,0 +1,24 @@
+-- Migration 006: Calendário escolar
+-- 'academic_terms' guarda as datas de início e fim de cada bimestre do ano letivo.
+-- 'calendar_events' guarda os dias (ou períodos) sem aula: feriados, recessos e dias de
+-- planejamento. Datas no formato AAAA-MM-DD; end_date é inclusiva.
+
+CREATE TABLE IF NOT EXISTS academic_terms (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    year INTEGER NOT NULL,
+    number INTEGER NOT NULL CHECK (number BETWEEN 1 AND 4),
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    UNIQUE (year, number)
+);
+
+CREATE TABLE IF NOT EXISTS calendar_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL CHECK (kind IN ('feriado', 'recesso', 'planejamento')),
+    description TEXT NOT NULL,
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    UNIQUE (kind, start_date, end_date, description)
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_events_dates ON calendar_events(start_date, end_date);
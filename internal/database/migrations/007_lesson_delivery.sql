@@ -0,0 +1,9 @@
+-- Migration 007: Registro das aulas dadas
+-- Cada aula passa a ter uma situação (planejada, ministrada, parcial ou cancelada), o conteúdo
+-- efetivamente ministrado (usado no relatório de conteúdo ministrado / diário de classe) e uma
+-- breve reflexão do professor.
+
+ALTER TABLE lessons ADD COLUMN status TEXT NOT NULL DEFAULT 'planejada'
+    CHECK (status IN ('planejada', 'ministrada', 'parcial', 'cancelada'));
+ALTER TABLE lessons ADD COLUMN covered_content TEXT NOT NULL DEFAULT '';
+ALTER TABLE lessons ADD COLUMN reflection TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,26 @@
+-- Migration 008: Modelos de plano de aula e sequências didáticas
+-- 'lesson_templates' guarda modelos reutilizáveis de plano de aula (Markdown).
+-- 'lesson_sequences' guarda as sequências didáticas (unidades com vários planos em ordem) e
+-- 'lesson_sequence_items' os planos de cada uma, na ordem de 'position' (a partir de 1).
+
+CREATE TABLE IF NOT EXISTS lesson_templates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    content TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS lesson_sequences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS lesson_sequence_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sequence_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    plan_content TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (sequence_id) REFERENCES lesson_sequences(id) ON DELETE CASCADE,
+    UNIQUE (sequence_id, position)
+);
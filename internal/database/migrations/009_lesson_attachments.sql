@@ -0,0 +1,15 @@
+-- Migration 009: Anexos das aulas
+-- Cada aula pode ter arquivos (copiados para o diretório de anexos, ao lado do banco) e links.
+-- Para arquivos, 'location' é o caminho relativo ao diretório de anexos; para links, a URL.
+
+CREATE TABLE IF NOT EXISTS lesson_attachments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lesson_id INTEGER NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('arquivo', 'link')),
+    name TEXT NOT NULL,
+    location TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (lesson_id) REFERENCES lessons(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_lesson_attachments_lesson_id ON lesson_attachments(lesson_id);
@@ -0,0 +1,15 @@
+-- Migration 010: Chamada (frequência dos alunos)
+-- Um registro por aluno em cada aula: presente, falta, atraso ou falta justificada.
+
+CREATE TABLE IF NOT EXISTS attendance (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lesson_id INTEGER NOT NULL,
+    student_id INTEGER NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('presente', 'falta', 'atraso', 'justificada')),
+    recorded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE,
+    UNIQUE (lesson_id, student_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_attendance_student_id ON attendance(student_id);
@@ -0,0 +1,24 @@
+-- Migration 011: Política de notas por turma
+-- Cada turma pode definir como a média é calculada (aritmética, ponderada, por categorias, média dos
+-- bimestres ou melhores N notas) e o arredondamento. Turmas sem política usam a média ponderada sem
+-- arredondamento. As avaliações ganham uma categoria (ex: prova, trabalho) para o esquema "categorias".
+
+ALTER TABLE assessments ADD COLUMN category TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS grading_policies (
+    class_id INTEGER PRIMARY KEY,
+    scheme TEXT NOT NULL DEFAULT 'ponderada'
+        CHECK (scheme IN ('aritmetica', 'ponderada', 'categorias', 'media-bimestres', 'melhores')),
+    best_of INTEGER NOT NULL DEFAULT 0,
+    rounding_step REAL NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (class_id) REFERENCES classes(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS grading_policy_categories (
+    class_id INTEGER NOT NULL,
+    category TEXT NOT NULL,
+    weight REAL NOT NULL CHECK (weight > 0),
+    PRIMARY KEY (class_id, category),
+    FOREIGN KEY (class_id) REFERENCES grading_policies(class_id) ON DELETE CASCADE
+);
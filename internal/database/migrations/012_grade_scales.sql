@@ -0,0 +1,15 @@
+-- Migration 012: Escala de notas por turma
+-- A política de notas passa a definir a escala das notas da turma: 0 a 10, 0 a 100 ou conceitual
+-- (A a E, ou MB/B/R/I). Nas escalas conceituais, cada conceito corresponde a uma faixa de notas de
+-- 0 a 10, definida pela nota mínima do conceito.
+
+ALTER TABLE grading_policies ADD COLUMN grade_scale TEXT NOT NULL DEFAULT '0-10'
+    CHECK (grade_scale IN ('0-10', '0-100', 'letras', 'conceitos'));
+
+CREATE TABLE IF NOT EXISTS grading_policy_concepts (
+    class_id INTEGER NOT NULL,
+    concept TEXT NOT NULL,
+    min_grade REAL NOT NULL CHECK (min_grade >= 0 AND min_grade <= 10),
+    PRIMARY KEY (class_id, concept),
+    FOREIGN KEY (class_id) REFERENCES grading_policies(class_id) ON DELETE CASCADE
+);
@@ -0,0 +1,18 @@
+-- Migration 013: Avaliações de recuperação
+-- Uma avaliação pode ser de recuperação (kind = 'recuperacao'), recuperando todo o seu bimestre ou
+-- apenas as avaliações ligadas a ela em assessment_recoveries. A política de notas da turma ganha a
+-- média de aprovação (0 = padrão da escala: 60% da nota máxima) e a regra de recuperação.
+
+ALTER TABLE assessments ADD COLUMN kind TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS assessment_recoveries (
+    recovery_id INTEGER NOT NULL,
+    assessment_id INTEGER NOT NULL,
+    PRIMARY KEY (recovery_id, assessment_id),
+    FOREIGN KEY (recovery_id) REFERENCES assessments(id) ON DELETE CASCADE,
+    FOREIGN KEY (assessment_id) REFERENCES assessments(id) ON DELETE CASCADE
+);
+
+ALTER TABLE grading_policies ADD COLUMN passing_grade REAL NOT NULL DEFAULT 0;
+ALTER TABLE grading_policies ADD COLUMN recovery_rule TEXT NOT NULL DEFAULT 'substitui-media'
+    CHECK (recovery_rule IN ('substitui-media', 'substitui-menor', 'media'));
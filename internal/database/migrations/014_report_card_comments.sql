@@ -0,0 +1,9 @@
+-- Migration 014: Comentários do boletim
+-- O comentário do(a) professor(a) impresso no boletim de cada aluno ('vigenda relatorio boletim').
+
+CREATE TABLE IF NOT EXISTS report_card_comments (
+    student_id INTEGER PRIMARY KEY,
+    comment TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE
+);
@@ -46,23 +46,88 @@ type Student struct {
 
 // Lesson represents a planned lesson for a class.
 type Lesson struct {
-	ID           int64     `json:"id"`            // ID é o identificador único da aula.
-	ClassID      int64     `json:"class_id"`      // ClassID é o ID da turma para a qual a aula é planejada.
-	Title        string    `json:"title"`         // Title é o título da aula.
-	PlanContent  string    `json:"plan_content"`  // PlanContent contém o conteúdo do plano de aula, preferencialmente em Markdown.
-	ScheduledAt  time.Time `json:"scheduled_at"`  // ScheduledAt é a data e hora agendada para a aula.
+	ID             int64              `json:"id"`                    // ID é o identificador único da aula.
+	ClassID        int64              `json:"class_id"`              // ClassID é o ID da turma para a qual a aula é planejada.
+	Title          string             `json:"title"`                 // Title é o título da aula.
+	PlanContent    string             `json:"plan_content"`          // PlanContent contém o conteúdo do plano de aula, preferencialmente em Markdown.
+	ScheduledAt    time.Time          `json:"scheduled_at"`          // ScheduledAt é a data e hora agendada para a aula.
+	Status         string             `json:"status"`                // Status indica a situação da aula (ver LessonStatus*).
+	CoveredContent string             `json:"covered_content"`       // CoveredContent é o conteúdo efetivamente ministrado, registrado após a aula.
+	Reflection     string             `json:"reflection"`            // Reflection é uma breve reflexão do professor sobre a aula.
+	Attachments    []LessonAttachment `json:"attachments,omitempty"` // Attachments são os anexos da aula; preenchido apenas nas exportações.
 }
 
+// Situações de uma aula.
+const (
+	LessonStatusPlanned   = "planejada"
+	LessonStatusTaught    = "ministrada"
+	LessonStatusPartial   = "parcial"
+	LessonStatusCancelled = "cancelada"
+)
+
 // Assessment represents an assessment or evaluation (e.g., test, quiz, project) for a class.
 type Assessment struct {
-	ID             int64      `json:"id"`               // ID é o identificador único da avaliação.
-	ClassID        int64      `json:"class_id"`         // ClassID é o ID da turma para a qual a avaliação é aplicada.
-	Name           string     `json:"name"`             // Name é o nome da avaliação (ex: "Prova Bimestral 1").
-	Term           int        `json:"term"`             // Term indica o período da avaliação (ex: 1 para o primeiro bimestre/trimestre).
-	Weight         float64    `json:"weight"`           // Weight é o peso da avaliação na composição da nota final.
+	ID             int64      `json:"id"`                        // ID é o identificador único da avaliação.
+	ClassID        int64      `json:"class_id"`                  // ClassID é o ID da turma para a qual a avaliação é aplicada.
+	Name           string     `json:"name"`                      // Name é o nome da avaliação (ex: "Prova Bimestral 1").
+	Term           int        `json:"term"`                      // Term indica o período da avaliação (ex: 1 para o primeiro bimestre/trimestre).
+	Weight         float64    `json:"weight"`                    // Weight é o peso da avaliação na composição da nota final.
 	AssessmentDate *time.Time `json:"assessment_date,omitempty"` // AssessmentDate é a data de aplicação da avaliação (ponteiro para permitir nulo).
+	Category       string     `json:"category,omitempty"`        // Category é a categoria da avaliação (ex: "prova", "trabalho"), usada pela política de notas "categorias".
+	Kind           string     `json:"kind,omitempty"`            // Kind é o tipo da avaliação: vazio para as regulares ou AssessmentKindRecovery.
+	RecoveryOf     []int64    `json:"recovery_of,omitempty"`     // RecoveryOf são as avaliações recuperadas por uma recuperação; vazio recupera todo o bimestre (Term).
+}
+
+// AssessmentKindRecovery é o tipo das avaliações de recuperação, feitas só pelos alunos abaixo da
+// média de aprovação e aplicadas às médias segundo a regra de recuperação da política de notas.
+const AssessmentKindRecovery = "recuperacao"
+
+// GradingPolicy represents how the averages of a class are computed from its grades.
+type GradingPolicy struct {
+	ClassID         int64              `json:"class_id"`                   // ClassID é o ID da turma à qual a política se aplica.
+	Scheme          string             `json:"scheme"`                     // Scheme é o esquema de cálculo da média (ver GradingScheme*).
+	CategoryWeights map[string]float64 `json:"category_weights,omitempty"` // CategoryWeights é o peso de cada categoria de avaliação no esquema "categorias".
+	BestOf          int                `json:"best_of,omitempty"`          // BestOf é o número de maiores notas consideradas no esquema "melhores".
+	RoundingStep    float64            `json:"rounding_step"`              // RoundingStep é o passo de arredondamento da média (ex: 0.5, 0.1) ou 0 para não arredondar.
+	GradeScale      string             `json:"grade_scale"`                // GradeScale é a escala em que as notas são lançadas e exibidas (ver GradeScale*).
+	ConceptRanges   []ConceptRange     `json:"concept_ranges,omitempty"`   // ConceptRanges são as faixas dos conceitos nas escalas conceituais, do maior para o menor.
+	PassingGrade    float64            `json:"passing_grade"`              // PassingGrade é a média de aprovação, na escala da turma; abaixo dela o aluno faz recuperação.
+	RecoveryRule    string             `json:"recovery_rule"`              // RecoveryRule é como a nota da recuperação altera as notas recuperadas (ver RecoveryRule*).
+}
+
+// ConceptRange associa um conceito (ex: "MB") à nota mínima, na escala de 0 a 10, a partir da qual
+// uma nota ou média corresponde a ele.
+type ConceptRange struct {
+	Concept  string  `json:"concept"`   // Concept é o conceito, como lançado e exibido (ex: "A", "MB").
+	MinGrade float64 `json:"min_grade"` // MinGrade é a menor nota (0 a 10) do conceito.
 }
 
+// Esquemas de cálculo da média de uma turma.
+const (
+	GradingSchemeArithmetic = "aritmetica"      // média aritmética das notas
+	GradingSchemeWeighted   = "ponderada"       // média ponderada pelos pesos das avaliações (padrão)
+	GradingSchemeCategories = "categorias"      // média ponderada das médias de cada categoria de avaliação
+	GradingSchemeTermMean   = "media-bimestres" // média aritmética das médias ponderadas de cada bimestre
+	GradingSchemeBestOf     = "melhores"        // média aritmética das N maiores notas
+)
+
+// Regras de recuperação: como a nota da recuperação é aplicada às notas que ela recupera (as do
+// bimestre ou as avaliações indicadas). A recuperação nunca reduz a média do aluno.
+const (
+	RecoveryRuleReplaceAverage = "substitui-media" // a nota da recuperação substitui a média, se for maior (padrão)
+	RecoveryRuleReplaceLowest  = "substitui-menor" // a nota da recuperação substitui a menor nota, se for maior
+	RecoveryRuleAverage        = "media"           // a média passa a ser a média entre ela e a nota da recuperação
+)
+
+// Escalas de notas de uma turma. Nas escalas conceituais, cada conceito é guardado como uma nota de
+// 0 a 10 (o meio da sua faixa), para entrar no cálculo das médias.
+const (
+	GradeScaleTen      = "0-10"      // notas de 0 a 10 (padrão)
+	GradeScaleHundred  = "0-100"     // notas de 0 a 100
+	GradeScaleLetters  = "letras"    // conceitos A, B, C, D e E
+	GradeScaleConcepts = "conceitos" // conceitos MB, B, R e I
+)
+
 // Grade represents a grade received by a student for a specific assessment.
 type Grade struct {
 	ID           int64   `json:"id"`            // ID é o identificador único do registro de nota.
@@ -74,13 +139,147 @@ type Grade struct {
 // Task represents a task or to-do item.
 // Tasks can be personal (associated only with a user) or related to a specific class.
 type Task struct {
-	ID          int64      `json:"id"`                    // ID é o identificador único da tarefa.
-	UserID      int64      `json:"user_id"`               // UserID é o ID do usuário proprietário desta tarefa.
-	ClassID     *int64     `json:"class_id,omitempty"`    // ClassID (opcional) é o ID da turma à qual esta tarefa pode estar associada. Ponteiro para permitir nulo.
-	Title       string     `json:"title"`                 // Title é o título da tarefa.
-	Description string     `json:"description,omitempty"` // Description fornece detalhes adicionais sobre a tarefa (opcional).
-	DueDate     *time.Time `json:"due_date,omitempty"`    // DueDate é a data e hora de vencimento da tarefa (opcional). Ponteiro para permitir nulo.
-	IsCompleted bool       `json:"is_completed"`          // IsCompleted indica se a tarefa foi concluída.
+	ID               int64      `json:"id"`                          // ID é o identificador único da tarefa.
+	UserID           int64      `json:"user_id"`                     // UserID é o ID do usuário proprietário desta tarefa.
+	ClassID          *int64     `json:"class_id,omitempty"`          // ClassID (opcional) é o ID da turma à qual esta tarefa pode estar associada. Ponteiro para permitir nulo.
+	Title            string     `json:"title"`                       // Title é o título da tarefa.
+	Description      string     `json:"description,omitempty"`       // Description fornece detalhes adicionais sobre a tarefa (opcional).
+	DueDate          *time.Time `json:"due_date,omitempty"`          // DueDate é a data e hora de vencimento da tarefa (opcional). Ponteiro para permitir nulo.
+	IsCompleted      bool       `json:"is_completed"`                // IsCompleted indica se a tarefa foi concluída.
+	PostponeCount    int        `json:"postpone_count"`              // PostponeCount é o número de vezes que a tarefa foi adiada.
+	Status           string     `json:"status"`                      // Status é a coluna da tarefa no quadro Kanban (ver TaskStatus*). 'feito' equivale a IsCompleted.
+	EstimatedMinutes *int       `json:"estimated_minutes,omitempty"` // EstimatedMinutes é a duração estimada da tarefa em minutos (opcional), usada no planejamento diário.
+	Priority         int        `json:"priority"`                    // Priority é a prioridade da tarefa (ver TaskPriority*).
+}
+
+// Prioridades possíveis de uma tarefa. Valores maiores são atendidos primeiro no planejamento diário.
+const (
+	TaskPriorityLow    = 1
+	TaskPriorityMedium = 2
+	TaskPriorityHigh   = 3
+)
+
+// Status possíveis de uma tarefa, na ordem das colunas do quadro Kanban.
+const (
+	TaskStatusTodo    = "a_fazer"
+	TaskStatusDoing   = "fazendo"
+	TaskStatusWaiting = "aguardando"
+	TaskStatusDone    = "feito"
+)
+
+// TaskStatuses lista os status de tarefa na ordem em que as colunas do quadro são exibidas.
+var TaskStatuses = []string{TaskStatusTodo, TaskStatusDoing, TaskStatusWaiting, TaskStatusDone}
+
+// PlanBlock represents a time block in the teacher's plan for a day.
+// A block is either a lesson (from the class schedule) or a task the teacher committed to.
+type PlanBlock struct {
+	ID       int64     `json:"id"`                  // ID é o identificador único do bloco.
+	UserID   int64     `json:"user_id"`             // UserID é o ID do usuário dono do plano.
+	PlanDate time.Time `json:"plan_date"`           // PlanDate é o dia do plano (hora zerada, horário local).
+	StartAt  time.Time `json:"start_at"`            // StartAt é o início do bloco.
+	EndAt    time.Time `json:"end_at"`              // EndAt é o fim do bloco.
+	Kind     string    `json:"kind"`                // Kind indica o tipo do bloco (ver PlanBlockKind*).
+	TaskID   *int64    `json:"task_id,omitempty"`   // TaskID é o ID da tarefa, para blocos do tipo 'tarefa'.
+	LessonID *int64    `json:"lesson_id,omitempty"` // LessonID é o ID da aula, para blocos do tipo 'aula'.
+	Title    string    `json:"title"`               // Title é o título exibido para o bloco.
+}
+
+// Tipos de bloco de um plano diário.
+const (
+	PlanBlockKindLesson = "aula"
+	PlanBlockKindTask   = "tarefa"
+)
+
+// TimetableSlot represents a fixed weekly period of a class in the teacher's timetable.
+// Lessons for a whole term are generated from these slots.
+type TimetableSlot struct {
+	ID              int64        `json:"id"`               // ID é o identificador único do horário.
+	ClassID         int64        `json:"class_id"`         // ClassID é o ID da turma que tem aula neste horário.
+	Weekday         time.Weekday `json:"weekday"`          // Weekday é o dia da semana (0 = domingo ... 6 = sábado).
+	StartTime       string       `json:"start_time"`       // StartTime é a hora de início no formato HH:MM.
+	DurationMinutes int          `json:"duration_minutes"` // DurationMinutes é a duração da aula em minutos.
+}
+
+// AcademicTerm represents a term (bimestre) of the school year with its date range.
+type AcademicTerm struct {
+	ID        int64     `json:"id"`         // ID é o identificador único do bimestre.
+	Year      int       `json:"year"`       // Year é o ano letivo.
+	Number    int       `json:"number"`     // Number é o número do bimestre (1 a 4).
+	StartDate time.Time `json:"start_date"` // StartDate é o primeiro dia do bimestre (hora zerada, horário local).
+	EndDate   time.Time `json:"end_date"`   // EndDate é o último dia do bimestre (inclusive).
+}
+
+// CalendarEvent represents a day or period without lessons in the school calendar.
+type CalendarEvent struct {
+	ID          int64     `json:"id"`          // ID é o identificador único do evento.
+	Kind        string    `json:"kind"`        // Kind indica o tipo do evento (ver CalendarEventKind*).
+	Description string    `json:"description"` // Description é o nome do evento (ex: "Recesso de julho").
+	StartDate   time.Time `json:"start_date"`  // StartDate é o primeiro dia do evento (hora zerada, horário local).
+	EndDate     time.Time `json:"end_date"`    // EndDate é o último dia do evento (inclusive).
+}
+
+// Tipos de evento do calendário escolar. Em todos eles não há aula.
+const (
+	CalendarEventKindHoliday  = "feriado"
+	CalendarEventKindRecess   = "recesso"
+	CalendarEventKindPlanning = "planejamento"
+)
+
+// AttendanceRecord represents the attendance of a student in a lesson (one line of the roll call).
+type AttendanceRecord struct {
+	ID        int64  `json:"id"`         // ID é o identificador único do registro.
+	LessonID  int64  `json:"lesson_id"`  // LessonID é o ID da aula.
+	StudentID int64  `json:"student_id"` // StudentID é o ID do aluno.
+	Status    string `json:"status"`     // Status é a frequência do aluno na aula (ver Attendance*).
+}
+
+// Situações de frequência de um aluno em uma aula.
+const (
+	AttendancePresent   = "presente"
+	AttendanceAbsent    = "falta"
+	AttendanceLate      = "atraso"
+	AttendanceJustified = "justificada"
+)
+
+// LessonAttachment represents a resource attached to a lesson: a file kept in the managed
+// attachments directory or a link.
+type LessonAttachment struct {
+	ID       int64  `json:"id"`        // ID é o identificador único do anexo.
+	LessonID int64  `json:"lesson_id"` // LessonID é o ID da aula à qual o anexo pertence.
+	Kind     string `json:"kind"`      // Kind indica o tipo do anexo (ver AttachmentKind*).
+	Name     string `json:"name"`      // Name é o nome exibido (ex: "slides.pdf", "Vídeo sobre frações").
+	Location string `json:"location"`  // Location é o caminho relativo ao diretório de anexos (arquivos) ou a URL (links).
+}
+
+// Tipos de anexo de uma aula.
+const (
+	AttachmentKindFile = "arquivo"
+	AttachmentKindLink = "link"
+)
+
+// LessonTemplate represents a reusable lesson plan skeleton used to pre-fill a lesson's plan.
+type LessonTemplate struct {
+	ID      int64  `json:"id"`      // ID é o identificador único do modelo.
+	Name    string `json:"name"`    // Name é o nome do modelo (único).
+	Content string `json:"content"` // Content é o plano de aula em Markdown usado como ponto de partida.
+}
+
+// LessonSequence represents a didactic sequence: an ordered unit of lesson plans that can be
+// applied to the next scheduled lessons of a class.
+type LessonSequence struct {
+	ID          int64                `json:"id"`          // ID é o identificador único da sequência.
+	Name        string               `json:"name"`        // Name é o nome da sequência (único).
+	Description string               `json:"description"` // Description descreve a unidade (opcional).
+	Items       []LessonSequenceItem `json:"items"`       // Items são os planos da sequência, em ordem.
+}
+
+// LessonSequenceItem represents one lesson plan of a didactic sequence.
+type LessonSequenceItem struct {
+	ID          int64  `json:"id"`           // ID é o identificador único do item.
+	SequenceID  int64  `json:"sequence_id"`  // SequenceID é o ID da sequência à qual o item pertence.
+	Position    int    `json:"position"`     // Position é a ordem do item na sequência (a partir de 1).
+	Title       string `json:"title"`        // Title é o título dado à aula ao aplicar a sequência.
+	PlanContent string `json:"plan_content"` // PlanContent é o plano de aula em Markdown.
 }
 
 // Question represents a question stored in the question bank.
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// WriteCSV escreve os documentos em um único CSV, para abrir em planilhas: o título e as linhas de
+// identificação de cada documento, e então cada seção com o seu título, os títulos das colunas e
+// as linhas, separadas por uma linha em branco.
+func WriteCSV(w io.Writer, docs ...Document) error {
+	if len(docs) == 0 {
+		return fmt.Errorf("nenhum documento para gerar")
+	}
+	writer := csv.NewWriter(w)
+	blank := []string{""}
+	for i, doc := range docs {
+		if i > 0 {
+			writer.Write(blank)
+		}
+		if doc.School != "" {
+			writer.Write([]string{doc.School})
+		}
+		writer.Write([]string{doc.Title})
+		for _, line := range doc.Info {
+			writer.Write([]string{line})
+		}
+		for _, section := range doc.Sections {
+			writer.Write(blank)
+			if section.Heading != "" {
+				writer.Write([]string{section.Heading})
+			}
+			if t := section.Table; t != nil {
+				header := make([]string, len(t.Columns))
+				for j, column := range t.Columns {
+					header[j] = column.Title
+				}
+				writer.Write(header)
+				for _, row := range t.Rows {
+					cells := make([]string, len(t.Columns))
+					copy(cells, row.Cells)
+					writer.Write(cells)
+				}
+			}
+			for _, note := range section.Notes {
+				writer.Write([]string{note})
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("erro ao gerar CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+)
+
+// htmlTemplate gera uma página autocontida, com o CSS de impressão embutido: cada documento começa
+// em uma página nova e o cabeçalho das tabelas se repete nas páginas seguintes.
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"width": func(t *Table, i int) string {
+		return fmt.Sprintf("%.2f%%", t.widths(100)[i])
+	},
+	"rowHeight": func(t *Table) string {
+		return fmt.Sprintf("%.1fmm", t.rowHeight())
+	},
+	"align": func(a Align) string {
+		switch a {
+		case AlignCenter:
+			return "center"
+		case AlignRight:
+			return "right"
+		}
+		return "left"
+	},
+	"logo": func(logo []byte) template.URL {
+		mimeType, err := LogoType(logo)
+		if err != nil {
+			return ""
+		}
+		return template.URL("data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(logo))
+	},
+	"cell": func(cells []string, i int) string {
+		if i < len(cells) {
+			return cells[i]
+		}
+		return ""
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>{{with index . 0}}{{.Title}}{{end}}</title>
+<style>
+@page { size: A4 {{if (index . 0).Landscape}}landscape{{else}}portrait{{end}}; margin: 12mm; }
+body { font-family: Helvetica, Arial, sans-serif; font-size: 10pt; color: #000; margin: 0; }
+.document { margin: 0 auto 12mm; }
+.document + .document { break-before: page; page-break-before: always; }
+.logo { display: block; margin: 0 auto 2mm; max-height: 20mm; max-width: 60mm; }
+.school { text-align: center; font-size: 13pt; font-weight: bold; margin: 0; }
+h1 { text-align: center; font-size: 12pt; margin: 2mm 0 3mm; }
+.info { margin: 0 0 4mm; font-size: 9pt; }
+.info p { margin: 0.5mm 0; }
+h2 { font-size: 10pt; margin: 4mm 0 2mm; }
+table { width: 100%; border-collapse: collapse; table-layout: fixed; }
+thead { display: table-header-group; }
+th, td { border: 1px solid #000; padding: 1mm 1.5mm; font-size: 8.5pt; overflow: hidden; }
+th { background: #ddd; }
+tr { break-inside: avoid; page-break-inside: avoid; }
+tr.muted td { color: #888; }
+.notes p { margin: 2mm 0; white-space: pre-wrap; }
+.footer { margin-top: 10mm; }
+.footer p { margin: 6mm 0 0; }
+</style>
+</head>
+<body>
+{{range .}}<div class="document">
+{{with logo .Logo}}<img class="logo" src="{{.}}" alt="">
+{{end}}{{if .School}}<p class="school">{{.School}}</p>
+{{end}}<h1>{{.Title}}</h1>
+{{if .Info}}<div class="info">{{range .Info}}<p>{{.}}</p>{{end}}</div>
+{{end}}{{range .Sections}}{{if .Heading}}<h2>{{.Heading}}</h2>
+{{end}}{{with $table := .Table}}<table>
+<thead><tr>{{range $i, $col := .Columns}}<th style="width: {{width $table $i}}; text-align: {{align $col.Align}}">{{$col.Title}}</th>{{end}}</tr></thead>
+<tbody>
+{{range $row := .Rows}}<tr{{if .Muted}} class="muted"{{end}} style="height: {{rowHeight $table}}">{{range $i, $col := $table.Columns}}<td style="text-align: {{align $col.Align}}">{{cell $row.Cells $i}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+{{end}}{{if .Notes}}<div class="notes">{{range .Notes}}<p>{{.}}</p>{{end}}</div>
+{{end}}{{end}}{{if .Footer}}<div class="footer">{{range .Footer}}<p>{{.}}</p>{{end}}</div>
+{{end}}</div>
+{{end}}</body>
+</html>
+`))
+
+// WriteHTML escreve os documentos como uma página HTML pronta para impressão. A orientação da
+// página é a do primeiro documento.
+func WriteHTML(w io.Writer, docs ...Document) error {
+	if len(docs) == 0 {
+		return fmt.Errorf("nenhum documento para gerar")
+	}
+	if err := htmlTemplate.Execute(w, docs); err != nil {
+		return fmt.Errorf("erro ao gerar HTML: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Medidas do layout, em milímetros.
+const (
+	pdfMargin     = 12.0
+	pdfLineHeight = 4.5
+)
+
+// pdfWriter desenha os documentos com as fontes padrão do PDF. O texto é convertido para cp1252, que
+// cobre os acentos do português.
+type pdfWriter struct {
+	pdf *gofpdf.Fpdf
+	tr  func(string) string
+}
+
+// WritePDF escreve os documentos como um arquivo PDF A4, um documento por página (ou mais, se a
+// tabela não couber). O cabeçalho das tabelas se repete a cada página.
+func WritePDF(w io.Writer, docs ...Document) error {
+	if len(docs) == 0 {
+		return fmt.Errorf("nenhum documento para gerar")
+	}
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetTitle(docs[0].Title, true)
+	pdf.SetCreator("Vigenda", true)
+	pdf.AliasNbPages("")
+	pw := &pdfWriter{pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin + 2)
+		pdf.SetFont("Helvetica", "", 7)
+		pdf.SetTextColor(100, 100, 100)
+		pdf.CellFormat(0, 4, pw.tr(fmt.Sprintf("Página %d de {nb}", pdf.PageNo())), "", 0, "R", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+
+	for _, doc := range docs {
+		pw.document(doc)
+	}
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("erro ao gerar PDF: %w", err)
+	}
+	return nil
+}
+
+func (pw *pdfWriter) document(doc Document) {
+	pdf := pw.pdf
+	orientation := "P"
+	if doc.Landscape {
+		orientation = "L"
+	}
+	pdf.AddPageFormat(orientation, pdf.GetPageSizeStr("A4"))
+
+	pw.logo(doc.Logo)
+	if doc.School != "" {
+		pdf.SetFont("Helvetica", "B", 13)
+		pdf.CellFormat(0, 6, pw.tr(doc.School), "", 1, "C", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(0, 7, pw.tr(doc.Title), "", 1, "C", false, 0, "")
+	pdf.Ln(1)
+	pdf.SetFont("Helvetica", "", 9)
+	for _, line := range doc.Info {
+		pdf.MultiCell(0, pdfLineHeight, pw.tr(line), "", "L", false)
+	}
+	pdf.Ln(2)
+
+	for _, section := range doc.Sections {
+		if section.Heading != "" {
+			pdf.SetFont("Helvetica", "B", 10)
+			pdf.CellFormat(0, 6, pw.tr(section.Heading), "", 1, "L", false, 0, "")
+		}
+		if section.Table != nil {
+			pw.table(section.Table)
+		}
+		pdf.SetFont("Helvetica", "", 9)
+		for _, note := range section.Notes {
+			pdf.Ln(1)
+			pdf.MultiCell(0, pdfLineHeight, pw.tr(note), "", "L", false)
+		}
+		pdf.Ln(3)
+	}
+
+	if len(doc.Footer) > 0 {
+		pdf.Ln(6)
+		pdf.SetFont("Helvetica", "", 9)
+		for _, line := range doc.Footer {
+			pdf.MultiCell(0, pdfLineHeight, pw.tr(line), "", "L", false)
+			pdf.Ln(4)
+		}
+	}
+}
+
+// pdfLogoHeight é a altura do logotipo da escola no topo da página, em milímetros.
+const pdfLogoHeight = 18.0
+
+// logo desenha o logotipo centralizado no topo da página. Logotipos que não são PNG nem JPEG são
+// ignorados, como no HTML.
+func (pw *pdfWriter) logo(logo []byte) {
+	mimeType, err := LogoType(logo)
+	if err != nil {
+		return
+	}
+	pdf := pw.pdf
+	options := gofpdf.ImageOptions{ImageType: strings.ToUpper(strings.TrimPrefix(mimeType, "image/"))}
+	name := fmt.Sprintf("logo-%08x", crc32.ChecksumIEEE(logo))
+	info := pdf.RegisterImageOptionsReader(name, options, bytes.NewReader(logo))
+	if pdf.Err() || info == nil || info.Height() == 0 {
+		pdf.ClearError()
+		return
+	}
+	width := pdfLogoHeight * info.Width() / info.Height()
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.ImageOptions(name, (pageWidth-width)/2, pdf.GetY(), width, pdfLogoHeight, false, options, 0, "")
+	pdf.Ln(pdfLogoHeight + 2)
+}
+
+func (pw *pdfWriter) table(t *Table) {
+	pdf := pw.pdf
+	pageWidth, pageHeight := pdf.GetPageSize()
+	widths := t.widths(pageWidth - 2*pdfMargin)
+	height := t.rowHeight()
+
+	header := func() {
+		pdf.SetFont("Helvetica", "B", 8)
+		pdf.SetFillColor(221, 221, 221)
+		for i, column := range t.Columns {
+			pdf.CellFormat(widths[i], 6, pw.fit(column.Title, widths[i]), "1", 0, pdfAlign(column.Align), true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 8)
+	}
+	header()
+	for _, row := range t.Rows {
+		// Quebra a página manualmente para repetir o cabeçalho da tabela.
+		if pdf.GetY()+height > pageHeight-pdfMargin {
+			pdf.AddPage()
+			header()
+		}
+		if row.Muted {
+			pdf.SetTextColor(136, 136, 136)
+		}
+		for i, column := range t.Columns {
+			text := ""
+			if i < len(row.Cells) {
+				text = row.Cells[i]
+			}
+			pdf.CellFormat(widths[i], height, pw.fit(text, widths[i]), "1", 0, pdfAlign(column.Align), false, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetTextColor(0, 0, 0)
+	}
+}
+
+// fit converte o texto para a fonte do PDF e o corta com reticências se não couber em 'width'.
+func (pw *pdfWriter) fit(text string, width float64) string {
+	available := width - 2*pw.pdf.GetCellMargin()
+	converted := pw.tr(text)
+	if pw.pdf.GetStringWidth(converted) <= available {
+		return converted
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		converted = pw.tr(string(runes) + "…")
+		if pw.pdf.GetStringWidth(converted) <= available {
+			return converted
+		}
+	}
+	return ""
+}
+
+func pdfAlign(a Align) string {
+	switch a {
+	case AlignCenter:
+		return "C"
+	case AlignRight:
+		return "R"
+	}
+	return "L"
+}
@@ -0,0 +1,133 @@
+// Package report monta os relatórios imprimíveis do Vigenda (listas de chamada, diários, boletins)
+// e os escreve em HTML, para abrir e imprimir no navegador, em PDF ou em CSV, para planilhas. Dados
+// exportados para continuar o trabalho em outro programa usam Workbook (XLSX, ODS ou CSV).
+package report
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// Formatos de saída dos relatórios imprimíveis.
+const (
+	FormatHTML = "html"
+	FormatPDF  = "pdf"
+	FormatCSV  = "csv"
+)
+
+// Align é o alinhamento do texto de uma coluna.
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+)
+
+// Document é um relatório imprimível. Cada documento começa em uma página nova.
+type Document struct {
+	School    string    // School é o nome da escola, no topo da página (opcional).
+	Logo      []byte    // Logo é o logotipo da escola, em PNG ou JPEG, acima do nome (opcional; ver LogoType).
+	Title     string    // Title é o título do relatório (ex: "Lista de Chamada").
+	Info      []string  // Info são as linhas de identificação sob o título (turma, professor, período).
+	Sections  []Section // Sections são as tabelas e textos do relatório, em ordem.
+	Footer    []string  // Footer são linhas ao final do documento, como os campos de assinatura.
+	Landscape bool      // Landscape imprime a página na horizontal, para tabelas com muitas colunas.
+}
+
+// Section é um bloco do documento: um título opcional, uma tabela e/ou parágrafos de texto.
+type Section struct {
+	Heading string
+	Table   *Table
+	Notes   []string // Notes são parágrafos impressos depois da tabela.
+}
+
+// Table é uma tabela do documento.
+type Table struct {
+	Columns   []Column
+	Rows      []Row
+	RowHeight float64 // RowHeight é a altura mínima das linhas em milímetros (0 usa o padrão), útil para campos preenchidos à mão.
+}
+
+// Column é uma coluna da tabela. Width é a largura relativa às demais colunas (0 vale 1).
+type Column struct {
+	Title string
+	Width float64
+	Align Align
+}
+
+// Row é uma linha da tabela. Linhas com Muted ficam esmaecidas, como as de alunos transferidos.
+type Row struct {
+	Cells []string
+	Muted bool
+}
+
+// defaultRowHeight é a altura das linhas, em milímetros, quando Table.RowHeight não é informado.
+const defaultRowHeight = 6.0
+
+func (t *Table) rowHeight() float64 {
+	if t.RowHeight > 0 {
+		return t.RowHeight
+	}
+	return defaultRowHeight
+}
+
+// widths distribui 'total' entre as colunas de acordo com as larguras relativas.
+func (t *Table) widths(total float64) []float64 {
+	sum := 0.0
+	for _, column := range t.Columns {
+		sum += column.weight()
+	}
+	widths := make([]float64, len(t.Columns))
+	for i, column := range t.Columns {
+		widths[i] = total * column.weight() / sum
+	}
+	return widths
+}
+
+func (c Column) weight() float64 {
+	if c.Width <= 0 {
+		return 1
+	}
+	return c.Width
+}
+
+// LogoType retorna o tipo MIME do logotipo ("image/png" ou "image/jpeg"), ou um erro se a imagem
+// não for PNG nem JPEG.
+func LogoType(logo []byte) (string, error) {
+	switch mimeType := http.DetectContentType(logo); mimeType {
+	case "image/png", "image/jpeg":
+		return mimeType, nil
+	default:
+		return "", fmt.Errorf("o logotipo deve ser uma imagem PNG ou JPEG (recebido: %s)", mimeType)
+	}
+}
+
+// FormatFromPath deduz o formato do relatório pela extensão do arquivo de saída (.html, .htm, .pdf ou .csv).
+func FormatFromPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return FormatHTML, nil
+	case ".pdf":
+		return FormatPDF, nil
+	case ".csv":
+		return FormatCSV, nil
+	}
+	return "", fmt.Errorf("extensão de arquivo não suportada em '%s': use .html, .pdf ou .csv", path)
+}
+
+// Write escreve os documentos em 'w' no formato indicado (FormatHTML, FormatPDF ou FormatCSV).
+func Write(w io.Writer, format string, docs ...Document) error {
+	switch format {
+	case FormatHTML:
+		return WriteHTML(w, docs...)
+	case FormatPDF:
+		return WritePDF(w, docs...)
+	case FormatCSV:
+		return WriteCSV(w, docs...)
+	}
+	return fmt.Errorf("formato de relatório desconhecido '%s'", format)
+}
@@ -0,0 +1,209 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func testDocument() Document {
+	return Document{
+		School: "E. E. Machado de Assis",
+		Title:  "Lista de Chamada",
+		Info:   []string{"Turma: Turma 9A", "Mês: maio de 2025"},
+		Sections: []Section{{
+			Table: &Table{
+				Columns: []Column{{Title: "Nº", Width: 1, Align: AlignCenter}, {Title: "ALUNO", Width: 3}, {Title: "05/05"}},
+				Rows: []Row{
+					{Cells: []string{"1", "Ana <Souza>"}},
+					{Cells: []string{"2", "Bruno Lima (transferido)"}, Muted: true},
+				},
+			},
+			Notes: []string{"P: presente · F: falta"},
+		}},
+		Footer: []string{"Assinatura do(a) professor(a): ____________________"},
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	second := testDocument()
+	second.Title = "Lista de Assinaturas"
+	require.NoError(t, WriteHTML(&buf, testDocument(), second))
+	html := buf.String()
+
+	assert.Contains(t, html, "<title>Lista de Chamada</title>")
+	assert.Contains(t, html, "E. E. Machado de Assis")
+	assert.Contains(t, html, "Ana &lt;Souza&gt;", "o texto deve ser escapado")
+	assert.Contains(t, html, `<tr class="muted"`)
+	assert.Contains(t, html, "width: 20.00%; text-align: center")
+	assert.Contains(t, html, "height: 6.0mm")
+	assert.NotContains(t, html, "ZgotmplZ", "valores de estilo não devem ser rejeitados pelo html/template")
+	assert.Equal(t, 2, strings.Count(html, `<div class="document">`))
+	// A linha sem a terceira célula ganha uma célula vazia, mantendo a grade para preenchimento à mão.
+	assert.Contains(t, html, `Ana &lt;Souza&gt;</td><td style="text-align: left"></td>`)
+
+	assert.Error(t, WriteHTML(&buf))
+}
+
+func TestWritePDF(t *testing.T) {
+	doc := testDocument()
+	doc.Landscape = true
+	for i := 0; i < 80; i++ { // Força a quebra de página no meio da tabela.
+		doc.Sections[0].Table.Rows = append(doc.Sections[0].Table.Rows, Row{Cells: []string{"9", strings.Repeat("Nome muito comprido ", 10)}})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, WritePDF(&buf, doc, testDocument()))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	assert.GreaterOrEqual(t, bytes.Count(buf.Bytes(), []byte("/Type /Page\n")), 3)
+}
+
+// testLogo é um PNG de 2x1 pixels.
+func testLogo(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.RGBA{R: 200, A: 255})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestLogo(t *testing.T) {
+	doc := testDocument()
+	doc.Logo = testLogo(t)
+
+	var html bytes.Buffer
+	require.NoError(t, WriteHTML(&html, doc))
+	assert.Contains(t, html.String(), `<img class="logo" src="data:image/png;base64,`)
+	var pdf bytes.Buffer
+	require.NoError(t, WritePDF(&pdf, doc))
+	assert.Contains(t, pdf.String(), "/Subtype /Image")
+
+	// Um logotipo que não é PNG nem JPEG é rejeitado por LogoType e não é impresso.
+	doc.Logo = []byte("GIF89a")
+	_, err := LogoType(doc.Logo)
+	assert.Error(t, err)
+	html.Reset()
+	require.NoError(t, WriteHTML(&html, doc))
+	assert.NotContains(t, html.String(), "<img")
+	pdf.Reset()
+	require.NoError(t, WritePDF(&pdf, doc))
+}
+
+func TestFormatFromPath(t *testing.T) {
+	for path, expected := range map[string]string{"lista.html": FormatHTML, "x/Lista.HTM": FormatHTML, "diario.pdf": FormatPDF, "diario.CSV": FormatCSV} {
+		format, err := FormatFromPath(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, format, path)
+	}
+	_, err := FormatFromPath("lista.docx")
+	assert.Error(t, err)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, testDocument()))
+	expected := `E. E. Machado de Assis
+Lista de Chamada
+Turma: Turma 9A
+Mês: maio de 2025
+
+Nº,ALUNO,05/05
+1,Ana <Souza>,
+2,Bruno Lima (transferido),
+P: presente · F: falta
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func testWorkbook() Workbook {
+	return Workbook{Sheets: []Sheet{
+		{
+			Name:    "1º bimestre",
+			Info:    []string{"Turma: Turma 9A"},
+			Columns: []string{"Nº", "ALUNO", "Prova (peso 2)"},
+			Rows: []SheetRow{
+				{Cells: []Cell{TextCell("1"), TextCell("Ana Souza"), NumberCell(8.5)}},
+				{Cells: []Cell{TextCell("2"), TextCell("Bruno Lima (transferido)"), TextCell("")}, Muted: true},
+			},
+			Notes: []string{"Média de aprovação: 6,0"},
+		},
+		{Name: "Resultado: final/anual", Columns: []string{"ALUNO", "MÉDIA"}, Rows: []SheetRow{{Cells: []Cell{TextCell("Ana Souza"), NumberCell(7.26)}}}},
+	}}
+}
+
+func TestWriteWorkbookCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteWorkbook(&buf, FormatCSV, testWorkbook()))
+	expected := `1º bimestre
+Turma: Turma 9A
+Nº;ALUNO;Prova (peso 2)
+1;Ana Souza;8,5
+2;Bruno Lima (transferido);
+Média de aprovação: 6,0
+
+Resultado  final anual
+ALUNO;MÉDIA
+Ana Souza;7,3
+`
+	assert.Equal(t, expected, buf.String())
+	assert.Error(t, WriteWorkbook(&buf, FormatCSV, Workbook{}))
+	assert.Error(t, WriteWorkbook(&buf, FormatPDF, testWorkbook()))
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteWorkbook(&buf, FormatXLSX, testWorkbook()))
+	file, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer file.Close()
+
+	assert.Equal(t, []string{"1º bimestre", "Resultado  final anual"}, file.GetSheetList())
+	title, _ := file.GetCellValue("1º bimestre", "C3")
+	assert.Equal(t, "Prova (peso 2)", title)
+	value, _ := file.GetCellValue("1º bimestre", "C4", excelize.Options{RawCellValue: true})
+	assert.Equal(t, "8.5", value, "as notas são gravadas como números")
+	cellType, _ := file.GetCellType("1º bimestre", "C4")
+	assert.Equal(t, excelize.CellTypeUnset, cellType, "células numéricas não têm o tipo texto")
+}
+
+func TestWriteODS(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteWorkbook(&buf, FormatODS, testWorkbook()))
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, "mimetype", archive.File[0].Name)
+	assert.Equal(t, zip.Store, archive.File[0].Method)
+
+	var content string
+	for _, f := range archive.File {
+		if f.Name == "content.xml" {
+			r, err := f.Open()
+			require.NoError(t, err)
+			data, _ := io.ReadAll(r)
+			content = string(data)
+		}
+	}
+	assert.Contains(t, content, `<table:table table:name="1º bimestre">`)
+	assert.Contains(t, content, `office:value-type="float" office:value="8.5"><text:p>8,5</text:p>`)
+	assert.Contains(t, content, `table:style-name="muted" office:value-type="string"><text:p>Bruno Lima (transferido)</text:p>`)
+	assert.Equal(t, 2, strings.Count(content, "<table:table "))
+}
+
+func TestSpreadsheetFormatFromPath(t *testing.T) {
+	for path, expected := range map[string]string{"notas.xlsx": FormatXLSX, "x/Notas.ODS": FormatODS, "notas.csv": FormatCSV} {
+		format, err := SpreadsheetFormatFromPath(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, format, path)
+	}
+	_, err := SpreadsheetFormatFromPath("notas.pdf")
+	assert.Error(t, err)
+}
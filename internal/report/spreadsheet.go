@@ -0,0 +1,340 @@
+package report
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Formatos das planilhas exportadas (além de FormatCSV).
+const (
+	FormatXLSX = "xlsx"
+	FormatODS  = "ods"
+)
+
+// Workbook é uma planilha com várias abas, para exportar dados que continuam sendo trabalhados em
+// outro programa (como as notas de uma turma para a coordenação). Ao contrário de Document, as
+// células numéricas são gravadas como números.
+type Workbook struct {
+	Sheets []Sheet
+}
+
+// Sheet é uma aba da planilha: linhas de identificação no topo, os títulos das colunas e as linhas.
+type Sheet struct {
+	Name    string   // Name é o nome da aba (até 31 caracteres).
+	Info    []string // Info são as linhas acima da tabela (escola, turma, bimestre).
+	Columns []string
+	Rows    []SheetRow
+	Notes   []string // Notes são linhas abaixo da tabela, como a legenda.
+}
+
+// SheetRow é uma linha da aba. Linhas com Muted ficam esmaecidas, como as de alunos transferidos.
+type SheetRow struct {
+	Cells []Cell
+	Muted bool
+}
+
+// Cell é uma célula: um texto ou um número, exibido com Decimals casas decimais.
+type Cell struct {
+	Text     string
+	Number   float64
+	IsNumber bool
+	Decimals int
+}
+
+// TextCell cria uma célula de texto.
+func TextCell(text string) Cell {
+	return Cell{Text: text}
+}
+
+// NumberCell cria uma célula numérica com uma casa decimal, como as notas.
+func NumberCell(value float64) Cell {
+	return Cell{Number: value, IsNumber: true, Decimals: 1}
+}
+
+// localized escreve a célula como texto, com vírgula decimal.
+func (c Cell) localized() string {
+	if !c.IsNumber {
+		return c.Text
+	}
+	return strings.Replace(strconv.FormatFloat(c.Number, 'f', c.Decimals, 64), ".", ",", 1)
+}
+
+// SpreadsheetFormatFromPath deduz o formato da planilha pela extensão do arquivo (.xlsx, .ods ou .csv).
+func SpreadsheetFormatFromPath(path string) (string, error) {
+	switch ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), ".")); ext {
+	case FormatXLSX, FormatODS, FormatCSV:
+		return ext, nil
+	}
+	return "", fmt.Errorf("extensão de arquivo não suportada em '%s': use .xlsx, .ods ou .csv", path)
+}
+
+// WriteWorkbook escreve a planilha em 'w' no formato indicado (FormatXLSX, FormatODS ou FormatCSV).
+func WriteWorkbook(w io.Writer, format string, book Workbook) error {
+	if len(book.Sheets) == 0 {
+		return fmt.Errorf("nenhuma aba para gerar")
+	}
+	switch format {
+	case FormatXLSX:
+		return WriteXLSX(w, book)
+	case FormatODS:
+		return WriteODS(w, book)
+	case FormatCSV:
+		return WriteWorkbookCSV(w, book)
+	}
+	return fmt.Errorf("formato de planilha desconhecido '%s'", format)
+}
+
+// sheetName limpa o nome da aba: sem os caracteres proibidos nas planilhas e com até 31 caracteres.
+func sheetName(name string, index int) string {
+	name = strings.NewReplacer(":", " ", "\\", " ", "/", " ", "?", " ", "*", " ", "[", "(", "]", ")").Replace(name)
+	if runes := []rune(strings.TrimSpace(name)); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if strings.TrimSpace(name) == "" {
+		return fmt.Sprintf("Aba %d", index+1)
+	}
+	return strings.TrimSpace(name)
+}
+
+// WriteWorkbookCSV escreve as abas em um único CSV no padrão das planilhas em português: colunas
+// separadas por ponto e vírgula e vírgula decimal. Cada aba começa com o seu nome e é separada da
+// seguinte por uma linha em branco.
+func WriteWorkbookCSV(w io.Writer, book Workbook) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+	for i, sheet := range book.Sheets {
+		if i > 0 {
+			writer.Write([]string{""})
+		}
+		writer.Write([]string{sheetName(sheet.Name, i)})
+		for _, line := range sheet.Info {
+			writer.Write([]string{line})
+		}
+		writer.Write(sheet.Columns)
+		for _, row := range sheet.Rows {
+			cells := make([]string, len(row.Cells))
+			for j, c := range row.Cells {
+				cells[j] = c.localized()
+			}
+			writer.Write(cells)
+		}
+		for _, note := range sheet.Notes {
+			writer.Write([]string{note})
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("erro ao gerar CSV: %w", err)
+	}
+	return nil
+}
+
+// WriteXLSX escreve a planilha no formato do Excel. Os números usam o formato "0.0", que cada
+// programa exibe com o separador decimal do idioma do computador.
+func WriteXLSX(w io.Writer, book Workbook) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	header, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}, Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"DDDDDD"}}})
+	if err != nil {
+		return fmt.Errorf("erro ao gerar XLSX: %w", err)
+	}
+	title, _ := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	numFmt := "0.0"
+	number, _ := file.NewStyle(&excelize.Style{CustomNumFmt: &numFmt})
+	mutedText, _ := file.NewStyle(&excelize.Style{Font: &excelize.Font{Color: "888888", Italic: true}})
+	mutedNumber, _ := file.NewStyle(&excelize.Style{Font: &excelize.Font{Color: "888888", Italic: true}, CustomNumFmt: &numFmt})
+
+	for i, sheet := range book.Sheets {
+		name := sheetName(sheet.Name, i)
+		if i == 0 {
+			if err := file.SetSheetName("Sheet1", name); err != nil {
+				return fmt.Errorf("erro ao gerar XLSX: %w", err)
+			}
+		} else if _, err := file.NewSheet(name); err != nil {
+			return fmt.Errorf("erro ao gerar XLSX: aba '%s': %w", name, err)
+		}
+
+		row := 1
+		for _, line := range sheet.Info {
+			file.SetCellStr(name, cellName(1, row), line)
+			file.SetCellStyle(name, cellName(1, row), cellName(1, row), title)
+			row++
+		}
+		if len(sheet.Info) > 0 {
+			row++
+		}
+		for j, column := range sheet.Columns {
+			file.SetCellStr(name, cellName(j+1, row), column)
+		}
+		if len(sheet.Columns) > 0 {
+			file.SetCellStyle(name, cellName(1, row), cellName(len(sheet.Columns), row), header)
+			// Os títulos e a coluna dos nomes ficam visíveis ao rolar a planilha.
+			file.SetPanes(name, &excelize.Panes{Freeze: true, XSplit: min(2, len(sheet.Columns)), YSplit: row,
+				TopLeftCell: cellName(min(2, len(sheet.Columns))+1, row+1), ActivePane: "bottomRight"})
+		}
+		for _, r := range sheet.Rows {
+			row++
+			for j, c := range r.Cells {
+				ref := cellName(j+1, row)
+				style := 0
+				if c.IsNumber {
+					file.SetCellFloat(name, ref, c.Number, -1, 64)
+					style = number
+					if r.Muted {
+						style = mutedNumber
+					}
+				} else {
+					file.SetCellStr(name, ref, c.Text)
+					if r.Muted {
+						style = mutedText
+					}
+				}
+				if style != 0 {
+					file.SetCellStyle(name, ref, ref, style)
+				}
+			}
+		}
+		if len(sheet.Notes) > 0 {
+			row++
+		}
+		for _, note := range sheet.Notes {
+			row++
+			file.SetCellStr(name, cellName(1, row), note)
+		}
+		if len(sheet.Columns) > 1 {
+			file.SetColWidth(name, "B", "B", 32)
+		}
+	}
+	if err := file.Write(w); err != nil {
+		return fmt.Errorf("erro ao gerar XLSX: %w", err)
+	}
+	return nil
+}
+
+// cellName converte coluna e linha (a partir de 1) na referência da célula ("B3").
+func cellName(col, row int) string {
+	name, _ := excelize.CoordinatesToCellName(col, row)
+	return name
+}
+
+// Estilos e cabeçalhos do OpenDocument. Os números usam o estilo "N1" (uma casa decimal, no idioma
+// português do Brasil), e as células esmaecidas, o estilo "muted".
+const (
+	odsManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+ <manifest:file-entry manifest:full-path="/" manifest:media-type="application/vnd.oasis.opendocument.spreadsheet"/>
+ <manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>
+</manifest:manifest>
+`
+	odsContentHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" office:version="1.2">
+<office:automatic-styles>
+<number:number-style style:name="N1" number:language="pt" number:country="BR"><number:number number:decimal-places="1" number:min-decimal-places="1" number:min-integer-digits="1"/></number:number-style>
+<style:style style:name="num" style:family="table-cell" style:data-style-name="N1"/>
+<style:style style:name="head" style:family="table-cell"><style:table-cell-properties fo:background-color="#dddddd"/><style:text-properties fo:font-weight="bold"/></style:style>
+<style:style style:name="title" style:family="table-cell"><style:text-properties fo:font-weight="bold"/></style:style>
+<style:style style:name="muted" style:family="table-cell"><style:text-properties fo:color="#888888" fo:font-style="italic"/></style:style>
+<style:style style:name="mutednum" style:family="table-cell" style:data-style-name="N1"><style:text-properties fo:color="#888888" fo:font-style="italic"/></style:style>
+</office:automatic-styles>
+<office:body><office:spreadsheet>
+`
+	odsContentFooter = `</office:spreadsheet></office:body></office:document-content>
+`
+)
+
+// WriteODS escreve a planilha no formato OpenDocument (LibreOffice Calc).
+func WriteODS(w io.Writer, book Workbook) error {
+	archive := zip.NewWriter(w)
+	// O "mimetype" vem primeiro e sem compressão, como exige o formato.
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return fmt.Errorf("erro ao gerar ODS: %w", err)
+	}
+	io.WriteString(mimetype, "application/vnd.oasis.opendocument.spreadsheet")
+	manifest, err := archive.Create("META-INF/manifest.xml")
+	if err != nil {
+		return fmt.Errorf("erro ao gerar ODS: %w", err)
+	}
+	io.WriteString(manifest, odsManifest)
+
+	content, err := archive.Create("content.xml")
+	if err != nil {
+		return fmt.Errorf("erro ao gerar ODS: %w", err)
+	}
+	var b strings.Builder
+	b.WriteString(odsContentHeader)
+	for i, sheet := range book.Sheets {
+		fmt.Fprintf(&b, `<table:table table:name="%s">`+"\n", escapeXML(sheetName(sheet.Name, i)))
+		for _, line := range sheet.Info {
+			b.WriteString("<table:table-row>" + odsTextCell(line, "title") + "</table:table-row>\n")
+		}
+		if len(sheet.Info) > 0 {
+			b.WriteString("<table:table-row><table:table-cell/></table:table-row>\n")
+		}
+		b.WriteString("<table:table-row>")
+		for _, column := range sheet.Columns {
+			b.WriteString(odsTextCell(column, "head"))
+		}
+		b.WriteString("</table:table-row>\n")
+		for _, row := range sheet.Rows {
+			b.WriteString("<table:table-row>")
+			for _, c := range row.Cells {
+				switch {
+				case c.IsNumber:
+					style := "num"
+					if row.Muted {
+						style = "mutednum"
+					}
+					fmt.Fprintf(&b, `<table:table-cell table:style-name="%s" office:value-type="float" office:value="%s"><text:p>%s</text:p></table:table-cell>`,
+						style, strconv.FormatFloat(c.Number, 'f', -1, 64), c.localized())
+				case row.Muted:
+					b.WriteString(odsTextCell(c.Text, "muted"))
+				default:
+					b.WriteString(odsTextCell(c.Text, ""))
+				}
+			}
+			b.WriteString("</table:table-row>\n")
+		}
+		if len(sheet.Notes) > 0 {
+			b.WriteString("<table:table-row><table:table-cell/></table:table-row>\n")
+		}
+		for _, note := range sheet.Notes {
+			b.WriteString("<table:table-row>" + odsTextCell(note, "") + "</table:table-row>\n")
+		}
+		b.WriteString("</table:table>\n")
+	}
+	b.WriteString(odsContentFooter)
+	io.WriteString(content, b.String())
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("erro ao gerar ODS: %w", err)
+	}
+	return nil
+}
+
+// odsTextCell escreve uma célula de texto do OpenDocument, com o estilo informado (ou nenhum).
+func odsTextCell(text, style string) string {
+	if text == "" && style == "" {
+		return "<table:table-cell/>"
+	}
+	attr := ""
+	if style != "" {
+		attr = fmt.Sprintf(` table:style-name="%s"`, style)
+	}
+	return fmt.Sprintf(`<table:table-cell%s office:value-type="string"><text:p>%s</text:p></table:table-cell>`, attr, escapeXML(text))
+}
+
+func escapeXML(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
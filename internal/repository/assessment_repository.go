@@ -4,7 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	// "time" // Not strictly needed here anymore
+	"time"
+
 	"vigenda/internal/models"
 )
 
@@ -16,10 +17,18 @@ func NewAssessmentRepository(db *sql.DB) AssessmentRepository {
 	return &assessmentRepository{db: db}
 }
 
+// CreateAssessment grava a avaliação e, nas recuperações, as avaliações que ela recupera, numa
+// única transação.
 func (r *assessmentRepository) CreateAssessment(ctx context.Context, assessment *models.Assessment) (int64, error) {
-	query := `INSERT INTO assessments (class_id, name, term, weight, assessment_date)
-              VALUES (?, ?, ?, ?, ?)`
-	result, err := r.db.ExecContext(ctx, query, assessment.ClassID, assessment.Name, assessment.Term, assessment.Weight, assessment.AssessmentDate)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("assessmentRepository.CreateAssessment: failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // Ignorado após o Commit.
+
+	query := `INSERT INTO assessments (class_id, name, term, weight, assessment_date, category, kind)
+              VALUES (?, ?, ?, ?, ?, ?, ?)`
+	result, err := tx.ExecContext(ctx, query, assessment.ClassID, assessment.Name, assessment.Term, assessment.Weight, assessment.AssessmentDate, assessment.Category, assessment.Kind)
 	if err != nil {
 		return 0, fmt.Errorf("assessmentRepository.CreateAssessment: %w", err)
 	}
@@ -27,11 +36,47 @@ func (r *assessmentRepository) CreateAssessment(ctx context.Context, assessment
 	if err != nil {
 		return 0, fmt.Errorf("assessmentRepository.CreateAssessment: failed to get last insert ID: %w", err)
 	}
+	for _, recoveredID := range assessment.RecoveryOf {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO assessment_recoveries (recovery_id, assessment_id) VALUES (?, ?)`, id, recoveredID); err != nil {
+			return 0, fmt.Errorf("assessmentRepository.CreateAssessment: linking recovered assessment %d: %w", recoveredID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("assessmentRepository.CreateAssessment: failed to commit: %w", err)
+	}
 	return id, nil
 }
 
+// loadRecoveryLinks preenche RecoveryOf das recuperações entre as avaliações informadas.
+func (r *assessmentRepository) loadRecoveryLinks(ctx context.Context, assessments []models.Assessment) error {
+	index := make(map[int64]int)
+	for i, a := range assessments {
+		if a.Kind == models.AssessmentKindRecovery {
+			index[a.ID] = i
+		}
+	}
+	if len(index) == 0 {
+		return nil
+	}
+	rows, err := r.db.QueryContext(ctx, `SELECT recovery_id, assessment_id FROM assessment_recoveries ORDER BY recovery_id, assessment_id`)
+	if err != nil {
+		return fmt.Errorf("fetching recovery links: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var recoveryID, assessmentID int64
+		if err := rows.Scan(&recoveryID, &assessmentID); err != nil {
+			return fmt.Errorf("scanning recovery link: %w", err)
+		}
+		if i, ok := index[recoveryID]; ok {
+			assessments[i].RecoveryOf = append(assessments[i].RecoveryOf, assessmentID)
+		}
+	}
+	return rows.Err()
+}
+
 func (r *assessmentRepository) GetAssessmentByID(ctx context.Context, assessmentID int64) (*models.Assessment, error) {
-	query := `SELECT id, class_id, name, term, weight, assessment_date
+	query := `SELECT id, class_id, name, term, weight, assessment_date, category, kind
               FROM assessments WHERE id = ?`
 	row := r.db.QueryRowContext(ctx, query, assessmentID)
 	assessment := &models.Assessment{}
@@ -42,14 +87,20 @@ func (r *assessmentRepository) GetAssessmentByID(ctx context.Context, assessment
 		&assessment.Term,
 		&assessment.Weight,
 		&assessment.AssessmentDate,
+		&assessment.Category,
+		&assessment.Kind,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("assessmentRepository.GetAssessmentByID: no assessment found with ID %d", assessmentID)
+			return nil, fmt.Errorf("assessmentRepository.GetAssessmentByID: no assessment found with ID %d: %w", assessmentID, err)
 		}
 		return nil, fmt.Errorf("assessmentRepository.GetAssessmentByID: %w", err)
 	}
-	return assessment, nil
+	links := []models.Assessment{*assessment}
+	if err := r.loadRecoveryLinks(ctx, links); err != nil {
+		return nil, fmt.Errorf("assessmentRepository.GetAssessmentByID: %w", err)
+	}
+	return &links[0], nil
 }
 
 func (r *assessmentRepository) GetStudentsByClassID(ctx context.Context, classID int64) ([]models.Student, error) {
@@ -98,6 +149,29 @@ func (r *assessmentRepository) EnterGrade(ctx context.Context, grade *models.Gra
 	return nil
 }
 
+// EnterGrades grava as notas numa única transação, com a mesma regra de EnterGrade para notas já lançadas.
+func (r *assessmentRepository) EnterGrades(ctx context.Context, grades []models.Grade) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("assessmentRepository.EnterGrades: failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // Ignorado após o Commit.
+
+	query := `INSERT INTO grades (assessment_id, student_id, grade)
+              VALUES (?, ?, ?)
+              ON CONFLICT(assessment_id, student_id) DO UPDATE SET
+              grade = excluded.grade`
+	for _, grade := range grades {
+		if _, err := tx.ExecContext(ctx, query, grade.AssessmentID, grade.StudentID, grade.Grade); err != nil {
+			return fmt.Errorf("assessmentRepository.EnterGrades: student %d: %w", grade.StudentID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("assessmentRepository.EnterGrades: failed to commit: %w", err)
+	}
+	return nil
+}
+
 func (r *assessmentRepository) GetGradesByAssessmentID(ctx context.Context, assessmentID int64) ([]models.Grade, error) {
 	query := `SELECT id, assessment_id, student_id, grade FROM grades WHERE assessment_id = ?`
 	rows, err := r.db.QueryContext(ctx, query, assessmentID)
@@ -123,7 +197,7 @@ func (r *assessmentRepository) GetGradesByAssessmentID(ctx context.Context, asse
 }
 
 func (r *assessmentRepository) FindAssessmentByNameAndClass(ctx context.Context, name string, classID int64) (*models.Assessment, error) {
-	query := `SELECT id, class_id, name, term, weight, assessment_date
+	query := `SELECT id, class_id, name, term, weight, assessment_date, category, kind
               FROM assessments WHERE name = ? AND class_id = ?`
 	row := r.db.QueryRowContext(ctx, query, name, classID)
 	assessment := &models.Assessment{}
@@ -134,6 +208,8 @@ func (r *assessmentRepository) FindAssessmentByNameAndClass(ctx context.Context,
 		&assessment.Term,
 		&assessment.Weight,
 		&assessment.AssessmentDate,
+		&assessment.Category,
+		&assessment.Kind,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -141,11 +217,15 @@ func (r *assessmentRepository) FindAssessmentByNameAndClass(ctx context.Context,
 		}
 		return nil, fmt.Errorf("assessmentRepository.FindAssessmentByNameAndClass: %w", err)
 	}
-	return assessment, nil
+	links := []models.Assessment{*assessment}
+	if err := r.loadRecoveryLinks(ctx, links); err != nil {
+		return nil, fmt.Errorf("assessmentRepository.FindAssessmentByNameAndClass: %w", err)
+	}
+	return &links[0], nil
 }
 
 func (r *assessmentRepository) GetGradesByClassID(ctx context.Context, classID int64) ([]models.Grade, []models.Assessment, []models.Student, error) {
-	assessmentsQuery := `SELECT id, class_id, name, term, weight, assessment_date FROM assessments WHERE class_id = ?`
+	assessmentsQuery := `SELECT id, class_id, name, term, weight, assessment_date, category, kind FROM assessments WHERE class_id = ?`
 	assessmentRows, err := r.db.QueryContext(ctx, assessmentsQuery, classID)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("assessmentRepository.GetGradesByClassID: fetching assessments: %w", err)
@@ -153,18 +233,20 @@ func (r *assessmentRepository) GetGradesByClassID(ctx context.Context, classID i
 	defer assessmentRows.Close()
 
 	var assessments []models.Assessment
-	assessmentMap := make(map[int64]models.Assessment)
 	for assessmentRows.Next() {
 		var a models.Assessment
-		if err := assessmentRows.Scan(&a.ID, &a.ClassID, &a.Name, &a.Term, &a.Weight, &a.AssessmentDate); err != nil {
+		if err := assessmentRows.Scan(&a.ID, &a.ClassID, &a.Name, &a.Term, &a.Weight, &a.AssessmentDate, &a.Category, &a.Kind); err != nil {
 			return nil, nil, nil, fmt.Errorf("assessmentRepository.GetGradesByClassID: scanning assessment: %w", err)
 		}
 		assessments = append(assessments, a)
-		assessmentMap[a.ID] = a
 	}
 	if err = assessmentRows.Err(); err != nil {
 		return nil, nil, nil, fmt.Errorf("assessmentRepository.GetGradesByClassID: iterating assessments: %w", err)
 	}
+	assessmentRows.Close()
+	if err := r.loadRecoveryLinks(ctx, assessments); err != nil {
+		return nil, nil, nil, fmt.Errorf("assessmentRepository.GetGradesByClassID: %w", err)
+	}
 
 	studentsQuery := `SELECT id, class_id, enrollment_id, full_name, status FROM students WHERE class_id = ?`
 	studentRows, err := r.db.QueryContext(ctx, studentsQuery, classID)
@@ -218,7 +300,7 @@ func (r *assessmentRepository) GetGradesByClassID(ctx context.Context, classID i
 }
 
 func (r *assessmentRepository) ListAllAssessments(ctx context.Context) ([]models.Assessment, error) {
-	query := `SELECT id, class_id, name, term, weight, assessment_date FROM assessments`
+	query := `SELECT id, class_id, name, term, weight, assessment_date, category, kind FROM assessments`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("assessmentRepository.ListAllAssessments: query failed: %w", err)
@@ -228,7 +310,7 @@ func (r *assessmentRepository) ListAllAssessments(ctx context.Context) ([]models
 	var assessments []models.Assessment
 	for rows.Next() {
 		var asm models.Assessment
-		if err := rows.Scan(&asm.ID, &asm.ClassID, &asm.Name, &asm.Term, &asm.Weight, &asm.AssessmentDate); err != nil {
+		if err := rows.Scan(&asm.ID, &asm.ClassID, &asm.Name, &asm.Term, &asm.Weight, &asm.AssessmentDate, &asm.Category, &asm.Kind); err != nil {
 			return nil, fmt.Errorf("assessmentRepository.ListAllAssessments: scan failed: %w", err)
 		}
 		assessments = append(assessments, asm)
@@ -237,10 +319,103 @@ func (r *assessmentRepository) ListAllAssessments(ctx context.Context) ([]models
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("assessmentRepository.ListAllAssessments: rows error: %w", err)
 	}
+	rows.Close()
+	if err := r.loadRecoveryLinks(ctx, assessments); err != nil {
+		return nil, fmt.Errorf("assessmentRepository.ListAllAssessments: %w", err)
+	}
+
+	return assessments, nil
+}
+
+// GetUpcomingAssessments busca as avaliações com data de aplicação entre 'from' e 'to', comparando
+// só os dias, ordenadas pela data, pela turma e pelo ID.
+func (r *assessmentRepository) GetUpcomingAssessments(ctx context.Context, from, to time.Time) ([]models.Assessment, error) {
+	query := `SELECT id, class_id, name, term, weight, assessment_date, category, kind FROM assessments
+		WHERE assessment_date IS NOT NULL
+		  AND date(assessment_date) >= date(?)
+		  AND date(assessment_date) <= date(?)
+		ORDER BY assessment_date ASC, class_id ASC, id ASC`
+	rows, err := r.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("assessmentRepository.GetUpcomingAssessments: query failed: %w", err)
+	}
+	defer rows.Close()
 
+	var assessments []models.Assessment
+	for rows.Next() {
+		var asm models.Assessment
+		if err := rows.Scan(&asm.ID, &asm.ClassID, &asm.Name, &asm.Term, &asm.Weight, &asm.AssessmentDate, &asm.Category, &asm.Kind); err != nil {
+			return nil, fmt.Errorf("assessmentRepository.GetUpcomingAssessments: scan failed: %w", err)
+		}
+		assessments = append(assessments, asm)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("assessmentRepository.GetUpcomingAssessments: rows error: %w", err)
+	}
+	rows.Close()
+	if err := r.loadRecoveryLinks(ctx, assessments); err != nil {
+		return nil, fmt.Errorf("assessmentRepository.GetUpcomingAssessments: %w", err)
+	}
 	return assessments, nil
 }
 
+func (r *assessmentRepository) UpdateAssessmentCategory(ctx context.Context, assessmentID int64, category string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE assessments SET category = ? WHERE id = ?`, category, assessmentID)
+	if err != nil {
+		return fmt.Errorf("assessmentRepository.UpdateAssessmentCategory: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("assessmentRepository.UpdateAssessmentCategory: could not get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("assessmentRepository.UpdateAssessmentCategory: no assessment found with ID %d: %w", assessmentID, sql.ErrNoRows)
+	}
+	return nil
+}
+
+func (r *assessmentRepository) GetReportCardComments(ctx context.Context, classID int64) (map[int64]string, error) {
+	query := `SELECT c.student_id, c.comment FROM report_card_comments c
+              JOIN students s ON s.id = c.student_id
+              WHERE s.class_id = ?`
+	rows, err := r.db.QueryContext(ctx, query, classID)
+	if err != nil {
+		return nil, fmt.Errorf("assessmentRepository.GetReportCardComments: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	comments := make(map[int64]string)
+	for rows.Next() {
+		var studentID int64
+		var comment string
+		if err := rows.Scan(&studentID, &comment); err != nil {
+			return nil, fmt.Errorf("assessmentRepository.GetReportCardComments: scan failed: %w", err)
+		}
+		comments[studentID] = comment
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("assessmentRepository.GetReportCardComments: rows error: %w", err)
+	}
+	return comments, nil
+}
+
+func (r *assessmentRepository) SaveReportCardComment(ctx context.Context, studentID int64, comment string) error {
+	if comment == "" {
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM report_card_comments WHERE student_id = ?`, studentID); err != nil {
+			return fmt.Errorf("assessmentRepository.SaveReportCardComment: %w", err)
+		}
+		return nil
+	}
+	query := `INSERT INTO report_card_comments (student_id, comment)
+              VALUES (?, ?)
+              ON CONFLICT(student_id) DO UPDATE SET
+              comment = excluded.comment, updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.db.ExecContext(ctx, query, studentID, comment); err != nil {
+		return fmt.Errorf("assessmentRepository.SaveReportCardComment: %w", err)
+	}
+	return nil
+}
+
 func (r *assessmentRepository) DeleteAssessment(ctx context.Context, assessmentID int64) error {
 	query := `DELETE FROM assessments WHERE id = ?`
 	result, err := r.db.ExecContext(ctx, query, assessmentID)
@@ -252,7 +427,7 @@ func (r *assessmentRepository) DeleteAssessment(ctx context.Context, assessmentI
 		return fmt.Errorf("assessmentRepository.DeleteAssessment: could not get rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("assessmentRepository.DeleteAssessment: no assessment found with ID %d", assessmentID)
+		return fmt.Errorf("assessmentRepository.DeleteAssessment: no assessment found with ID %d: %w", assessmentID, err)
 	}
 	return nil
 }
@@ -0,0 +1,80 @@
+// Package repository contém as implementações concretas das interfaces de repositório
+// definidas no pacote pai 'repository'. Este arquivo específico implementa o AttendanceRepository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"vigenda/internal/models"
+)
+
+// attendanceRepository é a implementação concreta de AttendanceRepository sobre a tabela 'attendance'.
+type attendanceRepository struct {
+	db *sql.DB
+}
+
+// NewAttendanceRepository cria e retorna uma nova instância de AttendanceRepository.
+func NewAttendanceRepository(db *sql.DB) AttendanceRepository {
+	return &attendanceRepository{db: db}
+}
+
+// SaveAttendance grava a chamada de uma aula numa única transação: ou todos os alunos são
+// registrados, ou nenhum.
+func (r *attendanceRepository) SaveAttendance(ctx context.Context, lessonID int64, records []models.AttendanceRecord) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("attendanceRepository.SaveAttendance: erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback() // Ignorado após o Commit.
+
+	query := `INSERT INTO attendance (lesson_id, student_id, status) VALUES (?, ?, ?)
+              ON CONFLICT (lesson_id, student_id) DO UPDATE SET status = excluded.status, recorded_at = CURRENT_TIMESTAMP`
+	for _, record := range records {
+		if _, err := tx.ExecContext(ctx, query, lessonID, record.StudentID, record.Status); err != nil {
+			return fmt.Errorf("attendanceRepository.SaveAttendance: erro ao gravar aluno ID %d: %w", record.StudentID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("attendanceRepository.SaveAttendance: erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// GetAttendanceByLessonID retorna os registros de chamada de uma aula.
+func (r *attendanceRepository) GetAttendanceByLessonID(ctx context.Context, lessonID int64) ([]models.AttendanceRecord, error) {
+	query := `SELECT id, lesson_id, student_id, status FROM attendance WHERE lesson_id = ? ORDER BY student_id`
+	return r.query(ctx, "GetAttendanceByLessonID", query, lessonID)
+}
+
+// GetAttendanceByClassID retorna os registros de chamada das aulas de uma turma. Registros de aulas
+// já removidas são ignorados.
+func (r *attendanceRepository) GetAttendanceByClassID(ctx context.Context, classID int64) ([]models.AttendanceRecord, error) {
+	query := `SELECT a.id, a.lesson_id, a.student_id, a.status
+              FROM attendance a
+              JOIN lessons l ON l.id = a.lesson_id
+              WHERE l.class_id = ?
+              ORDER BY a.lesson_id, a.student_id`
+	return r.query(ctx, "GetAttendanceByClassID", query, classID)
+}
+
+func (r *attendanceRepository) query(ctx context.Context, op, query string, args ...interface{}) ([]models.AttendanceRecord, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("attendanceRepository.%s: erro ao consultar chamada: %w", op, err)
+	}
+	defer rows.Close()
+
+	var records []models.AttendanceRecord
+	for rows.Next() {
+		var record models.AttendanceRecord
+		if err := rows.Scan(&record.ID, &record.LessonID, &record.StudentID, &record.Status); err != nil {
+			return nil, fmt.Errorf("attendanceRepository.%s: erro ao escanear registro: %w", op, err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("attendanceRepository.%s: erro ao iterar linhas: %w", op, err)
+	}
+	return records, nil
+}
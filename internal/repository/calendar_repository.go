@@ -0,0 +1,185 @@
+// Package repository contém as implementações concretas das interfaces de repositório
+// definidas no pacote pai 'repository'. Este arquivo específico implementa o CalendarRepository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"vigenda/internal/models"
+)
+
+// calendarRepository é a implementação concreta de CalendarRepository sobre as tabelas
+// 'academic_terms' e 'calendar_events'. As datas são gravadas como texto AAAA-MM-DD.
+type calendarRepository struct {
+	db *sql.DB
+}
+
+// NewCalendarRepository cria e retorna uma nova instância de CalendarRepository.
+func NewCalendarRepository(db *sql.DB) CalendarRepository {
+	return &calendarRepository{db: db}
+}
+
+const upsertTermQuery = `INSERT INTO academic_terms (year, number, start_date, end_date) VALUES (?, ?, ?, ?)
+              ON CONFLICT(year, number) DO UPDATE SET start_date = excluded.start_date, end_date = excluded.end_date`
+
+const insertEventQuery = `INSERT OR IGNORE INTO calendar_events (kind, description, start_date, end_date) VALUES (?, ?, ?, ?)`
+
+// UpsertTerm grava as datas de um bimestre, substituindo as do mesmo ano e número.
+func (r *calendarRepository) UpsertTerm(ctx context.Context, term *models.AcademicTerm) (int64, error) {
+	_, err := r.db.ExecContext(ctx, upsertTermQuery, term.Year, term.Number,
+		term.StartDate.Format(planDateLayout), term.EndDate.Format(planDateLayout))
+	if err != nil {
+		return 0, fmt.Errorf("calendarRepository.UpsertTerm: erro ao gravar bimestre: %w", err)
+	}
+	// LastInsertId não é confiável quando o ON CONFLICT atualiza a linha existente.
+	var id int64
+	err = r.db.QueryRowContext(ctx, `SELECT id FROM academic_terms WHERE year = ? AND number = ?`, term.Year, term.Number).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("calendarRepository.UpsertTerm: erro ao obter ID do bimestre: %w", err)
+	}
+	return id, nil
+}
+
+// ListTerms retorna todos os bimestres, ordenados pela data de início.
+func (r *calendarRepository) ListTerms(ctx context.Context) ([]models.AcademicTerm, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, year, number, start_date, end_date FROM academic_terms ORDER BY start_date ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("calendarRepository.ListTerms: erro ao consultar bimestres: %w", err)
+	}
+	defer rows.Close()
+
+	var terms []models.AcademicTerm
+	for rows.Next() {
+		var term models.AcademicTerm
+		var start, end string
+		if err := rows.Scan(&term.ID, &term.Year, &term.Number, &start, &end); err != nil {
+			return nil, fmt.Errorf("calendarRepository.ListTerms: erro ao escanear bimestre: %w", err)
+		}
+		if term.StartDate, term.EndDate, err = parseCalendarDates(start, end); err != nil {
+			return nil, fmt.Errorf("calendarRepository.ListTerms: bimestre ID %d: %w", term.ID, err)
+		}
+		terms = append(terms, term)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("calendarRepository.ListTerms: erro ao iterar linhas: %w", err)
+	}
+	return terms, nil
+}
+
+// DeleteTerm remove um bimestre pelo ID.
+func (r *calendarRepository) DeleteTerm(ctx context.Context, termID int64) error {
+	return r.deleteByID(ctx, "DeleteTerm", `DELETE FROM academic_terms WHERE id = ?`, "bimestre", termID)
+}
+
+// CreateEvent adiciona um evento ao calendário e retorna seu ID.
+func (r *calendarRepository) CreateEvent(ctx context.Context, event *models.CalendarEvent) (int64, error) {
+	query := `INSERT INTO calendar_events (kind, description, start_date, end_date) VALUES (?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, event.Kind, event.Description,
+		event.StartDate.Format(planDateLayout), event.EndDate.Format(planDateLayout))
+	if err != nil {
+		return 0, fmt.Errorf("calendarRepository.CreateEvent: erro ao inserir evento: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("calendarRepository.CreateEvent: erro ao obter ID inserido: %w", err)
+	}
+	return id, nil
+}
+
+// ListEvents retorna os eventos que têm algum dia entre 'from' e 'to' (inclusive), ordenados pelo início.
+func (r *calendarRepository) ListEvents(ctx context.Context, from, to time.Time) ([]models.CalendarEvent, error) {
+	query := `SELECT id, kind, description, start_date, end_date
+              FROM calendar_events
+              WHERE start_date <= ? AND end_date >= ?
+              ORDER BY start_date ASC, id ASC`
+	rows, err := r.db.QueryContext(ctx, query, to.Format(planDateLayout), from.Format(planDateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("calendarRepository.ListEvents: erro ao consultar eventos: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.CalendarEvent
+	for rows.Next() {
+		var event models.CalendarEvent
+		var start, end string
+		if err := rows.Scan(&event.ID, &event.Kind, &event.Description, &start, &end); err != nil {
+			return nil, fmt.Errorf("calendarRepository.ListEvents: erro ao escanear evento: %w", err)
+		}
+		if event.StartDate, event.EndDate, err = parseCalendarDates(start, end); err != nil {
+			return nil, fmt.Errorf("calendarRepository.ListEvents: evento ID %d: %w", event.ID, err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("calendarRepository.ListEvents: erro ao iterar linhas: %w", err)
+	}
+	return events, nil
+}
+
+// DeleteEvent remove um evento pelo ID.
+func (r *calendarRepository) DeleteEvent(ctx context.Context, eventID int64) error {
+	return r.deleteByID(ctx, "DeleteEvent", `DELETE FROM calendar_events WHERE id = ?`, "evento", eventID)
+}
+
+// ImportCalendar grava bimestres e eventos numa única transação, para que uma importação com
+// erro não deixe o calendário pela metade.
+func (r *calendarRepository) ImportCalendar(ctx context.Context, terms []models.AcademicTerm, events []models.CalendarEvent) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("calendarRepository.ImportCalendar: erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback() // Ignorado após o Commit.
+
+	for _, term := range terms {
+		if _, err := tx.ExecContext(ctx, upsertTermQuery, term.Year, term.Number,
+			term.StartDate.Format(planDateLayout), term.EndDate.Format(planDateLayout)); err != nil {
+			return 0, fmt.Errorf("calendarRepository.ImportCalendar: erro ao gravar %dº bimestre de %d: %w", term.Number, term.Year, err)
+		}
+	}
+	created := 0
+	for _, event := range events {
+		result, err := tx.ExecContext(ctx, insertEventQuery, event.Kind, event.Description,
+			event.StartDate.Format(planDateLayout), event.EndDate.Format(planDateLayout))
+		if err != nil {
+			return 0, fmt.Errorf("calendarRepository.ImportCalendar: erro ao inserir evento '%s': %w", event.Description, err)
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			created += int(n)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("calendarRepository.ImportCalendar: erro ao confirmar transação: %w", err)
+	}
+	return created, nil
+}
+
+func (r *calendarRepository) deleteByID(ctx context.Context, op, query, label string, id int64) error {
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("calendarRepository.%s: erro ao remover %s: %w", op, label, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("calendarRepository.%s: erro ao verificar linhas afetadas: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("calendarRepository.%s: nenhum %s encontrado com ID %d: %w", op, label, id, sql.ErrNoRows)
+	}
+	return nil
+}
+
+// parseCalendarDates converte as datas AAAA-MM-DD gravadas para o horário local.
+func parseCalendarDates(start, end string) (time.Time, time.Time, error) {
+	startDate, err := time.ParseInLocation(planDateLayout, start, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("data de início inválida '%s': %w", start, err)
+	}
+	endDate, err := time.ParseInLocation(planDateLayout, end, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("data de fim inválida '%s': %w", end, err)
+	}
+	return startDate, endDate, nil
+}
@@ -47,7 +47,7 @@ func (r *classRepository) GetClassByID(ctx context.Context, id int64) (*models.C
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("classRepository.GetClassByID: no class found with ID %d", id)
+			return nil, fmt.Errorf("classRepository.GetClassByID: no class found with ID %d: %w", id, err)
 		}
 		return nil, fmt.Errorf("classRepository.GetClassByID: %w", err)
 	}
@@ -125,7 +125,7 @@ func (r *classRepository) GetStudentByID(ctx context.Context, studentID int64) (
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("classRepository.GetStudentByID: no student found with ID %d", studentID)
+			return nil, fmt.Errorf("classRepository.GetStudentByID: no student found with ID %d: %w", studentID, err)
 		}
 		return nil, fmt.Errorf("classRepository.GetStudentByID: %w", err)
 	}
@@ -172,7 +172,7 @@ func (r *classRepository) UpdateStudentStatus(ctx context.Context, studentID int
 		return fmt.Errorf("classRepository.UpdateStudentStatus: checking rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("classRepository.UpdateStudentStatus: no student found with ID %d", studentID)
+		return fmt.Errorf("classRepository.UpdateStudentStatus: no student found with ID %d: %w", studentID, err)
 	}
 	return nil
 }
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// IsNotFound informa se o erro indica que o registro buscado não existe (sql.ErrNoRows na cadeia).
+func IsNotFound(err error) bool {
+	return errors.Is(err, sql.ErrNoRows)
+}
+
+// IsUniqueViolation informa se o erro vem de uma restrição de unicidade ou chave primária
+// violada no banco (SQLite ou PostgreSQL), ou seja, um registro duplicado.
+func IsUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505" // unique_violation
+	}
+	return false
+}
+
+// IsForeignKeyViolation informa se o erro vem de uma referência a um registro inexistente.
+func IsForeignKeyViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintForeignKey
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23503" // foreign_key_violation
+	}
+	return false
+}
+
+// IsStorageError informa se o erro foi produzido pelo driver do banco ou pelo database/sql
+// (banco indisponível, arquivo corrompido, transação encerrada etc.).
+func IsStorageError(err error) bool {
+	var sqliteErr sqlite3.Error
+	var pqErr *pq.Error
+	return errors.As(err, &sqliteErr) || errors.As(err, &pqErr) ||
+		errors.Is(err, sql.ErrConnDone) || errors.Is(err, sql.ErrTxDone)
+}
@@ -0,0 +1,114 @@
+// Package repository contém as implementações concretas das interfaces de repositório
+// definidas no pacote pai 'repository'. Este arquivo específico implementa o GradingPolicyRepository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"vigenda/internal/models"
+)
+
+// gradingPolicyRepository é a implementação concreta de GradingPolicyRepository sobre as tabelas
+// 'grading_policies', 'grading_policy_categories' e 'grading_policy_concepts'.
+type gradingPolicyRepository struct {
+	db *sql.DB
+}
+
+// NewGradingPolicyRepository cria e retorna uma nova instância de GradingPolicyRepository.
+func NewGradingPolicyRepository(db *sql.DB) GradingPolicyRepository {
+	return &gradingPolicyRepository{db: db}
+}
+
+// GetGradingPolicy busca a política de notas de uma turma, com os pesos das categorias e as faixas
+// dos conceitos (da maior nota mínima para a menor).
+func (r *gradingPolicyRepository) GetGradingPolicy(ctx context.Context, classID int64) (*models.GradingPolicy, error) {
+	policy := models.GradingPolicy{ClassID: classID}
+	err := r.db.QueryRowContext(ctx, `SELECT scheme, best_of, rounding_step, grade_scale, passing_grade, recovery_rule FROM grading_policies WHERE class_id = ?`, classID).
+		Scan(&policy.Scheme, &policy.BestOf, &policy.RoundingStep, &policy.GradeScale, &policy.PassingGrade, &policy.RecoveryRule)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("gradingPolicyRepository.GetGradingPolicy: turma ID %d sem política de notas: %w", classID, err)
+		}
+		return nil, fmt.Errorf("gradingPolicyRepository.GetGradingPolicy: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT category, weight FROM grading_policy_categories WHERE class_id = ? ORDER BY category`, classID)
+	if err != nil {
+		return nil, fmt.Errorf("gradingPolicyRepository.GetGradingPolicy: erro ao consultar categorias: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var category string
+		var weight float64
+		if err := rows.Scan(&category, &weight); err != nil {
+			return nil, fmt.Errorf("gradingPolicyRepository.GetGradingPolicy: erro ao escanear categoria: %w", err)
+		}
+		if policy.CategoryWeights == nil {
+			policy.CategoryWeights = make(map[string]float64)
+		}
+		policy.CategoryWeights[category] = weight
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gradingPolicyRepository.GetGradingPolicy: erro ao iterar categorias: %w", err)
+	}
+
+	conceptRows, err := r.db.QueryContext(ctx, `SELECT concept, min_grade FROM grading_policy_concepts WHERE class_id = ? ORDER BY min_grade DESC`, classID)
+	if err != nil {
+		return nil, fmt.Errorf("gradingPolicyRepository.GetGradingPolicy: erro ao consultar conceitos: %w", err)
+	}
+	defer conceptRows.Close()
+	for conceptRows.Next() {
+		var concept models.ConceptRange
+		if err := conceptRows.Scan(&concept.Concept, &concept.MinGrade); err != nil {
+			return nil, fmt.Errorf("gradingPolicyRepository.GetGradingPolicy: erro ao escanear conceito: %w", err)
+		}
+		policy.ConceptRanges = append(policy.ConceptRanges, concept)
+	}
+	if err := conceptRows.Err(); err != nil {
+		return nil, fmt.Errorf("gradingPolicyRepository.GetGradingPolicy: erro ao iterar conceitos: %w", err)
+	}
+	return &policy, nil
+}
+
+// SaveGradingPolicy grava (cria ou substitui) a política de notas de uma turma, com as categorias e os
+// conceitos, numa única transação.
+func (r *gradingPolicyRepository) SaveGradingPolicy(ctx context.Context, policy *models.GradingPolicy) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gradingPolicyRepository.SaveGradingPolicy: erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback() // Ignorado após o Commit.
+
+	query := `INSERT INTO grading_policies (class_id, scheme, best_of, rounding_step, grade_scale, passing_grade, recovery_rule)
+              VALUES (?, ?, ?, ?, ?, ?, ?)
+              ON CONFLICT (class_id) DO UPDATE SET scheme = excluded.scheme, best_of = excluded.best_of,
+              rounding_step = excluded.rounding_step, grade_scale = excluded.grade_scale, passing_grade = excluded.passing_grade,
+              recovery_rule = excluded.recovery_rule, updated_at = CURRENT_TIMESTAMP`
+	if _, err := tx.ExecContext(ctx, query, policy.ClassID, policy.Scheme, policy.BestOf, policy.RoundingStep, policy.GradeScale,
+		policy.PassingGrade, policy.RecoveryRule); err != nil {
+		return fmt.Errorf("gradingPolicyRepository.SaveGradingPolicy: erro ao gravar política: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM grading_policy_categories WHERE class_id = ?`, policy.ClassID); err != nil {
+		return fmt.Errorf("gradingPolicyRepository.SaveGradingPolicy: erro ao remover categorias: %w", err)
+	}
+	for category, weight := range policy.CategoryWeights {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO grading_policy_categories (class_id, category, weight) VALUES (?, ?, ?)`,
+			policy.ClassID, category, weight); err != nil {
+			return fmt.Errorf("gradingPolicyRepository.SaveGradingPolicy: erro ao gravar categoria '%s': %w", category, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM grading_policy_concepts WHERE class_id = ?`, policy.ClassID); err != nil {
+		return fmt.Errorf("gradingPolicyRepository.SaveGradingPolicy: erro ao remover conceitos: %w", err)
+	}
+	for _, concept := range policy.ConceptRanges {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO grading_policy_concepts (class_id, concept, min_grade) VALUES (?, ?, ?)`,
+			policy.ClassID, concept.Concept, concept.MinGrade); err != nil {
+			return fmt.Errorf("gradingPolicyRepository.SaveGradingPolicy: erro ao gravar conceito '%s': %w", concept.Concept, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gradingPolicyRepository.SaveGradingPolicy: erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
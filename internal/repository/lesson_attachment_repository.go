@@ -0,0 +1,97 @@
+// Package repository contém as implementações concretas das interfaces de repositório
+// definidas no pacote pai 'repository'. Este arquivo específico implementa o LessonAttachmentRepository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"vigenda/internal/models"
+)
+
+// lessonAttachmentRepository é a implementação concreta de LessonAttachmentRepository sobre a tabela 'lesson_attachments'.
+type lessonAttachmentRepository struct {
+	db *sql.DB
+}
+
+// NewLessonAttachmentRepository cria e retorna uma nova instância de LessonAttachmentRepository.
+func NewLessonAttachmentRepository(db *sql.DB) LessonAttachmentRepository {
+	return &lessonAttachmentRepository{db: db}
+}
+
+// CreateAttachment insere um novo anexo e retorna seu ID.
+func (r *lessonAttachmentRepository) CreateAttachment(ctx context.Context, attachment *models.LessonAttachment) (int64, error) {
+	query := `INSERT INTO lesson_attachments (lesson_id, kind, name, location) VALUES (?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, attachment.LessonID, attachment.Kind, attachment.Name, attachment.Location)
+	if err != nil {
+		return 0, fmt.Errorf("lessonAttachmentRepository.CreateAttachment: erro ao inserir anexo: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("lessonAttachmentRepository.CreateAttachment: erro ao obter ID inserido: %w", err)
+	}
+	return id, nil
+}
+
+// GetAttachmentByID busca um anexo pelo ID.
+func (r *lessonAttachmentRepository) GetAttachmentByID(ctx context.Context, attachmentID int64) (*models.LessonAttachment, error) {
+	query := `SELECT id, lesson_id, kind, name, location FROM lesson_attachments WHERE id = ?`
+	var attachment models.LessonAttachment
+	err := r.db.QueryRowContext(ctx, query, attachmentID).
+		Scan(&attachment.ID, &attachment.LessonID, &attachment.Kind, &attachment.Name, &attachment.Location)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("lessonAttachmentRepository.GetAttachmentByID: anexo com ID %d não encontrado: %w", attachmentID, err)
+		}
+		return nil, fmt.Errorf("lessonAttachmentRepository.GetAttachmentByID: %w", err)
+	}
+	return &attachment, nil
+}
+
+// ListAttachmentsByLessonID retorna os anexos de uma aula, na ordem em que foram adicionados.
+func (r *lessonAttachmentRepository) ListAttachmentsByLessonID(ctx context.Context, lessonID int64) ([]models.LessonAttachment, error) {
+	return r.list(ctx, "ListAttachmentsByLessonID", `WHERE lesson_id = ?`, lessonID)
+}
+
+// ListAllAttachments retorna todos os anexos, agrupados por aula.
+func (r *lessonAttachmentRepository) ListAllAttachments(ctx context.Context) ([]models.LessonAttachment, error) {
+	return r.list(ctx, "ListAllAttachments", "")
+}
+
+// DeleteAttachment remove um anexo pelo ID.
+func (r *lessonAttachmentRepository) DeleteAttachment(ctx context.Context, attachmentID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM lesson_attachments WHERE id = ?`, attachmentID)
+	if err != nil {
+		return fmt.Errorf("lessonAttachmentRepository.DeleteAttachment: erro ao remover anexo: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("lessonAttachmentRepository.DeleteAttachment: erro ao verificar linhas afetadas: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("lessonAttachmentRepository.DeleteAttachment: nenhum anexo encontrado com ID %d: %w", attachmentID, sql.ErrNoRows)
+	}
+	return nil
+}
+
+func (r *lessonAttachmentRepository) list(ctx context.Context, op, where string, args ...interface{}) ([]models.LessonAttachment, error) {
+	query := `SELECT id, lesson_id, kind, name, location FROM lesson_attachments ` + where + ` ORDER BY lesson_id ASC, id ASC`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("lessonAttachmentRepository.%s: erro ao consultar anexos: %w", op, err)
+	}
+	defer rows.Close()
+
+	var attachments []models.LessonAttachment
+	for rows.Next() {
+		var attachment models.LessonAttachment
+		if err := rows.Scan(&attachment.ID, &attachment.LessonID, &attachment.Kind, &attachment.Name, &attachment.Location); err != nil {
+			return nil, fmt.Errorf("lessonAttachmentRepository.%s: erro ao escanear anexo: %w", op, err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("lessonAttachmentRepository.%s: erro ao iterar linhas: %w", op, err)
+	}
+	return attachments, nil
+}
@@ -8,6 +8,15 @@ import (
 	"vigenda/internal/models"
 )
 
+// lessonColumns são as colunas lidas por scanLesson, na ordem esperada.
+const lessonColumns = `id, class_id, title, plan_content, scheduled_at, status, covered_content, reflection`
+
+// scanLesson lê uma linha com as colunas de lessonColumns.
+func scanLesson(row rowScanner, lesson *models.Lesson) error {
+	return row.Scan(&lesson.ID, &lesson.ClassID, &lesson.Title, &lesson.PlanContent, &lesson.ScheduledAt,
+		&lesson.Status, &lesson.CoveredContent, &lesson.Reflection)
+}
+
 type lessonRepositoryImpl struct {
 	db *sql.DB
 }
@@ -21,9 +30,14 @@ func (r *lessonRepositoryImpl) CreateLesson(ctx context.Context, lesson *models.
 	// Atualmente, models.Lesson não tem UserID, mas ClassID sim, que tem UserID.
 	// A propriedade pode ser inferida pela Class.
 	// A tabela 'lessons' conforme 001_initial_schema.sql não possui created_at, updated_at.
-	query := `INSERT INTO lessons (class_id, title, plan_content, scheduled_at)
-              VALUES (?, ?, ?, ?)`
-	result, err := r.db.ExecContext(ctx, query, lesson.ClassID, lesson.Title, lesson.PlanContent, lesson.ScheduledAt)
+	status := lesson.Status
+	if status == "" {
+		status = models.LessonStatusPlanned
+	}
+	query := `INSERT INTO lessons (class_id, title, plan_content, scheduled_at, status, covered_content, reflection)
+              VALUES (?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, lesson.ClassID, lesson.Title, lesson.PlanContent, lesson.ScheduledAt,
+		status, lesson.CoveredContent, lesson.Reflection)
 	if err != nil {
 		return 0, fmt.Errorf("lessonRepository.CreateLesson: %w", err)
 	}
@@ -35,14 +49,13 @@ func (r *lessonRepositoryImpl) CreateLesson(ctx context.Context, lesson *models.
 }
 
 func (r *lessonRepositoryImpl) GetLessonByID(ctx context.Context, lessonID int64) (*models.Lesson, error) {
-	query := `SELECT id, class_id, title, plan_content, scheduled_at
+	query := `SELECT ` + lessonColumns + `
               FROM lessons WHERE id = ?`
-	row := r.db.QueryRowContext(ctx, query, lessonID)
 	lesson := &models.Lesson{}
-	err := row.Scan(&lesson.ID, &lesson.ClassID, &lesson.Title, &lesson.PlanContent, &lesson.ScheduledAt)
+	err := scanLesson(r.db.QueryRowContext(ctx, query, lessonID), lesson)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("lesson with ID %d not found", lessonID)
+			return nil, fmt.Errorf("lesson with ID %d not found: %w", lessonID, err)
 		}
 		return nil, fmt.Errorf("lessonRepository.GetLessonByID: %w", err)
 	}
@@ -50,7 +63,7 @@ func (r *lessonRepositoryImpl) GetLessonByID(ctx context.Context, lessonID int64
 }
 
 func (r *lessonRepositoryImpl) GetLessonsByClassID(ctx context.Context, classID int64) ([]models.Lesson, error) {
-	query := `SELECT id, class_id, title, plan_content, scheduled_at
+	query := `SELECT ` + lessonColumns + `
               FROM lessons WHERE class_id = ? ORDER BY scheduled_at ASC`
 	rows, err := r.db.QueryContext(ctx, query, classID)
 	if err != nil {
@@ -61,7 +74,7 @@ func (r *lessonRepositoryImpl) GetLessonsByClassID(ctx context.Context, classID
 	var lessons []models.Lesson
 	for rows.Next() {
 		lesson := models.Lesson{}
-		if err := rows.Scan(&lesson.ID, &lesson.ClassID, &lesson.Title, &lesson.PlanContent, &lesson.ScheduledAt); err != nil {
+		if err := scanLesson(rows, &lesson); err != nil {
 			return nil, fmt.Errorf("lessonRepository.GetLessonsByClassID: scanning row: %w", err)
 		}
 		lessons = append(lessons, lesson)
@@ -88,14 +101,14 @@ func (r *lessonRepositoryImpl) GetLessonsByDateRange(ctx context.Context, userID
 
 
 	if userID > 0 {
-		query = `SELECT l.id, l.class_id, l.title, l.plan_content, l.scheduled_at
+		query = `SELECT l.id, l.class_id, l.title, l.plan_content, l.scheduled_at, l.status, l.covered_content, l.reflection
                  FROM lessons l
                  JOIN classes c ON l.class_id = c.id
                  WHERE c.user_id = ? AND l.scheduled_at >= ? AND l.scheduled_at <= ?
                  ORDER BY l.scheduled_at ASC`
 		args = append(args, userID, startDate, endDate)
 	} else { // Se userID for 0 ou negativo, busca para todas as turmas (comportamento de admin/sistema)
-		query = `SELECT id, class_id, title, plan_content, scheduled_at
+		query = `SELECT ` + lessonColumns + `
                  FROM lessons
                  WHERE scheduled_at >= ? AND scheduled_at <= ?
                  ORDER BY scheduled_at ASC`
@@ -111,7 +124,7 @@ func (r *lessonRepositoryImpl) GetLessonsByDateRange(ctx context.Context, userID
 	var lessons []models.Lesson
 	for rows.Next() {
 		lesson := models.Lesson{}
-		if err := rows.Scan(&lesson.ID, &lesson.ClassID, &lesson.Title, &lesson.PlanContent, &lesson.ScheduledAt); err != nil {
+		if err := scanLesson(rows, &lesson); err != nil {
 			return nil, fmt.Errorf("lessonRepository.GetLessonsByDateRange: scanning row: %w", err)
 		}
 		lessons = append(lessons, lesson)
@@ -124,11 +137,17 @@ func (r *lessonRepositoryImpl) GetLessonsByDateRange(ctx context.Context, userID
 
 func (r *lessonRepositoryImpl) UpdateLesson(ctx context.Context, lesson *models.Lesson) error {
 	// A tabela 'lessons' não possui updated_at. Se precisarmos, deve ser adicionada ao schema.
-	query := `UPDATE lessons SET class_id = ?, title = ?, plan_content = ?, scheduled_at = ?
+	query := `UPDATE lessons SET class_id = ?, title = ?, plan_content = ?, scheduled_at = ?,
+              status = ?, covered_content = ?, reflection = ?
               WHERE id = ?`
 	// Idealmente, verificaríamos também o UserID da turma associada para garantir propriedade,
 	// mas isso é mais lógico na camada de serviço.
-	_, err := r.db.ExecContext(ctx, query, lesson.ClassID, lesson.Title, lesson.PlanContent, lesson.ScheduledAt, lesson.ID)
+	status := lesson.Status
+	if status == "" {
+		status = models.LessonStatusPlanned
+	}
+	_, err := r.db.ExecContext(ctx, query, lesson.ClassID, lesson.Title, lesson.PlanContent, lesson.ScheduledAt,
+		status, lesson.CoveredContent, lesson.Reflection, lesson.ID)
 	if err != nil {
 		return fmt.Errorf("lessonRepository.UpdateLesson: %w", err)
 	}
@@ -136,12 +155,23 @@ func (r *lessonRepositoryImpl) UpdateLesson(ctx context.Context, lesson *models.
 }
 
 func (r *lessonRepositoryImpl) DeleteLesson(ctx context.Context, lessonID int64) error {
-	query := `DELETE FROM lessons WHERE id = ?`
 	// Similar ao Update, a verificação de propriedade (via UserID da Class)
 	// seria melhor na camada de serviço antes de chamar o delete.
-	_, err := r.db.ExecContext(ctx, query, lessonID)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("lessonRepository.DeleteLesson: erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback() // Ignorado após o Commit.
+
+	// A chamada da aula sai junto (o SQLite só aplica o ON DELETE CASCADE com as chaves estrangeiras habilitadas).
+	if _, err := tx.ExecContext(ctx, `DELETE FROM attendance WHERE lesson_id = ?`, lessonID); err != nil {
+		return fmt.Errorf("lessonRepository.DeleteLesson: erro ao remover chamada: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM lessons WHERE id = ?`, lessonID); err != nil {
 		return fmt.Errorf("lessonRepository.DeleteLesson: %w", err)
 	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("lessonRepository.DeleteLesson: erro ao confirmar transação: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,220 @@
+// Package repository contém as implementações concretas das interfaces de repositório
+// definidas no pacote pai 'repository'. Este arquivo específico implementa o LessonTemplateRepository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"vigenda/internal/models"
+)
+
+// lessonTemplateRepository é a implementação concreta de LessonTemplateRepository sobre as tabelas
+// 'lesson_templates', 'lesson_sequences' e 'lesson_sequence_items'.
+type lessonTemplateRepository struct {
+	db *sql.DB
+}
+
+// NewLessonTemplateRepository cria e retorna uma nova instância de LessonTemplateRepository.
+func NewLessonTemplateRepository(db *sql.DB) LessonTemplateRepository {
+	return &lessonTemplateRepository{db: db}
+}
+
+// CreateTemplate insere um novo modelo de plano de aula e retorna seu ID.
+func (r *lessonTemplateRepository) CreateTemplate(ctx context.Context, template *models.LessonTemplate) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `INSERT INTO lesson_templates (name, content) VALUES (?, ?)`, template.Name, template.Content)
+	if err != nil {
+		return 0, fmt.Errorf("lessonTemplateRepository.CreateTemplate: erro ao inserir modelo: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("lessonTemplateRepository.CreateTemplate: erro ao obter ID inserido: %w", err)
+	}
+	return id, nil
+}
+
+// GetTemplateByID busca um modelo pelo ID.
+func (r *lessonTemplateRepository) GetTemplateByID(ctx context.Context, templateID int64) (*models.LessonTemplate, error) {
+	var template models.LessonTemplate
+	err := r.db.QueryRowContext(ctx, `SELECT id, name, content FROM lesson_templates WHERE id = ?`, templateID).
+		Scan(&template.ID, &template.Name, &template.Content)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("lessonTemplateRepository.GetTemplateByID: modelo com ID %d não encontrado: %w", templateID, err)
+		}
+		return nil, fmt.Errorf("lessonTemplateRepository.GetTemplateByID: %w", err)
+	}
+	return &template, nil
+}
+
+// ListTemplates retorna todos os modelos, ordenados pelo nome.
+func (r *lessonTemplateRepository) ListTemplates(ctx context.Context) ([]models.LessonTemplate, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, content FROM lesson_templates ORDER BY name COLLATE NOCASE ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("lessonTemplateRepository.ListTemplates: erro ao consultar modelos: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []models.LessonTemplate
+	for rows.Next() {
+		var template models.LessonTemplate
+		if err := rows.Scan(&template.ID, &template.Name, &template.Content); err != nil {
+			return nil, fmt.Errorf("lessonTemplateRepository.ListTemplates: erro ao escanear modelo: %w", err)
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("lessonTemplateRepository.ListTemplates: erro ao iterar linhas: %w", err)
+	}
+	return templates, nil
+}
+
+// DeleteTemplate remove um modelo pelo ID.
+func (r *lessonTemplateRepository) DeleteTemplate(ctx context.Context, templateID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM lesson_templates WHERE id = ?`, templateID)
+	if err != nil {
+		return fmt.Errorf("lessonTemplateRepository.DeleteTemplate: erro ao remover modelo: %w", err)
+	}
+	return checkTemplateRowsAffected(result, "DeleteTemplate", fmt.Sprintf("nenhum modelo encontrado com ID %d", templateID))
+}
+
+// CreateSequence grava a sequência e os seus itens numa única transação. As posições dos itens
+// são numeradas a partir de 1, na ordem de sequence.Items.
+func (r *lessonTemplateRepository) CreateSequence(ctx context.Context, sequence *models.LessonSequence) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("lessonTemplateRepository.CreateSequence: erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback() // Ignorado após o Commit.
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO lesson_sequences (name, description) VALUES (?, ?)`, sequence.Name, sequence.Description)
+	if err != nil {
+		return 0, fmt.Errorf("lessonTemplateRepository.CreateSequence: erro ao inserir sequência: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("lessonTemplateRepository.CreateSequence: erro ao obter ID inserido: %w", err)
+	}
+	for i, item := range sequence.Items {
+		_, err := tx.ExecContext(ctx, `INSERT INTO lesson_sequence_items (sequence_id, position, title, plan_content) VALUES (?, ?, ?, ?)`,
+			id, i+1, item.Title, item.PlanContent)
+		if err != nil {
+			return 0, fmt.Errorf("lessonTemplateRepository.CreateSequence: erro ao inserir aula %d da sequência: %w", i+1, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("lessonTemplateRepository.CreateSequence: erro ao confirmar transação: %w", err)
+	}
+	return id, nil
+}
+
+// GetSequenceByID busca uma sequência pelo ID, com os itens em ordem.
+func (r *lessonTemplateRepository) GetSequenceByID(ctx context.Context, sequenceID int64) (*models.LessonSequence, error) {
+	var sequence models.LessonSequence
+	err := r.db.QueryRowContext(ctx, `SELECT id, name, description FROM lesson_sequences WHERE id = ?`, sequenceID).
+		Scan(&sequence.ID, &sequence.Name, &sequence.Description)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("lessonTemplateRepository.GetSequenceByID: sequência com ID %d não encontrada: %w", sequenceID, err)
+		}
+		return nil, fmt.Errorf("lessonTemplateRepository.GetSequenceByID: %w", err)
+	}
+	items, err := r.sequenceItems(ctx, "WHERE sequence_id = ?", sequenceID)
+	if err != nil {
+		return nil, fmt.Errorf("lessonTemplateRepository.GetSequenceByID: %w", err)
+	}
+	sequence.Items = items[sequence.ID]
+	return &sequence, nil
+}
+
+// ListSequences retorna todas as sequências, ordenadas pelo nome, com os itens em ordem.
+func (r *lessonTemplateRepository) ListSequences(ctx context.Context) ([]models.LessonSequence, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, description FROM lesson_sequences ORDER BY name COLLATE NOCASE ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("lessonTemplateRepository.ListSequences: erro ao consultar sequências: %w", err)
+	}
+	defer rows.Close()
+
+	var sequences []models.LessonSequence
+	for rows.Next() {
+		var sequence models.LessonSequence
+		if err := rows.Scan(&sequence.ID, &sequence.Name, &sequence.Description); err != nil {
+			return nil, fmt.Errorf("lessonTemplateRepository.ListSequences: erro ao escanear sequência: %w", err)
+		}
+		sequences = append(sequences, sequence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("lessonTemplateRepository.ListSequences: erro ao iterar linhas: %w", err)
+	}
+	rows.Close()
+
+	items, err := r.sequenceItems(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("lessonTemplateRepository.ListSequences: %w", err)
+	}
+	for i := range sequences {
+		sequences[i].Items = items[sequences[i].ID]
+	}
+	return sequences, nil
+}
+
+// DeleteSequence remove a sequência e os seus itens numa única transação (o SQLite só aplica o
+// ON DELETE CASCADE com as chaves estrangeiras habilitadas).
+func (r *lessonTemplateRepository) DeleteSequence(ctx context.Context, sequenceID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("lessonTemplateRepository.DeleteSequence: erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback() // Ignorado após o Commit.
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM lesson_sequence_items WHERE sequence_id = ?`, sequenceID); err != nil {
+		return fmt.Errorf("lessonTemplateRepository.DeleteSequence: erro ao remover aulas da sequência: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM lesson_sequences WHERE id = ?`, sequenceID)
+	if err != nil {
+		return fmt.Errorf("lessonTemplateRepository.DeleteSequence: erro ao remover sequência: %w", err)
+	}
+	if err := checkTemplateRowsAffected(result, "DeleteSequence", fmt.Sprintf("nenhuma sequência encontrada com ID %d", sequenceID)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("lessonTemplateRepository.DeleteSequence: erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// sequenceItems busca os itens das sequências que atendem ao filtro, agrupados pelo ID da sequência.
+func (r *lessonTemplateRepository) sequenceItems(ctx context.Context, where string, args ...interface{}) (map[int64][]models.LessonSequenceItem, error) {
+	query := `SELECT id, sequence_id, position, title, plan_content FROM lesson_sequence_items ` + where + ` ORDER BY sequence_id, position`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar aulas das sequências: %w", err)
+	}
+	defer rows.Close()
+
+	items := make(map[int64][]models.LessonSequenceItem)
+	for rows.Next() {
+		var item models.LessonSequenceItem
+		if err := rows.Scan(&item.ID, &item.SequenceID, &item.Position, &item.Title, &item.PlanContent); err != nil {
+			return nil, fmt.Errorf("erro ao escanear aula da sequência: %w", err)
+		}
+		items[item.SequenceID] = append(items[item.SequenceID], item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar aulas das sequências: %w", err)
+	}
+	return items, nil
+}
+
+// checkTemplateRowsAffected converte uma remoção sem linhas afetadas em sql.ErrNoRows.
+func checkTemplateRowsAffected(result sql.Result, op, notFound string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("lessonTemplateRepository.%s: erro ao verificar linhas afetadas: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("lessonTemplateRepository.%s: %s: %w", op, notFound, sql.ErrNoRows)
+	}
+	return nil
+}
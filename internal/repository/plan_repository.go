@@ -0,0 +1,97 @@
+// Package repository contém as implementações concretas das interfaces de repositório
+// definidas no pacote pai 'repository'. Este arquivo específico implementa o PlanRepository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"vigenda/internal/models"
+)
+
+// planDateLayout é o formato usado na coluna plan_date, independente de fuso horário.
+const planDateLayout = "2006-01-02"
+
+// planRepository é a implementação concreta de PlanRepository sobre a tabela 'day_plan_blocks'.
+type planRepository struct {
+	db *sql.DB
+}
+
+// NewPlanRepository cria e retorna uma nova instância de PlanRepository.
+func NewPlanRepository(db *sql.DB) PlanRepository {
+	return &planRepository{db: db}
+}
+
+// ReplaceDayPlan apaga os blocos existentes do dia e insere os novos dentro de uma transação,
+// para que um plano nunca fique pela metade.
+func (r *planRepository) ReplaceDayPlan(ctx context.Context, userID int64, day time.Time, blocks []models.PlanBlock) error {
+	planDate := day.Format(planDateLayout)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("planRepository.ReplaceDayPlan: erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback() // Ignorado após o Commit.
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM day_plan_blocks WHERE user_id = ? AND plan_date = ?`, userID, planDate); err != nil {
+		return fmt.Errorf("planRepository.ReplaceDayPlan: erro ao remover plano anterior: %w", err)
+	}
+
+	query := `INSERT INTO day_plan_blocks (user_id, plan_date, start_at, end_at, kind, task_id, lesson_id, title)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	for _, block := range blocks {
+		var taskID, lessonID sql.NullInt64
+		if block.TaskID != nil {
+			taskID = sql.NullInt64{Int64: *block.TaskID, Valid: true}
+		}
+		if block.LessonID != nil {
+			lessonID = sql.NullInt64{Int64: *block.LessonID, Valid: true}
+		}
+		if _, err := tx.ExecContext(ctx, query, userID, planDate, block.StartAt, block.EndAt, block.Kind, taskID, lessonID, block.Title); err != nil {
+			return fmt.Errorf("planRepository.ReplaceDayPlan: erro ao inserir bloco '%s': %w", block.Title, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("planRepository.ReplaceDayPlan: erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// GetDayPlan busca os blocos do plano de um usuário para o dia informado.
+func (r *planRepository) GetDayPlan(ctx context.Context, userID int64, day time.Time) ([]models.PlanBlock, error) {
+	query := `SELECT id, user_id, start_at, end_at, kind, task_id, lesson_id, title
+              FROM day_plan_blocks
+              WHERE user_id = ? AND plan_date = ?
+              ORDER BY start_at ASC`
+	rows, err := r.db.QueryContext(ctx, query, userID, day.Format(planDateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("planRepository.GetDayPlan: erro ao consultar plano: %w", err)
+	}
+	defer rows.Close()
+
+	planDate := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	var blocks []models.PlanBlock
+	for rows.Next() {
+		block := models.PlanBlock{PlanDate: planDate}
+		var taskID, lessonID sql.NullInt64
+		if err := rows.Scan(&block.ID, &block.UserID, &block.StartAt, &block.EndAt, &block.Kind, &taskID, &lessonID, &block.Title); err != nil {
+			return nil, fmt.Errorf("planRepository.GetDayPlan: erro ao escanear bloco: %w", err)
+		}
+		// O driver devolve os horários em UTC; converte para o fuso do dia consultado.
+		block.StartAt = block.StartAt.In(day.Location())
+		block.EndAt = block.EndAt.In(day.Location())
+		if taskID.Valid {
+			block.TaskID = &taskID.Int64
+		}
+		if lessonID.Valid {
+			block.LessonID = &lessonID.Int64
+		}
+		blocks = append(blocks, block)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("planRepository.GetDayPlan: erro ao iterar linhas: %w", err)
+	}
+	return blocks, nil
+}
@@ -85,6 +85,12 @@ type TaskRepository interface {
 	// GetUpcomingActiveTasks recupera tarefas ativas (não concluídas) de um usuário específico
 	// com data de vencimento a partir de 'fromDate', limitadas por 'limit'.
 	GetUpcomingActiveTasks(ctx context.Context, userID int64, fromDate time.Time, limit int) ([]models.Task, error)
+	// GetOverdueActiveTasks recupera tarefas ativas de um usuário com data de vencimento anterior a 'before'.
+	GetOverdueActiveTasks(ctx context.Context, userID int64, before time.Time) ([]models.Task, error)
+	// PostponeTask altera a data de vencimento de uma tarefa e incrementa seu contador de adiamentos.
+	PostponeTask(ctx context.Context, taskID int64, newDueDate time.Time) error
+	// UpdateTaskStatus altera o status (coluna do quadro Kanban) de uma tarefa, sincronizando is_completed.
+	UpdateTaskStatus(ctx context.Context, taskID int64, status string) error
 }
 
 //go:generate mockgen -source=repository.go -destination=stubs/class_repository_mock.go -package=stubs ClassRepository
@@ -134,26 +140,137 @@ type LessonRepository interface {
 	DeleteLesson(ctx context.Context, lessonID int64) error
 }
 
+// PlanRepository define a interface para operações de persistência do plano diário ('day_plan_blocks').
+type PlanRepository interface {
+	// ReplaceDayPlan substitui, de forma atômica, todos os blocos do plano de um usuário para o dia 'day'.
+	ReplaceDayPlan(ctx context.Context, userID int64, day time.Time, blocks []models.PlanBlock) error
+	// GetDayPlan recupera os blocos do plano de um usuário para o dia 'day', ordenados pelo horário de início.
+	GetDayPlan(ctx context.Context, userID int64, day time.Time) ([]models.PlanBlock, error)
+}
+
 // AssessmentRepository define a interface para operações de acesso a dados relacionadas a 'assessments' (avaliações) e 'grades' (notas).
 type AssessmentRepository interface {
-	// CreateAssessment adiciona uma nova avaliação ao banco de dados e retorna seu ID.
+	// CreateAssessment adiciona uma nova avaliação ao banco de dados e retorna seu ID. Nas
+	// recuperações, grava também as avaliações recuperadas (RecoveryOf).
 	CreateAssessment(ctx context.Context, assessment *models.Assessment) (int64, error)
 	// GetAssessmentByID recupera uma avaliação específica por seu ID.
 	GetAssessmentByID(ctx context.Context, assessmentID int64) (*models.Assessment, error)
 	// EnterGrade registra ou atualiza a nota de um aluno para uma avaliação.
 	EnterGrade(ctx context.Context, grade *models.Grade) error
+	// EnterGrades registra ou atualiza várias notas numa única transação: ou todas são gravadas, ou nenhuma.
+	EnterGrades(ctx context.Context, grades []models.Grade) error
 	// GetGradesByClassID recupera todas as notas, avaliações e alunos de uma turma específica.
 	// Usado para calcular a média da turma, pois necessita de todas essas informações.
 	GetGradesByClassID(ctx context.Context, classID int64) ([]models.Grade, []models.Assessment, []models.Student, error)
 	// ListAllAssessments recupera todas as avaliações (pode precisar de filtragem por usuário ou turma).
 	ListAllAssessments(ctx context.Context) ([]models.Assessment, error)
+	// GetUpcomingAssessments recupera as avaliações de todas as turmas com data de aplicação entre
+	// 'from' e 'to' (dias inclusivos), ordenadas pela data e pela turma.
+	GetUpcomingAssessments(ctx context.Context, from, to time.Time) ([]models.Assessment, error)
 	// DeleteAssessment remove uma avaliação e suas notas associadas (via ON DELETE CASCADE no DB).
 	DeleteAssessment(ctx context.Context, assessmentID int64) error
 	// FindAssessmentByNameAndClass busca uma avaliação específica pelo nome e ID da turma.
 	FindAssessmentByNameAndClass(ctx context.Context, name string, classID int64) (*models.Assessment, error)
 	// GetGradesByAssessmentID recupera todas as notas para uma avaliação específica.
 	GetGradesByAssessmentID(ctx context.Context, assessmentID int64) ([]models.Grade, error)
+	// UpdateAssessmentCategory altera a categoria de uma avaliação (ex: "prova", "trabalho").
+	UpdateAssessmentCategory(ctx context.Context, assessmentID int64, category string) error
+	// GetReportCardComments recupera os comentários do boletim dos alunos de uma turma, por ID do aluno.
+	GetReportCardComments(ctx context.Context, classID int64) (map[int64]string, error)
+	// SaveReportCardComment grava (ou substitui) o comentário do boletim de um aluno; um comentário
+	// vazio o remove.
+	SaveReportCardComment(ctx context.Context, studentID int64, comment string) error
 	// GetAssessmentWithGrades (Comentado) poderia ser um exemplo de consulta mais complexa,
 	// retornando uma avaliação junto com todas as suas notas associadas.
 	// GetAssessmentWithGrades(ctx context.Context, assessmentID int64) (*models.AssessmentWithGrades, error)
 }
+
+// TimetableRepository define a interface para operações de persistência do horário semanal ('timetable_slots').
+type TimetableRepository interface {
+	// CreateSlot adiciona um horário e retorna seu ID.
+	CreateSlot(ctx context.Context, slot *models.TimetableSlot) (int64, error)
+	// GetSlotByID recupera um horário pelo ID.
+	GetSlotByID(ctx context.Context, slotID int64) (*models.TimetableSlot, error)
+	// ListSlots retorna todos os horários, ordenados por dia da semana e hora de início.
+	ListSlots(ctx context.Context) ([]models.TimetableSlot, error)
+	// UpdateSlot altera dia, hora de início e duração de um horário existente.
+	UpdateSlot(ctx context.Context, slot *models.TimetableSlot) error
+	// DeleteSlot remove um horário pelo ID.
+	DeleteSlot(ctx context.Context, slotID int64) error
+}
+
+// CalendarRepository define a interface para operações de persistência do calendário escolar
+// ('academic_terms' e 'calendar_events').
+type CalendarRepository interface {
+	// UpsertTerm grava as datas de um bimestre, substituindo as do mesmo ano e número, e retorna seu ID.
+	UpsertTerm(ctx context.Context, term *models.AcademicTerm) (int64, error)
+	// ListTerms retorna todos os bimestres, ordenados pela data de início.
+	ListTerms(ctx context.Context) ([]models.AcademicTerm, error)
+	// DeleteTerm remove um bimestre pelo ID.
+	DeleteTerm(ctx context.Context, termID int64) error
+	// CreateEvent adiciona um evento ao calendário e retorna seu ID.
+	CreateEvent(ctx context.Context, event *models.CalendarEvent) (int64, error)
+	// ListEvents retorna os eventos que têm algum dia entre 'from' e 'to' (inclusive), ordenados pelo início.
+	ListEvents(ctx context.Context, from, to time.Time) ([]models.CalendarEvent, error)
+	// DeleteEvent remove um evento pelo ID.
+	DeleteEvent(ctx context.Context, eventID int64) error
+	// ImportCalendar grava bimestres e eventos numa única transação. Eventos idênticos a outros já
+	// cadastrados são ignorados. Retorna o número de eventos efetivamente criados.
+	ImportCalendar(ctx context.Context, terms []models.AcademicTerm, events []models.CalendarEvent) (int, error)
+}
+
+// LessonTemplateRepository define a interface para operações de persistência dos modelos de plano
+// de aula ('lesson_templates') e das sequências didáticas ('lesson_sequences' e 'lesson_sequence_items').
+type LessonTemplateRepository interface {
+	// CreateTemplate adiciona um modelo de plano de aula e retorna seu ID.
+	CreateTemplate(ctx context.Context, template *models.LessonTemplate) (int64, error)
+	// GetTemplateByID recupera um modelo pelo ID.
+	GetTemplateByID(ctx context.Context, templateID int64) (*models.LessonTemplate, error)
+	// ListTemplates retorna todos os modelos, ordenados pelo nome.
+	ListTemplates(ctx context.Context) ([]models.LessonTemplate, error)
+	// DeleteTemplate remove um modelo pelo ID.
+	DeleteTemplate(ctx context.Context, templateID int64) error
+	// CreateSequence grava uma sequência com todos os seus itens numa única transação e retorna seu ID.
+	CreateSequence(ctx context.Context, sequence *models.LessonSequence) (int64, error)
+	// GetSequenceByID recupera uma sequência pelo ID, com os itens em ordem.
+	GetSequenceByID(ctx context.Context, sequenceID int64) (*models.LessonSequence, error)
+	// ListSequences retorna todas as sequências, ordenadas pelo nome, com os itens em ordem.
+	ListSequences(ctx context.Context) ([]models.LessonSequence, error)
+	// DeleteSequence remove uma sequência e os seus itens.
+	DeleteSequence(ctx context.Context, sequenceID int64) error
+}
+
+// LessonAttachmentRepository define a interface para operações de persistência dos anexos das aulas ('lesson_attachments').
+type LessonAttachmentRepository interface {
+	// CreateAttachment adiciona um anexo e retorna seu ID.
+	CreateAttachment(ctx context.Context, attachment *models.LessonAttachment) (int64, error)
+	// GetAttachmentByID recupera um anexo pelo ID.
+	GetAttachmentByID(ctx context.Context, attachmentID int64) (*models.LessonAttachment, error)
+	// ListAttachmentsByLessonID retorna os anexos de uma aula, na ordem em que foram adicionados.
+	ListAttachmentsByLessonID(ctx context.Context, lessonID int64) ([]models.LessonAttachment, error)
+	// ListAllAttachments retorna todos os anexos de todas as aulas.
+	ListAllAttachments(ctx context.Context) ([]models.LessonAttachment, error)
+	// DeleteAttachment remove um anexo pelo ID.
+	DeleteAttachment(ctx context.Context, attachmentID int64) error
+}
+
+// AttendanceRepository define a interface para operações de persistência da chamada ('attendance').
+type AttendanceRepository interface {
+	// SaveAttendance grava a chamada de uma aula numa única transação, substituindo a situação já
+	// registrada dos alunos presentes em 'records'.
+	SaveAttendance(ctx context.Context, lessonID int64, records []models.AttendanceRecord) error
+	// GetAttendanceByLessonID retorna os registros de chamada de uma aula.
+	GetAttendanceByLessonID(ctx context.Context, lessonID int64) ([]models.AttendanceRecord, error)
+	// GetAttendanceByClassID retorna os registros de chamada de todas as aulas de uma turma.
+	GetAttendanceByClassID(ctx context.Context, classID int64) ([]models.AttendanceRecord, error)
+}
+
+// GradingPolicyRepository define a interface para operações de persistência da política de notas das turmas ('grading_policies').
+type GradingPolicyRepository interface {
+	// GetGradingPolicy recupera a política de notas de uma turma. Retorna um erro com sql.ErrNoRows
+	// na cadeia se a turma não tiver política gravada.
+	GetGradingPolicy(ctx context.Context, classID int64) (*models.GradingPolicy, error)
+	// SaveGradingPolicy cria ou substitui a política de notas de uma turma, incluindo os pesos das
+	// categorias e as faixas dos conceitos.
+	SaveGradingPolicy(ctx context.Context, policy *models.GradingPolicy) error
+}
@@ -195,6 +195,23 @@ func (r *StubTaskRepository) DeleteTask(ctx context.Context, taskID int64) error
 	return nil
 }
 
+func (r *StubTaskRepository) GetOverdueActiveTasks(ctx context.Context, userID int64, before time.Time) ([]models.Task, error) {
+	fmt.Printf("[StubTaskRepository] GetOverdueActiveTasks called for UserID: %d, Before: %s\n", userID, before.Format("2006-01-02"))
+	return []models.Task{}, nil
+}
+
+func (r *StubTaskRepository) PostponeTask(ctx context.Context, taskID int64, newDueDate time.Time) error {
+	fmt.Printf("[StubTaskRepository] PostponeTask: ID %d, NewDueDate: %s\n", taskID, newDueDate.Format("2006-01-02"))
+	_, err := r.DB.ExecContext(ctx, "UPDATE tasks SET due_date = ?, postpone_count = postpone_count + 1 WHERE id = ?", newDueDate, taskID)
+	return err
+}
+
+func (r *StubTaskRepository) UpdateTaskStatus(ctx context.Context, taskID int64, status string) error {
+	fmt.Printf("[StubTaskRepository] UpdateTaskStatus: ID %d, Status: %s\n", taskID, status)
+	_, err := r.DB.ExecContext(ctx, "UPDATE tasks SET status = ?, is_completed = ? WHERE id = ?", status, status == models.TaskStatusDone, taskID)
+	return err
+}
+
 
 // StubClassRepository
 type StubClassRepository struct {
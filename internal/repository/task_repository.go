@@ -22,12 +22,105 @@ func NewTaskRepository(db *sql.DB) TaskRepository {
 	return &taskRepository{db: db}
 }
 
+// taskColumns lista as colunas lidas em todas as consultas de tarefas, na ordem esperada por scanTask.
+const taskColumns = `id, user_id, class_id, title, description, due_date, is_completed, postpone_count, status, estimated_minutes, priority`
+
+// taskScanner abstrai *sql.Row e *sql.Rows para que scanTask sirva às duas formas de consulta.
+type taskScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTask lê uma linha com as colunas de taskColumns e converte os campos NULLable
+// (class_id, description, due_date, estimated_minutes) para os ponteiros/valores de models.Task.
+func scanTask(scanner taskScanner) (models.Task, error) {
+	task := models.Task{}
+	var classID sql.NullInt64
+	var description sql.NullString
+	var dueDate sql.NullTime
+	var estimatedMinutes sql.NullInt64
+
+	err := scanner.Scan(
+		&task.ID,
+		&task.UserID,
+		&classID,
+		&task.Title,
+		&description,
+		&dueDate,
+		&task.IsCompleted,
+		&task.PostponeCount,
+		&task.Status,
+		&estimatedMinutes,
+		&task.Priority,
+	)
+	if err != nil {
+		return models.Task{}, err
+	}
+	if classID.Valid {
+		task.ClassID = &classID.Int64
+	}
+	if description.Valid {
+		task.Description = description.String
+	}
+	if dueDate.Valid {
+		task.DueDate = &dueDate.Time
+	}
+	if estimatedMinutes.Valid {
+		minutes := int(estimatedMinutes.Int64)
+		task.EstimatedMinutes = &minutes
+	}
+	return task, nil
+}
+
+// scanTasks percorre o resultado de uma consulta de tarefas usando scanTask.
+// 'op' identifica o método chamador nas mensagens de erro.
+func scanTasks(rows *sql.Rows, op string) ([]models.Task, error) {
+	var tasks []models.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("taskRepository.%s: erro ao escanear tarefa: %w", op, err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("taskRepository.%s: erro ao iterar linhas: %w", op, err)
+	}
+	return tasks, nil
+}
+
+// statusForTask retorna o status a ser gravado para a tarefa, mantendo-o coerente com IsCompleted:
+// tarefas concluídas ficam sempre em 'feito' e tarefas sem status começam em 'a_fazer'.
+func statusForTask(task *models.Task) string {
+	if task.IsCompleted {
+		return models.TaskStatusDone
+	}
+	if task.Status == "" || task.Status == models.TaskStatusDone {
+		return models.TaskStatusTodo
+	}
+	return task.Status
+}
+
+// planningValuesForTask converte os campos de planejamento da tarefa para gravação:
+// estimativa ausente vira NULL e prioridade não definida (0) vira média.
+func planningValuesForTask(task *models.Task) (sql.NullInt64, int) {
+	var estimatedMinutes sql.NullInt64
+	if task.EstimatedMinutes != nil {
+		estimatedMinutes.Int64 = int64(*task.EstimatedMinutes)
+		estimatedMinutes.Valid = true
+	}
+	priority := task.Priority
+	if priority == 0 {
+		priority = models.TaskPriorityMedium
+	}
+	return estimatedMinutes, priority
+}
+
 // CreateTask insere uma nova tarefa no banco de dados.
 // Retorna o ID da tarefa recém-criada ou um erro.
 // Os campos ClassID e DueDate são tratados como opcionais (NULLable no banco de dados).
 func (r *taskRepository) CreateTask(ctx context.Context, task *models.Task) (int64, error) {
-	query := `INSERT INTO tasks (user_id, class_id, title, description, due_date, is_completed)
-              VALUES (?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO tasks (user_id, class_id, title, description, due_date, is_completed, status, estimated_minutes, priority)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	var classID sql.NullInt64
 	if task.ClassID != nil {
@@ -41,7 +134,9 @@ func (r *taskRepository) CreateTask(ctx context.Context, task *models.Task) (int
 		dueDate.Valid = true
 	}
 
-	result, err := r.db.ExecContext(ctx, query, task.UserID, classID, task.Title, task.Description, dueDate, task.IsCompleted)
+	estimatedMinutes, priority := planningValuesForTask(task)
+
+	result, err := r.db.ExecContext(ctx, query, task.UserID, classID, task.Title, task.Description, dueDate, task.IsCompleted, statusForTask(task), estimatedMinutes, priority)
 	if err != nil {
 		return 0, fmt.Errorf("taskRepository.CreateTask: erro ao executar insert: %w", err)
 	}
@@ -55,141 +150,47 @@ func (r *taskRepository) CreateTask(ctx context.Context, task *models.Task) (int
 // GetTaskByID busca uma tarefa pelo seu ID.
 // Retorna um ponteiro para models.Task ou nil se não encontrada, além de um erro.
 func (r *taskRepository) GetTaskByID(ctx context.Context, id int64) (*models.Task, error) {
-	query := `SELECT id, user_id, class_id, title, description, due_date, is_completed
-              FROM tasks WHERE id = ?`
-	row := r.db.QueryRowContext(ctx, query, id)
-	task := &models.Task{}
-	var classID sql.NullInt64
-	var description sql.NullString
-	var dueDate sql.NullTime
-
-	err := row.Scan(
-		&task.ID,
-		&task.UserID,
-		&classID,
-		&task.Title,
-		&description,
-		&dueDate,
-		&task.IsCompleted,
-	)
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = ?`
+	task, err := scanTask(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("taskRepository.GetTaskByID: nenhuma tarefa encontrada com ID %d: %w", id, err)
 		}
 		return nil, fmt.Errorf("taskRepository.GetTaskByID: erro ao escanear linha: %w", err)
 	}
-
-	if classID.Valid {
-		task.ClassID = &classID.Int64
-	}
-	if description.Valid {
-		task.Description = description.String
-	}
-	if dueDate.Valid {
-		task.DueDate = &dueDate.Time
-	}
-	return task, nil
+	return &task, nil
 }
 
 // GetTasksByClassID busca todas as tarefas associadas a um ClassID específico.
 // Retorna uma slice de models.Task ou um erro.
 func (r *taskRepository) GetTasksByClassID(ctx context.Context, classID int64) ([]models.Task, error) {
-	query := `SELECT id, user_id, class_id, title, description, due_date, is_completed
-              FROM tasks WHERE class_id = ?`
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE class_id = ?`
 	rows, err := r.db.QueryContext(ctx, query, classID)
 	if err != nil {
 		return nil, fmt.Errorf("taskRepository.GetTasksByClassID: erro ao consultar tarefas por classID: %w", err)
 	}
 	defer rows.Close()
-
-	var tasks []models.Task
-	for rows.Next() {
-		task := models.Task{}
-		var cID sql.NullInt64 // Usar nome diferente para evitar sombreamento
-		var description sql.NullString
-		var dueDate sql.NullTime
-
-		err := rows.Scan(
-			&task.ID,
-			&task.UserID,
-			&cID,
-			&task.Title,
-			&description,
-			&dueDate,
-			&task.IsCompleted,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("taskRepository.GetTasksByClassID: erro ao escanear tarefa: %w", err)
-		}
-		if cID.Valid {
-			task.ClassID = &cID.Int64
-		}
-		if description.Valid {
-			task.Description = description.String
-		}
-		if dueDate.Valid {
-			task.DueDate = &dueDate.Time
-		}
-		tasks = append(tasks, task)
-	}
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("taskRepository.GetTasksByClassID: erro ao iterar linhas: %w", err)
-	}
-	return tasks, nil
+	return scanTasks(rows, "GetTasksByClassID")
 }
 
 // GetAllTasks busca todas as tarefas do banco de dados.
 // Em uma aplicação real, isso provavelmente seria paginado ou filtrado por usuário.
 // Retorna uma slice de models.Task ou um erro.
 func (r *taskRepository) GetAllTasks(ctx context.Context) ([]models.Task, error) {
-	query := `SELECT id, user_id, class_id, title, description, due_date, is_completed FROM tasks`
+	query := `SELECT ` + taskColumns + ` FROM tasks`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("taskRepository.GetAllTasks: erro ao consultar todas as tarefas: %w", err)
 	}
 	defer rows.Close()
-
-	var tasks []models.Task
-	for rows.Next() {
-		task := models.Task{}
-		var classID sql.NullInt64
-		var description sql.NullString
-		var dueDate sql.NullTime
-
-		err := rows.Scan(
-			&task.ID,
-			&task.UserID,
-			&classID,
-			&task.Title,
-			&description,
-			&dueDate,
-			&task.IsCompleted,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("taskRepository.GetAllTasks: erro ao escanear tarefa: %w", err)
-		}
-		if classID.Valid {
-			task.ClassID = &classID.Int64
-		}
-		if description.Valid {
-			task.Description = description.String
-		}
-		if dueDate.Valid {
-			task.DueDate = &dueDate.Time
-		}
-		tasks = append(tasks, task)
-	}
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("taskRepository.GetAllTasks: erro ao iterar linhas: %w", err)
-	}
-	return tasks, nil
+	return scanTasks(rows, "GetAllTasks")
 }
 
 // MarkTaskCompleted atualiza o status de uma tarefa para concluída (is_completed = true).
 // Retorna um erro se a tarefa não for encontrada ou se houver um problema na atualização.
 func (r *taskRepository) MarkTaskCompleted(ctx context.Context, taskID int64) error {
-	query := `UPDATE tasks SET is_completed = ? WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, true, taskID)
+	query := `UPDATE tasks SET is_completed = ?, status = ? WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, true, models.TaskStatusDone, taskID)
 	if err != nil {
 		return fmt.Errorf("taskRepository.MarkTaskCompleted: erro ao executar update: %w", err)
 	}
@@ -198,7 +199,7 @@ func (r *taskRepository) MarkTaskCompleted(ctx context.Context, taskID int64) er
 		return fmt.Errorf("taskRepository.MarkTaskCompleted: erro ao verificar linhas afetadas: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("taskRepository.MarkTaskCompleted: nenhuma tarefa encontrada com ID %d ou tarefa já estava concluída", taskID)
+		return fmt.Errorf("taskRepository.MarkTaskCompleted: nenhuma tarefa encontrada com ID %d ou tarefa já estava concluída: %w", taskID, sql.ErrNoRows)
 	}
 	return nil
 }
@@ -208,7 +209,7 @@ func (r *taskRepository) MarkTaskCompleted(ctx context.Context, taskID int64) er
 // Retorna uma slice de models.Task ou um erro.
 func (r *taskRepository) GetUpcomingActiveTasks(ctx context.Context, userID int64, fromDate time.Time, limit int) ([]models.Task, error) {
 	query := `
-		SELECT id, user_id, class_id, title, description, due_date, is_completed
+		SELECT ` + taskColumns + `
 		FROM tasks
 		WHERE user_id = ?
 		  AND is_completed = false
@@ -221,41 +222,45 @@ func (r *taskRepository) GetUpcomingActiveTasks(ctx context.Context, userID int6
 		return nil, fmt.Errorf("taskRepository.GetUpcomingActiveTasks: erro ao consultar tarefas: %w", err)
 	}
 	defer rows.Close()
+	return scanTasks(rows, "GetUpcomingActiveTasks")
+}
 
-	var tasks []models.Task
-	for rows.Next() {
-		task := models.Task{}
-		var classID sql.NullInt64
-		var description sql.NullString
-		var dueDate sql.NullTime
+// GetOverdueActiveTasks busca tarefas ativas (não concluídas) de um usuário cuja data de
+// vencimento é anterior a 'before', da mais atrasada para a mais recente.
+func (r *taskRepository) GetOverdueActiveTasks(ctx context.Context, userID int64, before time.Time) ([]models.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE user_id = ?
+		  AND is_completed = false
+		  AND due_date IS NOT NULL
+		  AND datetime(due_date) < datetime(?)
+		ORDER BY due_date ASC`
 
-		err := rows.Scan(
-			&task.ID,
-			&task.UserID,
-			&classID,
-			&task.Title,
-			&description,
-			&dueDate,
-			&task.IsCompleted,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("taskRepository.GetUpcomingActiveTasks: erro ao escanear tarefa: %w", err)
-		}
-		if classID.Valid {
-			task.ClassID = &classID.Int64
-		}
-		if description.Valid {
-			task.Description = description.String
-		}
-		if dueDate.Valid {
-			task.DueDate = &dueDate.Time
-		}
-		tasks = append(tasks, task)
+	rows, err := r.db.QueryContext(ctx, query, userID, before)
+	if err != nil {
+		return nil, fmt.Errorf("taskRepository.GetOverdueActiveTasks: erro ao consultar tarefas atrasadas: %w", err)
 	}
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("taskRepository.GetUpcomingActiveTasks: erro ao iterar linhas: %w", err)
+	defer rows.Close()
+	return scanTasks(rows, "GetOverdueActiveTasks")
+}
+
+// PostponeTask define a nova data de vencimento de uma tarefa e incrementa seu contador de adiamentos.
+// Retorna um erro se a tarefa não for encontrada.
+func (r *taskRepository) PostponeTask(ctx context.Context, taskID int64, newDueDate time.Time) error {
+	query := `UPDATE tasks SET due_date = ?, postpone_count = postpone_count + 1 WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, newDueDate, taskID)
+	if err != nil {
+		return fmt.Errorf("taskRepository.PostponeTask: erro ao executar update: %w", err)
 	}
-	return tasks, nil
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("taskRepository.PostponeTask: erro ao verificar linhas afetadas: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("taskRepository.PostponeTask: nenhuma tarefa encontrada com ID %d: %w", taskID, sql.ErrNoRows)
+	}
+	return nil
 }
 
 // DeleteTask remove uma tarefa do banco de dados pelo seu ID.
@@ -271,7 +276,7 @@ func (r *taskRepository) DeleteTask(ctx context.Context, taskID int64) error {
 		return fmt.Errorf("taskRepository.DeleteTask: erro ao verificar linhas afetadas: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("taskRepository.DeleteTask: nenhuma tarefa encontrada com ID %d", taskID)
+		return fmt.Errorf("taskRepository.DeleteTask: nenhuma tarefa encontrada com ID %d: %w", taskID, sql.ErrNoRows)
 	}
 	return nil
 }
@@ -279,7 +284,8 @@ func (r *taskRepository) DeleteTask(ctx context.Context, taskID int64) error {
 // UpdateTask atualiza todos os campos de uma tarefa existente no banco de dados.
 // Retorna um erro se a tarefa não for encontrada ou se houver um problema na atualização.
 func (r *taskRepository) UpdateTask(ctx context.Context, task *models.Task) error {
-	query := `UPDATE tasks SET user_id = ?, class_id = ?, title = ?, description = ?, due_date = ?, is_completed = ?
+	query := `UPDATE tasks SET user_id = ?, class_id = ?, title = ?, description = ?, due_date = ?, is_completed = ?, status = ?,
+              estimated_minutes = ?, priority = ?
               WHERE id = ?`
 
 	var classID sql.NullInt64
@@ -298,7 +304,9 @@ func (r *taskRepository) UpdateTask(ctx context.Context, task *models.Task) erro
 		dueDate.Valid = false // Garante que será NULL se task.DueDate for nil
 	}
 
-	result, err := r.db.ExecContext(ctx, query, task.UserID, classID, task.Title, task.Description, dueDate, task.IsCompleted, task.ID)
+	estimatedMinutes, priority := planningValuesForTask(task)
+
+	result, err := r.db.ExecContext(ctx, query, task.UserID, classID, task.Title, task.Description, dueDate, task.IsCompleted, statusForTask(task), estimatedMinutes, priority, task.ID)
 	if err != nil {
 		return fmt.Errorf("taskRepository.UpdateTask: erro ao executar update: %w", err)
 	}
@@ -314,3 +322,21 @@ func (r *taskRepository) UpdateTask(ctx context.Context, task *models.Task) erro
 	}
 	return nil
 }
+
+// UpdateTaskStatus move uma tarefa para outra coluna do quadro Kanban.
+// is_completed é atualizado junto para refletir se o novo status é 'feito'.
+func (r *taskRepository) UpdateTaskStatus(ctx context.Context, taskID int64, status string) error {
+	query := `UPDATE tasks SET status = ?, is_completed = ? WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, status, status == models.TaskStatusDone, taskID)
+	if err != nil {
+		return fmt.Errorf("taskRepository.UpdateTaskStatus: erro ao executar update: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("taskRepository.UpdateTaskStatus: erro ao verificar linhas afetadas: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("taskRepository.UpdateTaskStatus: nenhuma tarefa encontrada com ID %d: %w", taskID, sql.ErrNoRows)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Package repository contém as implementações concretas das interfaces de repositório
+// definidas no pacote pai 'repository'. Este arquivo específico implementa o TimetableRepository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"vigenda/internal/models"
+)
+
+// timetableRepository é a implementação concreta de TimetableRepository sobre a tabela 'timetable_slots'.
+type timetableRepository struct {
+	db *sql.DB
+}
+
+// NewTimetableRepository cria e retorna uma nova instância de TimetableRepository.
+func NewTimetableRepository(db *sql.DB) TimetableRepository {
+	return &timetableRepository{db: db}
+}
+
+// CreateSlot insere um novo horário e retorna seu ID.
+func (r *timetableRepository) CreateSlot(ctx context.Context, slot *models.TimetableSlot) (int64, error) {
+	query := `INSERT INTO timetable_slots (class_id, weekday, start_time, duration_minutes) VALUES (?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, slot.ClassID, int(slot.Weekday), slot.StartTime, slot.DurationMinutes)
+	if err != nil {
+		return 0, fmt.Errorf("timetableRepository.CreateSlot: erro ao inserir horário: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("timetableRepository.CreateSlot: erro ao obter ID inserido: %w", err)
+	}
+	return id, nil
+}
+
+// GetSlotByID busca um horário pelo ID.
+func (r *timetableRepository) GetSlotByID(ctx context.Context, slotID int64) (*models.TimetableSlot, error) {
+	query := `SELECT id, class_id, weekday, start_time, duration_minutes FROM timetable_slots WHERE id = ?`
+	slot, err := scanTimetableSlot(r.db.QueryRowContext(ctx, query, slotID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("timetableRepository.GetSlotByID: horário com ID %d não encontrado: %w", slotID, err)
+		}
+		return nil, fmt.Errorf("timetableRepository.GetSlotByID: %w", err)
+	}
+	return slot, nil
+}
+
+// ListSlots retorna todos os horários, ordenados por dia da semana e hora de início.
+func (r *timetableRepository) ListSlots(ctx context.Context) ([]models.TimetableSlot, error) {
+	query := `SELECT id, class_id, weekday, start_time, duration_minutes
+              FROM timetable_slots
+              ORDER BY weekday ASC, start_time ASC, class_id ASC`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("timetableRepository.ListSlots: erro ao consultar horários: %w", err)
+	}
+	defer rows.Close()
+
+	var slots []models.TimetableSlot
+	for rows.Next() {
+		slot, err := scanTimetableSlot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("timetableRepository.ListSlots: erro ao escanear horário: %w", err)
+		}
+		slots = append(slots, *slot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("timetableRepository.ListSlots: erro ao iterar linhas: %w", err)
+	}
+	return slots, nil
+}
+
+// UpdateSlot altera dia, hora de início e duração de um horário.
+func (r *timetableRepository) UpdateSlot(ctx context.Context, slot *models.TimetableSlot) error {
+	query := `UPDATE timetable_slots SET class_id = ?, weekday = ?, start_time = ?, duration_minutes = ? WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, slot.ClassID, int(slot.Weekday), slot.StartTime, slot.DurationMinutes, slot.ID)
+	if err != nil {
+		return fmt.Errorf("timetableRepository.UpdateSlot: erro ao atualizar horário: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("timetableRepository.UpdateSlot: erro ao verificar linhas afetadas: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("timetableRepository.UpdateSlot: nenhum horário encontrado com ID %d: %w", slot.ID, sql.ErrNoRows)
+	}
+	return nil
+}
+
+// DeleteSlot remove um horário pelo ID.
+func (r *timetableRepository) DeleteSlot(ctx context.Context, slotID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM timetable_slots WHERE id = ?`, slotID)
+	if err != nil {
+		return fmt.Errorf("timetableRepository.DeleteSlot: erro ao remover horário: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("timetableRepository.DeleteSlot: erro ao verificar linhas afetadas: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("timetableRepository.DeleteSlot: nenhum horário encontrado com ID %d: %w", slotID, sql.ErrNoRows)
+	}
+	return nil
+}
+
+// rowScanner é satisfeito por *sql.Row e *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTimetableSlot(row rowScanner) (*models.TimetableSlot, error) {
+	slot := &models.TimetableSlot{}
+	var weekday int
+	if err := row.Scan(&slot.ID, &slot.ClassID, &weekday, &slot.StartTime, &slot.DurationMinutes); err != nil {
+		return nil, err
+	}
+	slot.Weekday = time.Weekday(weekday)
+	return slot, nil
+}
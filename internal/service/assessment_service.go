@@ -3,41 +3,71 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 	"vigenda/internal/models"
 	"vigenda/internal/repository" // Added import
 )
 
 type assessmentServiceImpl struct {
-	assessmentRepo repository.AssessmentRepository
-	classRepo      repository.ClassRepository // Added classRepo for fetching students
+	assessmentRepo  repository.AssessmentRepository
+	classRepo       repository.ClassRepository         // Added classRepo for fetching students
+	calendarService CalendarService                    // Define o bimestre padrão a partir da data da avaliação (pode ser nil)
+	policyRepo      repository.GradingPolicyRepository // Política de notas de cada turma (pode ser nil: todas usam a média ponderada)
 }
 
 // NewAssessmentService creates a new instance of AssessmentService.
-// It now accepts AssessmentRepository and ClassRepository as dependencies.
+// It now accepts AssessmentRepository and ClassRepository as dependencies, and the
+// CalendarService used to default an assessment's term from its date (may be nil) and the
+// GradingPolicyRepository holding each class's grading policy (may be nil).
 func NewAssessmentService(
 	assessmentRepo repository.AssessmentRepository,
 	classRepo repository.ClassRepository,
+	calendarService CalendarService,
+	policyRepo repository.GradingPolicyRepository,
 ) AssessmentService {
 	return &assessmentServiceImpl{
-		assessmentRepo: assessmentRepo,
-		classRepo:      classRepo,
+		assessmentRepo:  assessmentRepo,
+		classRepo:       classRepo,
+		calendarService: calendarService,
+		policyRepo:      policyRepo,
 	}
 }
 
 func (s *assessmentServiceImpl) CreateAssessment(ctx context.Context, name string, classID int64, term int, weight float64) (models.Assessment, error) {
+	return s.createAssessment(ctx, name, classID, term, weight, nil)
+}
+
+func (s *assessmentServiceImpl) CreateAssessmentOnDate(ctx context.Context, name string, classID int64, term int, weight float64, date time.Time) (models.Assessment, error) {
+	if term == 0 && s.calendarService != nil {
+		calendarTerm, err := s.calendarService.TermForDate(ctx, date)
+		if err != nil {
+			return models.Assessment{}, fmt.Errorf("service.CreateAssessmentOnDate: %w", err)
+		}
+		if calendarTerm == nil {
+			return models.Assessment{}, ValidationErrorf("a data %s não está em nenhum bimestre do calendário escolar: informe o bimestre",
+				date.Format("02/01/2006"))
+		}
+		term = calendarTerm.Number
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	return s.createAssessment(ctx, name, classID, term, weight, &day)
+}
+
+func (s *assessmentServiceImpl) createAssessment(ctx context.Context, name string, classID int64, term int, weight float64, date *time.Time) (models.Assessment, error) {
 	if name == "" {
-		return models.Assessment{}, fmt.Errorf("assessment name cannot be empty")
+		return models.Assessment{}, ValidationErrorf("nome da avaliação não pode ser vazio")
 	}
 	if classID == 0 {
-		return models.Assessment{}, fmt.Errorf("class ID cannot be zero")
+		return models.Assessment{}, ValidationErrorf("ID da turma não pode ser zero")
 	}
 	// Bypassing validation for the special "Nota Final" assessment
 	if name != FinalGradeAssessmentName {
 		if term <= 0 {
-			return models.Assessment{}, fmt.Errorf("term must be positive")
+			return models.Assessment{}, ValidationErrorf("bimestre deve ser positivo")
 		}
 		if weight <= 0 {
-			return models.Assessment{}, fmt.Errorf("weight must be positive")
+			return models.Assessment{}, ValidationErrorf("peso deve ser positivo")
 		}
 	}
 	// TODO: Validate classID exists using s.classRepo.GetClassByID(ctx, classID)
@@ -47,10 +77,11 @@ func (s *assessmentServiceImpl) CreateAssessment(ctx context.Context, name strin
 
 	assessment := models.Assessment{
 		// UserID:  userID, // Removed
-		ClassID: classID,
-		Name:    name,
-		Term:    term,
-		Weight:  weight,
+		ClassID:        classID,
+		Name:           name,
+		Term:           term,
+		Weight:         weight,
+		AssessmentDate: date,
 	}
 
 	id, err := s.assessmentRepo.CreateAssessment(ctx, &assessment)
@@ -63,10 +94,10 @@ func (s *assessmentServiceImpl) CreateAssessment(ctx context.Context, name strin
 
 func (s *assessmentServiceImpl) EnterGrades(ctx context.Context, assessmentID int64, studentGrades map[int64]float64) error {
 	if assessmentID == 0 {
-		return fmt.Errorf("assessment ID cannot be zero")
+		return ValidationErrorf("ID da avaliação não pode ser zero")
 	}
 	if len(studentGrades) == 0 {
-		return fmt.Errorf("no grades provided")
+		return ValidationErrorf("nenhuma nota informada")
 	}
 
 	// Optional: Validate assessmentID exists
@@ -75,42 +106,50 @@ func (s *assessmentServiceImpl) EnterGrades(ctx context.Context, assessmentID in
 		return fmt.Errorf("service.EnterGrades: validating assessment: %w", err)
 	}
 	if assessment == nil {
-		return fmt.Errorf("assessment with ID %d not found", assessmentID)
+		return NotFoundErrorf("avaliação com ID %d não encontrada", assessmentID)
 	}
 
 	// Optional: Validate studentIDs exist within the assessment's class
 	// This would involve fetching students for assessment.ClassID and checking existence.
 	// For now, we assume student IDs are valid and belong to the correct class.
 
-	// Assuming UserID 1 for now
-	// userID := int64(1) // UserID is not part of models.Grade
-
-	for studentID, gradeVal := range studentGrades {
-		if studentID == 0 {
-			return fmt.Errorf("student ID cannot be zero in grades map")
-		}
-		// Basic grade validation (e.g., 0-10, or whatever scale)
-		if gradeVal < 0 || gradeVal > 100 { // Assuming a 0-100 scale for placeholder
-			// return fmt.Errorf("invalid grade value %.2f for student %d. Must be between 0 and 100", gradeVal, studentID)
-			// For now, let's allow any float. Specific validation can be added.
+	// Todas as notas são validadas na escala da turma antes de qualquer uma ser gravada.
+	if err := s.validateGrades(ctx, assessment.ClassID, studentGrades); err != nil {
+		return err
+	}
+	if IsRecovery(*assessment) {
+		if err := s.validateRecoveryStudents(ctx, *assessment, studentGrades); err != nil {
+			return err
 		}
+	}
 
-		grade := models.Grade{
-			AssessmentID: assessmentID,
-			StudentID:    studentID,
-			// UserID:       userID, // Removed: User who entered the grade is not stored in Grade model
-			Grade:        gradeVal,
-		}
-		if err := s.assessmentRepo.EnterGrade(ctx, &grade); err != nil {
-			return fmt.Errorf("service.EnterGrades: entering grade for student %d: %w", studentID, err)
-		}
+	// As notas são gravadas numa única transação, em ordem de aluno: ou todas entram, ou nenhuma.
+	grades := make([]models.Grade, 0, len(studentGrades))
+	for studentID, gradeVal := range studentGrades {
+		grades = append(grades, models.Grade{AssessmentID: assessmentID, StudentID: studentID, Grade: gradeVal})
+	}
+	sort.Slice(grades, func(i, j int) bool { return grades[i].StudentID < grades[j].StudentID })
+	if err := s.assessmentRepo.EnterGrades(ctx, grades); err != nil {
+		return fmt.Errorf("service.EnterGrades: %w", err)
 	}
 	return nil
 }
 
 func (s *assessmentServiceImpl) CalculateClassAverage(ctx context.Context, classID int64, terms []int) (map[int64]float64, error) {
+	averages, err := s.CalculateClassAverages(ctx, classID, terms)
+	if err != nil {
+		return nil, err
+	}
+	studentAverages := make(map[int64]float64, len(averages))
+	for studentID, average := range averages {
+		studentAverages[studentID] = average.Adjusted
+	}
+	return studentAverages, nil
+}
+
+func (s *assessmentServiceImpl) CalculateClassAverages(ctx context.Context, classID int64, terms []int) (map[int64]StudentAverage, error) {
 	if classID == 0 {
-		return nil, fmt.Errorf("class ID cannot be zero")
+		return nil, ValidationErrorf("ID da turma não pode ser zero")
 	}
 
 	grades, allAssessments, students, err := s.assessmentRepo.GetGradesByClassID(ctx, classID)
@@ -119,7 +158,7 @@ func (s *assessmentServiceImpl) CalculateClassAverage(ctx context.Context, class
 	}
 
 	if len(students) == 0 {
-		return nil, fmt.Errorf("no students found in class %d to calculate average", classID)
+		return nil, NotFoundErrorf("nenhum aluno encontrado na turma %d para calcular a média", classID)
 	}
 
 	// Create a set for quick lookup of terms to include
@@ -131,52 +170,86 @@ func (s *assessmentServiceImpl) CalculateClassAverage(ctx context.Context, class
 		}
 	}
 
-	// Filter assessments based on terms
-	var assessments []models.Assessment
+	// Filter assessments based on terms. The "Nota Final" assessment is not part of any average, and
+	// recovery assessments only change the grades they recover.
+	var assessments, recoveries []models.Assessment
 	for _, a := range allAssessments {
-		if !filterByTerm || includeTerms[a.Term] {
+		if filterByTerm && !includeTerms[a.Term] {
+			continue
+		}
+		if isRegularAssessment(a) {
 			assessments = append(assessments, a)
+		} else if IsRecovery(a) {
+			recoveries = append(recoveries, a)
 		}
 	}
+	sort.Slice(recoveries, func(i, j int) bool { return recoveries[i].ID < recoveries[j].ID })
 
 	if len(assessments) == 0 {
-		return nil, fmt.Errorf("no assessments found for class %d matching the specified terms", classID)
+		return nil, NotFoundErrorf("nenhuma avaliação encontrada na turma %d para os bimestres informados", classID)
+	}
+
+	policy, err := s.GetGradingPolicy(ctx, classID)
+	if err != nil {
+		return nil, fmt.Errorf("service.CalculateClassAverage: %w", err)
 	}
 
 	assessmentMap := make(map[int64]models.Assessment)
 	for _, a := range assessments {
 		assessmentMap[a.ID] = a
 	}
+	recoveryGrades := make(map[int64]map[int64]float64) // studentID -> recoveryID -> nota
+	for _, r := range recoveries {
+		for _, g := range grades {
+			if g.AssessmentID != r.ID {
+				continue
+			}
+			if recoveryGrades[g.StudentID] == nil {
+				recoveryGrades[g.StudentID] = make(map[int64]float64)
+			}
+			recoveryGrades[g.StudentID][r.ID] = g.Grade
+		}
+	}
 
-	studentTotals := make(map[int64]struct {
-		totalWeightedGrade float64
-		totalWeight        float64
-	})
-
+	studentGrades := make(map[int64][]studentGrade)
 	for _, g := range grades {
 		assessment, ok := assessmentMap[g.AssessmentID]
 		if !ok {
 			// This grade is for an assessment filtered out by term, so skip it
 			continue
 		}
-
-		st := studentTotals[g.StudentID]
-		st.totalWeightedGrade += g.Grade * assessment.Weight
-		st.totalWeight += assessment.Weight
-		studentTotals[g.StudentID] = st
+		studentGrades[g.StudentID] = append(studentGrades[g.StudentID], studentGrade{assessment: assessment, grade: g.Grade})
 	}
 
-	studentAverages := make(map[int64]float64)
+	studentAverages := make(map[int64]StudentAverage)
 	for _, student := range students {
 		if student.Status != "ativo" {
 			continue // Only calculate for active students
 		}
-		totals, ok := studentTotals[student.ID]
-		if ok && totals.totalWeight > 0 {
-			studentAverages[student.ID] = totals.totalWeightedGrade / totals.totalWeight
-		} else {
-			studentAverages[student.ID] = 0 // Student has no grades for the selected terms
+		// Students without grades for the selected terms get 0.
+		original, _ := policyAverage(policy, studentGrades[student.ID])
+		average := StudentAverage{Original: RoundGrade(original, policy.RoundingStep)}
+		average.Adjusted = average.Original
+
+		// As recuperações são aplicadas em ordem, cada uma só para quem estava abaixo da média de
+		// aprovação nas avaliações recuperadas antes de qualquer recuperação.
+		adjusted := studentGrades[student.ID]
+		for _, r := range recoveries {
+			grade, ok := recoveryGrades[student.ID][r.ID]
+			scope := recoveryScope(r, assessments)
+			if !ok || len(scope) == 0 || recoveryAverage(policy, scope, studentGrades[student.ID]) >= policy.PassingGrade {
+				continue
+			}
+			if updated, changed := applyRecovery(policy, scope, adjusted, grade); changed {
+				adjusted = updated
+				average.Recovered = true
+			}
+		}
+		if average.Recovered {
+			value, _ := policyAverage(policy, adjusted)
+			average.Adjusted = RoundGrade(value, policy.RoundingStep)
 		}
+		studentAverages[student.ID] = average
 	}
 
 	return studentAverages, nil
@@ -202,14 +275,14 @@ func (s *assessmentServiceImpl) ListAllAssessments(ctx context.Context) ([]model
 
 func (s *assessmentServiceImpl) DeleteAssessment(ctx context.Context, assessmentID int64) error {
 	if assessmentID == 0 {
-		return fmt.Errorf("assessment ID cannot be zero")
+		return ValidationErrorf("ID da avaliação não pode ser zero")
 	}
 	return s.assessmentRepo.DeleteAssessment(ctx, assessmentID)
 }
 
 func (s *assessmentServiceImpl) GetStudentsForGrading(ctx context.Context, assessmentID int64) ([]models.Student, *models.Assessment, error) {
 	if assessmentID == 0 {
-		return nil, nil, fmt.Errorf("assessment ID cannot be zero")
+		return nil, nil, ValidationErrorf("ID da avaliação não pode ser zero")
 	}
 
 	// 1. Get the assessment details
@@ -218,7 +291,7 @@ func (s *assessmentServiceImpl) GetStudentsForGrading(ctx context.Context, asses
 		return nil, nil, fmt.Errorf("service.GetStudentsForGrading: failed to get assessment: %w", err)
 	}
 	if assessment == nil {
-		return nil, nil, fmt.Errorf("assessment with ID %d not found", assessmentID)
+		return nil, nil, NotFoundErrorf("avaliação com ID %d não encontrada", assessmentID)
 	}
 
 	// 2. Get students from the assessment's class
@@ -230,6 +303,18 @@ func (s *assessmentServiceImpl) GetStudentsForGrading(ctx context.Context, asses
 	return students, assessment, nil
 }
 
+// GetGradesByAssessmentID busca as notas já lançadas em uma avaliação.
+func (s *assessmentServiceImpl) GetGradesByAssessmentID(ctx context.Context, assessmentID int64) ([]models.Grade, error) {
+	if assessmentID == 0 {
+		return nil, ValidationErrorf("ID da avaliação não pode ser zero")
+	}
+	grades, err := s.assessmentRepo.GetGradesByAssessmentID(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetGradesByAssessmentID: %w", err)
+	}
+	return grades, nil
+}
+
 const FinalGradeAssessmentName = "Nota Final"
 
 // getOrCreateFinalAssessment finds or creates a special assessment for final grades.
@@ -256,7 +341,12 @@ func (s *assessmentServiceImpl) getOrCreateFinalAssessment(ctx context.Context,
 
 func (s *assessmentServiceImpl) EnterFinalGrades(ctx context.Context, classID int64, finalGrades map[int64]float64) error {
 	if classID == 0 {
-		return fmt.Errorf("class ID cannot be zero")
+		return ValidationErrorf("ID da turma não pode ser zero")
+	}
+
+	// As notas são validadas antes de criar a avaliação "Nota Final", para não deixá-la vazia.
+	if err := s.validateGrades(ctx, classID, finalGrades); err != nil {
+		return err
 	}
 
 	// 1. Get the special assessment ID for final grades
@@ -271,7 +361,7 @@ func (s *assessmentServiceImpl) EnterFinalGrades(ctx context.Context, classID in
 
 func (s *assessmentServiceImpl) GetFinalGradesByClassID(ctx context.Context, classID int64) ([]models.Student, map[int64]float64, error) {
 	if classID == 0 {
-		return nil, nil, fmt.Errorf("class ID cannot be zero")
+		return nil, nil, ValidationErrorf("ID da turma não pode ser zero")
 	}
 
 	// 1. Find the "Nota Final" assessment
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"math"
 	"testing"
 	"vigenda/internal/models"
 )
@@ -59,5 +61,27 @@ func TestEnterGrades(t *testing.T) {
 }
 
 func TestCalculateClassAverage(t *testing.T) {
-	// TODO: Implement test
+	ctx := context.Background()
+	// Sem política gravada, a turma usa a média ponderada sem arredondamento (ver grading_policy_test.go).
+	assessmentService, _ := newGradebookService()
+	averages, err := assessmentService.CalculateClassAverage(ctx, 1, []int{1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := map[int64]float64{1: 26.0 / 3, 2: 5, 3: 0}
+	if len(averages) != len(expected) {
+		t.Fatalf("Expected averages for %d active students, got %v", len(expected), averages)
+	}
+	for studentID, average := range expected {
+		if math.Abs(averages[studentID]-average) > 1e-9 {
+			t.Errorf("Student %d: expected %.4f, got %.4f", studentID, average, averages[studentID])
+		}
+	}
+
+	if _, err := assessmentService.CalculateClassAverage(ctx, 1, []int{3}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found for a term without assessments, got %v", err)
+	}
+	if _, err := assessmentService.CalculateClassAverage(ctx, 0, nil); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for class 0, got %v", err)
+	}
 }
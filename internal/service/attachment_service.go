@@ -0,0 +1,229 @@
+// Package service contém as implementações concretas das interfaces de serviço.
+// Este arquivo específico implementa a interface AttachmentService (anexos das aulas).
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// attachmentServiceImpl é a implementação concreta de AttachmentService. Os arquivos anexados ficam em
+// dir/aula-<ID da aula>/, e o banco guarda o caminho relativo a dir.
+type attachmentServiceImpl struct {
+	attachmentRepo repository.LessonAttachmentRepository
+	lessonRepo     repository.LessonRepository
+	dir            string
+}
+
+// NewAttachmentService cria uma nova instância de AttachmentService que guarda os arquivos em 'dir'.
+func NewAttachmentService(attachmentRepo repository.LessonAttachmentRepository, lessonRepo repository.LessonRepository, dir string) AttachmentService {
+	return &attachmentServiceImpl{attachmentRepo: attachmentRepo, lessonRepo: lessonRepo, dir: dir}
+}
+
+func (s *attachmentServiceImpl) AttachFile(ctx context.Context, lessonID int64, path, name string) (models.LessonAttachment, error) {
+	if err := s.checkLesson(ctx, "attachmentService.AttachFile", lessonID); err != nil {
+		return models.LessonAttachment{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return models.LessonAttachment{}, &Error{Category: CategoryValidation, Message: fmt.Sprintf("não foi possível ler o arquivo '%s'", path), Err: err}
+	}
+	if info.IsDir() {
+		return models.LessonAttachment{}, ValidationErrorf("'%s' é um diretório: anexe os arquivos individualmente", path)
+	}
+
+	relative, err := s.copyIntoDir(lessonID, path)
+	if err != nil {
+		return models.LessonAttachment{}, err
+	}
+	attachment := models.LessonAttachment{LessonID: lessonID, Kind: models.AttachmentKindFile, Name: strings.TrimSpace(name), Location: relative}
+	if attachment.Name == "" {
+		attachment.Name = filepath.Base(path)
+	}
+	id, err := s.attachmentRepo.CreateAttachment(ctx, &attachment)
+	if err != nil {
+		// Sem o registro, a cópia ficaria órfã no diretório de anexos.
+		_ = os.Remove(filepath.Join(s.dir, relative))
+		return models.LessonAttachment{}, fmt.Errorf("attachmentService.AttachFile: %w", err)
+	}
+	attachment.ID = id
+	return attachment, nil
+}
+
+func (s *attachmentServiceImpl) AttachLink(ctx context.Context, lessonID int64, rawURL, name string) (models.LessonAttachment, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return models.LessonAttachment{}, ValidationErrorf("link inválido '%s': use um endereço http:// ou https://", rawURL)
+	}
+	if err := s.checkLesson(ctx, "attachmentService.AttachLink", lessonID); err != nil {
+		return models.LessonAttachment{}, err
+	}
+	attachment := models.LessonAttachment{LessonID: lessonID, Kind: models.AttachmentKindLink, Name: strings.TrimSpace(name), Location: rawURL}
+	if attachment.Name == "" {
+		attachment.Name = rawURL
+	}
+	id, err := s.attachmentRepo.CreateAttachment(ctx, &attachment)
+	if err != nil {
+		return models.LessonAttachment{}, fmt.Errorf("attachmentService.AttachLink: %w", err)
+	}
+	attachment.ID = id
+	return attachment, nil
+}
+
+func (s *attachmentServiceImpl) GetAttachment(ctx context.Context, attachmentID int64) (models.LessonAttachment, error) {
+	attachment, err := s.attachmentRepo.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		return models.LessonAttachment{}, attachmentLookupError("attachmentService.GetAttachment", attachmentID, err)
+	}
+	return *attachment, nil
+}
+
+func (s *attachmentServiceImpl) ListAttachments(ctx context.Context, lessonID int64) ([]models.LessonAttachment, error) {
+	if err := s.checkLesson(ctx, "attachmentService.ListAttachments", lessonID); err != nil {
+		return nil, err
+	}
+	attachments, err := s.attachmentRepo.ListAttachmentsByLessonID(ctx, lessonID)
+	if err != nil {
+		return nil, fmt.Errorf("attachmentService.ListAttachments: %w", err)
+	}
+	if attachments == nil {
+		attachments = []models.LessonAttachment{}
+	}
+	return attachments, nil
+}
+
+func (s *attachmentServiceImpl) ListAllAttachments(ctx context.Context) (map[int64][]models.LessonAttachment, error) {
+	attachments, err := s.attachmentRepo.ListAllAttachments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("attachmentService.ListAllAttachments: %w", err)
+	}
+	byLesson := make(map[int64][]models.LessonAttachment)
+	for _, attachment := range attachments {
+		byLesson[attachment.LessonID] = append(byLesson[attachment.LessonID], attachment)
+	}
+	return byLesson, nil
+}
+
+func (s *attachmentServiceImpl) DeleteAttachment(ctx context.Context, attachmentID int64) error {
+	attachment, err := s.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.attachmentRepo.DeleteAttachment(ctx, attachmentID); err != nil {
+		return attachmentLookupError("attachmentService.DeleteAttachment", attachmentID, err)
+	}
+	return s.removeFile(attachment)
+}
+
+func (s *attachmentServiceImpl) DeleteLessonAttachments(ctx context.Context, lessonID int64) error {
+	attachments, err := s.attachmentRepo.ListAttachmentsByLessonID(ctx, lessonID)
+	if err != nil {
+		return fmt.Errorf("attachmentService.DeleteLessonAttachments: %w", err)
+	}
+	for _, attachment := range attachments {
+		if err := s.attachmentRepo.DeleteAttachment(ctx, attachment.ID); err != nil && !repository.IsNotFound(err) {
+			return fmt.Errorf("attachmentService.DeleteLessonAttachments: anexo ID %d: %w", attachment.ID, err)
+		}
+		if err := s.removeFile(attachment); err != nil {
+			return err
+		}
+	}
+	// O diretório da aula só é removido se tiver ficado vazio.
+	_ = os.Remove(filepath.Join(s.dir, lessonDirName(lessonID)))
+	return nil
+}
+
+func (s *attachmentServiceImpl) Target(attachment models.LessonAttachment) string {
+	if attachment.Kind == models.AttachmentKindLink {
+		return attachment.Location
+	}
+	target := filepath.Join(s.dir, filepath.FromSlash(attachment.Location))
+	if absolute, err := filepath.Abs(target); err == nil {
+		return absolute
+	}
+	return target
+}
+
+func (s *attachmentServiceImpl) Dir() string {
+	return s.dir
+}
+
+// checkLesson confirma que a aula existe.
+func (s *attachmentServiceImpl) checkLesson(ctx context.Context, op string, lessonID int64) error {
+	if _, err := s.lessonRepo.GetLessonByID(ctx, lessonID); err != nil {
+		return lessonLookupError(op, lessonID, err)
+	}
+	return nil
+}
+
+// copyIntoDir copia 'path' para o diretório da aula e retorna o caminho relativo da cópia. Se já
+// houver um arquivo com o mesmo nome, acrescenta um número ao nome (ex: "slides (2).pdf").
+func (s *attachmentServiceImpl) copyIntoDir(lessonID int64, path string) (string, error) {
+	lessonDir := filepath.Join(s.dir, lessonDirName(lessonID))
+	if err := os.MkdirAll(lessonDir, 0o755); err != nil {
+		return "", StorageError(fmt.Sprintf("não foi possível criar o diretório de anexos '%s'", lessonDir), err)
+	}
+	src, err := os.Open(path)
+	if err != nil {
+		return "", &Error{Category: CategoryValidation, Message: fmt.Sprintf("não foi possível ler o arquivo '%s'", path), Err: err}
+	}
+	defer src.Close()
+
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+	name := base
+	var dst *os.File
+	for n := 2; ; n++ {
+		dst, err = os.OpenFile(filepath.Join(lessonDir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			break
+		}
+		if !os.IsExist(err) {
+			return "", StorageError(fmt.Sprintf("não foi possível copiar '%s' para o diretório de anexos", path), err)
+		}
+		name = fmt.Sprintf("%s (%d)%s", stem, n, ext)
+	}
+	target := dst.Name()
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(target)
+		return "", StorageError(fmt.Sprintf("não foi possível copiar '%s' para o diretório de anexos", path), err)
+	}
+	return lessonDirName(lessonID) + "/" + name, nil
+}
+
+// removeFile apaga a cópia de um anexo do tipo arquivo. Uma cópia que já não existe é ignorada.
+func (s *attachmentServiceImpl) removeFile(attachment models.LessonAttachment) error {
+	if attachment.Kind != models.AttachmentKindFile {
+		return nil
+	}
+	if err := os.Remove(s.Target(attachment)); err != nil && !os.IsNotExist(err) {
+		return StorageError(fmt.Sprintf("não foi possível apagar o arquivo do anexo '%s'", attachment.Name), err)
+	}
+	return nil
+}
+
+// lessonDirName é o subdiretório de anexos de uma aula.
+func lessonDirName(lessonID int64) string {
+	return fmt.Sprintf("aula-%d", lessonID)
+}
+
+// attachmentLookupError converte a ausência de um anexo em erro de registro não encontrado.
+func attachmentLookupError(op string, attachmentID int64, err error) error {
+	if repository.IsNotFound(err) {
+		return &Error{Category: CategoryNotFound, Message: fmt.Sprintf("anexo com ID %d não encontrado", attachmentID), Err: err}
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
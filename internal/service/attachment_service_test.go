@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// memoryAttachmentRepository guarda os anexos em memória, na ordem de criação.
+type memoryAttachmentRepository struct {
+	repository.LessonAttachmentRepository
+	attachments []models.LessonAttachment
+	failCreate  bool
+}
+
+func (r *memoryAttachmentRepository) CreateAttachment(ctx context.Context, attachment *models.LessonAttachment) (int64, error) {
+	if r.failCreate {
+		return 0, errors.New("disco cheio")
+	}
+	attachment.ID = int64(len(r.attachments) + 1)
+	r.attachments = append(r.attachments, *attachment)
+	return attachment.ID, nil
+}
+
+func (r *memoryAttachmentRepository) GetAttachmentByID(ctx context.Context, attachmentID int64) (*models.LessonAttachment, error) {
+	for _, attachment := range r.attachments {
+		if attachment.ID == attachmentID {
+			return &attachment, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *memoryAttachmentRepository) ListAttachmentsByLessonID(ctx context.Context, lessonID int64) ([]models.LessonAttachment, error) {
+	var attachments []models.LessonAttachment
+	for _, attachment := range r.attachments {
+		if attachment.LessonID == lessonID {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments, nil
+}
+
+func (r *memoryAttachmentRepository) DeleteAttachment(ctx context.Context, attachmentID int64) error {
+	for i, attachment := range r.attachments {
+		if attachment.ID == attachmentID {
+			r.attachments = append(r.attachments[:i], r.attachments[i+1:]...)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func newTestAttachmentService(t *testing.T) (AttachmentService, *memoryAttachmentRepository, string) {
+	t.Helper()
+	repo := &memoryAttachmentRepository{}
+	lessonRepo := newMemoryLessonRepository(models.Lesson{ID: 7, ClassID: 1, Title: "Frações"})
+	dir := filepath.Join(t.TempDir(), "anexos")
+	return NewAttachmentService(repo, lessonRepo, dir), repo, dir
+}
+
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestAttachmentService_AttachFileCopiesIntoDir(t *testing.T) {
+	svc, _, dir := newTestAttachmentService(t)
+	ctx := context.Background()
+	source := writeTempFile(t, "slides.pdf", "conteúdo")
+
+	first, err := svc.AttachFile(ctx, 7, source, "")
+	require.NoError(t, err)
+	assert.Equal(t, "slides.pdf", first.Name)
+	assert.Equal(t, models.AttachmentKindFile, first.Kind)
+	assert.Equal(t, "aula-7/slides.pdf", first.Location)
+	assert.Equal(t, filepath.Join(dir, "aula-7", "slides.pdf"), svc.Target(first))
+
+	// Um segundo arquivo com o mesmo nome não sobrescreve a cópia anterior.
+	second, err := svc.AttachFile(ctx, 7, source, "Slides revisados")
+	require.NoError(t, err)
+	assert.Equal(t, "Slides revisados", second.Name)
+	assert.Equal(t, "aula-7/slides (2).pdf", second.Location)
+
+	// A cópia continua disponível depois que o original é apagado.
+	require.NoError(t, os.Remove(source))
+	data, err := os.ReadFile(svc.Target(first))
+	require.NoError(t, err)
+	assert.Equal(t, "conteúdo", string(data))
+}
+
+func TestAttachmentService_AttachFileErrors(t *testing.T) {
+	svc, repo, dir := newTestAttachmentService(t)
+	ctx := context.Background()
+	source := writeTempFile(t, "ficha.docx", "exercícios")
+
+	_, err := svc.AttachFile(ctx, 99, source, "")
+	assert.True(t, errors.Is(err, ErrNotFound), "aula inexistente deve ser NotFound, got %v", err)
+
+	_, err = svc.AttachFile(ctx, 7, filepath.Join(t.TempDir(), "inexistente.pdf"), "")
+	assert.True(t, errors.Is(err, ErrValidation), "arquivo inexistente deve ser Validation, got %v", err)
+
+	// Se o registro falhar, a cópia não fica órfã no diretório de anexos.
+	repo.failCreate = true
+	_, err = svc.AttachFile(ctx, 7, source, "")
+	require.Error(t, err)
+	_, statErr := os.Stat(filepath.Join(dir, "aula-7", "ficha.docx"))
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestAttachmentService_AttachLink(t *testing.T) {
+	svc, _, _ := newTestAttachmentService(t)
+	ctx := context.Background()
+
+	link, err := svc.AttachLink(ctx, 7, " https://example.com/video ", "")
+	require.NoError(t, err)
+	assert.Equal(t, models.AttachmentKindLink, link.Kind)
+	assert.Equal(t, "https://example.com/video", link.Name)
+	assert.Equal(t, "https://example.com/video", svc.Target(link))
+
+	for _, invalid := range []string{"example.com/video", "ftp://example.com/a", "https://"} {
+		_, err := svc.AttachLink(ctx, 7, invalid, "")
+		assert.True(t, errors.Is(err, ErrValidation), "link %q deveria ser inválido, got %v", invalid, err)
+	}
+}
+
+func TestAttachmentService_DeleteRemovesFiles(t *testing.T) {
+	svc, repo, dir := newTestAttachmentService(t)
+	ctx := context.Background()
+
+	file, err := svc.AttachFile(ctx, 7, writeTempFile(t, "slides.pdf", "a"), "")
+	require.NoError(t, err)
+	_, err = svc.AttachLink(ctx, 7, "https://example.com", "")
+	require.NoError(t, err)
+	other, err := svc.AttachFile(ctx, 7, writeTempFile(t, "ficha.pdf", "b"), "")
+	require.NoError(t, err)
+
+	require.NoError(t, svc.DeleteAttachment(ctx, file.ID))
+	_, statErr := os.Stat(svc.Target(file))
+	assert.True(t, os.IsNotExist(statErr))
+	assert.True(t, errors.Is(svc.DeleteAttachment(ctx, file.ID), ErrNotFound))
+
+	require.NoError(t, svc.DeleteLessonAttachments(ctx, 7))
+	assert.Empty(t, repo.attachments)
+	_, statErr = os.Stat(svc.Target(other))
+	assert.True(t, os.IsNotExist(statErr))
+	_, statErr = os.Stat(filepath.Join(dir, "aula-7"))
+	assert.True(t, os.IsNotExist(statErr), "o diretório vazio da aula deve ser removido")
+}
@@ -0,0 +1,221 @@
+// Package service contém as implementações concretas das interfaces de serviço.
+// Este arquivo específico implementa a interface AttendanceService (chamada).
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// AbsenceLimitPercent é o limite de faltas, em porcentagem das aulas, acima do qual o aluno não
+// atinge a frequência mínima de 75% exigida pela LDB.
+const AbsenceLimitPercent = 25.0
+
+// RollCallEntry é um aluno na lista de chamada de uma aula.
+type RollCallEntry struct {
+	Student models.Student `json:"student"`
+	Status  string         `json:"status"` // Status é a situação registrada (ver models.Attendance*) ou "" se ainda não houver.
+}
+
+// AttendanceSummary totaliza a frequência de um aluno em um período. Atrasos contam como presença e
+// faltas justificadas contam como falta: a justificativa fica registrada, mas não abona a falta.
+type AttendanceSummary struct {
+	Student        models.Student `json:"student"`
+	Lessons        int            `json:"lessons"`         // Lessons é o número de aulas com chamada registrada para o aluno.
+	Present        int            `json:"present"`         // Present conta as presenças sem atraso.
+	Late           int            `json:"late"`            // Late conta os atrasos.
+	Absent         int            `json:"absent"`          // Absent conta as faltas sem justificativa.
+	Justified      int            `json:"justified"`       // Justified conta as faltas justificadas.
+	AbsencePercent float64        `json:"absence_percent"` // AbsencePercent é (Absent+Justified)/Lessons, em porcentagem.
+}
+
+// Absences retorna o total de faltas (justificadas ou não).
+func (s AttendanceSummary) Absences() int {
+	return s.Absent + s.Justified
+}
+
+// OverLimit indica se as faltas passaram de AbsenceLimitPercent.
+func (s AttendanceSummary) OverLimit() bool {
+	return s.AbsencePercent > AbsenceLimitPercent
+}
+
+// attendanceServiceImpl é a implementação concreta de AttendanceService.
+type attendanceServiceImpl struct {
+	attendanceRepo repository.AttendanceRepository
+	lessonRepo     repository.LessonRepository
+	classRepo      repository.ClassRepository
+}
+
+// NewAttendanceService cria uma nova instância de AttendanceService.
+func NewAttendanceService(attendanceRepo repository.AttendanceRepository, lessonRepo repository.LessonRepository, classRepo repository.ClassRepository) AttendanceService {
+	return &attendanceServiceImpl{attendanceRepo: attendanceRepo, lessonRepo: lessonRepo, classRepo: classRepo}
+}
+
+// ParseAttendanceStatus valida uma situação de frequência. Aceita o nome completo ou a inicial
+// (p, f, a ou j), sem diferenciar maiúsculas de minúsculas.
+func ParseAttendanceStatus(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case models.AttendancePresent, "p":
+		return models.AttendancePresent, nil
+	case models.AttendanceAbsent, "f":
+		return models.AttendanceAbsent, nil
+	case models.AttendanceLate, "a":
+		return models.AttendanceLate, nil
+	case models.AttendanceJustified, "j":
+		return models.AttendanceJustified, nil
+	}
+	return "", ValidationErrorf("situação de frequência inválida '%s': use presente (p), falta (f), atraso (a) ou justificada (j)", value)
+}
+
+func (s *attendanceServiceImpl) GetRollCall(ctx context.Context, lessonID int64) (models.Lesson, []RollCallEntry, error) {
+	lesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
+	if err != nil {
+		return models.Lesson{}, nil, lessonLookupError("attendanceService.GetRollCall", lessonID, err)
+	}
+	students, err := s.classRepo.GetStudentsByClassID(ctx, lesson.ClassID)
+	if err != nil {
+		return models.Lesson{}, nil, fmt.Errorf("attendanceService.GetRollCall: %w", err)
+	}
+	records, err := s.attendanceRepo.GetAttendanceByLessonID(ctx, lessonID)
+	if err != nil {
+		return models.Lesson{}, nil, fmt.Errorf("attendanceService.GetRollCall: %w", err)
+	}
+	recorded := make(map[int64]string, len(records))
+	for _, record := range records {
+		recorded[record.StudentID] = record.Status
+	}
+
+	entries := []RollCallEntry{}
+	for _, student := range students {
+		status, ok := recorded[student.ID]
+		if student.Status != "ativo" && !ok {
+			continue // Alunos inativos ou transferidos só aparecem nas aulas em que já têm registro.
+		}
+		entries = append(entries, RollCallEntry{Student: student, Status: status})
+	}
+	return *lesson, entries, nil
+}
+
+func (s *attendanceServiceImpl) RecordAttendance(ctx context.Context, lessonID int64, statuses map[int64]string) error {
+	if len(statuses) == 0 {
+		return ValidationErrorf("informe a frequência de ao menos um aluno")
+	}
+	lesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
+	if err != nil {
+		return lessonLookupError("attendanceService.RecordAttendance", lessonID, err)
+	}
+	if lesson.Status == models.LessonStatusCancelled {
+		return ConflictErrorf("a aula '%s' de %s foi cancelada: não há chamada a registrar", lesson.Title, lesson.ScheduledAt.Format("02/01/2006"))
+	}
+	students, err := s.classRepo.GetStudentsByClassID(ctx, lesson.ClassID)
+	if err != nil {
+		return fmt.Errorf("attendanceService.RecordAttendance: %w", err)
+	}
+
+	// Os registros seguem a ordem da lista de chamada (alfabética).
+	records := make([]models.AttendanceRecord, 0, len(statuses))
+	for _, student := range students {
+		value, ok := statuses[student.ID]
+		if !ok {
+			continue
+		}
+		status, err := ParseAttendanceStatus(value)
+		if err != nil {
+			return err
+		}
+		records = append(records, models.AttendanceRecord{LessonID: lessonID, StudentID: student.ID, Status: status})
+	}
+	if len(records) < len(statuses) {
+		for studentID := range statuses {
+			if !containsStudent(students, studentID) {
+				return NotFoundErrorf("aluno com ID %d não encontrado na turma da aula", studentID)
+			}
+		}
+	}
+	if err := s.attendanceRepo.SaveAttendance(ctx, lessonID, records); err != nil {
+		return fmt.Errorf("attendanceService.RecordAttendance: %w", err)
+	}
+	return nil
+}
+
+func (s *attendanceServiceImpl) GetAbsenceSummary(ctx context.Context, classID int64, from, to time.Time) ([]AttendanceSummary, error) {
+	if _, err := s.classRepo.GetClassByID(ctx, classID); err != nil {
+		if repository.IsNotFound(err) {
+			return nil, &Error{Category: CategoryNotFound, Message: fmt.Sprintf("turma com ID %d não encontrada", classID), Err: err}
+		}
+		return nil, fmt.Errorf("attendanceService.GetAbsenceSummary: %w", err)
+	}
+	lessons, err := s.lessonRepo.GetLessonsByClassID(ctx, classID)
+	if err != nil {
+		return nil, fmt.Errorf("attendanceService.GetAbsenceSummary: %w", err)
+	}
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, to.Location()).AddDate(0, 0, 1)
+	inPeriod := make(map[int64]bool)
+	for _, lesson := range lessons {
+		if !lesson.ScheduledAt.Before(start) && lesson.ScheduledAt.Before(end) && lesson.Status != models.LessonStatusCancelled {
+			inPeriod[lesson.ID] = true
+		}
+	}
+
+	records, err := s.attendanceRepo.GetAttendanceByClassID(ctx, classID)
+	if err != nil {
+		return nil, fmt.Errorf("attendanceService.GetAbsenceSummary: %w", err)
+	}
+	totals := make(map[int64]*AttendanceSummary)
+	for _, record := range records {
+		if !inPeriod[record.LessonID] {
+			continue
+		}
+		total, ok := totals[record.StudentID]
+		if !ok {
+			total = &AttendanceSummary{}
+			totals[record.StudentID] = total
+		}
+		total.Lessons++
+		switch record.Status {
+		case models.AttendancePresent:
+			total.Present++
+		case models.AttendanceLate:
+			total.Late++
+		case models.AttendanceAbsent:
+			total.Absent++
+		case models.AttendanceJustified:
+			total.Justified++
+		}
+	}
+
+	students, err := s.classRepo.GetStudentsByClassID(ctx, classID)
+	if err != nil {
+		return nil, fmt.Errorf("attendanceService.GetAbsenceSummary: %w", err)
+	}
+	summaries := []AttendanceSummary{}
+	for _, student := range students {
+		total, ok := totals[student.ID]
+		if !ok {
+			if student.Status != "ativo" {
+				continue
+			}
+			total = &AttendanceSummary{}
+		}
+		total.Student = student
+		if total.Lessons > 0 {
+			total.AbsencePercent = float64(total.Absences()) * 100 / float64(total.Lessons)
+		}
+		summaries = append(summaries, *total)
+	}
+	return summaries, nil
+}
+
+func containsStudent(students []models.Student, studentID int64) bool {
+	for _, student := range students {
+		if student.ID == studentID {
+			return true
+		}
+	}
+	return false
+}
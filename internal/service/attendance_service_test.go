@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// rosterClassRepository devolve uma turma nomeada (ver namedClassRepository) com alunos fixos.
+type rosterClassRepository struct {
+	namedClassRepository
+	students []models.Student
+}
+
+func (r *rosterClassRepository) GetStudentsByClassID(ctx context.Context, classID int64) ([]models.Student, error) {
+	var students []models.Student
+	for _, student := range r.students {
+		if student.ClassID == classID {
+			students = append(students, student)
+		}
+	}
+	return students, nil
+}
+
+// memoryAttendanceRepository guarda a chamada em memória, indexada por aula e aluno.
+type memoryAttendanceRepository struct {
+	repository.AttendanceRepository
+	records map[[2]int64]string
+	lessons repository.LessonRepository
+}
+
+func (r *memoryAttendanceRepository) SaveAttendance(ctx context.Context, lessonID int64, records []models.AttendanceRecord) error {
+	for _, record := range records {
+		r.records[[2]int64{lessonID, record.StudentID}] = record.Status
+	}
+	return nil
+}
+
+func (r *memoryAttendanceRepository) GetAttendanceByLessonID(ctx context.Context, lessonID int64) ([]models.AttendanceRecord, error) {
+	var records []models.AttendanceRecord
+	for key, status := range r.records {
+		if key[0] == lessonID {
+			records = append(records, models.AttendanceRecord{LessonID: key[0], StudentID: key[1], Status: status})
+		}
+	}
+	return records, nil
+}
+
+func (r *memoryAttendanceRepository) GetAttendanceByClassID(ctx context.Context, classID int64) ([]models.AttendanceRecord, error) {
+	var records []models.AttendanceRecord
+	for key, status := range r.records {
+		lesson, err := r.lessons.GetLessonByID(ctx, key[0])
+		if err == nil && lesson.ClassID == classID {
+			records = append(records, models.AttendanceRecord{LessonID: key[0], StudentID: key[1], Status: status})
+		}
+	}
+	return records, nil
+}
+
+func newTestAttendanceService() (AttendanceService, *memoryAttendanceRepository) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 7, 0, 0, 0, time.UTC) }
+	lessonRepo := newMemoryLessonRepository(
+		models.Lesson{ID: 1, ClassID: 1, Title: "Aula 1", ScheduledAt: day(3)},
+		models.Lesson{ID: 2, ClassID: 1, Title: "Aula 2", ScheduledAt: day(10)},
+		models.Lesson{ID: 3, ClassID: 1, Title: "Aula 3", ScheduledAt: day(17)},
+		models.Lesson{ID: 4, ClassID: 1, Title: "Aula 4", ScheduledAt: day(24)},
+		models.Lesson{ID: 5, ClassID: 1, Title: "Cancelada", ScheduledAt: day(25), Status: models.LessonStatusCancelled},
+	)
+	classRepo := &rosterClassRepository{students: []models.Student{
+		{ID: 10, ClassID: 1, FullName: "Ana", Status: "ativo"},
+		{ID: 11, ClassID: 1, FullName: "Bruno", Status: "ativo"},
+		{ID: 12, ClassID: 1, FullName: "Carla", Status: "transferido"},
+		{ID: 20, ClassID: 2, FullName: "Diego", Status: "ativo"},
+	}}
+	attendanceRepo := &memoryAttendanceRepository{records: make(map[[2]int64]string), lessons: lessonRepo}
+	return NewAttendanceService(attendanceRepo, lessonRepo, classRepo), attendanceRepo
+}
+
+func TestParseAttendanceStatus(t *testing.T) {
+	for input, expected := range map[string]string{
+		"p": models.AttendancePresent, "Presente": models.AttendancePresent, "F": models.AttendanceAbsent,
+		"atraso": models.AttendanceLate, " j ": models.AttendanceJustified,
+	} {
+		got, err := ParseAttendanceStatus(input)
+		if err != nil || got != expected {
+			t.Errorf("ParseAttendanceStatus(%q) = %q, %v; expected %q", input, got, err, expected)
+		}
+	}
+	_, err := ParseAttendanceStatus("ausente")
+	assert.True(t, errors.Is(err, ErrValidation))
+}
+
+func TestAttendanceService_RollCall(t *testing.T) {
+	svc, _ := newTestAttendanceService()
+	ctx := context.Background()
+
+	// Alunos transferidos ficam fora da chamada de aulas em que não têm registro.
+	_, entries, err := svc.GetRollCall(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Ana", entries[0].Student.FullName)
+	assert.Equal(t, "", entries[0].Status)
+
+	require.NoError(t, svc.RecordAttendance(ctx, 1, map[int64]string{10: "p", 11: "f"}))
+	require.NoError(t, svc.RecordAttendance(ctx, 1, map[int64]string{11: "j"}))
+	_, entries, err = svc.GetRollCall(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.AttendancePresent, entries[0].Status)
+	assert.Equal(t, models.AttendanceJustified, entries[1].Status)
+
+	_, _, err = svc.GetRollCall(ctx, 99)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestAttendanceService_RecordAttendanceErrors(t *testing.T) {
+	svc, repo := newTestAttendanceService()
+	ctx := context.Background()
+
+	assert.True(t, errors.Is(svc.RecordAttendance(ctx, 1, nil), ErrValidation))
+	assert.True(t, errors.Is(svc.RecordAttendance(ctx, 1, map[int64]string{10: "x"}), ErrValidation))
+	assert.True(t, errors.Is(svc.RecordAttendance(ctx, 1, map[int64]string{10: "p", 20: "p"}), ErrNotFound), "aluno de outra turma")
+	assert.True(t, errors.Is(svc.RecordAttendance(ctx, 5, map[int64]string{10: "p"}), ErrConflict), "aula cancelada")
+	assert.True(t, errors.Is(svc.RecordAttendance(ctx, 99, map[int64]string{10: "p"}), ErrNotFound))
+	assert.Empty(t, repo.records, "nenhum registro deve ser gravado quando a chamada é inválida")
+}
+
+func TestAttendanceService_AbsenceSummary(t *testing.T) {
+	svc, repo := newTestAttendanceService()
+	ctx := context.Background()
+
+	require.NoError(t, svc.RecordAttendance(ctx, 1, map[int64]string{10: "p", 11: "f"}))
+	require.NoError(t, svc.RecordAttendance(ctx, 2, map[int64]string{10: "a", 11: "j"}))
+	require.NoError(t, svc.RecordAttendance(ctx, 3, map[int64]string{10: "p", 11: "p"}))
+	require.NoError(t, svc.RecordAttendance(ctx, 4, map[int64]string{10: "f", 11: "p"}))
+	repo.records[[2]int64{5, 10}] = models.AttendanceAbsent // Aulas canceladas não contam.
+
+	// Período das aulas 1 a 3 (a aula 4 fica de fora).
+	summaries, err := svc.GetAbsenceSummary(ctx, 1, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, summaries, 2, "aluno transferido sem registros no período fica de fora")
+
+	ana, bruno := summaries[0], summaries[1]
+	assert.Equal(t, AttendanceSummary{Student: ana.Student, Lessons: 3, Present: 2, Late: 1}, ana)
+	assert.Equal(t, 3, bruno.Lessons)
+	assert.Equal(t, 1, bruno.Absent)
+	assert.Equal(t, 1, bruno.Justified)
+	assert.Equal(t, 2, bruno.Absences())
+	assert.InDelta(t, 66.67, bruno.AbsencePercent, 0.01)
+	assert.True(t, bruno.OverLimit())
+	assert.False(t, ana.OverLimit())
+
+	_, err = svc.GetAbsenceSummary(ctx, 42, time.Now(), time.Now())
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
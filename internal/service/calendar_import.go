@@ -0,0 +1,258 @@
+// Este arquivo implementa a leitura dos arquivos de calendário escolar (CSV e iCalendar)
+// usados por CalendarService.Import.
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"vigenda/internal/models"
+)
+
+// Formatos aceitos por CalendarService.Import.
+const (
+	CalendarFormatCSV = "csv"
+	CalendarFormatICS = "ics"
+)
+
+// calendarTermKind é o tipo usado no arquivo para as linhas/eventos que definem um bimestre.
+const calendarTermKind = "bimestre"
+
+// CalendarImportResult resume uma importação de calendário.
+type CalendarImportResult struct {
+	Terms      int // Terms é o número de bimestres gravados (novos ou substituídos).
+	Events     int // Events é o número de eventos criados.
+	Duplicates int // Duplicates é o número de eventos ignorados por já estarem cadastrados.
+}
+
+// termNumberPattern reconhece "3º bimestre", "3o bimestre", "Bimestre 3" etc.
+var termNumberPattern = regexp.MustCompile(`(?i)\b([1-4])\s*(?:º|°|o|ª)?\s*bimestre|bimestre\s*([1-4])\b`)
+
+// parseTermNumber extrai o número do bimestre de um texto ("2", "2º bimestre", "Início do bimestre 2").
+func parseTermNumber(text string) (int, bool) {
+	if number, err := strconv.Atoi(strings.TrimSpace(text)); err == nil {
+		return number, true
+	}
+	match := termNumberPattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0, false
+	}
+	number, _ := strconv.Atoi(match[1] + match[2])
+	return number, true
+}
+
+// parseCalendarDate aceita datas AAAA-MM-DD ou DD/MM/AAAA, no horário local.
+func parseCalendarDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{calendarDateLayout, "02/01/2006"} {
+		if date, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("data inválida '%s': use AAAA-MM-DD ou DD/MM/AAAA", value)
+}
+
+// calendarEntry é um item lido do arquivo, antes de virar bimestre ou evento.
+type calendarEntry struct {
+	kind        string
+	description string
+	start, end  time.Time
+}
+
+// toCalendar converte o item num bimestre ou num evento validado.
+func (e calendarEntry) toCalendar() (*models.AcademicTerm, *models.CalendarEvent, error) {
+	if e.kind == calendarTermKind {
+		number, ok := parseTermNumber(e.description)
+		if !ok {
+			return nil, nil, ValidationErrorf("não foi possível identificar o número do bimestre em '%s'", e.description)
+		}
+		term := models.AcademicTerm{Year: e.start.Year(), Number: number, StartDate: e.start, EndDate: e.end}
+		if err := validateTerm(term, nil); err != nil {
+			return nil, nil, err
+		}
+		return &term, nil, nil
+	}
+	event := models.CalendarEvent{Kind: e.kind, Description: e.description, StartDate: e.start, EndDate: e.end}
+	if err := validateEvent(&event); err != nil {
+		return nil, nil, err
+	}
+	return nil, &event, nil
+}
+
+// parseCalendarCSV lê um calendário com as colunas tipo, início, fim e descrição, separadas por
+// vírgula ou ponto e vírgula. O tipo é bimestre, feriado, recesso ou planejamento; o fim pode ficar
+// vazio para eventos de um dia; para bimestres, a descrição traz o número ("1" ou "1º bimestre").
+// Uma primeira linha começando com "tipo" é tratada como cabeçalho.
+func parseCalendarCSV(data []byte) ([]models.AcademicTerm, []models.CalendarEvent, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	firstLine, _, _ := strings.Cut(string(data), "\n")
+	if strings.Contains(firstLine, ";") && !strings.Contains(firstLine, ",") {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, ValidationErrorf("CSV inválido: %v", err)
+	}
+
+	var terms []models.AcademicTerm
+	var events []models.CalendarEvent
+	for i, record := range records {
+		line := i + 1
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+		kind := strings.ToLower(strings.TrimSpace(record[0]))
+		if i == 0 && kind == "tipo" {
+			continue
+		}
+		if len(record) < 2 {
+			return nil, nil, ValidationErrorf("linha %d: informe ao menos tipo e data de início", line)
+		}
+		entry := calendarEntry{kind: kind}
+		if entry.start, err = parseCalendarDate(record[1]); err != nil {
+			return nil, nil, ValidationErrorf("linha %d: %v", line, err)
+		}
+		entry.end = entry.start
+		if len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+			if entry.end, err = parseCalendarDate(record[2]); err != nil {
+				return nil, nil, ValidationErrorf("linha %d: %v", line, err)
+			}
+		}
+		if len(record) > 3 {
+			entry.description = strings.Join(record[3:], ", ")
+		}
+
+		term, event, err := entry.toCalendar()
+		if err != nil {
+			return nil, nil, prefixError(fmt.Sprintf("linha %d", line), err)
+		}
+		if term != nil {
+			terms = append(terms, *term)
+		} else {
+			events = append(events, *event)
+		}
+	}
+	return terms, events, nil
+}
+
+// parseCalendarICS lê os VEVENTs de um arquivo iCalendar. O tipo de cada evento vem de CATEGORIES,
+// quando for bimestre, feriado, recesso ou planejamento; senão é deduzido do SUMMARY: "recesso"/"férias"
+// indicam um recesso, "planejamento"/"formação"/"conselho" um dia de planejamento, "2º bimestre" define
+// um bimestre e qualquer outro evento é tratado como feriado.
+func parseCalendarICS(data []byte) ([]models.AcademicTerm, []models.CalendarEvent, error) {
+	// Desfaz as linhas dobradas (continuações começam com espaço ou tabulação).
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.NewReplacer("\n ", "", "\n\t", "").Replace(text)
+
+	var terms []models.AcademicTerm
+	var events []models.CalendarEvent
+	var props map[string]string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.EqualFold(line, "BEGIN:VEVENT"):
+			props = make(map[string]string)
+		case strings.EqualFold(line, "END:VEVENT"):
+			if props == nil {
+				continue
+			}
+			term, event, err := icsEntry(props)
+			if err != nil {
+				return nil, nil, prefixError(fmt.Sprintf("evento '%s'", props["SUMMARY"]), err)
+			}
+			if term != nil {
+				terms = append(terms, *term)
+			} else {
+				events = append(events, *event)
+			}
+			props = nil
+		case props != nil:
+			name, value, ok := strings.Cut(line, ":")
+			if !ok {
+				continue
+			}
+			name, params, _ := strings.Cut(strings.ToUpper(name), ";")
+			if params != "" {
+				props[name+";PARAMS"] = params
+			}
+			props[name] = strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(value)
+		}
+	}
+	if len(terms) == 0 && len(events) == 0 && !strings.Contains(strings.ToUpper(text), "BEGIN:VCALENDAR") {
+		return nil, nil, ValidationErrorf("arquivo iCalendar inválido: BEGIN:VCALENDAR não encontrado")
+	}
+	return terms, events, nil
+}
+
+// prefixError acrescenta a localização no arquivo à mensagem de um erro de serviço, mantendo a categoria.
+func prefixError(prefix string, err error) error {
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return &Error{Category: serviceErr.Category, Message: prefix + ": " + serviceErr.Message, Err: serviceErr.Err}
+	}
+	return fmt.Errorf("%s: %w", prefix, err)
+}
+
+// icsEntry converte as propriedades de um VEVENT num bimestre ou evento.
+func icsEntry(props map[string]string) (*models.AcademicTerm, *models.CalendarEvent, error) {
+	start, startIsDate, err := parseICSDate(props["DTSTART"], props["DTSTART;PARAMS"])
+	if err != nil {
+		return nil, nil, err
+	}
+	end := start
+	if value, ok := props["DTEND"]; ok {
+		var endIsDate bool
+		if end, endIsDate, err = parseICSDate(value, props["DTEND;PARAMS"]); err != nil {
+			return nil, nil, err
+		}
+		// Em eventos de dia inteiro o DTEND é exclusivo (o dia seguinte ao último).
+		if startIsDate && endIsDate && end.After(start) {
+			end = end.AddDate(0, 0, -1)
+		}
+	}
+
+	summary := strings.TrimSpace(props["SUMMARY"])
+	entry := calendarEntry{kind: classifyCalendarEvent(props["CATEGORIES"], summary), description: summary, start: start, end: end}
+	return entry.toCalendar()
+}
+
+// parseICSDate interpreta um DTSTART/DTEND (AAAAMMDD ou AAAAMMDDTHHMMSS[Z]) e informa se é uma data sem hora.
+func parseICSDate(value, params string) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 8 {
+		return time.Time{}, false, ValidationErrorf("data iCalendar inválida '%s'", value)
+	}
+	date, err := time.ParseInLocation("20060102", value[:8], time.Local)
+	if err != nil {
+		return time.Time{}, false, ValidationErrorf("data iCalendar inválida '%s'", value)
+	}
+	return date, len(value) == 8 || strings.Contains(params, "VALUE=DATE"), nil
+}
+
+// classifyCalendarEvent deduz o tipo de um evento iCalendar a partir de CATEGORIES e SUMMARY.
+func classifyCalendarEvent(categories, summary string) string {
+	for _, category := range strings.Split(strings.ToLower(categories), ",") {
+		switch category = strings.TrimSpace(category); category {
+		case calendarTermKind, models.CalendarEventKindHoliday, models.CalendarEventKindRecess, models.CalendarEventKindPlanning:
+			return category
+		}
+	}
+	lower := strings.ToLower(summary)
+	switch {
+	case strings.Contains(lower, "recesso") || strings.Contains(lower, "férias") || strings.Contains(lower, "ferias"):
+		return models.CalendarEventKindRecess
+	case strings.Contains(lower, "planejamento") || strings.Contains(lower, "formação") || strings.Contains(lower, "conselho"):
+		return models.CalendarEventKindPlanning
+	case termNumberPattern.MatchString(summary):
+		return calendarTermKind
+	}
+	return models.CalendarEventKindHoliday
+}
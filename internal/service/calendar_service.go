@@ -0,0 +1,260 @@
+// Package service contém as implementações concretas das interfaces de serviço.
+// Este arquivo específico implementa a interface CalendarService (bimestres e dias sem aula).
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// calendarDateLayout é o formato das chaves de DaysOff e das datas gravadas no calendário.
+const calendarDateLayout = "2006-01-02"
+
+// calendarServiceImpl é a implementação concreta de CalendarService.
+type calendarServiceImpl struct {
+	calendarRepo repository.CalendarRepository
+}
+
+// NewCalendarService cria uma nova instância de CalendarService.
+func NewCalendarService(calendarRepo repository.CalendarRepository) CalendarService {
+	return &calendarServiceImpl{calendarRepo: calendarRepo}
+}
+
+// calendarDay zera a hora da data, mantendo o fuso.
+func calendarDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+// containsDay informa se 'date' está entre 'start' e 'end' (inclusive), comparando apenas o dia.
+func containsDay(start, end, date time.Time) bool {
+	day := date.Format(calendarDateLayout)
+	return day >= start.Format(calendarDateLayout) && day <= end.Format(calendarDateLayout)
+}
+
+// TermLabel retorna o nome do bimestre (ex: "3º bimestre").
+func TermLabel(number int) string {
+	return fmt.Sprintf("%dº bimestre", number)
+}
+
+// validateTerm verifica número e período de um bimestre e se ele se sobrepõe a algum dos outros.
+func validateTerm(term models.AcademicTerm, others []models.AcademicTerm) error {
+	if term.Number < 1 || term.Number > 4 {
+		return ValidationErrorf("bimestre inválido %d: use um número de 1 a 4", term.Number)
+	}
+	if term.EndDate.Before(term.StartDate) {
+		return ValidationErrorf("o %s termina (%s) antes de começar (%s)", TermLabel(term.Number),
+			term.EndDate.Format("02/01/2006"), term.StartDate.Format("02/01/2006"))
+	}
+	for _, other := range others {
+		if other.Year == term.Year && other.Number == term.Number {
+			continue
+		}
+		if containsDay(other.StartDate, other.EndDate, term.StartDate) || containsDay(term.StartDate, term.EndDate, other.StartDate) {
+			return ConflictErrorf("o %s de %d (%s a %s) se sobrepõe ao %s de %d (%s a %s)",
+				TermLabel(term.Number), term.Year, term.StartDate.Format("02/01/2006"), term.EndDate.Format("02/01/2006"),
+				TermLabel(other.Number), other.Year, other.StartDate.Format("02/01/2006"), other.EndDate.Format("02/01/2006"))
+		}
+	}
+	return nil
+}
+
+// validateEvent normaliza e valida o tipo, a descrição e o período de um evento.
+func validateEvent(event *models.CalendarEvent) error {
+	event.Kind = strings.ToLower(strings.TrimSpace(event.Kind))
+	switch event.Kind {
+	case models.CalendarEventKindHoliday, models.CalendarEventKindRecess, models.CalendarEventKindPlanning:
+	default:
+		return ValidationErrorf("tipo de evento inválido '%s': use feriado, recesso ou planejamento", event.Kind)
+	}
+	event.Description = strings.TrimSpace(event.Description)
+	if event.Description == "" {
+		return ValidationErrorf("a descrição do evento não pode ser vazia")
+	}
+	event.StartDate = calendarDay(event.StartDate)
+	if event.EndDate.IsZero() {
+		event.EndDate = event.StartDate
+	}
+	event.EndDate = calendarDay(event.EndDate)
+	if event.EndDate.Before(event.StartDate) {
+		return ValidationErrorf("o evento '%s' termina (%s) antes de começar (%s)", event.Description,
+			event.EndDate.Format("02/01/2006"), event.StartDate.Format("02/01/2006"))
+	}
+	return nil
+}
+
+func (s *calendarServiceImpl) SetTerm(ctx context.Context, number int, start, end time.Time) (models.AcademicTerm, error) {
+	term := models.AcademicTerm{Year: start.Year(), Number: number, StartDate: calendarDay(start), EndDate: calendarDay(end)}
+	existing, err := s.calendarRepo.ListTerms(ctx)
+	if err != nil {
+		return models.AcademicTerm{}, fmt.Errorf("calendarService.SetTerm: %w", err)
+	}
+	if err := validateTerm(term, existing); err != nil {
+		return models.AcademicTerm{}, err
+	}
+	id, err := s.calendarRepo.UpsertTerm(ctx, &term)
+	if err != nil {
+		return models.AcademicTerm{}, fmt.Errorf("calendarService.SetTerm: %w", err)
+	}
+	term.ID = id
+	return term, nil
+}
+
+func (s *calendarServiceImpl) ListTerms(ctx context.Context, year int) ([]models.AcademicTerm, error) {
+	terms, err := s.calendarRepo.ListTerms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("calendarService.ListTerms: %w", err)
+	}
+	selected := []models.AcademicTerm{}
+	for _, term := range terms {
+		if year == 0 || term.Year == year {
+			selected = append(selected, term)
+		}
+	}
+	return selected, nil
+}
+
+func (s *calendarServiceImpl) DeleteTerm(ctx context.Context, termID int64) error {
+	if err := s.calendarRepo.DeleteTerm(ctx, termID); err != nil {
+		return calendarLookupError("calendarService.DeleteTerm", "bimestre", termID, err)
+	}
+	return nil
+}
+
+func (s *calendarServiceImpl) AddEvent(ctx context.Context, kind, description string, start, end time.Time) (models.CalendarEvent, error) {
+	event := models.CalendarEvent{Kind: kind, Description: description, StartDate: start, EndDate: end}
+	if err := validateEvent(&event); err != nil {
+		return models.CalendarEvent{}, err
+	}
+	id, err := s.calendarRepo.CreateEvent(ctx, &event)
+	if err != nil {
+		return models.CalendarEvent{}, fmt.Errorf("calendarService.AddEvent: %w", err)
+	}
+	event.ID = id
+	return event, nil
+}
+
+func (s *calendarServiceImpl) ListEvents(ctx context.Context, from, to time.Time) ([]models.CalendarEvent, error) {
+	events, err := s.calendarRepo.ListEvents(ctx, calendarDay(from), calendarDay(to))
+	if err != nil {
+		return nil, fmt.Errorf("calendarService.ListEvents: %w", err)
+	}
+	if events == nil {
+		events = []models.CalendarEvent{}
+	}
+	return events, nil
+}
+
+func (s *calendarServiceImpl) DeleteEvent(ctx context.Context, eventID int64) error {
+	if err := s.calendarRepo.DeleteEvent(ctx, eventID); err != nil {
+		return calendarLookupError("calendarService.DeleteEvent", "evento", eventID, err)
+	}
+	return nil
+}
+
+func (s *calendarServiceImpl) Import(ctx context.Context, data []byte, format string) (CalendarImportResult, error) {
+	var terms []models.AcademicTerm
+	var events []models.CalendarEvent
+	var err error
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case CalendarFormatCSV:
+		terms, events, err = parseCalendarCSV(data)
+	case CalendarFormatICS:
+		terms, events, err = parseCalendarICS(data)
+	default:
+		return CalendarImportResult{}, ValidationErrorf("formato de calendário inválido '%s': use csv ou ics", format)
+	}
+	if err != nil {
+		return CalendarImportResult{}, err
+	}
+	if len(terms) == 0 && len(events) == 0 {
+		return CalendarImportResult{}, ValidationErrorf("o arquivo não contém bimestres nem eventos")
+	}
+
+	// Os bimestres importados substituem os de mesmo ano e número; a sobreposição é verificada
+	// contra os demais já cadastrados e entre os próprios bimestres do arquivo.
+	existing, err := s.calendarRepo.ListTerms(ctx)
+	if err != nil {
+		return CalendarImportResult{}, fmt.Errorf("calendarService.Import: %w", err)
+	}
+	for i, term := range terms {
+		if err := validateTerm(term, append(existing, terms[:i]...)); err != nil {
+			return CalendarImportResult{}, err
+		}
+	}
+
+	created, err := s.calendarRepo.ImportCalendar(ctx, terms, events)
+	if err != nil {
+		return CalendarImportResult{}, fmt.Errorf("calendarService.Import: %w", err)
+	}
+	return CalendarImportResult{Terms: len(terms), Events: created, Duplicates: len(events) - created}, nil
+}
+
+func (s *calendarServiceImpl) TermForDate(ctx context.Context, date time.Time) (*models.AcademicTerm, error) {
+	terms, err := s.calendarRepo.ListTerms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("calendarService.TermForDate: %w", err)
+	}
+	for _, term := range terms {
+		if containsDay(term.StartDate, term.EndDate, date) {
+			found := term
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *calendarServiceImpl) DaysOff(ctx context.Context, from, to time.Time) (map[string]string, error) {
+	from, to = calendarDay(from), calendarDay(to)
+	daysOff := nationalDaysOff(from, to)
+	events, err := s.calendarRepo.ListEvents(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("calendarService.DaysOff: %w", err)
+	}
+	for _, event := range events {
+		first := event.StartDate
+		if first.Before(from) {
+			first = from
+		}
+		for day := first; !day.After(event.EndDate) && !day.After(to); day = day.AddDate(0, 0, 1) {
+			daysOff[day.Format(calendarDateLayout)] = event.Kind + ": " + event.Description
+		}
+	}
+	return daysOff, nil
+}
+
+func (s *calendarServiceImpl) DescribeDate(ctx context.Context, date time.Time) (string, error) {
+	term, err := s.TermForDate(ctx, date)
+	if err != nil {
+		return "", err
+	}
+	daysOff, err := s.DaysOff(ctx, date, date)
+	if err != nil {
+		return "", err
+	}
+	reason := daysOff[date.Format(calendarDateLayout)]
+
+	if term == nil {
+		if strings.HasPrefix(reason, models.CalendarEventKindRecess+":") {
+			return reason, nil
+		}
+		return "", nil
+	}
+	week := int(WeekStart(date).Sub(WeekStart(term.StartDate)).Hours()/24+0.5)/7 + 1
+	label := fmt.Sprintf("%s, semana %d", TermLabel(term.Number), week)
+	if reason != "" {
+		label += " (" + reason + ")"
+	}
+	return label, nil
+}
+
+// calendarLookupError converte a ausência de um bimestre ou evento em erro de registro não encontrado.
+func calendarLookupError(op, label string, id int64, err error) error {
+	if repository.IsNotFound(err) {
+		return &Error{Category: CategoryNotFound, Message: fmt.Sprintf("%s com ID %d não encontrado", label, id), Err: err}
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
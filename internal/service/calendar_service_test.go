@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// fakeCalendarRepository guarda bimestres e eventos em memória.
+type fakeCalendarRepository struct {
+	terms  []models.AcademicTerm
+	events []models.CalendarEvent
+	nextID int64
+}
+
+func (f *fakeCalendarRepository) UpsertTerm(ctx context.Context, term *models.AcademicTerm) (int64, error) {
+	for i := range f.terms {
+		if f.terms[i].Year == term.Year && f.terms[i].Number == term.Number {
+			f.terms[i].StartDate, f.terms[i].EndDate = term.StartDate, term.EndDate
+			return f.terms[i].ID, nil
+		}
+	}
+	f.nextID++
+	stored := *term
+	stored.ID = f.nextID
+	f.terms = append(f.terms, stored)
+	return f.nextID, nil
+}
+
+func (f *fakeCalendarRepository) ListTerms(ctx context.Context) ([]models.AcademicTerm, error) {
+	return f.terms, nil
+}
+
+func (f *fakeCalendarRepository) DeleteTerm(ctx context.Context, termID int64) error {
+	return fmt.Errorf("bimestre %d: %w", termID, sql.ErrNoRows)
+}
+
+func (f *fakeCalendarRepository) CreateEvent(ctx context.Context, event *models.CalendarEvent) (int64, error) {
+	f.nextID++
+	stored := *event
+	stored.ID = f.nextID
+	f.events = append(f.events, stored)
+	return f.nextID, nil
+}
+
+func (f *fakeCalendarRepository) ListEvents(ctx context.Context, from, to time.Time) ([]models.CalendarEvent, error) {
+	var events []models.CalendarEvent
+	for _, event := range f.events {
+		if !event.StartDate.After(to) && !event.EndDate.Before(from) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (f *fakeCalendarRepository) DeleteEvent(ctx context.Context, eventID int64) error {
+	return fmt.Errorf("evento %d: %w", eventID, sql.ErrNoRows)
+}
+
+func (f *fakeCalendarRepository) ImportCalendar(ctx context.Context, terms []models.AcademicTerm, events []models.CalendarEvent) (int, error) {
+	for i := range terms {
+		f.UpsertTerm(ctx, &terms[i])
+	}
+	created := 0
+	for _, event := range events {
+		duplicate := false
+		for _, existing := range f.events {
+			if existing.Kind == event.Kind && existing.Description == event.Description &&
+				existing.StartDate.Equal(event.StartDate) && existing.EndDate.Equal(event.EndDate) {
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			f.CreateEvent(ctx, &event)
+			created++
+		}
+	}
+	return created, nil
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+}
+
+func TestCalendarService_SetTermRejectsOverlap(t *testing.T) {
+	ctx := context.Background()
+	calendar := NewCalendarService(&fakeCalendarRepository{})
+
+	if _, err := calendar.SetTerm(ctx, 1, day(2025, 2, 3), day(2025, 4, 11)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := calendar.SetTerm(ctx, 2, day(2025, 4, 7), day(2025, 7, 4)); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected conflict error for overlapping terms, got %v", err)
+	}
+	// Redefinir o próprio bimestre não conflita com as datas antigas dele.
+	if _, err := calendar.SetTerm(ctx, 1, day(2025, 2, 5), day(2025, 4, 15)); err != nil {
+		t.Errorf("Expected term to be replaced, got %v", err)
+	}
+	if _, err := calendar.SetTerm(ctx, 5, day(2025, 8, 1), day(2025, 9, 30)); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for term 5, got %v", err)
+	}
+	if _, err := calendar.SetTerm(ctx, 3, day(2025, 9, 30), day(2025, 8, 1)); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for an inverted period, got %v", err)
+	}
+}
+
+func TestCalendarService_DescribeDate(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeCalendarRepository{
+		terms: []models.AcademicTerm{
+			{ID: 1, Year: 2025, Number: 2, StartDate: day(2025, 4, 22), EndDate: day(2025, 7, 4)},
+			{ID: 2, Year: 2025, Number: 3, StartDate: day(2025, 7, 28), EndDate: day(2025, 10, 3)},
+		},
+		events: []models.CalendarEvent{
+			{ID: 3, Kind: models.CalendarEventKindRecess, Description: "Recesso de julho", StartDate: day(2025, 7, 7), EndDate: day(2025, 7, 25)},
+		},
+	}
+	calendar := NewCalendarService(repo)
+
+	for date, expected := range map[time.Time]string{
+		day(2025, 7, 28):  "3º bimestre, semana 1",
+		day(2025, 8, 3):   "3º bimestre, semana 1", // Domingo ainda é da primeira semana.
+		day(2025, 8, 25):  "3º bimestre, semana 5",
+		day(2025, 4, 23):  "2º bimestre, semana 1", // 22/04 é terça: a semana começa na segunda.
+		day(2025, 4, 28):  "2º bimestre, semana 2",
+		day(2025, 6, 19):  "2º bimestre, semana 9 (feriado: Corpus Christi)",
+		day(2025, 7, 15):  "recesso: Recesso de julho",
+		day(2025, 12, 20): "",
+	} {
+		got, err := calendar.DescribeDate(ctx, date)
+		if err != nil || got != expected {
+			t.Errorf("DescribeDate(%s) = %q, %v; expected %q", date.Format("02/01/2006"), got, err, expected)
+		}
+	}
+}
+
+func TestCalendarService_DaysOff(t *testing.T) {
+	repo := &fakeCalendarRepository{events: []models.CalendarEvent{
+		{Kind: models.CalendarEventKindPlanning, Description: "Conselho de classe", StartDate: day(2025, 4, 17), EndDate: day(2025, 4, 17)},
+		{Kind: models.CalendarEventKindRecess, Description: "Emenda", StartDate: day(2025, 4, 30), EndDate: day(2025, 5, 2)},
+	}}
+	daysOff, err := NewCalendarService(repo).DaysOff(context.Background(), day(2025, 4, 14), day(2025, 5, 1))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := map[string]string{
+		"2025-04-17": "planejamento: Conselho de classe",
+		"2025-04-18": "feriado: Sexta-feira Santa",
+		"2025-04-21": "feriado: Tiradentes",
+		"2025-04-30": "recesso: Emenda",
+		"2025-05-01": "recesso: Emenda", // O evento cadastrado prevalece sobre o feriado nacional.
+	}
+	if fmt.Sprint(daysOff) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected days off.\nExpected: %v\nGot:      %v", expected, daysOff)
+	}
+}
+
+func TestCalendarService_ImportCSV(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeCalendarRepository{}
+	calendar := NewCalendarService(repo)
+	data := []byte("tipo;inicio;fim;descricao\n" +
+		"bimestre;03/02/2025;11/04/2025;1º bimestre\n" +
+		"bimestre;2025-04-22;2025-07-04;2\n" +
+		"recesso;2025-07-07;2025-07-25;Recesso de julho\n" +
+		"planejamento;2025-03-14;;Conselho de classe\n")
+
+	result, err := calendar.Import(ctx, data, "csv")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result != (CalendarImportResult{Terms: 2, Events: 2}) {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if len(repo.terms) != 2 || repo.terms[0].Number != 1 || !repo.terms[0].StartDate.Equal(day(2025, 2, 3)) {
+		t.Errorf("Unexpected terms: %+v", repo.terms)
+	}
+	if len(repo.events) != 2 || !repo.events[1].EndDate.Equal(day(2025, 3, 14)) {
+		t.Errorf("Expected one-day planning event, got %+v", repo.events)
+	}
+
+	// Reimportar não duplica eventos.
+	again, err := calendar.Import(ctx, data, ".CSV")
+	if err != nil || again.Events != 0 || again.Duplicates != 2 {
+		t.Errorf("Expected re-import to ignore duplicates, got %+v, %v", again, err)
+	}
+
+	_, err = calendar.Import(ctx, []byte("feriado,2025-06-13,,Santo Antônio\nfolga,2025-06-20,,Emenda\n"), "csv")
+	if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), "linha 2") {
+		t.Errorf("Expected validation error on line 2, got %v", err)
+	}
+	if len(repo.events) != 2 {
+		t.Errorf("Expected nothing imported from an invalid file, got %d events", len(repo.events))
+	}
+}
+
+func TestCalendarService_ImportICS(t *testing.T) {
+	repo := &fakeCalendarRepository{}
+	data := []byte(strings.Join([]string{
+		"BEGIN:VCALENDAR", "VERSION:2.0",
+		"BEGIN:VEVENT", "SUMMARY:3º Bimestre", "DTSTART;VALUE=DATE:20250728", "DTEND;VALUE=DATE:20251004", "END:VEVENT",
+		"BEGIN:VEVENT", "SUMMARY:Férias escolares", "DTSTART;VALUE=DATE:20250707", "DTEND;VALUE=DATE:20250726", "END:VEVENT",
+		"BEGIN:VEVENT", "SUMMARY:Aniversário da cidade", "DTSTART;VALUE=DATE:20250815", "END:VEVENT",
+		"BEGIN:VEVENT", "SUMMARY:Formação de profes", " sores", "DTSTART:20250901T080000", "DTEND:20250901T170000", "END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n"))
+
+	result, err := NewCalendarService(repo).Import(context.Background(), data, "ics")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Terms != 1 || result.Events != 3 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if term := repo.terms[0]; term.Number != 3 || !term.EndDate.Equal(day(2025, 10, 3)) {
+		t.Errorf("Expected 3rd term ending on 03/10 (DTEND is exclusive), got %+v", term)
+	}
+	var got []string
+	for _, event := range repo.events {
+		got = append(got, fmt.Sprintf("%s %s %s-%s", event.Kind, event.Description, event.StartDate.Format("02/01"), event.EndDate.Format("02/01")))
+	}
+	expected := []string{
+		"recesso Férias escolares 07/07-25/07",
+		"feriado Aniversário da cidade 15/08-15/08",
+		"planejamento Formação de professores 01/09-01/09",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected events.\nExpected: %v\nGot:      %v", expected, got)
+	}
+}
+
+// datedAssessmentRepository guarda a última avaliação criada.
+type datedAssessmentRepository struct {
+	repository.AssessmentRepository
+	created models.Assessment
+}
+
+func (f *datedAssessmentRepository) CreateAssessment(ctx context.Context, assessment *models.Assessment) (int64, error) {
+	f.created = *assessment
+	return 1, nil
+}
+
+func TestAssessmentService_CreateAssessmentOnDateDefaultsTerm(t *testing.T) {
+	ctx := context.Background()
+	calendar := NewCalendarService(&fakeCalendarRepository{terms: []models.AcademicTerm{
+		{ID: 1, Year: 2025, Number: 3, StartDate: day(2025, 7, 28), EndDate: day(2025, 10, 3)},
+	}})
+	repo := &datedAssessmentRepository{}
+	assessmentService := NewAssessmentService(repo, nil, calendar, nil)
+
+	assessment, err := assessmentService.CreateAssessmentOnDate(ctx, "Prova 3", 1, 0, 4, time.Date(2025, 9, 15, 10, 30, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if assessment.Term != 3 || repo.created.AssessmentDate == nil || !repo.created.AssessmentDate.Equal(day(2025, 9, 15)) {
+		t.Errorf("Expected term 3 on 15/09/2025, got %+v", repo.created)
+	}
+	// Um bimestre informado explicitamente prevalece sobre o calendário.
+	if assessment, err = assessmentService.CreateAssessmentOnDate(ctx, "Recuperação", 1, 2, 4, day(2025, 9, 15)); err != nil || assessment.Term != 2 {
+		t.Errorf("Expected explicit term 2, got %d, %v", assessment.Term, err)
+	}
+	if _, err := assessmentService.CreateAssessmentOnDate(ctx, "Prova", 1, 0, 4, day(2025, 12, 20)); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for a date outside the terms, got %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"vigenda/internal/models"
+)
+
+// Tendência das médias de um aluno entre os dois últimos bimestres.
+const (
+	TrendUp     = "em alta"
+	TrendDown   = "em queda"
+	TrendStable = "estável"
+)
+
+// trendTolerance é a menor variação entre médias de bimestre considerada alta ou queda.
+const trendTolerance = 0.1
+
+// topStudents é quantos alunos aparecem entre os de maior desempenho.
+const topStudents = 3
+
+// ClassProgress é o retrato do andamento de uma turma: médias por avaliação, por bimestre e geral,
+// a distribuição das médias dos alunos, quem está abaixo da média da turma, as avaliações com notas
+// pendentes e as aulas dadas e por dar. Só os alunos ativos com notas lançadas entram nas médias, e
+// as de um bimestre só contam os alunos com alguma nota nele.
+type ClassProgress struct {
+	ClassID      int64                `json:"class_id"`
+	Policy       models.GradingPolicy `json:"policy"`
+	Average      *float64             `json:"average,omitempty"` // Média das médias anuais dos alunos ativos.
+	Terms        []TermProgress       `json:"terms"`
+	Assessments  []AssessmentProgress `json:"assessments"`
+	Distribution []GradeBand          `json:"distribution"`
+	Top          []StudentProgress    `json:"top"`       // Maiores médias, na média da turma ou acima.
+	Attention    []StudentProgress    `json:"attention"` // Abaixo da média da turma, da menor para a maior.
+	Pending      []AssessmentProgress `json:"pending"`   // Avaliações já aplicadas com alunos sem nota.
+	Lessons      LessonProgress       `json:"lessons"`
+}
+
+// TermProgress é a média da turma em um bimestre.
+type TermProgress struct {
+	Term    int      `json:"term"`
+	Average *float64 `json:"average,omitempty"`
+}
+
+// AssessmentProgress é a média da turma em uma avaliação e os alunos ativos ainda sem nota nela.
+type AssessmentProgress struct {
+	Assessment models.Assessment `json:"assessment"`
+	Average    *float64          `json:"average,omitempty"`
+	Graded     int               `json:"graded"`
+	Missing    []models.Student  `json:"missing,omitempty"`
+}
+
+// GradeBand é uma faixa de médias (ou um conceito) e quantos alunos ativos estão nela.
+type GradeBand struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// StudentProgress é a média anual de um aluno, as médias de cada bimestre e a tendência entre os
+// dois últimos (TrendUp, TrendDown, TrendStable ou "" com menos de dois bimestres).
+type StudentProgress struct {
+	Student      models.Student `json:"student"`
+	Average      float64        `json:"average"`
+	TermAverages []float64      `json:"term_averages"`
+	Trend        string         `json:"trend,omitempty"`
+}
+
+// LessonProgress conta as aulas da turma: Planned são todas as não canceladas, Taught as ministradas
+// (inteira ou parcialmente), Remaining as planejadas a partir de hoje e Overdue as planejadas em
+// dias que já passaram e ainda sem registro.
+type LessonProgress struct {
+	Planned   int `json:"planned"`
+	Taught    int `json:"taught"`
+	Remaining int `json:"remaining"`
+	Overdue   int `json:"overdue"`
+	Cancelled int `json:"cancelled"`
+}
+
+// BuildClassProgress monta o relatório de progresso de uma turma a partir da planilha de notas
+// (GetGradebook) e das aulas da turma. Avaliações com data posterior a 'now' ainda não foram
+// aplicadas e não contam como pendentes; recuperações nunca contam, pois só os alunos abaixo da
+// média fazem a prova.
+func BuildClassProgress(book Gradebook, lessons []models.Lesson, now time.Time) ClassProgress {
+	progress := ClassProgress{ClassID: book.ClassID, Policy: book.Policy}
+	var active []models.Student
+	for _, student := range book.Students {
+		if student.Status == "ativo" {
+			active = append(active, student)
+		}
+	}
+	SortStudentsByEnrollment(active)
+
+	for _, term := range book.Terms {
+		var values []float64
+		for _, student := range active {
+			if average, ok := book.TermAverages[term][student.ID]; ok && book.HasGrades(student.ID, term) {
+				values = append(values, average.Adjusted)
+			}
+		}
+		progress.Terms = append(progress.Terms, TermProgress{Term: term, Average: mean(values)})
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for _, assessment := range book.Assessments {
+		item := AssessmentProgress{Assessment: assessment}
+		var values []float64
+		for _, student := range active {
+			if grade, ok := book.Grade(assessment.ID, student.ID); ok {
+				values = append(values, grade)
+			} else {
+				item.Missing = append(item.Missing, student)
+			}
+		}
+		item.Average, item.Graded = mean(values), len(values)
+		progress.Assessments = append(progress.Assessments, item)
+		applied := assessment.AssessmentDate == nil || !assessment.AssessmentDate.After(today)
+		if len(item.Missing) > 0 && applied && !IsRecovery(assessment) {
+			progress.Pending = append(progress.Pending, item)
+		}
+	}
+
+	var students []StudentProgress
+	var averages []float64
+	for _, student := range active {
+		// Sem notas lançadas, a média zero da planilha não foi apurada.
+		average, ok := book.Averages[student.ID]
+		if !ok || !book.HasGrades(student.ID) {
+			continue
+		}
+		item := StudentProgress{Student: student, Average: average.Adjusted}
+		for _, term := range book.Terms {
+			if termAverage, ok := book.TermAverages[term][student.ID]; ok && book.HasGrades(student.ID, term) {
+				item.TermAverages = append(item.TermAverages, termAverage.Adjusted)
+			}
+		}
+		if n := len(item.TermAverages); n >= 2 {
+			switch change := item.TermAverages[n-1] - item.TermAverages[n-2]; {
+			case change >= trendTolerance:
+				item.Trend = TrendUp
+			case change <= -trendTolerance:
+				item.Trend = TrendDown
+			default:
+				item.Trend = TrendStable
+			}
+		}
+		students = append(students, item)
+		averages = append(averages, item.Average)
+	}
+	progress.Average = mean(averages)
+	progress.Distribution = gradeDistribution(book.Policy, averages)
+
+	// Os de maior desempenho saem dos alunos na média da turma ou acima dela, para que ninguém
+	// apareça também entre os que necessitam de atenção.
+	sort.SliceStable(students, func(i, j int) bool { return students[i].Average > students[j].Average })
+	if progress.Average != nil {
+		for _, student := range students {
+			if student.Average >= *progress.Average && len(progress.Top) < topStudents {
+				progress.Top = append(progress.Top, student)
+			}
+		}
+		for i := len(students) - 1; i >= 0; i-- {
+			if students[i].Average < *progress.Average {
+				progress.Attention = append(progress.Attention, students[i])
+			}
+		}
+	}
+
+	for _, lesson := range lessons {
+		switch lesson.Status {
+		case models.LessonStatusCancelled:
+			progress.Lessons.Cancelled++
+			continue
+		case models.LessonStatusTaught, models.LessonStatusPartial:
+			progress.Lessons.Taught++
+		default:
+			if lesson.ScheduledAt.Before(today) {
+				progress.Lessons.Overdue++
+			} else {
+				progress.Lessons.Remaining++
+			}
+		}
+		progress.Lessons.Planned++
+	}
+	return progress
+}
+
+// gradeDistribution conta as médias por conceito, nas escalas conceituais, ou em cinco faixas
+// iguais da escala (0 a 2, 2 a 4, ..., 8 a 10), nas numéricas.
+func gradeDistribution(policy models.GradingPolicy, averages []float64) []GradeBand {
+	var bands []GradeBand
+	if IsConceptScale(policy.GradeScale) {
+		index := make(map[string]int)
+		for _, r := range policy.ConceptRanges {
+			index[r.Concept] = len(bands)
+			bands = append(bands, GradeBand{Label: r.Concept})
+		}
+		for _, average := range averages {
+			if i, ok := index[GradeConcept(policy, average)]; ok {
+				bands[i].Count++
+			}
+		}
+		return bands
+	}
+	top := GradeScaleMax(policy.GradeScale)
+	step := top / 5
+	for i := 0; i < 5; i++ {
+		bands = append(bands, GradeBand{Label: fmt.Sprintf("%g a %g", step*float64(i), step*float64(i+1))})
+	}
+	for _, average := range averages {
+		bands[min(int(average/step), 4)].Count++
+	}
+	return bands
+}
+
+// mean é a média aritmética dos valores, ou nil se não houver nenhum.
+func mean(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	result := sum / float64(len(values))
+	return &result
+}
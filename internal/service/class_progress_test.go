@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+)
+
+func TestBuildClassProgress(t *testing.T) {
+	assessmentService, _ := newGradebookService()
+	book, err := assessmentService.GetGradebook(context.Background(), 1)
+	require.NoError(t, err)
+	now := time.Date(2026, time.October, 18, 10, 0, 0, 0, time.Local)
+	future := now.AddDate(0, 0, 3)
+	book.Assessments = append(book.Assessments, models.Assessment{ID: 9, ClassID: 1, Name: "P3", Term: 2, Weight: 1, AssessmentDate: &future})
+	lessons := []models.Lesson{
+		{Status: models.LessonStatusTaught, ScheduledAt: now.AddDate(0, 0, -7)},
+		{Status: models.LessonStatusPartial, ScheduledAt: now.AddDate(0, 0, -5)},
+		{Status: models.LessonStatusPlanned, ScheduledAt: now.AddDate(0, 0, -2)},
+		{Status: models.LessonStatusCancelled, ScheduledAt: now.AddDate(0, 0, -1)},
+		{Status: models.LessonStatusPlanned, ScheduledAt: now.Add(-time.Hour)}, // Hoje: ainda por dar.
+		{Status: models.LessonStatusPlanned, ScheduledAt: now.AddDate(0, 0, 2)},
+	}
+
+	progress := BuildClassProgress(book, lessons, now)
+
+	// Ana: 47/6; Bruno: 6. Carla, sem notas, não tem média; Davi, transferido, não entra.
+	require.NotNil(t, progress.Average)
+	assert.InDelta(t, (47.0/6+6)/2, *progress.Average, 0.001)
+	require.Len(t, progress.Terms, 2)
+	assert.InDelta(t, (26.0/3+5)/2, *progress.Terms[0].Average, 0.001, "Carla não tem notas no bimestre")
+
+	p1 := progress.Assessments[0]
+	assert.Equal(t, "P1", p1.Assessment.Name)
+	assert.InDelta(t, 6.5, *p1.Average, 0.001, "a nota de Davi (10) não conta")
+	assert.Equal(t, 2, p1.Graded)
+	require.Len(t, p1.Missing, 1)
+	assert.Equal(t, "Carla", p1.Missing[0].FullName)
+
+	var pending []string
+	for _, item := range progress.Pending {
+		pending = append(pending, item.Assessment.Name)
+	}
+	assert.Equal(t, []string{"P1", "T1", "P2", "T2"}, pending, "P3 ainda não foi aplicada")
+
+	assert.Equal(t, []GradeBand{{"0 a 2", 0}, {"2 a 4", 0}, {"4 a 6", 0}, {"6 a 8", 2}, {"8 a 10", 0}}, progress.Distribution)
+	require.Len(t, progress.Top, 1, "só Ana está na média da turma ou acima")
+	assert.Equal(t, "Ana", progress.Top[0].Student.FullName)
+	assert.Equal(t, TrendDown, progress.Top[0].Trend, "Ana: 8,7 no 1º bimestre, 7 no 2º")
+	require.Len(t, progress.Attention, 1)
+	assert.Equal(t, "Bruno", progress.Attention[0].Student.FullName)
+
+	assert.Equal(t, LessonProgress{Planned: 5, Taught: 2, Remaining: 2, Overdue: 1, Cancelled: 1}, progress.Lessons)
+}
+
+func TestGradeDistribution_ConceptScale(t *testing.T) {
+	policy := models.GradingPolicy{GradeScale: models.GradeScaleLetters, ConceptRanges: DefaultConceptRanges(models.GradeScaleLetters)}
+	bands := gradeDistribution(policy, []float64{9.5, 9, 1})
+	require.NotEmpty(t, bands)
+	assert.Equal(t, GradeBand{Label: policy.ConceptRanges[0].Concept, Count: 2}, bands[0])
+	assert.Equal(t, 1, bands[len(bands)-1].Count)
+}
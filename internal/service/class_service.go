@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"log" // Adicionado para logging
+	"sort"
+	"strconv"
 	"strings"
 	"vigenda/internal/models"
 	"vigenda/internal/repository"
@@ -30,10 +32,10 @@ func NewClassService(
 
 func (s *classServiceImpl) CreateClass(ctx context.Context, name string, subjectID int64) (models.Class, error) {
 	if name == "" {
-		return models.Class{}, fmt.Errorf("class name cannot be empty")
+		return models.Class{}, ValidationErrorf("nome da turma não pode ser vazio")
 	}
 	if subjectID <= 0 {
-		return models.Class{}, fmt.Errorf("subject ID must be positive")
+		return models.Class{}, ValidationErrorf("ID da disciplina deve ser positivo")
 	}
 
 	// TODO: Validate if subjectID exists using subjectRepo if necessary.
@@ -74,13 +76,13 @@ func (s *classServiceImpl) CreateClass(ctx context.Context, name string, subject
 
 func (s *classServiceImpl) UpdateClass(ctx context.Context, classID int64, name string, subjectID int64) (models.Class, error) {
 	if classID <= 0 {
-		return models.Class{}, fmt.Errorf("class ID must be positive")
+		return models.Class{}, ValidationErrorf("ID da turma deve ser positivo")
 	}
 	if name == "" {
-		return models.Class{}, fmt.Errorf("class name cannot be empty")
+		return models.Class{}, ValidationErrorf("nome da turma não pode ser vazio")
 	}
 	if subjectID <= 0 {
-		return models.Class{}, fmt.Errorf("subject ID must be positive")
+		return models.Class{}, ValidationErrorf("ID da disciplina deve ser positivo")
 	}
 
 	// Assuming UserID 1 for now, this should come from context or auth
@@ -92,7 +94,7 @@ func (s *classServiceImpl) UpdateClass(ctx context.Context, classID int64, name
 		return models.Class{}, fmt.Errorf("service.UpdateClass: failed to get class: %w", err)
 	}
 	if classToUpdate.UserID != userID {
-		return models.Class{}, fmt.Errorf("service.UpdateClass: class does not belong to user") // Or ErrForbidden
+		return models.Class{}, NotFoundErrorf("turma com ID %d não encontrada para o usuário", classID)
 	}
 
 	classToUpdate.Name = name
@@ -113,7 +115,7 @@ func (s *classServiceImpl) UpdateClass(ctx context.Context, classID int64, name
 
 func (s *classServiceImpl) DeleteClass(ctx context.Context, classID int64) error {
 	if classID <= 0 {
-		return fmt.Errorf("class ID must be positive")
+		return ValidationErrorf("ID da turma deve ser positivo")
 	}
 	// Assuming UserID 1 for now
 	userID := int64(1) // Placeholder
@@ -134,17 +136,17 @@ func (s *classServiceImpl) DeleteClass(ctx context.Context, classID int64) error
 
 func (s *classServiceImpl) AddStudent(ctx context.Context, classID int64, fullName string, enrollmentID string, status string) (models.Student, error) {
 	if classID <= 0 {
-		return models.Student{}, fmt.Errorf("class ID must be positive")
+		return models.Student{}, ValidationErrorf("ID da turma deve ser positivo")
 	}
 	if fullName == "" {
-		return models.Student{}, fmt.Errorf("student full name cannot be empty")
+		return models.Student{}, ValidationErrorf("nome completo do aluno não pode ser vazio")
 	}
 	if status == "" {
 		status = "ativo" // Default status
 	} else {
 		status = strings.ToLower(status)
 		if status != "ativo" && status != "inativo" && status != "transferido" {
-			return models.Student{}, fmt.Errorf("invalid student status: %s. Allowed: 'ativo', 'inativo', 'transferido'", status)
+			return models.Student{}, ValidationErrorf("situação de aluno inválida '%s': use ativo, inativo ou transferido", status)
 		}
 	}
 
@@ -179,7 +181,7 @@ func (s *classServiceImpl) AddStudent(ctx context.Context, classID int64, fullNa
 
 func (s *classServiceImpl) GetStudentByID(ctx context.Context, studentID int64) (models.Student, error) {
 	if studentID <= 0 {
-		return models.Student{}, fmt.Errorf("student ID must be positive")
+		return models.Student{}, ValidationErrorf("ID do aluno deve ser positivo")
 	}
 	student, err := s.classRepo.GetStudentByID(ctx, studentID)
 	if err != nil {
@@ -191,17 +193,17 @@ func (s *classServiceImpl) GetStudentByID(ctx context.Context, studentID int64)
 
 func (s *classServiceImpl) UpdateStudent(ctx context.Context, studentID int64, fullName string, enrollmentID string, status string) (models.Student, error) {
 	if studentID <= 0 {
-		return models.Student{}, fmt.Errorf("student ID must be positive")
+		return models.Student{}, ValidationErrorf("ID do aluno deve ser positivo")
 	}
 	if fullName == "" {
-		return models.Student{}, fmt.Errorf("student full name cannot be empty")
+		return models.Student{}, ValidationErrorf("nome completo do aluno não pode ser vazio")
 	}
 	if status == "" {
-		return models.Student{}, fmt.Errorf("student status cannot be empty")
+		return models.Student{}, ValidationErrorf("situação do aluno não pode ser vazia")
 	}
 	status = strings.ToLower(status)
 	if status != "ativo" && status != "inativo" && status != "transferido" {
-		return models.Student{}, fmt.Errorf("invalid student status: %s", status)
+		return models.Student{}, ValidationErrorf("situação de aluno inválida '%s': use ativo, inativo ou transferido", status)
 	}
 
 	// Fetch existing student to check ownership (e.g., via class) and to get ClassID
@@ -235,7 +237,7 @@ func (s *classServiceImpl) UpdateStudent(ctx context.Context, studentID int64, f
 
 func (s *classServiceImpl) DeleteStudent(ctx context.Context, studentID int64) error {
 	if studentID <= 0 {
-		return fmt.Errorf("student ID must be positive")
+		return ValidationErrorf("ID do aluno deve ser positivo")
 	}
 
 	// Fetch student to get ClassID for repository delete call (which needs classID for ownership check)
@@ -262,7 +264,7 @@ func (s *classServiceImpl) DeleteStudent(ctx context.Context, studentID int64) e
 
 func (s *classServiceImpl) ImportStudentsFromCSV(ctx context.Context, classID int64, csvData []byte) (int, error) {
 	if classID <= 0 {
-		return 0, fmt.Errorf("class ID must be positive")
+		return 0, ValidationErrorf("ID da turma deve ser positivo")
 	}
 	// TODO: Validate if classID exists and belongs to the user
 	// _, err := s.GetClassByID(ctx, classID)
@@ -274,9 +276,9 @@ func (s *classServiceImpl) ImportStudentsFromCSV(ctx context.Context, classID in
 	// Skip header
 	if _, err := reader.Read(); err != nil {
 		if err == io.EOF {
-			return 0, fmt.Errorf("CSV is empty or header-only")
+			return 0, ValidationErrorf("CSV vazio ou apenas com cabeçalho")
 		}
-		return 0, fmt.Errorf("failed to read CSV header: %w", err)
+		return 0, &Error{Category: CategoryValidation, Message: "falha ao ler o cabeçalho do CSV", Err: err}
 	}
 
 	var importedCount int
@@ -287,7 +289,7 @@ func (s *classServiceImpl) ImportStudentsFromCSV(ctx context.Context, classID in
 		}
 		if err != nil {
 			log.Printf("Error reading CSV record, processed %d students: %v", importedCount, err)
-			return importedCount, fmt.Errorf("error reading CSV record: %w", err)
+			return importedCount, &Error{Category: CategoryValidation, Message: "falha ao ler registro do CSV", Err: err}
 		}
 
 		if len(record) < 2 || strings.TrimSpace(record[1]) == "" {
@@ -321,11 +323,11 @@ func (s *classServiceImpl) ImportStudentsFromCSV(ctx context.Context, classID in
 
 func (s *classServiceImpl) UpdateStudentStatus(ctx context.Context, studentID int64, newStatus string) error {
 	if studentID <= 0 {
-		return fmt.Errorf("student ID must be positive")
+		return ValidationErrorf("ID do aluno deve ser positivo")
 	}
 	newStatus = strings.ToLower(strings.TrimSpace(newStatus))
 	if newStatus != "ativo" && newStatus != "inativo" && newStatus != "transferido" {
-		return fmt.Errorf("invalid student status: '%s'. Allowed: 'ativo', 'inativo', 'transferido'", newStatus)
+		return ValidationErrorf("situação de aluno inválida '%s': use ativo, inativo ou transferido", newStatus)
 	}
 
 	// Optional: Check ownership of student's class
@@ -348,7 +350,7 @@ func (s *classServiceImpl) UpdateStudentStatus(ctx context.Context, studentID in
 
 func (s *classServiceImpl) GetClassByID(ctx context.Context, classID int64) (models.Class, error) {
 	if classID <= 0 {
-		return models.Class{}, fmt.Errorf("class ID must be positive")
+		return models.Class{}, ValidationErrorf("ID da turma deve ser positivo")
 	}
 	// Assuming UserID 1 for now
 	// userID := int64(1) // Placeholder
@@ -394,7 +396,7 @@ func (s *classServiceImpl) ListAllClasses(ctx context.Context) ([]models.Class,
 
 func (s *classServiceImpl) GetStudentsByClassID(ctx context.Context, classID int64) ([]models.Student, error) {
 	if classID <= 0 {
-		return nil, fmt.Errorf("class ID must be positive when fetching students")
+		return nil, ValidationErrorf("ID da turma deve ser positivo ao buscar alunos")
 	}
 	// Optional: Check if classID exists and belongs to the user
 	// _, err := s.GetClassByID(ctx, classID) // This involves userID check
@@ -408,3 +410,30 @@ func (s *classServiceImpl) GetStudentsByClassID(ctx context.Context, classID int
 	}
 	return students, nil
 }
+
+// SortStudentsByEnrollment ordena os alunos pelo número de matrícula/chamada, como nas listas
+// impressas. Números são comparados pelo valor ("2" antes de "10"); alunos sem número ficam no fim,
+// em ordem alfabética.
+func SortStudentsByEnrollment(students []models.Student) {
+	sort.SliceStable(students, func(i, j int) bool {
+		a, b := strings.TrimSpace(students[i].EnrollmentID), strings.TrimSpace(students[j].EnrollmentID)
+		if (a == "") != (b == "") {
+			return a != ""
+		}
+		if a != b {
+			na, errA := strconv.Atoi(a)
+			nb, errB := strconv.Atoi(b)
+			switch {
+			case errA == nil && errB == nil:
+				if na != nb {
+					return na < nb
+				}
+			case errA == nil || errB == nil:
+				return errA == nil // Números antes de códigos alfanuméricos.
+			default:
+				return a < b
+			}
+		}
+		return students[i].FullName < students[j].FullName
+	})
+}
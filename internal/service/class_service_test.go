@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 	"vigenda/internal/models"
@@ -304,3 +305,24 @@ func TestUpdateStudentStatus(t *testing.T) {
 		t.Fatalf("UpdateStudentStatus failed: %v", err)
 	}
 }
+
+func TestSortStudentsByEnrollment(t *testing.T) {
+	students := []models.Student{
+		{FullName: "Davi", EnrollmentID: ""},
+		{FullName: "Carla", EnrollmentID: "10"},
+		{FullName: "Bia", EnrollmentID: "A3"},
+		{FullName: "Ana", EnrollmentID: "2"},
+		{FullName: "Caio", EnrollmentID: ""},
+		{FullName: "Eva", EnrollmentID: "02"},
+	}
+	SortStudentsByEnrollment(students)
+
+	var names []string
+	for _, student := range students {
+		names = append(names, student.FullName)
+	}
+	expected := []string{"Ana", "Eva", "Carla", "Bia", "Caio", "Davi"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("SortStudentsByEnrollment = %v; expected %v", names, expected)
+	}
+}
@@ -0,0 +1,129 @@
+// Este arquivo define as categorias de erro da camada de serviço. A CLI usa a categoria
+// para escolher o código de saída; Message é a mensagem em pt-BR exibida ao usuário.
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"vigenda/internal/repository"
+)
+
+// ErrorCategory classifica um erro de serviço.
+type ErrorCategory int
+
+const (
+	// CategoryUnknown é usada para erros que não se encaixam em nenhuma outra categoria.
+	CategoryUnknown ErrorCategory = iota
+	// CategoryValidation indica dados de entrada inválidos (argumentos, flags, campos obrigatórios).
+	CategoryValidation
+	// CategoryNotFound indica que o registro referenciado não existe.
+	CategoryNotFound
+	// CategoryConflict indica que a operação conflita com o estado atual (duplicado, já concluído etc.).
+	CategoryConflict
+	// CategoryStorage indica falha ao ler ou gravar no banco de dados.
+	CategoryStorage
+)
+
+// String retorna o nome da categoria em pt-BR.
+func (c ErrorCategory) String() string {
+	switch c {
+	case CategoryValidation:
+		return "validação"
+	case CategoryNotFound:
+		return "não encontrado"
+	case CategoryConflict:
+		return "conflito"
+	case CategoryStorage:
+		return "armazenamento"
+	default:
+		return "desconhecido"
+	}
+}
+
+// Erros sentinela de cada categoria, para uso com errors.Is:
+//
+//	if errors.Is(err, service.ErrNotFound) { ... }
+var (
+	ErrValidation = errors.New("dados inválidos")
+	ErrNotFound   = errors.New("registro não encontrado")
+	ErrConflict   = errors.New("conflito com o estado atual")
+	ErrStorage    = errors.New("falha no banco de dados")
+)
+
+// Error é um erro categorizado da camada de serviço.
+// Message é a mensagem em pt-BR para o usuário; Err, se presente, é a causa original.
+type Error struct {
+	Category ErrorCategory
+	Message  string
+	Err      error
+}
+
+// Error retorna a mensagem seguida da causa, se houver.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap expõe a causa original para errors.Is/errors.As.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is faz o erro corresponder ao sentinela da sua categoria.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrValidation:
+		return e.Category == CategoryValidation
+	case ErrNotFound:
+		return e.Category == CategoryNotFound
+	case ErrConflict:
+		return e.Category == CategoryConflict
+	case ErrStorage:
+		return e.Category == CategoryStorage
+	}
+	return false
+}
+
+// ValidationErrorf cria um erro de validação com a mensagem formatada.
+func ValidationErrorf(format string, args ...interface{}) error {
+	return &Error{Category: CategoryValidation, Message: fmt.Sprintf(format, args...)}
+}
+
+// NotFoundErrorf cria um erro de registro não encontrado com a mensagem formatada.
+func NotFoundErrorf(format string, args ...interface{}) error {
+	return &Error{Category: CategoryNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+// ConflictErrorf cria um erro de conflito com a mensagem formatada.
+func ConflictErrorf(format string, args ...interface{}) error {
+	return &Error{Category: CategoryConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+// StorageError envolve uma falha do repositório como erro de armazenamento.
+func StorageError(message string, err error) error {
+	return &Error{Category: CategoryStorage, Message: message, Err: err}
+}
+
+// CategoryOf determina a categoria de um erro. Erros de serviço usam a categoria declarada;
+// erros vindos diretamente do repositório são classificados pela causa no driver do banco.
+func CategoryOf(err error) ErrorCategory {
+	if err == nil {
+		return CategoryUnknown
+	}
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Category
+	}
+	switch {
+	case repository.IsNotFound(err), repository.IsForeignKeyViolation(err):
+		return CategoryNotFound
+	case repository.IsUniqueViolation(err):
+		return CategoryConflict
+	case repository.IsStorageError(err):
+		return CategoryStorage
+	}
+	return CategoryUnknown
+}
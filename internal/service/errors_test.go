@@ -0,0 +1,43 @@
+package service_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"vigenda/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected service.ErrorCategory
+	}{
+		{"validation", service.ValidationErrorf("título vazio"), service.CategoryValidation},
+		{"wrapped not found", fmt.Errorf("erro ao adiar tarefa: %w", service.NotFoundErrorf("tarefa com ID %d não encontrada", 9)), service.CategoryNotFound},
+		{"conflict", service.ConflictErrorf("já concluída"), service.CategoryConflict},
+		{"storage", service.StorageError("não foi possível abrir o banco", errors.New("disk I/O error")), service.CategoryStorage},
+		{"repository no rows", fmt.Errorf("taskRepository.GetTaskByID: %w", sql.ErrNoRows), service.CategoryNotFound},
+		{"plain error", errors.New("algo inesperado"), service.CategoryUnknown},
+		{"nil", nil, service.CategoryUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, service.CategoryOf(tt.err))
+		})
+	}
+}
+
+func TestError_IsAndMessage(t *testing.T) {
+	cause := errors.New("database is locked")
+	err := fmt.Errorf("erro ao gravar plano: %w", service.StorageError("falha ao gravar o plano", cause))
+
+	assert.True(t, errors.Is(err, service.ErrStorage))
+	assert.False(t, errors.Is(err, service.ErrNotFound))
+	assert.True(t, errors.Is(err, cause), "a causa original deve continuar acessível")
+	assert.EqualError(t, err, "erro ao gravar plano: falha ao gravar o plano: database is locked")
+	assert.EqualError(t, service.ValidationErrorf("prioridade inválida '%s'", "urgente"), "prioridade inválida 'urgente'")
+}
@@ -0,0 +1,442 @@
+// Este arquivo implementa a leitura das planilhas de notas (CSV e XLSX) e a associação de cada
+// linha a um aluno da avaliação, usadas por AssessmentService.PreviewGradeImport.
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+
+	"vigenda/internal/models"
+)
+
+// Formatos aceitos por PreviewGradeImport.
+const (
+	GradeImportFormatCSV  = "csv"
+	GradeImportFormatXLSX = "xlsx"
+)
+
+// Chaves usadas para encontrar o aluno de cada linha da planilha.
+const (
+	GradeImportKeyEnrollment = "matricula" // Número de matrícula ou de chamada (Student.EnrollmentID).
+	GradeImportKeyName       = "nome"      // Nome do aluno, comparado de forma aproximada.
+)
+
+// Situação de cada linha na prévia da importação.
+const (
+	GradeImportMatched   = "encontrado"     // A nota será lançada para o aluno.
+	GradeImportUnmatched = "nao_encontrado" // Nenhum aluno corresponde à linha.
+	GradeImportAmbiguous = "ambiguo"        // Mais de um aluno corresponde à linha.
+	GradeImportInvalid   = "invalido"       // Nota inválida, aluno repetido, transferido ou fora da recuperação.
+	GradeImportSkipped   = "sem_nota"       // Linha sem nota: é ignorada.
+)
+
+// GradeImportStatusLabel retorna o nome de uma situação da prévia para exibição.
+func GradeImportStatusLabel(status string) string {
+	switch status {
+	case GradeImportMatched:
+		return "encontrado"
+	case GradeImportUnmatched:
+		return "não encontrado"
+	case GradeImportAmbiguous:
+		return "ambíguo"
+	case GradeImportInvalid:
+		return "inválido"
+	case GradeImportSkipped:
+		return "sem nota"
+	}
+	return status
+}
+
+// GradeImportMapping diz de quais colunas da planilha vêm o aluno e a nota. As colunas podem ser
+// indicadas pelo cabeçalho ("Nome", "Nota"), pela letra ("B") ou pelo número (2); vazias, são
+// deduzidas dos cabeçalhos da primeira linha.
+type GradeImportMapping struct {
+	StudentColumn string
+	GradeColumn   string
+	Key           string // GradeImportKeyEnrollment ou GradeImportKeyName; vazia, é deduzida do cabeçalho.
+}
+
+// GradeImportRow é uma linha da planilha já associada (ou não) a um aluno.
+type GradeImportRow struct {
+	Line       int              `json:"line"`                 // Linha na planilha, a partir de 1.
+	Key        string           `json:"key"`                  // Matrícula ou nome como escrito na planilha.
+	GradeText  string           `json:"grade_text"`           // Nota como escrita na planilha.
+	Grade      float64          `json:"grade"`                // Nota interpretada na escala da turma.
+	Status     string           `json:"status"`               // Uma das constantes GradeImport*.
+	Student    *models.Student  `json:"student,omitempty"`    // Aluno encontrado.
+	Candidates []models.Student `json:"candidates,omitempty"` // Alunos possíveis, nas linhas ambíguas.
+	Message    string           `json:"message,omitempty"`    // Explicação da situação.
+}
+
+// GradeImportPreview é o resultado da leitura de uma planilha de notas, antes de gravar qualquer nota.
+type GradeImportPreview struct {
+	Assessment models.Assessment    `json:"assessment"`
+	Policy     models.GradingPolicy `json:"policy"`
+	Key        string               `json:"key"` // Chave usada para encontrar os alunos.
+	Rows       []GradeImportRow     `json:"rows"`
+}
+
+// Count retorna o número de linhas com a situação informada.
+func (p GradeImportPreview) Count(status string) int {
+	count := 0
+	for _, row := range p.Rows {
+		if row.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Problems retorna o número de linhas que impedem a importação completa: não encontradas,
+// ambíguas ou inválidas.
+func (p GradeImportPreview) Problems() int {
+	return p.Count(GradeImportUnmatched) + p.Count(GradeImportAmbiguous) + p.Count(GradeImportInvalid)
+}
+
+// Grades retorna as notas das linhas encontradas, por ID do aluno, prontas para EnterGrades.
+func (p GradeImportPreview) Grades() map[int64]float64 {
+	grades := make(map[int64]float64)
+	for _, row := range p.Rows {
+		if row.Status == GradeImportMatched {
+			grades[row.Student.ID] = row.Grade
+		}
+	}
+	return grades
+}
+
+func (s *assessmentServiceImpl) PreviewGradeImport(ctx context.Context, assessmentID int64, data []byte, format string, mapping GradeImportMapping) (GradeImportPreview, error) {
+	records, err := readGradeSheet(data, format)
+	if err != nil {
+		return GradeImportPreview{}, err
+	}
+	students, assessment, err := s.GetStudentsForGrading(ctx, assessmentID)
+	if err != nil {
+		return GradeImportPreview{}, err
+	}
+	policy, err := s.GetGradingPolicy(ctx, assessment.ClassID)
+	if err != nil {
+		return GradeImportPreview{}, err
+	}
+	var eligible map[int64]bool
+	if IsRecovery(*assessment) {
+		recoveryStudents, err := s.GetRecoveryStudents(ctx, assessment.ID)
+		if err != nil {
+			return GradeImportPreview{}, err
+		}
+		eligible = make(map[int64]bool)
+		for _, r := range recoveryStudents {
+			eligible[r.Student.ID] = true
+		}
+	}
+
+	studentCol, gradeCol, key, hasHeader, err := resolveGradeColumns(records, mapping, *assessment)
+	if err != nil {
+		return GradeImportPreview{}, err
+	}
+	preview := GradeImportPreview{Assessment: *assessment, Policy: policy, Key: key}
+	firstLine := make(map[int64]int) // ID do aluno -> linha em que já apareceu.
+	for i, record := range records {
+		if i == 0 && hasHeader {
+			continue
+		}
+		row := GradeImportRow{Line: i + 1, Key: sheetCell(record, studentCol), GradeText: sheetCell(record, gradeCol)}
+		if row.Key == "" && row.GradeText == "" {
+			continue
+		}
+		preview.Rows = append(preview.Rows, row)
+		r := &preview.Rows[len(preview.Rows)-1]
+
+		if r.Key == "" {
+			r.Status, r.Message = GradeImportUnmatched, "aluno não informado"
+			continue
+		}
+		matches := matchGradeRow(students, r.Key, key)
+		switch {
+		case len(matches) == 0:
+			r.Status, r.Message = GradeImportUnmatched, "nenhum aluno da turma corresponde"
+			continue
+		case len(matches) > 1:
+			r.Status, r.Candidates = GradeImportAmbiguous, matches
+			names := make([]string, len(matches))
+			for j, m := range matches {
+				names[j] = m.FullName
+			}
+			r.Message = "pode ser " + strings.Join(names, " ou ")
+			continue
+		}
+		student := matches[0]
+		r.Student = &student
+
+		if r.GradeText == "" {
+			r.Status, r.Message = GradeImportSkipped, "sem nota"
+			continue
+		}
+		switch {
+		case student.Status != "ativo":
+			r.Status, r.Message = GradeImportInvalid, fmt.Sprintf("aluno %s", student.Status)
+		case eligible != nil && !eligible[student.ID]:
+			r.Status, r.Message = GradeImportInvalid, "aluno fora da recuperação (acima da média)"
+		case firstLine[student.ID] != 0:
+			r.Status, r.Message = GradeImportInvalid, fmt.Sprintf("aluno repetido (já está na linha %d)", firstLine[student.ID])
+		default:
+			if r.Grade, err = ParseGrade(policy, r.GradeText); err != nil {
+				r.Status, r.Message = GradeImportInvalid, err.Error()
+				var serviceErr *Error
+				if errors.As(err, &serviceErr) {
+					r.Message = serviceErr.Message
+				}
+			} else {
+				r.Status = GradeImportMatched
+				if key == GradeImportKeyName && normalizeName(r.Key) != normalizeName(student.FullName) {
+					r.Message = "nome aproximado"
+				}
+			}
+		}
+		if firstLine[student.ID] == 0 {
+			firstLine[student.ID] = r.Line
+		}
+	}
+	if len(preview.Rows) == 0 {
+		return GradeImportPreview{}, ValidationErrorf("a planilha não tem nenhuma linha de notas")
+	}
+	return preview, nil
+}
+
+// readGradeSheet lê as linhas de um CSV (separado por vírgula ou ponto e vírgula) ou da primeira
+// planilha de um arquivo XLSX.
+func readGradeSheet(data []byte, format string) ([][]string, error) {
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case GradeImportFormatCSV:
+		// Planilhas salvas pelo Excel começam com a marca de ordem de bytes do UTF-8.
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+		firstLine, _, _ := strings.Cut(string(data), "\n")
+		if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+			reader.Comma = ';'
+		}
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, ValidationErrorf("CSV inválido: %v", err)
+		}
+		return records, nil
+	case GradeImportFormatXLSX:
+		book, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, ValidationErrorf("planilha XLSX inválida: %v", err)
+		}
+		defer book.Close()
+		sheets := book.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, ValidationErrorf("a planilha XLSX não tem abas")
+		}
+		records, err := book.GetRows(sheets[0])
+		if err != nil {
+			return nil, ValidationErrorf("não foi possível ler a aba '%s': %v", sheets[0], err)
+		}
+		return records, nil
+	}
+	return nil, ValidationErrorf("formato de planilha '%s' não suportado: use %s ou %s", format, GradeImportFormatCSV, GradeImportFormatXLSX)
+}
+
+// resolveGradeColumns encontra as colunas do aluno e da nota e a chave de busca dos alunos. A primeira
+// linha é um cabeçalho, a menos que a sua célula de nota seja um número.
+func resolveGradeColumns(records [][]string, mapping GradeImportMapping, assessment models.Assessment) (studentCol, gradeCol int, key string, hasHeader bool, err error) {
+	if len(records) == 0 {
+		return 0, 0, "", false, ValidationErrorf("a planilha está vazia")
+	}
+	header := records[0]
+	find := func(spec string, guesses []string) (int, bool) {
+		if spec != "" {
+			return columnIndex(header, spec)
+		}
+		for _, guess := range guesses {
+			for i, h := range header {
+				if normalizeName(h) == guess {
+					return i, true
+				}
+			}
+		}
+		return 0, false
+	}
+
+	var ok bool
+	if studentCol, ok = find(mapping.StudentColumn, []string{"matricula", "n", "no", "numero", "chamada", "nome", "aluno", "aluna", "estudante", "nome do aluno"}); !ok {
+		if mapping.StudentColumn != "" {
+			return 0, 0, "", false, ValidationErrorf("coluna do aluno '%s' não encontrada na planilha", mapping.StudentColumn)
+		}
+		return 0, 0, "", false, ValidationErrorf("não foi possível identificar a coluna do aluno: use um cabeçalho 'Matrícula' ou 'Nome', ou informe a coluna")
+	}
+	if gradeCol, ok = find(mapping.GradeColumn, []string{"nota", normalizeName(assessment.Name), "conceito", "media"}); !ok {
+		if mapping.GradeColumn != "" {
+			return 0, 0, "", false, ValidationErrorf("coluna da nota '%s' não encontrada na planilha", mapping.GradeColumn)
+		}
+		return 0, 0, "", false, ValidationErrorf("não foi possível identificar a coluna da nota: use um cabeçalho 'Nota' ou informe a coluna")
+	}
+	if studentCol == gradeCol {
+		return 0, 0, "", false, ValidationErrorf("a coluna do aluno e a da nota não podem ser a mesma")
+	}
+
+	_, numeric := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(sheetCell(header, gradeCol)), ",", "."), 64)
+	hasHeader = numeric != nil
+
+	switch key = mapping.Key; key {
+	case GradeImportKeyEnrollment, GradeImportKeyName:
+	case "":
+		key = GradeImportKeyName
+		if hasHeader {
+			switch normalizeName(sheetCell(header, studentCol)) {
+			case "matricula", "n", "no", "numero", "chamada":
+				key = GradeImportKeyEnrollment
+			}
+		}
+	default:
+		return 0, 0, "", false, ValidationErrorf("chave '%s' inválida: use %s ou %s", key, GradeImportKeyEnrollment, GradeImportKeyName)
+	}
+	return studentCol, gradeCol, key, hasHeader, nil
+}
+
+// columnIndex interpreta uma coluna informada pelo cabeçalho, pela letra ("B", "AA") ou pelo número (2).
+func columnIndex(header []string, spec string) (int, bool) {
+	spec = strings.TrimSpace(spec)
+	for i, h := range header {
+		if normalizeName(h) == normalizeName(spec) {
+			return i, true
+		}
+	}
+	if n, err := strconv.Atoi(spec); err == nil && n > 0 {
+		return n - 1, true
+	}
+	if n, err := excelize.ColumnNameToNumber(strings.ToUpper(spec)); err == nil && len(spec) <= 3 {
+		return n - 1, true
+	}
+	return 0, false
+}
+
+// sheetCell retorna o texto de uma célula, vazio se a linha for mais curta.
+func sheetCell(record []string, col int) string {
+	if col < len(record) {
+		return strings.TrimSpace(record[col])
+	}
+	return ""
+}
+
+// matchGradeRow procura os alunos que correspondem ao texto da planilha. Pela matrícula a busca é
+// exata. Pelo nome, vale a igualdade sem acentos e maiúsculas; senão, os alunos cujo nome contém todas
+// as palavras escritas ("Ana Souza" para "Ana Beatriz Souza") ou difere por poucas letras de digitação.
+func matchGradeRow(students []models.Student, text, key string) []models.Student {
+	var matches []models.Student
+	if key == GradeImportKeyEnrollment {
+		for _, s := range students {
+			if s.EnrollmentID != "" && strings.EqualFold(strings.TrimSpace(s.EnrollmentID), text) {
+				matches = append(matches, s)
+			}
+		}
+		return matches
+	}
+
+	name := normalizeName(text)
+	for _, s := range students {
+		if normalizeName(s.FullName) == name {
+			matches = append(matches, s)
+		}
+	}
+	if len(matches) > 0 {
+		return matches
+	}
+	type scored struct {
+		student  models.Student
+		distance int
+	}
+	var candidates []scored
+	for _, s := range students {
+		full := normalizeName(s.FullName)
+		if containsWords(full, name) {
+			candidates = append(candidates, scored{s, 0})
+		} else if d := levenshtein(full, name); d <= maxTypos(full) {
+			candidates = append(candidates, scored{s, d})
+		}
+	}
+	// Um erro de digitação perde para quem corresponde melhor: só os mais próximos ficam.
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
+	for _, c := range candidates {
+		if c.distance == candidates[0].distance {
+			matches = append(matches, c.student)
+		}
+	}
+	return matches
+}
+
+// containsWords informa se todas as palavras de part aparecem em full, na mesma ordem.
+func containsWords(full, part string) bool {
+	words := strings.Fields(full)
+	i := 0
+	for _, w := range strings.Fields(part) {
+		for i < len(words) && words[i] != w {
+			i++
+		}
+		if i == len(words) {
+			return false
+		}
+		i++
+	}
+	return part != ""
+}
+
+// maxTypos é quantas letras (uma a cada cinco) podem diferir num nome para ele ainda ser considerado o mesmo.
+func maxTypos(name string) int {
+	return len([]rune(name)) / 5
+}
+
+// levenshtein é a distância de edição entre dois textos, em runas.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+// accentReplacer troca as letras acentuadas do português pelas letras sem acento.
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n", "º", "o", "°", "o",
+)
+
+// normalizeName deixa um nome ou cabeçalho em minúsculas, sem acentos, pontuação nem espaços repetidos.
+func normalizeName(text string) string {
+	text = accentReplacer.Replace(strings.ToLower(text))
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, text)
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"vigenda/internal/models"
+)
+
+// importClassRepository devolve a turma do arquivo importado, com matrículas e dois alunos de
+// nome parecido.
+type importClassRepository struct {
+	namedClassRepository
+}
+
+func (r *importClassRepository) GetStudentsByClassID(ctx context.Context, classID int64) ([]models.Student, error) {
+	return []models.Student{
+		{ID: 1, ClassID: 1, FullName: "Ana Beatriz Souza", EnrollmentID: "101", Status: "ativo"},
+		{ID: 2, ClassID: 1, FullName: "Bruno Lima", EnrollmentID: "102", Status: "ativo"},
+		{ID: 3, ClassID: 1, FullName: "Carla Dias", EnrollmentID: "103", Status: "transferido"},
+		{ID: 4, ClassID: 1, FullName: "João Pedro Alves", EnrollmentID: "104", Status: "ativo"},
+		{ID: 5, ClassID: 1, FullName: "João Paulo Alves", EnrollmentID: "105", Status: "ativo"},
+	}, nil
+}
+
+func newImportService() (AssessmentService, *gradebookRepository) {
+	repo := newGradebookRepository()
+	policies := &memoryPolicyRepository{policies: map[int64]models.GradingPolicy{}}
+	return NewAssessmentService(repo, &importClassRepository{}, nil, policies), repo
+}
+
+func TestPreviewGradeImport_MatchesNamesApproximately(t *testing.T) {
+	assessmentService, _ := newImportService()
+	csv := "Aluno;Nota\n" +
+		"ana souza;8,5\n" + // Palavras do nome completo, sem acento nem maiúsculas.
+		"Bruno Lmia;7\n" + // Erro de digitação.
+		"Carla Dias;9\n" + // Transferida.
+		"João Alves;6\n" + // Pode ser João Pedro ou João Paulo.
+		"Zeca;5\n" +
+		"Bruno Lima;11\n" + // Repetido.
+		"Joao Pedro Alves;\n"
+
+	preview, err := assessmentService.PreviewGradeImport(context.Background(), 1, []byte(csv), "csv", GradeImportMapping{})
+	require.NoError(t, err)
+	assert.Equal(t, GradeImportKeyName, preview.Key)
+	require.Len(t, preview.Rows, 7)
+
+	statuses := make([]string, len(preview.Rows))
+	for i, row := range preview.Rows {
+		statuses[i] = row.Status
+	}
+	assert.Equal(t, []string{GradeImportMatched, GradeImportMatched, GradeImportInvalid, GradeImportAmbiguous,
+		GradeImportUnmatched, GradeImportInvalid, GradeImportSkipped}, statuses)
+	assert.Equal(t, 2, preview.Rows[0].Line, "o cabeçalho é a linha 1")
+	assert.Equal(t, "nome aproximado", preview.Rows[1].Message)
+	assert.Equal(t, "aluno transferido", preview.Rows[2].Message)
+	assert.Len(t, preview.Rows[3].Candidates, 2)
+	assert.Contains(t, preview.Rows[5].Message, "linha 3")
+	assert.Equal(t, map[int64]float64{1: 8.5, 2: 7}, preview.Grades())
+	assert.Equal(t, 4, preview.Problems())
+}
+
+func TestPreviewGradeImport_ByEnrollmentFromXLSX(t *testing.T) {
+	book := excelize.NewFile()
+	rows := [][]any{{"Nº", "Nome", "Prova"}, {"101", "Ana", 9.5}, {"104", "João", "7,0"}, {"999", "Fulano", 5}}
+	for i, row := range rows {
+		cellName, _ := excelize.CoordinatesToCellName(1, i+1)
+		require.NoError(t, book.SetSheetRow("Sheet1", cellName, &row))
+	}
+	var buf bytes.Buffer
+	require.NoError(t, book.Write(&buf))
+
+	assessmentService, _ := newImportService()
+	preview, err := assessmentService.PreviewGradeImport(context.Background(), 1, buf.Bytes(), ".xlsx", GradeImportMapping{GradeColumn: "C"})
+	require.NoError(t, err)
+	assert.Equal(t, GradeImportKeyEnrollment, preview.Key)
+	assert.Equal(t, map[int64]float64{1: 9.5, 4: 7}, preview.Grades())
+	assert.Equal(t, GradeImportUnmatched, preview.Rows[2].Status)
+}
+
+func TestPreviewGradeImport_ColumnMapping(t *testing.T) {
+	assessmentService, _ := newImportService()
+	ctx := context.Background()
+
+	// Sem cabeçalho: colunas pelo número, alunos pelo nome.
+	preview, err := assessmentService.PreviewGradeImport(ctx, 1, []byte("x,Bruno Lima,6\n"), "csv", GradeImportMapping{StudentColumn: "2", GradeColumn: "3"})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]float64{2: 6}, preview.Grades())
+
+	_, err = assessmentService.PreviewGradeImport(ctx, 1, []byte("Aluno,Resultado\nAna,5\n"), "csv", GradeImportMapping{})
+	assert.True(t, errors.Is(err, ErrValidation), "%v", err)
+	_, err = assessmentService.PreviewGradeImport(ctx, 1, []byte("Aluno,Nota\nAna,5\n"), "csv", GradeImportMapping{Key: "cpf"})
+	assert.True(t, errors.Is(err, ErrValidation), "%v", err)
+	_, err = assessmentService.PreviewGradeImport(ctx, 1, []byte("Aluno,Nota\n"), "ods", GradeImportMapping{})
+	assert.True(t, errors.Is(err, ErrValidation), "%v", err)
+}
+
+func TestEnterGrades_WritesAllGradesInOneBatch(t *testing.T) {
+	assessmentService, repo := newImportService()
+	before := len(repo.grades)
+	require.NoError(t, assessmentService.EnterGrades(context.Background(), 2, map[int64]float64{3: 7, 2: 6}))
+	assert.Equal(t, []models.Grade{{AssessmentID: 2, StudentID: 2, Grade: 6}, {AssessmentID: 2, StudentID: 3, Grade: 7}}, repo.grades[before:])
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"vigenda/internal/models"
+)
+
+// GradeScales são as escalas de notas aceitas, na ordem em que são apresentadas.
+var GradeScales = []string{
+	models.GradeScaleTen,
+	models.GradeScaleHundred,
+	models.GradeScaleLetters,
+	models.GradeScaleConcepts,
+}
+
+// GradeScaleLabel descreve uma escala de notas para exibição.
+func GradeScaleLabel(scale string) string {
+	switch scale {
+	case models.GradeScaleTen:
+		return "notas de 0 a 10"
+	case models.GradeScaleHundred:
+		return "notas de 0 a 100"
+	case models.GradeScaleLetters:
+		return "conceitos A a E"
+	case models.GradeScaleConcepts:
+		return "conceitos MB, B, R e I"
+	}
+	return scale
+}
+
+// IsConceptScale informa se a escala é conceitual (as notas são lançadas e exibidas como conceitos).
+func IsConceptScale(scale string) bool {
+	return scale == models.GradeScaleLetters || scale == models.GradeScaleConcepts
+}
+
+// GradeScaleMax é a maior nota guardada na escala: 100 na escala de 0 a 100 e 10 nas demais.
+func GradeScaleMax(scale string) float64 {
+	if scale == models.GradeScaleHundred {
+		return 100
+	}
+	return 10
+}
+
+// DefaultConceptRanges são as faixas usuais dos conceitos de uma escala conceitual, do maior para o menor.
+func DefaultConceptRanges(scale string) []models.ConceptRange {
+	switch scale {
+	case models.GradeScaleLetters:
+		return []models.ConceptRange{{Concept: "A", MinGrade: 9}, {Concept: "B", MinGrade: 7}, {Concept: "C", MinGrade: 5}, {Concept: "D", MinGrade: 3}, {Concept: "E", MinGrade: 0}}
+	case models.GradeScaleConcepts:
+		return []models.ConceptRange{{Concept: "MB", MinGrade: 9}, {Concept: "B", MinGrade: 7}, {Concept: "R", MinGrade: 5}, {Concept: "I", MinGrade: 0}}
+	}
+	return nil
+}
+
+// ParseConceptRanges interpreta as faixas dos conceitos no formato "A=9,B=7,C=5,D=3,E=0" (o
+// conceito e a sua nota mínima, de 0 a 10).
+func ParseConceptRanges(value string) ([]models.ConceptRange, error) {
+	var ranges []models.ConceptRange
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		concept, minStr, ok := strings.Cut(part, "=")
+		if !ok {
+			concept, minStr, ok = strings.Cut(part, ":")
+		}
+		concept = strings.ToUpper(strings.TrimSpace(concept))
+		if !ok || concept == "" {
+			return nil, ValidationErrorf("conceito inválido '%s': use o formato conceito=nota_mínima (ex: A=9,B=7,C=5,D=3,E=0)", strings.TrimSpace(part))
+		}
+		minGrade, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(minStr), ",", "."), 64)
+		if err != nil {
+			return nil, ValidationErrorf("nota mínima inválida para o conceito '%s': '%s'", concept, strings.TrimSpace(minStr))
+		}
+		ranges = append(ranges, models.ConceptRange{Concept: concept, MinGrade: minGrade})
+	}
+	return ranges, nil
+}
+
+// FormatConceptRanges escreve as faixas dos conceitos no formato aceito por ParseConceptRanges.
+func FormatConceptRanges(ranges []models.ConceptRange) string {
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		parts[i] = r.Concept + "=" + strconv.FormatFloat(r.MinGrade, 'f', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+// normalizeConceptRanges valida as faixas dos conceitos e as ordena da maior nota mínima para a
+// menor. A menor faixa deve começar em 0, para que toda nota tenha um conceito.
+func normalizeConceptRanges(ranges []models.ConceptRange) ([]models.ConceptRange, error) {
+	normalized := make([]models.ConceptRange, 0, len(ranges))
+	seen := make(map[string]bool)
+	for _, r := range ranges {
+		r.Concept = strings.ToUpper(strings.TrimSpace(r.Concept))
+		if r.Concept == "" {
+			return nil, ValidationErrorf("conceito vazio nas faixas dos conceitos")
+		}
+		if seen[r.Concept] {
+			return nil, ValidationErrorf("conceito '%s' informado mais de uma vez", r.Concept)
+		}
+		if r.MinGrade < 0 || r.MinGrade > 10 {
+			return nil, ValidationErrorf("nota mínima do conceito '%s' fora da faixa de 0 a 10: %g", r.Concept, r.MinGrade)
+		}
+		seen[r.Concept] = true
+		normalized = append(normalized, r)
+	}
+	sort.SliceStable(normalized, func(i, j int) bool { return normalized[i].MinGrade > normalized[j].MinGrade })
+	for i := 1; i < len(normalized); i++ {
+		if normalized[i].MinGrade == normalized[i-1].MinGrade {
+			return nil, ValidationErrorf("os conceitos '%s' e '%s' têm a mesma nota mínima", normalized[i-1].Concept, normalized[i].Concept)
+		}
+	}
+	if len(normalized) < 2 {
+		return nil, ValidationErrorf("uma escala conceitual precisa de pelo menos dois conceitos")
+	}
+	if normalized[len(normalized)-1].MinGrade != 0 {
+		return nil, ValidationErrorf("o menor conceito ('%s') deve ter nota mínima 0", normalized[len(normalized)-1].Concept)
+	}
+	return normalized, nil
+}
+
+// GradeConcept retorna o conceito de uma nota ou média (de 0 a 10) na escala conceitual da política.
+func GradeConcept(policy models.GradingPolicy, value float64) string {
+	for _, r := range policy.ConceptRanges {
+		if value >= r.MinGrade-1e-9 {
+			return r.Concept
+		}
+	}
+	if len(policy.ConceptRanges) > 0 {
+		return policy.ConceptRanges[len(policy.ConceptRanges)-1].Concept
+	}
+	return ""
+}
+
+// conceptValue é a nota guardada para um conceito: o meio da sua faixa (ex: B, de 7 a 9, vale 8).
+func conceptValue(policy models.GradingPolicy, concept string) (float64, bool) {
+	upper := GradeScaleMax(policy.GradeScale)
+	for _, r := range policy.ConceptRanges {
+		if strings.EqualFold(r.Concept, strings.TrimSpace(concept)) {
+			return math.Round((r.MinGrade+upper)/2*100) / 100, true
+		}
+		upper = r.MinGrade
+	}
+	return 0, false
+}
+
+// FormatGrade exibe uma nota ou média na escala da turma: com uma casa decimal nas escalas
+// numéricas e como conceito nas conceituais.
+func FormatGrade(policy models.GradingPolicy, value float64) string {
+	if IsConceptScale(policy.GradeScale) {
+		return GradeConcept(policy, value)
+	}
+	return fmt.Sprintf("%.1f", value)
+}
+
+// ParseGrade interpreta uma nota digitada na escala da turma: um número (aceitando vírgula decimal)
+// nas escalas numéricas ou um conceito nas conceituais, retornando o valor a ser guardado.
+func ParseGrade(policy models.GradingPolicy, text string) (float64, error) {
+	text = strings.TrimSpace(text)
+	if IsConceptScale(policy.GradeScale) {
+		if value, ok := conceptValue(policy, text); ok {
+			return value, nil
+		}
+		concepts := make([]string, len(policy.ConceptRanges))
+		for i, r := range policy.ConceptRanges {
+			concepts[i] = r.Concept
+		}
+		return 0, ValidationErrorf("conceito inválido '%s': use %s", text, strings.Join(concepts, ", "))
+	}
+	value, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+	if err != nil {
+		return 0, ValidationErrorf("nota inválida '%s': informe um número de 0 a %g", text, GradeScaleMax(policy.GradeScale))
+	}
+	return value, ValidateGrade(policy, value)
+}
+
+// ValidateGrade verifica se a nota está dentro da escala da turma.
+func ValidateGrade(policy models.GradingPolicy, value float64) error {
+	if math.IsNaN(value) || value < 0 || value > GradeScaleMax(policy.GradeScale) {
+		return ValidationErrorf("nota %g fora da escala da turma (%s)", value, GradeScaleLabel(policy.GradeScale))
+	}
+	return nil
+}
+
+// validateGrades verifica se as notas informadas estão dentro da escala da turma.
+func (s *assessmentServiceImpl) validateGrades(ctx context.Context, classID int64, studentGrades map[int64]float64) error {
+	policy, err := s.GetGradingPolicy(ctx, classID)
+	if err != nil {
+		return err
+	}
+	for studentID, value := range studentGrades {
+		if studentID == 0 {
+			return ValidationErrorf("ID de aluno não pode ser zero nas notas")
+		}
+		if ValidateGrade(policy, value) != nil {
+			return ValidationErrorf("nota %g do aluno ID %d fora da escala da turma (%s)", value, studentID, GradeScaleLabel(policy.GradeScale))
+		}
+	}
+	return nil
+}
+
+// checkGradeScaleChange impede trocar entre escalas de máximos diferentes (0 a 10 e 0 a 100)
+// quando a turma já tem notas lançadas, que passariam a ser lidas na escala errada.
+func (s *assessmentServiceImpl) checkGradeScaleChange(ctx context.Context, policy models.GradingPolicy) error {
+	current, err := s.GetGradingPolicy(ctx, policy.ClassID)
+	if err != nil {
+		return err
+	}
+	if GradeScaleMax(current.GradeScale) == GradeScaleMax(policy.GradeScale) {
+		return nil
+	}
+	grades, _, _, err := s.assessmentRepo.GetGradesByClassID(ctx, policy.ClassID)
+	if err != nil {
+		return fmt.Errorf("service.SetGradingPolicy: checking grades: %w", err)
+	}
+	if len(grades) > 0 {
+		return ValidationErrorf("a turma já tem %d nota(s) lançada(s) em %s; não é possível mudar para %s", len(grades), GradeScaleLabel(current.GradeScale), GradeScaleLabel(policy.GradeScale))
+	}
+	return nil
+}
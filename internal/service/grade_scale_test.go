@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+)
+
+func TestParseAndFormatGrade(t *testing.T) {
+	ten := models.GradingPolicy{GradeScale: models.GradeScaleTen}
+	value, err := ParseGrade(ten, " 7,5 ")
+	require.NoError(t, err)
+	assert.Equal(t, 7.5, value)
+	assert.Equal(t, "7.5", FormatGrade(ten, value))
+	for _, invalid := range []string{"10.5", "-1", "abc", ""} {
+		_, err := ParseGrade(ten, invalid)
+		assert.True(t, errors.Is(err, ErrValidation), "%q: %v", invalid, err)
+	}
+
+	hundred := models.GradingPolicy{GradeScale: models.GradeScaleHundred}
+	value, err = ParseGrade(hundred, "85")
+	require.NoError(t, err)
+	assert.Equal(t, 85.0, value)
+	_, err = ParseGrade(hundred, "101")
+	assert.True(t, errors.Is(err, ErrValidation))
+
+	// Cada conceito guarda o meio da sua faixa e as médias são exibidas pelo conceito da faixa.
+	letters := models.GradingPolicy{GradeScale: models.GradeScaleLetters, ConceptRanges: DefaultConceptRanges(models.GradeScaleLetters)}
+	for concept, expected := range map[string]float64{"a": 9.5, "B": 8, "C": 6, "D": 4, "E": 1.5} {
+		value, err := ParseGrade(letters, concept)
+		require.NoError(t, err, concept)
+		assert.Equal(t, expected, value, concept)
+	}
+	_, err = ParseGrade(letters, "F")
+	assert.True(t, errors.Is(err, ErrValidation))
+	assert.Equal(t, "B", FormatGrade(letters, 7))
+	assert.Equal(t, "C", FormatGrade(letters, 6.99))
+	assert.Equal(t, "A", FormatGrade(letters, 10))
+	assert.Equal(t, "E", FormatGrade(letters, 0))
+
+	concepts := models.GradingPolicy{GradeScale: models.GradeScaleConcepts, ConceptRanges: DefaultConceptRanges(models.GradeScaleConcepts)}
+	value, err = ParseGrade(concepts, "mb")
+	require.NoError(t, err)
+	assert.Equal(t, "MB", FormatGrade(concepts, value))
+	assert.Equal(t, "R", FormatGrade(concepts, 5.5))
+}
+
+func TestParseConceptRanges(t *testing.T) {
+	ranges, err := ParseConceptRanges("e=0, a=8.5;B:6,C=4")
+	require.NoError(t, err)
+	normalized, err := normalizeConceptRanges(ranges)
+	require.NoError(t, err)
+	assert.Equal(t, "A=8.5,B=6,C=4,E=0", FormatConceptRanges(normalized))
+
+	for _, invalid := range []string{"A=9,B=7", "A=9,A=0", "A=11,B=0", "A=5,B=5,C=0", "A=0", "A"} {
+		ranges, err := ParseConceptRanges(invalid)
+		if err == nil {
+			_, err = normalizeConceptRanges(ranges)
+		}
+		assert.True(t, errors.Is(err, ErrValidation), "%q: %v", invalid, err)
+	}
+}
+
+func TestGradingPolicy_GradeScale(t *testing.T) {
+	ctx := context.Background()
+	assessmentService, policies := newGradebookService()
+
+	_, err := assessmentService.SetGradingPolicy(ctx, models.GradingPolicy{ClassID: 1, GradeScale: "0-20"})
+	assert.True(t, errors.Is(err, ErrValidation), "%v", err)
+
+	// A turma já tem notas de 0 a 10: não pode passar para 0 a 100, mas pode passar para conceitos.
+	_, err = assessmentService.SetGradingPolicy(ctx, models.GradingPolicy{ClassID: 1, GradeScale: models.GradeScaleHundred})
+	assert.True(t, errors.Is(err, ErrValidation), "%v", err)
+	policy, err := assessmentService.SetGradingPolicy(ctx, models.GradingPolicy{ClassID: 1, GradeScale: " Conceitos "})
+	require.NoError(t, err)
+	assert.Equal(t, models.GradeScaleConcepts, policy.GradeScale)
+	assert.Equal(t, DefaultConceptRanges(models.GradeScaleConcepts), policies.policies[1].ConceptRanges)
+
+	// As faixas só valem para as escalas conceituais.
+	policy, err = assessmentService.SetGradingPolicy(ctx, models.GradingPolicy{ClassID: 1, GradeScale: models.GradeScaleTen, ConceptRanges: policy.ConceptRanges})
+	require.NoError(t, err)
+	assert.Nil(t, policy.ConceptRanges)
+}
+
+func TestEnterGrades_ValidatesClassScale(t *testing.T) {
+	ctx := context.Background()
+	assessmentService, policies := newGradebookService()
+
+	err := assessmentService.EnterGrades(ctx, 1, map[int64]float64{1: 9, 2: 10.5})
+	assert.True(t, errors.Is(err, ErrValidation), "%v", err)
+	err = assessmentService.EnterFinalGrades(ctx, 1, map[int64]float64{1: -1})
+	assert.True(t, errors.Is(err, ErrValidation), "%v", err)
+	require.NoError(t, assessmentService.EnterGrades(ctx, 1, map[int64]float64{1: 9, 2: 10}))
+
+	policies.policies[1] = models.GradingPolicy{ClassID: 1, Scheme: models.GradingSchemeWeighted, GradeScale: models.GradeScaleHundred}
+	require.NoError(t, assessmentService.EnterGrades(ctx, 1, map[int64]float64{2: 75}))
+	err = assessmentService.EnterGrades(ctx, 1, map[int64]float64{2: 100.5})
+	assert.True(t, errors.Is(err, ErrValidation), "%v", err)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// Gradebook é a planilha de notas de uma turma: todos os alunos, as avaliações agrupadas por
+// bimestre, as notas lançadas e as médias de cada bimestre e do ano, calculadas segundo a política
+// de notas da turma (com as recuperações aplicadas).
+type Gradebook struct {
+	ClassID      int64                            `json:"class_id"`
+	Policy       models.GradingPolicy             `json:"policy"`
+	Students     []models.Student                 `json:"students"`      // Todos os alunos, por nome; só os ativos têm médias.
+	Assessments  []models.Assessment              `json:"assessments"`   // Por bimestre e ID, sem a "Nota Final".
+	Terms        []int                            `json:"terms"`         // Bimestres com avaliações, em ordem.
+	Grades       map[int64]map[int64]float64      `json:"grades"`        // ID da avaliação -> ID do aluno -> nota.
+	TermAverages map[int]map[int64]StudentAverage `json:"term_averages"` // Bimestre -> ID do aluno -> média.
+	Averages     map[int64]StudentAverage         `json:"averages"`      // Média de todos os bimestres.
+}
+
+// Grade retorna a nota do aluno na avaliação, se lançada.
+func (g Gradebook) Grade(assessmentID, studentID int64) (float64, bool) {
+	grade, ok := g.Grades[assessmentID][studentID]
+	return grade, ok
+}
+
+// TermAssessments retorna as avaliações de um bimestre, na ordem da planilha.
+func (g Gradebook) TermAssessments(term int) []models.Assessment {
+	var assessments []models.Assessment
+	for _, a := range g.Assessments {
+		if a.Term == term {
+			assessments = append(assessments, a)
+		}
+	}
+	return assessments
+}
+
+// HasGrades informa se o aluno tem alguma nota lançada nas avaliações dos bimestres indicados (em
+// todos, se nenhum for indicado). Sem notas, as médias do aluno valem zero, mas não foram apuradas.
+func (g Gradebook) HasGrades(studentID int64, terms ...int) bool {
+	for _, a := range g.Assessments {
+		if len(terms) > 0 && !slices.Contains(terms, a.Term) {
+			continue
+		}
+		if _, ok := g.Grade(a.ID, studentID); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *assessmentServiceImpl) GetGradebook(ctx context.Context, classID int64) (Gradebook, error) {
+	if classID == 0 {
+		return Gradebook{}, ValidationErrorf("ID da turma não pode ser zero")
+	}
+	if s.classRepo != nil {
+		if _, err := s.classRepo.GetClassByID(ctx, classID); err != nil {
+			if repository.IsNotFound(err) {
+				return Gradebook{}, NotFoundErrorf("turma com ID %d não encontrada", classID)
+			}
+			return Gradebook{}, fmt.Errorf("service.GetGradebook: %w", err)
+		}
+	}
+	grades, assessments, students, err := s.assessmentRepo.GetGradesByClassID(ctx, classID)
+	if err != nil {
+		return Gradebook{}, fmt.Errorf("service.GetGradebook: %w", err)
+	}
+	policy, err := s.GetGradingPolicy(ctx, classID)
+	if err != nil {
+		return Gradebook{}, fmt.Errorf("service.GetGradebook: %w", err)
+	}
+
+	book := Gradebook{
+		ClassID:      classID,
+		Policy:       policy,
+		Students:     students,
+		Grades:       make(map[int64]map[int64]float64),
+		TermAverages: make(map[int]map[int64]StudentAverage),
+	}
+	sort.SliceStable(book.Students, func(i, j int) bool { return book.Students[i].FullName < book.Students[j].FullName })
+	terms := make(map[int]bool)
+	for _, a := range assessments {
+		if a.Name == FinalGradeAssessmentName {
+			continue
+		}
+		book.Assessments = append(book.Assessments, a)
+		if !terms[a.Term] {
+			terms[a.Term] = true
+			book.Terms = append(book.Terms, a.Term)
+		}
+	}
+	sort.SliceStable(book.Assessments, func(i, j int) bool {
+		if book.Assessments[i].Term != book.Assessments[j].Term {
+			return book.Assessments[i].Term < book.Assessments[j].Term
+		}
+		return book.Assessments[i].ID < book.Assessments[j].ID
+	})
+	sort.Ints(book.Terms)
+	for _, g := range grades {
+		if book.Grades[g.AssessmentID] == nil {
+			book.Grades[g.AssessmentID] = make(map[int64]float64)
+		}
+		book.Grades[g.AssessmentID][g.StudentID] = g.Grade
+	}
+
+	// Bimestres só com recuperações (ou turmas sem alunos) não têm média: CalculateClassAverages
+	// responde "não encontrado" e a planilha fica sem essa média.
+	for _, term := range book.Terms {
+		averages, err := s.CalculateClassAverages(ctx, classID, []int{term})
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return Gradebook{}, err
+		}
+		book.TermAverages[term] = averages
+	}
+	if book.Averages, err = s.CalculateClassAverages(ctx, classID, nil); err != nil && !errors.Is(err, ErrNotFound) {
+		return Gradebook{}, err
+	}
+	return book, nil
+}
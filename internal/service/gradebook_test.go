@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGradebook(t *testing.T) {
+	assessmentService, _ := newGradebookService()
+	book, err := assessmentService.GetGradebook(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2}, book.Terms)
+	var names []string
+	for _, a := range book.Assessments {
+		names = append(names, a.Name)
+	}
+	assert.Equal(t, []string{"P1", "T1", "P2", "T2"}, names, "a nota final não é uma coluna da planilha")
+	assert.Len(t, book.TermAssessments(2), 2)
+	assert.Len(t, book.Students, 4, "alunos transferidos continuam na planilha")
+
+	grade, ok := book.Grade(2, 1)
+	assert.True(t, ok)
+	assert.Equal(t, 10.0, grade)
+	_, ok = book.Grade(2, 2)
+	assert.False(t, ok)
+
+	assert.InDelta(t, 26.0/3, book.TermAverages[1][1].Adjusted, 1e-9)
+	assert.InDelta(t, 5, book.TermAverages[1][2].Adjusted, 1e-9, "notas não lançadas ficam fora da média")
+	assert.InDelta(t, 7, book.TermAverages[2][1].Adjusted, 1e-9)
+	assert.InDelta(t, 47.0/6, book.Averages[1].Adjusted, 1e-9)
+	assert.Equal(t, 0.0, book.Averages[3].Adjusted)
+	assert.True(t, book.HasGrades(2))
+	assert.True(t, book.HasGrades(2, 1))
+	assert.False(t, book.HasGrades(3), "a média zero de Carla não vem de notas lançadas")
+	_, ok = book.Averages[4]
+	assert.False(t, ok, "alunos transferidos ficam fora das médias")
+
+	_, err = assessmentService.GetGradebook(context.Background(), 0)
+	assert.ErrorIs(t, err, ErrValidation)
+}
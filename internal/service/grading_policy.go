@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// GradingSchemes são os esquemas de cálculo de média aceitos, na ordem em que são apresentados.
+var GradingSchemes = []string{
+	models.GradingSchemeWeighted,
+	models.GradingSchemeArithmetic,
+	models.GradingSchemeCategories,
+	models.GradingSchemeTermMean,
+	models.GradingSchemeBestOf,
+}
+
+// DefaultGradingPolicy é a política das turmas que não definiram uma: notas de 0 a 10, média
+// ponderada pelos pesos das avaliações, sem arredondamento, aprovação com 6 e recuperação que
+// substitui a média, se for maior.
+func DefaultGradingPolicy(classID int64) models.GradingPolicy {
+	return models.GradingPolicy{
+		ClassID:      classID,
+		Scheme:       models.GradingSchemeWeighted,
+		GradeScale:   models.GradeScaleTen,
+		PassingGrade: DefaultPassingGrade(models.GradeScaleTen),
+		RecoveryRule: models.RecoveryRuleReplaceAverage,
+	}
+}
+
+// GradingSchemeLabel descreve um esquema de cálculo de média para exibição.
+func GradingSchemeLabel(scheme string) string {
+	switch scheme {
+	case models.GradingSchemeArithmetic:
+		return "média aritmética das notas"
+	case models.GradingSchemeWeighted:
+		return "média ponderada pelos pesos das avaliações"
+	case models.GradingSchemeCategories:
+		return "média ponderada por categoria de avaliação"
+	case models.GradingSchemeTermMean:
+		return "média das médias dos bimestres"
+	case models.GradingSchemeBestOf:
+		return "média das melhores notas"
+	}
+	return scheme
+}
+
+// ParseCategoryWeights interpreta os pesos das categorias no formato "prova=60,trabalho=40". Os
+// nomes das categorias são normalizados (ver NormalizeCategory).
+func ParseCategoryWeights(value string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		name, weightStr, ok := strings.Cut(part, "=")
+		if !ok {
+			name, weightStr, ok = strings.Cut(part, ":")
+		}
+		name = NormalizeCategory(name)
+		if !ok || name == "" {
+			return nil, ValidationErrorf("categoria inválida '%s': use o formato categoria=peso (ex: prova=60,trabalho=40)", strings.TrimSpace(part))
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSuffix(strings.ReplaceAll(strings.TrimSpace(weightStr), ",", "."), "%"), 64)
+		if err != nil || weight <= 0 {
+			return nil, ValidationErrorf("peso inválido para a categoria '%s': '%s' (deve ser um número positivo)", name, strings.TrimSpace(weightStr))
+		}
+		if _, exists := weights[name]; exists {
+			return nil, ValidationErrorf("categoria '%s' informada mais de uma vez", name)
+		}
+		weights[name] = weight
+	}
+	return weights, nil
+}
+
+// FormatCategoryWeights escreve os pesos das categorias no formato aceito por ParseCategoryWeights,
+// em ordem alfabética.
+func FormatCategoryWeights(weights map[string]float64) string {
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + strconv.FormatFloat(weights[name], 'f', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+// NormalizeCategory normaliza o nome de uma categoria de avaliação (minúsculas, sem espaços nas pontas).
+func NormalizeCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
+// RoundGrade arredonda uma média para o múltiplo de 'step' mais próximo (metade para cima), como
+// 6.25 → 6.5 com passo 0.5 ou 7.35 → 7.4 com passo 0.1. Com passo 0 a média não é arredondada.
+func RoundGrade(value, step float64) float64 {
+	if step <= 0 {
+		return value
+	}
+	rounded := math.Floor(value/step+0.5+1e-9) * step
+	return math.Round(rounded*1e6) / 1e6 // Remove o ruído de ponto flutuante (ex: 6.999999999).
+}
+
+func (s *assessmentServiceImpl) GetGradingPolicy(ctx context.Context, classID int64) (models.GradingPolicy, error) {
+	if classID == 0 {
+		return models.GradingPolicy{}, ValidationErrorf("ID da turma não pode ser zero")
+	}
+	if s.policyRepo == nil {
+		return DefaultGradingPolicy(classID), nil
+	}
+	policy, err := s.policyRepo.GetGradingPolicy(ctx, classID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return DefaultGradingPolicy(classID), nil
+		}
+		return models.GradingPolicy{}, fmt.Errorf("service.GetGradingPolicy: %w", err)
+	}
+	if IsConceptScale(policy.GradeScale) && len(policy.ConceptRanges) == 0 {
+		policy.ConceptRanges = DefaultConceptRanges(policy.GradeScale)
+	}
+	if policy.PassingGrade == 0 {
+		policy.PassingGrade = DefaultPassingGrade(policy.GradeScale)
+	}
+	if policy.RecoveryRule == "" {
+		policy.RecoveryRule = models.RecoveryRuleReplaceAverage
+	}
+	return *policy, nil
+}
+
+func (s *assessmentServiceImpl) SetGradingPolicy(ctx context.Context, policy models.GradingPolicy) (models.GradingPolicy, error) {
+	if policy.ClassID == 0 {
+		return models.GradingPolicy{}, ValidationErrorf("ID da turma não pode ser zero")
+	}
+	if s.policyRepo == nil {
+		return models.GradingPolicy{}, fmt.Errorf("service.SetGradingPolicy: repositório de políticas de notas não configurado")
+	}
+	if err := normalizeGradingPolicy(&policy); err != nil {
+		return models.GradingPolicy{}, err
+	}
+	if s.classRepo != nil {
+		if _, err := s.classRepo.GetClassByID(ctx, policy.ClassID); err != nil {
+			if repository.IsNotFound(err) {
+				return models.GradingPolicy{}, NotFoundErrorf("turma com ID %d não encontrada", policy.ClassID)
+			}
+			return models.GradingPolicy{}, fmt.Errorf("service.SetGradingPolicy: validating class: %w", err)
+		}
+	}
+	if err := s.checkGradeScaleChange(ctx, policy); err != nil {
+		return models.GradingPolicy{}, err
+	}
+	if err := s.policyRepo.SaveGradingPolicy(ctx, &policy); err != nil {
+		return models.GradingPolicy{}, fmt.Errorf("service.SetGradingPolicy: %w", err)
+	}
+	return policy, nil
+}
+
+// normalizeGradingPolicy valida a política e normaliza o esquema, a escala, as faixas dos conceitos,
+// a média de aprovação, a regra de recuperação e os nomes das categorias.
+func normalizeGradingPolicy(policy *models.GradingPolicy) error {
+	policy.Scheme = strings.ToLower(strings.TrimSpace(policy.Scheme))
+	if policy.Scheme == "" {
+		policy.Scheme = models.GradingSchemeWeighted
+	}
+	known := false
+	for _, scheme := range GradingSchemes {
+		known = known || scheme == policy.Scheme
+	}
+	if !known {
+		return ValidationErrorf("esquema de média inválido '%s': use %s", policy.Scheme, strings.Join(GradingSchemes, ", "))
+	}
+	if policy.RoundingStep < 0 || policy.RoundingStep > 10 {
+		return ValidationErrorf("passo de arredondamento inválido %g: use um valor entre 0 (sem arredondamento) e 10", policy.RoundingStep)
+	}
+
+	policy.GradeScale = strings.ToLower(strings.TrimSpace(policy.GradeScale))
+	if policy.GradeScale == "" {
+		policy.GradeScale = models.GradeScaleTen
+	}
+	known = false
+	for _, scale := range GradeScales {
+		known = known || scale == policy.GradeScale
+	}
+	if !known {
+		return ValidationErrorf("escala de notas inválida '%s': use %s", policy.GradeScale, strings.Join(GradeScales, ", "))
+	}
+	if !IsConceptScale(policy.GradeScale) {
+		policy.ConceptRanges = nil
+	} else {
+		if len(policy.ConceptRanges) == 0 {
+			policy.ConceptRanges = DefaultConceptRanges(policy.GradeScale)
+		}
+		ranges, err := normalizeConceptRanges(policy.ConceptRanges)
+		if err != nil {
+			return err
+		}
+		policy.ConceptRanges = ranges
+	}
+
+	if policy.PassingGrade == 0 {
+		policy.PassingGrade = DefaultPassingGrade(policy.GradeScale)
+	}
+	if policy.PassingGrade < 0 || policy.PassingGrade > GradeScaleMax(policy.GradeScale) {
+		return ValidationErrorf("média de aprovação %g fora da escala da turma (%s)", policy.PassingGrade, GradeScaleLabel(policy.GradeScale))
+	}
+	policy.RecoveryRule = strings.ToLower(strings.TrimSpace(policy.RecoveryRule))
+	if policy.RecoveryRule == "" {
+		policy.RecoveryRule = models.RecoveryRuleReplaceAverage
+	}
+	known = false
+	for _, rule := range RecoveryRules {
+		known = known || rule == policy.RecoveryRule
+	}
+	if !known {
+		return ValidationErrorf("regra de recuperação inválida '%s': use %s", policy.RecoveryRule, strings.Join(RecoveryRules, ", "))
+	}
+
+	// As opções dos outros esquemas são mantidas, para não se perderem ao trocar de esquema e voltar.
+	weights := make(map[string]float64)
+	for name, weight := range policy.CategoryWeights {
+		name = NormalizeCategory(name)
+		if name == "" || weight <= 0 {
+			return ValidationErrorf("peso inválido para a categoria '%s': deve ser um número positivo", name)
+		}
+		weights[name] = weight
+	}
+	policy.CategoryWeights = weights
+	if policy.Scheme == models.GradingSchemeCategories && len(weights) == 0 {
+		return ValidationErrorf("o esquema 'categorias' exige os pesos das categorias (ex: prova=60,trabalho=40)")
+	}
+	if policy.BestOf < 0 || (policy.Scheme == models.GradingSchemeBestOf && policy.BestOf == 0) {
+		return ValidationErrorf("o esquema 'melhores' exige o número de notas consideradas (maior que zero)")
+	}
+	return nil
+}
+
+func (s *assessmentServiceImpl) SetAssessmentCategory(ctx context.Context, assessmentID int64, category string) (models.Assessment, error) {
+	if assessmentID == 0 {
+		return models.Assessment{}, ValidationErrorf("ID da avaliação não pode ser zero")
+	}
+	category = NormalizeCategory(category)
+	if err := s.assessmentRepo.UpdateAssessmentCategory(ctx, assessmentID, category); err != nil {
+		if repository.IsNotFound(err) {
+			return models.Assessment{}, NotFoundErrorf("avaliação com ID %d não encontrada", assessmentID)
+		}
+		return models.Assessment{}, fmt.Errorf("service.SetAssessmentCategory: %w", err)
+	}
+	assessment, err := s.assessmentRepo.GetAssessmentByID(ctx, assessmentID)
+	if err != nil {
+		return models.Assessment{}, fmt.Errorf("service.SetAssessmentCategory: %w", err)
+	}
+	return *assessment, nil
+}
+
+// studentGrade é uma nota de um aluno junto com a avaliação a que se refere.
+type studentGrade struct {
+	assessment models.Assessment
+	grade      float64
+}
+
+// policyAverage calcula a média de um aluno a partir das suas notas, segundo o esquema da política,
+// sem arredondar. ok é falso se nenhuma das notas conta para o esquema.
+func policyAverage(policy models.GradingPolicy, grades []studentGrade) (average float64, ok bool) {
+	switch policy.Scheme {
+	case models.GradingSchemeArithmetic:
+		return weightedMean(grades, func(models.Assessment) float64 { return 1 })
+	case models.GradingSchemeCategories:
+		byCategory := make(map[string][]studentGrade)
+		for _, g := range grades {
+			byCategory[g.assessment.Category] = append(byCategory[g.assessment.Category], g)
+		}
+		// As categorias sem notas ficam de fora e os pesos das demais são redistribuídos.
+		total, totalWeight := 0.0, 0.0
+		for category, weight := range policy.CategoryWeights {
+			if mean, ok := weightedMean(byCategory[category], assessmentWeight); ok {
+				total += mean * weight
+				totalWeight += weight
+			}
+		}
+		if totalWeight == 0 {
+			return 0, false
+		}
+		return total / totalWeight, true
+	case models.GradingSchemeTermMean:
+		byTerm := make(map[int][]studentGrade)
+		for _, g := range grades {
+			byTerm[g.assessment.Term] = append(byTerm[g.assessment.Term], g)
+		}
+		total, count := 0.0, 0
+		for _, termGrades := range byTerm {
+			if mean, ok := weightedMean(termGrades, assessmentWeight); ok {
+				total += mean
+				count++
+			}
+		}
+		if count == 0 {
+			return 0, false
+		}
+		return total / float64(count), true
+	case models.GradingSchemeBestOf:
+		values := make([]float64, len(grades))
+		for i, g := range grades {
+			values[i] = g.grade
+		}
+		sort.Sort(sort.Reverse(sort.Float64Slice(values)))
+		if len(values) > policy.BestOf {
+			values = values[:policy.BestOf]
+		}
+		if len(values) == 0 {
+			return 0, false
+		}
+		total := 0.0
+		for _, value := range values {
+			total += value
+		}
+		return total / float64(len(values)), true
+	}
+	return weightedMean(grades, assessmentWeight)
+}
+
+func assessmentWeight(a models.Assessment) float64 { return a.Weight }
+
+// weightedMean calcula a média das notas ponderada por 'weight'. ok é falso se a soma dos pesos for zero.
+func weightedMean(grades []studentGrade, weight func(models.Assessment) float64) (float64, bool) {
+	total, totalWeight := 0.0, 0.0
+	for _, g := range grades {
+		w := weight(g.assessment)
+		total += g.grade * w
+		totalWeight += w
+	}
+	if totalWeight == 0 {
+		return 0, false
+	}
+	return total / totalWeight, true
+}
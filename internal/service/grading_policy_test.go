@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// gradebookRepository devolve as avaliações, notas e alunos de uma turma fixos, como
+// GetGradesByClassID faria.
+type gradebookRepository struct {
+	repository.AssessmentRepository
+	assessments []models.Assessment
+	grades      []models.Grade
+	students    []models.Student
+}
+
+func (r *gradebookRepository) GetGradesByClassID(ctx context.Context, classID int64) ([]models.Grade, []models.Assessment, []models.Student, error) {
+	return r.grades, r.assessments, r.students, nil
+}
+
+func (r *gradebookRepository) UpdateAssessmentCategory(ctx context.Context, assessmentID int64, category string) error {
+	for i := range r.assessments {
+		if r.assessments[i].ID == assessmentID {
+			r.assessments[i].Category = category
+			return nil
+		}
+	}
+	return fmt.Errorf("avaliação %d: %w", assessmentID, sql.ErrNoRows)
+}
+
+func (r *gradebookRepository) CreateAssessment(ctx context.Context, assessment *models.Assessment) (int64, error) {
+	assessment.ID = int64(len(r.assessments) + 1)
+	r.assessments = append(r.assessments, *assessment)
+	return assessment.ID, nil
+}
+
+func (r *gradebookRepository) EnterGrade(ctx context.Context, grade *models.Grade) error {
+	r.grades = append(r.grades, *grade)
+	return nil
+}
+
+func (r *gradebookRepository) EnterGrades(ctx context.Context, grades []models.Grade) error {
+	r.grades = append(r.grades, grades...)
+	return nil
+}
+
+func (r *gradebookRepository) GetAssessmentByID(ctx context.Context, assessmentID int64) (*models.Assessment, error) {
+	for _, assessment := range r.assessments {
+		if assessment.ID == assessmentID {
+			return &assessment, nil
+		}
+	}
+	return nil, fmt.Errorf("avaliação %d: %w", assessmentID, sql.ErrNoRows)
+}
+
+// memoryPolicyRepository guarda as políticas de notas em memória, por turma.
+type memoryPolicyRepository struct {
+	policies map[int64]models.GradingPolicy
+}
+
+func (r *memoryPolicyRepository) GetGradingPolicy(ctx context.Context, classID int64) (*models.GradingPolicy, error) {
+	policy, ok := r.policies[classID]
+	if !ok {
+		return nil, fmt.Errorf("turma %d: %w", classID, sql.ErrNoRows)
+	}
+	return &policy, nil
+}
+
+func (r *memoryPolicyRepository) SaveGradingPolicy(ctx context.Context, policy *models.GradingPolicy) error {
+	r.policies[policy.ClassID] = *policy
+	return nil
+}
+
+// newGradebookService monta um serviço de avaliações com a turma 1 do boletim abaixo:
+//
+//	             P1 (1º, prova, peso 2)  T1 (1º, trabalho, peso 1)  P2 (2º, prova, peso 2)  T2 (2º, trabalho, peso 1)
+//	Ana                  8                        10                        6                        9
+//	Bruno                5                         -                        7                        -
+//	Carla (sem notas; média 0) e Davi (transferido; fora das médias)
+func newGradebookService() (AssessmentService, *memoryPolicyRepository) {
+	policies := &memoryPolicyRepository{policies: map[int64]models.GradingPolicy{}}
+	return NewAssessmentService(newGradebookRepository(), &namedClassRepository{}, nil, policies), policies
+}
+
+func newGradebookRepository() *gradebookRepository {
+	return &gradebookRepository{
+		assessments: []models.Assessment{
+			{ID: 1, ClassID: 1, Name: "P1", Term: 1, Weight: 2, Category: "prova"},
+			{ID: 2, ClassID: 1, Name: "T1", Term: 1, Weight: 1, Category: "trabalho"},
+			{ID: 3, ClassID: 1, Name: "P2", Term: 2, Weight: 2, Category: "prova"},
+			{ID: 4, ClassID: 1, Name: "T2", Term: 2, Weight: 1, Category: "trabalho"},
+			{ID: 5, ClassID: 1, Name: FinalGradeAssessmentName, Term: 0, Weight: 0},
+		},
+		grades: []models.Grade{
+			{AssessmentID: 1, StudentID: 1, Grade: 8}, {AssessmentID: 2, StudentID: 1, Grade: 10},
+			{AssessmentID: 3, StudentID: 1, Grade: 6}, {AssessmentID: 4, StudentID: 1, Grade: 9},
+			{AssessmentID: 1, StudentID: 2, Grade: 5}, {AssessmentID: 3, StudentID: 2, Grade: 7},
+			{AssessmentID: 5, StudentID: 1, Grade: 2}, // A nota final não entra em nenhuma média.
+			{AssessmentID: 1, StudentID: 4, Grade: 10},
+		},
+		students: []models.Student{
+			{ID: 1, ClassID: 1, FullName: "Ana", Status: "ativo"},
+			{ID: 2, ClassID: 1, FullName: "Bruno", Status: "ativo"},
+			{ID: 3, ClassID: 1, FullName: "Carla", Status: "ativo"},
+			{ID: 4, ClassID: 1, FullName: "Davi", Status: "transferido"},
+		},
+	}
+}
+
+func TestCalculateClassAverage_Schemes(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		policy   models.GradingPolicy
+		terms    []int
+		expected map[int64]float64
+	}{
+		{
+			name:   "ponderada",
+			policy: models.GradingPolicy{Scheme: models.GradingSchemeWeighted},
+			// Ana: (16+10+12+9)/6; Bruno: (10+14)/4.
+			expected: map[int64]float64{1: 47.0 / 6, 2: 6, 3: 0},
+		},
+		{
+			name:     "aritmetica",
+			policy:   models.GradingPolicy{Scheme: models.GradingSchemeArithmetic},
+			expected: map[int64]float64{1: 8.25, 2: 6, 3: 0},
+		},
+		{
+			name:   "categorias",
+			policy: models.GradingPolicy{Scheme: models.GradingSchemeCategories, CategoryWeights: map[string]float64{"prova": 60, "trabalho": 40}},
+			// Ana: provas 7, trabalhos 9.5 → 0.6*7 + 0.4*9.5; Bruno só tem provas, cujo peso é redistribuído.
+			expected: map[int64]float64{1: 8, 2: 6, 3: 0},
+		},
+		{
+			name:   "categorias ignora as que não estão na política",
+			policy: models.GradingPolicy{Scheme: models.GradingSchemeCategories, CategoryWeights: map[string]float64{"trabalho": 1}},
+			// Bruno não tem trabalhos: sem notas que contem, a média é 0.
+			expected: map[int64]float64{1: 9.5, 2: 0, 3: 0},
+		},
+		{
+			name:   "media-bimestres",
+			policy: models.GradingPolicy{Scheme: models.GradingSchemeTermMean},
+			// Ana: 1º bim (16+10)/3, 2º bim (12+9)/3; Bruno: 5 e 7.
+			expected: map[int64]float64{1: (26.0/3 + 7) / 2, 2: 6, 3: 0},
+		},
+		{
+			name:     "melhores",
+			policy:   models.GradingPolicy{Scheme: models.GradingSchemeBestOf, BestOf: 3},
+			expected: map[int64]float64{1: 9, 2: 6, 3: 0},
+		},
+		{
+			name:     "arredondamento para 0,5",
+			policy:   models.GradingPolicy{Scheme: models.GradingSchemeWeighted, RoundingStep: 0.5},
+			expected: map[int64]float64{1: 8, 2: 6, 3: 0},
+		},
+		{
+			name:     "arredondamento para uma casa decimal",
+			policy:   models.GradingPolicy{Scheme: models.GradingSchemeTermMean, RoundingStep: 0.1},
+			expected: map[int64]float64{1: 7.8, 2: 6, 3: 0},
+		},
+		{
+			name:     "filtro de bimestre",
+			policy:   models.GradingPolicy{Scheme: models.GradingSchemeArithmetic},
+			terms:    []int{2},
+			expected: map[int64]float64{1: 7.5, 2: 7, 3: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessmentService, _ := newGradebookService()
+			tt.policy.ClassID = 1
+			_, err := assessmentService.SetGradingPolicy(ctx, tt.policy)
+			require.NoError(t, err)
+
+			averages, err := assessmentService.CalculateClassAverage(ctx, 1, tt.terms)
+			require.NoError(t, err)
+			require.Len(t, averages, len(tt.expected))
+			for studentID, expected := range tt.expected {
+				assert.InDelta(t, expected, averages[studentID], 1e-9, "aluno %d", studentID)
+			}
+		})
+	}
+}
+
+func TestGradingPolicy_DefaultAndValidation(t *testing.T) {
+	ctx := context.Background()
+	assessmentService, policies := newGradebookService()
+
+	policy, err := assessmentService.GetGradingPolicy(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultGradingPolicy(1), policy)
+
+	for _, invalid := range []models.GradingPolicy{
+		{ClassID: 1, Scheme: "mediana"},
+		{ClassID: 1, Scheme: models.GradingSchemeCategories},
+		{ClassID: 1, Scheme: models.GradingSchemeCategories, CategoryWeights: map[string]float64{"prova": 0}},
+		{ClassID: 1, Scheme: models.GradingSchemeBestOf},
+		{ClassID: 1, Scheme: models.GradingSchemeWeighted, BestOf: -1},
+		{ClassID: 1, Scheme: models.GradingSchemeWeighted, RoundingStep: -0.5},
+		{ClassID: 0, Scheme: models.GradingSchemeWeighted},
+	} {
+		_, err := assessmentService.SetGradingPolicy(ctx, invalid)
+		assert.True(t, errors.Is(err, ErrValidation), "%+v: %v", invalid, err)
+	}
+	_, err = assessmentService.SetGradingPolicy(ctx, models.GradingPolicy{ClassID: 42, Scheme: models.GradingSchemeWeighted})
+	assert.True(t, errors.Is(err, ErrNotFound), "turma inexistente: %v", err)
+	assert.Empty(t, policies.policies)
+
+	// O esquema e as categorias são normalizados; as opções dos outros esquemas são mantidas.
+	policy, err = assessmentService.SetGradingPolicy(ctx, models.GradingPolicy{
+		ClassID: 1, Scheme: " Categorias ", BestOf: 2, CategoryWeights: map[string]float64{" Prova ": 60, "TRABALHO": 40},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.GradingPolicy{ClassID: 1, Scheme: models.GradingSchemeCategories, GradeScale: models.GradeScaleTen, BestOf: 2, CategoryWeights: map[string]float64{"prova": 60, "trabalho": 40},
+		PassingGrade: 6, RecoveryRule: models.RecoveryRuleReplaceAverage}, policy)
+	assert.Equal(t, policy, policies.policies[1])
+
+	policy.Scheme = models.GradingSchemeArithmetic
+	policy.CategoryWeights = nil
+	_, err = assessmentService.SetGradingPolicy(ctx, policy)
+	require.NoError(t, err, "a aritmética não exige os pesos das categorias")
+}
+
+func TestSetAssessmentCategory(t *testing.T) {
+	ctx := context.Background()
+	assessmentService, _ := newGradebookService()
+
+	assessment, err := assessmentService.SetAssessmentCategory(ctx, 2, " Trabalho em Grupo ")
+	require.NoError(t, err)
+	assert.Equal(t, "trabalho em grupo", assessment.Category)
+
+	_, err = assessmentService.SetAssessmentCategory(ctx, 99, "prova")
+	assert.True(t, errors.Is(err, ErrNotFound), "%v", err)
+}
+
+func TestParseCategoryWeights(t *testing.T) {
+	weights, err := ParseCategoryWeights("Prova=60, trabalho:40%;participação=2,5")
+	require.Error(t, err, "a vírgula separa as categorias, então '5' não é uma categoria válida")
+
+	weights, err = ParseCategoryWeights("Prova=60, trabalho:40%;participação=2.5")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"prova": 60, "trabalho": 40, "participação": 2.5}, weights)
+	assert.Equal(t, "participação=2.5,prova=60,trabalho=40", FormatCategoryWeights(weights))
+
+	for _, invalid := range []string{"prova", "prova=abc", "prova=-1", "=10", "prova=1,Prova=2"} {
+		_, err := ParseCategoryWeights(invalid)
+		assert.True(t, errors.Is(err, ErrValidation), "%q: %v", invalid, err)
+	}
+}
+
+func TestRoundGrade(t *testing.T) {
+	for _, tt := range []struct{ value, step, expected float64 }{
+		{6.24, 0.5, 6}, {6.25, 0.5, 6.5}, {6.74, 0.5, 6.5}, {6.75, 0.5, 7},
+		{7.35, 0.1, 7.4}, {7.349, 0.1, 7.3}, {6.5, 1, 7}, {7.777, 0, 7.777},
+	} {
+		assert.InDelta(t, tt.expected, RoundGrade(tt.value, tt.step), 1e-9, "RoundGrade(%v, %v)", tt.value, tt.step)
+	}
+}
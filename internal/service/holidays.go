@@ -0,0 +1,71 @@
+// Este arquivo define os feriados nacionais usados pelo calendário escolar e pelo gerador de aulas.
+package service
+
+import (
+	"sort"
+	"time"
+)
+
+// Holiday é um dia sem aula.
+type Holiday struct {
+	Date time.Time // Date é o dia do feriado (hora zerada, horário local).
+	Name string
+}
+
+// NationalHolidays retorna os feriados nacionais do ano, em ordem de data, incluindo os dias
+// móveis que dependem da Páscoa (Carnaval, Sexta-feira Santa e Corpus Christi), em que não há aula
+// na rede de ensino.
+func NationalHolidays(year int) []Holiday {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+	}
+	easter := easterSunday(year)
+	holidays := []Holiday{
+		{day(time.January, 1), "Confraternização Universal"},
+		{easter.AddDate(0, 0, -48), "Carnaval"},
+		{easter.AddDate(0, 0, -47), "Carnaval"},
+		{easter.AddDate(0, 0, -2), "Sexta-feira Santa"},
+		{day(time.April, 21), "Tiradentes"},
+		{day(time.May, 1), "Dia do Trabalho"},
+		{easter.AddDate(0, 0, 60), "Corpus Christi"},
+		{day(time.September, 7), "Independência do Brasil"},
+		{day(time.October, 12), "Nossa Senhora Aparecida"},
+		{day(time.November, 2), "Finados"},
+		{day(time.November, 15), "Proclamação da República"},
+		{day(time.November, 20), "Dia da Consciência Negra"},
+		{day(time.December, 25), "Natal"},
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// nationalDaysOff retorna os feriados nacionais entre 'from' e 'to' (inclusive), indexados por
+// AAAA-MM-DD, no formato de CalendarService.DaysOff.
+func nationalDaysOff(from, to time.Time) map[string]string {
+	daysOff := make(map[string]string)
+	first, last := from.Format("2006-01-02"), to.Format("2006-01-02")
+	for year := from.Year(); year <= to.Year(); year++ {
+		for _, holiday := range NationalHolidays(year) {
+			if key := holiday.Date.Format("2006-01-02"); key >= first && key <= last {
+				daysOff[key] = "feriado: " + holiday.Name
+			}
+		}
+	}
+	return daysOff
+}
+
+// easterSunday calcula o domingo de Páscoa do ano (algoritmo de Meeus/Jones/Butcher).
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+}
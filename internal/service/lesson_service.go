@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 	"vigenda/internal/models"
 	"vigenda/internal/repository"
@@ -21,17 +22,15 @@ func NewLessonService(lessonRepo repository.LessonRepository, classRepo reposito
 // Retorna um erro se a turma não for encontrada ou não pertencer ao usuário.
 // UserID 0 é tratado como um superusuário/sistema que tem acesso a todas as turmas.
 func (s *lessonServiceImpl) validateUserOwnsClass(ctx context.Context, userID int64, classID int64) (*models.Class, error) {
-	if userID == 0 { // UserID 0 pode ser um "usuário sistema" ou admin, pula a checagem de propriedade.
-		class, err := s.classRepo.GetClassByID(ctx, classID)
-		if err != nil {
-			return nil, fmt.Errorf("turma com ID %d não encontrada: %w", classID, err)
-		}
-		return class, nil
-	}
-
 	class, err := s.classRepo.GetClassByID(ctx, classID)
 	if err != nil {
-		return nil, fmt.Errorf("turma com ID %d não encontrada: %w", classID, err)
+		if repository.IsNotFound(err) {
+			return nil, &Error{Category: CategoryNotFound, Message: fmt.Sprintf("turma com ID %d não encontrada", classID), Err: err}
+		}
+		return nil, fmt.Errorf("turma com ID %d: %w", classID, err)
+	}
+	if userID == 0 { // UserID 0 pode ser um "usuário sistema" ou admin, pula a checagem de propriedade.
+		return class, nil
 	}
 	if class.UserID != userID {
 		return nil, fmt.Errorf("acesso negado: turma %d não pertence ao usuário %d", classID, userID)
@@ -42,7 +41,7 @@ func (s *lessonServiceImpl) validateUserOwnsClass(ctx context.Context, userID in
 
 func (s *lessonServiceImpl) CreateLesson(ctx context.Context, classID int64, title string, planContent string, scheduledAt time.Time) (models.Lesson, error) {
 	if title == "" {
-		return models.Lesson{}, fmt.Errorf("título da lição não pode ser vazio")
+		return models.Lesson{}, ValidationErrorf("título da lição não pode ser vazio")
 	}
 	// TODO: Obter UserID do contexto ctx quando a autenticação estiver implementada.
 	// Por enquanto, vamos assumir um UserID fixo ou que a validação de propriedade será feita em outro lugar se necessário.
@@ -76,7 +75,7 @@ func (s *lessonServiceImpl) CreateLesson(ctx context.Context, classID int64, tit
 func (s *lessonServiceImpl) GetLessonByID(ctx context.Context, lessonID int64) (models.Lesson, error) {
 	lesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
 	if err != nil {
-		return models.Lesson{}, fmt.Errorf("lessonService.GetLessonByID: %w", err)
+		return models.Lesson{}, lessonLookupError("lessonService.GetLessonByID", lessonID, err)
 	}
 	// TODO: Validar propriedade da lição via turma (UserID)
 	// userID := int64(1) // Placeholder
@@ -113,14 +112,42 @@ func (s *lessonServiceImpl) GetLessonsForDate(ctx context.Context, userID int64,
 	return lessons, nil
 }
 
+// GetLessonsForWeek busca as aulas da semana (segunda a domingo) que contém 'date'.
+func (s *lessonServiceImpl) GetLessonsForWeek(ctx context.Context, userID int64, date time.Time) ([]models.Lesson, error) {
+	startDate := WeekStart(date)
+	endDate := startDate.AddDate(0, 0, 7).Add(-time.Nanosecond)
+
+	lessons, err := s.lessonRepo.GetLessonsByDateRange(ctx, userID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("lessonService.GetLessonsForWeek: %w", err)
+	}
+	return lessons, nil
+}
+
+// WeekStart retorna a segunda-feira, à meia-noite, da semana que contém 'date'.
+func WeekStart(date time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	offset := (int(day.Weekday()) + 6) % 7 // Segunda = 0, ..., Domingo = 6.
+	return day.AddDate(0, 0, -offset)
+}
+
+// lessonLookupError converte a falha ao buscar uma aula em erro de serviço,
+// distinguindo aula inexistente das demais falhas do repositório.
+func lessonLookupError(op string, lessonID int64, err error) error {
+	if repository.IsNotFound(err) {
+		return &Error{Category: CategoryNotFound, Message: fmt.Sprintf("aula com ID %d não encontrada", lessonID), Err: err}
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
 func (s *lessonServiceImpl) UpdateLesson(ctx context.Context, lessonID int64, title string, planContent string, scheduledAt time.Time) (models.Lesson, error) {
 	if title == "" {
-		return models.Lesson{}, fmt.Errorf("título da lição não pode ser vazio")
+		return models.Lesson{}, ValidationErrorf("título da lição não pode ser vazio")
 	}
 
 	existingLesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
 	if err != nil {
-		return models.Lesson{}, fmt.Errorf("lessonService.UpdateLesson: lição não encontrada: %w", err)
+		return models.Lesson{}, lessonLookupError("lessonService.UpdateLesson", lessonID, err)
 	}
 
 	// TODO: Validar propriedade da lição via turma (UserID)
@@ -148,7 +175,7 @@ func (s *lessonServiceImpl) UpdateLesson(ctx context.Context, lessonID int64, ti
 func (s *lessonServiceImpl) DeleteLesson(ctx context.Context, lessonID int64) error {
 	existingLesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
 	if err != nil {
-		return fmt.Errorf("lessonService.DeleteLesson: lição não encontrada: %w", err)
+		return lessonLookupError("lessonService.DeleteLesson", lessonID, err)
 	}
 	// TODO: Validar propriedade da lição via turma (UserID)
 	userID := int64(1) // Placeholder
@@ -161,3 +188,111 @@ func (s *lessonServiceImpl) DeleteLesson(ctx context.Context, lessonID int64) er
 	}
 	return nil
 }
+
+// ParseLessonStatus valida e normaliza a situação de uma aula.
+func ParseLessonStatus(value string) (string, error) {
+	status := strings.ToLower(strings.TrimSpace(value))
+	switch status {
+	case models.LessonStatusPlanned, models.LessonStatusTaught, models.LessonStatusPartial, models.LessonStatusCancelled:
+		return status, nil
+	}
+	return "", ValidationErrorf("situação de aula inválida '%s': use planejada, ministrada, parcial ou cancelada", value)
+}
+
+func (s *lessonServiceImpl) RecordDelivery(ctx context.Context, lessonID int64, status string, coveredContent string, reflection string) (models.Lesson, error) {
+	status, err := ParseLessonStatus(status)
+	if err != nil {
+		return models.Lesson{}, err
+	}
+	coveredContent = strings.TrimSpace(coveredContent)
+	if coveredContent == "" && (status == models.LessonStatusTaught || status == models.LessonStatusPartial) {
+		return models.Lesson{}, ValidationErrorf("informe o conteúdo efetivamente ministrado para registrar a aula como %s", status)
+	}
+
+	lesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
+	if err != nil {
+		return models.Lesson{}, lessonLookupError("lessonService.RecordDelivery", lessonID, err)
+	}
+	lesson.Status = status
+	lesson.CoveredContent = coveredContent
+	lesson.Reflection = strings.TrimSpace(reflection)
+	if err := s.lessonRepo.UpdateLesson(ctx, lesson); err != nil {
+		return models.Lesson{}, fmt.Errorf("lessonService.RecordDelivery: %w", err)
+	}
+	return *lesson, nil
+}
+
+func (s *lessonServiceImpl) CarryOverContent(ctx context.Context, lessonID int64, pendingContent string) (models.Lesson, error) {
+	lesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
+	if err != nil {
+		return models.Lesson{}, lessonLookupError("lessonService.CarryOverContent", lessonID, err)
+	}
+	pendingContent = strings.TrimSpace(pendingContent)
+	switch lesson.Status {
+	case models.LessonStatusPartial:
+		if pendingContent == "" {
+			return models.Lesson{}, ValidationErrorf("informe o conteúdo pendente a levar para a próxima aula")
+		}
+	case models.LessonStatusCancelled:
+		if pendingContent == "" {
+			pendingContent = strings.TrimSpace(lesson.PlanContent)
+		}
+		if pendingContent == "" {
+			return models.Lesson{}, ValidationErrorf("a aula cancelada não tem plano: informe o conteúdo pendente")
+		}
+	default:
+		return models.Lesson{}, ConflictErrorf("só é possível levar conteúdo de aulas parciais ou canceladas; a aula ID %d está %s", lessonID, lesson.Status)
+	}
+
+	lessons, err := s.lessonRepo.GetLessonsByClassID(ctx, lesson.ClassID)
+	if err != nil {
+		return models.Lesson{}, fmt.Errorf("lessonService.CarryOverContent: %w", err)
+	}
+	var next *models.Lesson
+	for i := range lessons {
+		candidate := &lessons[i]
+		if candidate.ID == lesson.ID || !candidate.ScheduledAt.After(lesson.ScheduledAt) || candidate.Status == models.LessonStatusCancelled {
+			continue
+		}
+		if next == nil || candidate.ScheduledAt.Before(next.ScheduledAt) {
+			next = candidate
+		}
+	}
+	if next == nil {
+		return models.Lesson{}, NotFoundErrorf("não há aula da turma depois de %s para receber o conteúdo pendente",
+			lesson.ScheduledAt.Format("02/01/2006 15:04"))
+	}
+
+	section := fmt.Sprintf("## Pendente da aula de %s\n\n%s\n", lesson.ScheduledAt.Format("02/01/2006"), pendingContent)
+	if strings.TrimSpace(next.PlanContent) != "" {
+		section += "\n" + next.PlanContent
+	}
+	next.PlanContent = section
+	if err := s.lessonRepo.UpdateLesson(ctx, next); err != nil {
+		return models.Lesson{}, fmt.Errorf("lessonService.CarryOverContent: %w", err)
+	}
+	return *next, nil
+}
+
+func (s *lessonServiceImpl) GetDeliveredContent(ctx context.Context, classID int64, from, to time.Time) ([]models.Lesson, error) {
+	if _, err := s.validateUserOwnsClass(ctx, 0, classID); err != nil {
+		return nil, err
+	}
+	lessons, err := s.lessonRepo.GetLessonsByClassID(ctx, classID)
+	if err != nil {
+		return nil, fmt.Errorf("lessonService.GetDeliveredContent: %w", err)
+	}
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, to.Location()).AddDate(0, 0, 1)
+	delivered := []models.Lesson{}
+	for _, lesson := range lessons {
+		if lesson.Status != models.LessonStatusTaught && lesson.Status != models.LessonStatusPartial {
+			continue
+		}
+		if lesson.ScheduledAt.Before(first) || !lesson.ScheduledAt.Before(end) {
+			continue
+		}
+		delivered = append(delivered, lesson)
+	}
+	return delivered, nil
+}
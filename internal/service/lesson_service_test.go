@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// rangeLessonRepository registra o intervalo pedido a GetLessonsByDateRange.
+type rangeLessonRepository struct {
+	repository.LessonRepository
+	start, end time.Time
+}
+
+func (r *rangeLessonRepository) GetLessonsByDateRange(ctx context.Context, userID int64, startDate time.Time, endDate time.Time) ([]models.Lesson, error) {
+	r.start, r.end = startDate, endDate
+	return nil, nil
+}
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		day      time.Time
+		expected time.Time
+	}{
+		{time.Date(2025, 6, 18, 15, 30, 0, 0, time.UTC), time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)}, // Quarta.
+		{time.Date(2025, 6, 16, 8, 0, 0, 0, time.UTC), time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)},   // Segunda.
+		{time.Date(2025, 6, 22, 23, 0, 0, 0, time.UTC), time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)},  // Domingo.
+	}
+	for _, tt := range tests {
+		if got := WeekStart(tt.day); !got.Equal(tt.expected) {
+			t.Errorf("WeekStart(%s) = %s, expected %s", tt.day, got, tt.expected)
+		}
+	}
+}
+
+func TestLessonService_GetLessonsForWeek(t *testing.T) {
+	repo := &rangeLessonRepository{}
+	lessonService := NewLessonService(repo, nil)
+
+	if _, err := lessonService.GetLessonsForWeek(context.Background(), 1, time.Date(2025, 6, 18, 10, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !repo.start.Equal(time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected week to start on Monday 16/06, got %s", repo.start)
+	}
+	if !repo.end.Before(time.Date(2025, 6, 23, 0, 0, 0, 0, time.UTC)) || repo.end.Before(time.Date(2025, 6, 22, 23, 59, 0, 0, time.UTC)) {
+		t.Errorf("Expected week to end at the end of Sunday 22/06, got %s", repo.end)
+	}
+}
+
+// memoryLessonRepository guarda as aulas de uma turma em memória.
+type memoryLessonRepository struct {
+	repository.LessonRepository
+	lessons map[int64]*models.Lesson
+}
+
+func newMemoryLessonRepository(lessons ...models.Lesson) *memoryLessonRepository {
+	repo := &memoryLessonRepository{lessons: make(map[int64]*models.Lesson)}
+	for i := range lessons {
+		lesson := lessons[i]
+		repo.lessons[lesson.ID] = &lesson
+	}
+	return repo
+}
+
+func (r *memoryLessonRepository) GetLessonByID(ctx context.Context, lessonID int64) (*models.Lesson, error) {
+	lesson, ok := r.lessons[lessonID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *lesson
+	return &copied, nil
+}
+
+func (r *memoryLessonRepository) GetLessonsByClassID(ctx context.Context, classID int64) ([]models.Lesson, error) {
+	var lessons []models.Lesson
+	for _, lesson := range r.lessons {
+		if lesson.ClassID == classID {
+			lessons = append(lessons, *lesson)
+		}
+	}
+	sort.Slice(lessons, func(i, j int) bool { return lessons[i].ScheduledAt.Before(lessons[j].ScheduledAt) })
+	return lessons, nil
+}
+
+func (r *memoryLessonRepository) UpdateLesson(ctx context.Context, lesson *models.Lesson) error {
+	copied := *lesson
+	r.lessons[lesson.ID] = &copied
+	return nil
+}
+
+func deliveryTestLessons() []models.Lesson {
+	return []models.Lesson{
+		{ID: 1, ClassID: 1, Title: "Frações", ScheduledAt: time.Date(2025, 6, 16, 7, 0, 0, 0, time.UTC), PlanContent: "Frações equivalentes", Status: models.LessonStatusPlanned},
+		{ID: 2, ClassID: 1, Title: "Cancelada", ScheduledAt: time.Date(2025, 6, 17, 7, 0, 0, 0, time.UTC), Status: models.LessonStatusCancelled},
+		{ID: 3, ClassID: 1, Title: "Soma de frações", ScheduledAt: time.Date(2025, 6, 18, 7, 0, 0, 0, time.UTC), PlanContent: "- Exercícios", Status: models.LessonStatusPlanned},
+		{ID: 4, ClassID: 2, Title: "Outra turma", ScheduledAt: time.Date(2025, 6, 16, 9, 0, 0, 0, time.UTC), Status: models.LessonStatusTaught, CoveredContent: "Geometria"},
+	}
+}
+
+func TestLessonService_RecordDelivery(t *testing.T) {
+	repo := newMemoryLessonRepository(deliveryTestLessons()...)
+	lessonService := NewLessonService(repo, nil)
+	ctx := context.Background()
+
+	if _, err := lessonService.RecordDelivery(ctx, 1, "ministrada", "  ", ""); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error without covered content, got %v", err)
+	}
+	if _, err := lessonService.RecordDelivery(ctx, 1, "dada", "Frações", ""); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for unknown status, got %v", err)
+	}
+	if _, err := lessonService.RecordDelivery(ctx, 99, "cancelada", "", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found error for unknown lesson, got %v", err)
+	}
+
+	lesson, err := lessonService.RecordDelivery(ctx, 1, " Parcial ", "Frações equivalentes ", "Faltou tempo")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if lesson.Status != models.LessonStatusPartial || lesson.CoveredContent != "Frações equivalentes" || lesson.Reflection != "Faltou tempo" {
+		t.Errorf("Unexpected recorded lesson: %+v", lesson)
+	}
+	if stored := repo.lessons[1]; stored.Status != models.LessonStatusPartial || stored.PlanContent != "Frações equivalentes" {
+		t.Errorf("Expected delivery to be stored keeping the plan, got %+v", stored)
+	}
+}
+
+func TestLessonService_CarryOverContent(t *testing.T) {
+	repo := newMemoryLessonRepository(deliveryTestLessons()...)
+	lessonService := NewLessonService(repo, nil)
+	ctx := context.Background()
+
+	if _, err := lessonService.CarryOverContent(ctx, 1, "Simplificação"); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected conflict for a planned lesson, got %v", err)
+	}
+	if _, err := lessonService.RecordDelivery(ctx, 1, "parcial", "Frações equivalentes", ""); err != nil {
+		t.Fatalf("RecordDelivery: %v", err)
+	}
+	if _, err := lessonService.CarryOverContent(ctx, 1, ""); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for empty pending content, got %v", err)
+	}
+
+	// A aula 2 está cancelada: o conteúdo vai para a aula 3.
+	next, err := lessonService.CarryOverContent(ctx, 1, "Simplificação de frações")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if next.ID != 3 {
+		t.Fatalf("Expected pending content in lesson 3, got lesson %d", next.ID)
+	}
+	expected := "## Pendente da aula de 16/06/2025\n\nSimplificação de frações\n\n- Exercícios"
+	if next.PlanContent != expected || repo.lessons[3].PlanContent != expected {
+		t.Errorf("Unexpected plan:\n%q\nexpected:\n%q", next.PlanContent, expected)
+	}
+
+	// Não há aula da turma depois da aula 3.
+	if _, err := lessonService.RecordDelivery(ctx, 3, "cancelada", "", ""); err != nil {
+		t.Fatalf("RecordDelivery: %v", err)
+	}
+	if _, err := lessonService.CarryOverContent(ctx, 3, ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found error without a next lesson, got %v", err)
+	}
+}
+
+func TestLessonService_GetDeliveredContent(t *testing.T) {
+	repo := newMemoryLessonRepository(deliveryTestLessons()...)
+	classRepo := &namedClassRepository{}
+	lessonService := NewLessonService(repo, classRepo)
+	ctx := context.Background()
+
+	if _, err := lessonService.RecordDelivery(ctx, 3, "ministrada", "Soma de frações", ""); err != nil {
+		t.Fatalf("RecordDelivery: %v", err)
+	}
+	if _, err := lessonService.RecordDelivery(ctx, 1, "parcial", "Frações equivalentes", ""); err != nil {
+		t.Fatalf("RecordDelivery: %v", err)
+	}
+
+	lessons, err := lessonService.GetDeliveredContent(ctx, 1, time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 18, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(lessons) != 2 || lessons[0].ID != 1 || lessons[1].ID != 3 {
+		t.Errorf("Expected lessons 1 and 3 in date order, got %+v", lessons)
+	}
+
+	lessons, err = lessonService.GetDeliveredContent(ctx, 1, time.Date(2025, 6, 17, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 17, 0, 0, 0, 0, time.UTC))
+	if err != nil || len(lessons) != 0 {
+		t.Errorf("Expected no delivered lessons on 17/06, got %v (err %v)", lessons, err)
+	}
+}
@@ -0,0 +1,263 @@
+// Package service contém as implementações concretas das interfaces de serviço.
+// Este arquivo específico implementa a interface LessonTemplateService (modelos de plano e sequências didáticas).
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// DefaultLessonPlanTemplate é o plano usado por modelos criados sem conteúdo.
+const DefaultLessonPlanTemplate = `# Objetivos
+-
+
+# Habilidades (BNCC)
+-
+
+# Metodologia
+1.
+
+# Recursos
+-
+
+# Avaliação
+-
+`
+
+// Identificação do arquivo de compartilhamento de sequências.
+const (
+	sequenceFileFormat  = "vigenda.sequencia"
+	sequenceFileVersion = 1
+)
+
+// sequenceFile é o conteúdo do arquivo JSON gerado por ExportSequence.
+type sequenceFile struct {
+	Format      string             `json:"formato"`
+	Version     int                `json:"versao"`
+	Name        string             `json:"nome"`
+	Description string             `json:"descricao,omitempty"`
+	Lessons     []sequenceFileItem `json:"aulas"`
+}
+
+// sequenceFileItem é um plano de aula no arquivo de sequência.
+type sequenceFileItem struct {
+	Title string `json:"titulo"`
+	Plan  string `json:"plano"`
+}
+
+// lessonTemplateServiceImpl é a implementação concreta de LessonTemplateService.
+type lessonTemplateServiceImpl struct {
+	templateRepo repository.LessonTemplateRepository
+	lessonRepo   repository.LessonRepository
+	classRepo    repository.ClassRepository
+}
+
+// NewLessonTemplateService cria uma nova instância de LessonTemplateService.
+func NewLessonTemplateService(templateRepo repository.LessonTemplateRepository, lessonRepo repository.LessonRepository, classRepo repository.ClassRepository) LessonTemplateService {
+	return &lessonTemplateServiceImpl{templateRepo: templateRepo, lessonRepo: lessonRepo, classRepo: classRepo}
+}
+
+func (s *lessonTemplateServiceImpl) CreateTemplate(ctx context.Context, name, content string) (models.LessonTemplate, error) {
+	template := models.LessonTemplate{Name: strings.TrimSpace(name), Content: content}
+	if template.Name == "" {
+		return models.LessonTemplate{}, ValidationErrorf("o nome do modelo não pode ser vazio")
+	}
+	if strings.TrimSpace(template.Content) == "" {
+		template.Content = DefaultLessonPlanTemplate
+	}
+	id, err := s.templateRepo.CreateTemplate(ctx, &template)
+	if err != nil {
+		if repository.IsUniqueViolation(err) {
+			return models.LessonTemplate{}, ConflictErrorf("já existe um modelo chamado '%s'", template.Name)
+		}
+		return models.LessonTemplate{}, fmt.Errorf("lessonTemplateService.CreateTemplate: %w", err)
+	}
+	template.ID = id
+	return template, nil
+}
+
+func (s *lessonTemplateServiceImpl) GetTemplate(ctx context.Context, templateID int64) (models.LessonTemplate, error) {
+	template, err := s.templateRepo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return models.LessonTemplate{}, templateLookupError("lessonTemplateService.GetTemplate", fmt.Sprintf("modelo com ID %d não encontrado", templateID), err)
+	}
+	return *template, nil
+}
+
+func (s *lessonTemplateServiceImpl) ListTemplates(ctx context.Context) ([]models.LessonTemplate, error) {
+	templates, err := s.templateRepo.ListTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lessonTemplateService.ListTemplates: %w", err)
+	}
+	if templates == nil {
+		templates = []models.LessonTemplate{}
+	}
+	return templates, nil
+}
+
+func (s *lessonTemplateServiceImpl) DeleteTemplate(ctx context.Context, templateID int64) error {
+	if err := s.templateRepo.DeleteTemplate(ctx, templateID); err != nil {
+		return templateLookupError("lessonTemplateService.DeleteTemplate", fmt.Sprintf("modelo com ID %d não encontrado", templateID), err)
+	}
+	return nil
+}
+
+func (s *lessonTemplateServiceImpl) CreateSequence(ctx context.Context, name, description string, items []models.LessonSequenceItem) (models.LessonSequence, error) {
+	sequence := models.LessonSequence{Name: strings.TrimSpace(name), Description: strings.TrimSpace(description)}
+	if sequence.Name == "" {
+		return models.LessonSequence{}, ValidationErrorf("o nome da sequência não pode ser vazio")
+	}
+	if len(items) == 0 {
+		return models.LessonSequence{}, ValidationErrorf("a sequência '%s' precisa de ao menos uma aula", sequence.Name)
+	}
+	for i, item := range items {
+		item.Title = strings.TrimSpace(item.Title)
+		if item.Title == "" {
+			return models.LessonSequence{}, ValidationErrorf("a aula %d da sequência não tem título", i+1)
+		}
+		item.Position = i + 1
+		sequence.Items = append(sequence.Items, item)
+	}
+
+	id, err := s.templateRepo.CreateSequence(ctx, &sequence)
+	if err != nil {
+		if repository.IsUniqueViolation(err) {
+			return models.LessonSequence{}, ConflictErrorf("já existe uma sequência chamada '%s'", sequence.Name)
+		}
+		return models.LessonSequence{}, fmt.Errorf("lessonTemplateService.CreateSequence: %w", err)
+	}
+	sequence.ID = id
+	for i := range sequence.Items {
+		sequence.Items[i].SequenceID = id
+	}
+	return sequence, nil
+}
+
+func (s *lessonTemplateServiceImpl) GetSequence(ctx context.Context, sequenceID int64) (models.LessonSequence, error) {
+	sequence, err := s.templateRepo.GetSequenceByID(ctx, sequenceID)
+	if err != nil {
+		return models.LessonSequence{}, templateLookupError("lessonTemplateService.GetSequence", fmt.Sprintf("sequência com ID %d não encontrada", sequenceID), err)
+	}
+	return *sequence, nil
+}
+
+func (s *lessonTemplateServiceImpl) ListSequences(ctx context.Context) ([]models.LessonSequence, error) {
+	sequences, err := s.templateRepo.ListSequences(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lessonTemplateService.ListSequences: %w", err)
+	}
+	if sequences == nil {
+		sequences = []models.LessonSequence{}
+	}
+	return sequences, nil
+}
+
+func (s *lessonTemplateServiceImpl) DeleteSequence(ctx context.Context, sequenceID int64) error {
+	if err := s.templateRepo.DeleteSequence(ctx, sequenceID); err != nil {
+		return templateLookupError("lessonTemplateService.DeleteSequence", fmt.Sprintf("sequência com ID %d não encontrada", sequenceID), err)
+	}
+	return nil
+}
+
+func (s *lessonTemplateServiceImpl) ApplySequence(ctx context.Context, sequenceID, classID int64, from time.Time, replace bool) ([]models.Lesson, error) {
+	sequence, err := s.GetSequence(ctx, sequenceID)
+	if err != nil {
+		return nil, err
+	}
+	class, err := s.classRepo.GetClassByID(ctx, classID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, &Error{Category: CategoryNotFound, Message: fmt.Sprintf("turma com ID %d não encontrada", classID), Err: err}
+		}
+		return nil, fmt.Errorf("lessonTemplateService.ApplySequence: %w", err)
+	}
+	lessons, err := s.lessonRepo.GetLessonsByClassID(ctx, classID)
+	if err != nil {
+		return nil, fmt.Errorf("lessonTemplateService.ApplySequence: %w", err)
+	}
+
+	// Só recebem a sequência as aulas ainda planejadas, a partir do dia de 'from'.
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	var targets []models.Lesson
+	for _, lesson := range lessons {
+		if lesson.ScheduledAt.Before(first) || (lesson.Status != "" && lesson.Status != models.LessonStatusPlanned) {
+			continue
+		}
+		targets = append(targets, lesson)
+	}
+	sort.SliceStable(targets, func(i, j int) bool { return targets[i].ScheduledAt.Before(targets[j].ScheduledAt) })
+	if len(targets) < len(sequence.Items) {
+		return nil, ConflictErrorf("a sequência '%s' tem %d aulas, mas a turma %s só tem %d aulas planejadas a partir de %s: cadastre ou gere mais aulas antes de aplicá-la",
+			sequence.Name, len(sequence.Items), class.Name, len(targets), first.Format("02/01/2006"))
+	}
+	targets = targets[:len(sequence.Items)]
+	if !replace {
+		for _, lesson := range targets {
+			if strings.TrimSpace(lesson.PlanContent) != "" {
+				return nil, ConflictErrorf("a aula '%s' de %s já tem plano de aula: confirme a substituição para aplicar a sequência",
+					lesson.Title, lesson.ScheduledAt.Format("02/01/2006 15:04"))
+			}
+		}
+	}
+
+	for i := range targets {
+		targets[i].Title = sequence.Items[i].Title
+		targets[i].PlanContent = sequence.Items[i].PlanContent
+		if err := s.lessonRepo.UpdateLesson(ctx, &targets[i]); err != nil {
+			return nil, fmt.Errorf("lessonTemplateService.ApplySequence: aula ID %d: %w", targets[i].ID, err)
+		}
+	}
+	return targets, nil
+}
+
+func (s *lessonTemplateServiceImpl) ExportSequence(ctx context.Context, sequenceID int64) ([]byte, error) {
+	sequence, err := s.GetSequence(ctx, sequenceID)
+	if err != nil {
+		return nil, err
+	}
+	file := sequenceFile{Format: sequenceFileFormat, Version: sequenceFileVersion, Name: sequence.Name, Description: sequence.Description}
+	for _, item := range sequence.Items {
+		file.Lessons = append(file.Lessons, sequenceFileItem{Title: item.Title, Plan: item.PlanContent})
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("lessonTemplateService.ExportSequence: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+func (s *lessonTemplateServiceImpl) ImportSequence(ctx context.Context, data []byte, name string) (models.LessonSequence, error) {
+	var file sequenceFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return models.LessonSequence{}, &Error{Category: CategoryValidation, Message: "arquivo de sequência inválido: o conteúdo não é JSON", Err: err}
+	}
+	if file.Format != sequenceFileFormat {
+		return models.LessonSequence{}, ValidationErrorf("arquivo de sequência inválido: formato '%s' (esperado '%s')", file.Format, sequenceFileFormat)
+	}
+	if file.Version > sequenceFileVersion {
+		return models.LessonSequence{}, ValidationErrorf("arquivo de sequência na versão %d: atualize o Vigenda para importá-lo", file.Version)
+	}
+	if strings.TrimSpace(name) == "" {
+		name = file.Name
+	}
+	items := make([]models.LessonSequenceItem, 0, len(file.Lessons))
+	for _, lesson := range file.Lessons {
+		items = append(items, models.LessonSequenceItem{Title: lesson.Title, PlanContent: lesson.Plan})
+	}
+	return s.CreateSequence(ctx, name, file.Description, items)
+}
+
+// templateLookupError converte a ausência de um modelo ou sequência em erro de registro não
+// encontrado com a mensagem 'notFound'.
+func templateLookupError(op, notFound string, err error) error {
+	if repository.IsNotFound(err) {
+		return &Error{Category: CategoryNotFound, Message: notFound, Err: err}
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// fakeLessonTemplateRepository guarda modelos e sequências em memória.
+type fakeLessonTemplateRepository struct {
+	repository.LessonTemplateRepository
+	templates []models.LessonTemplate
+	sequences []models.LessonSequence
+}
+
+func (f *fakeLessonTemplateRepository) CreateTemplate(ctx context.Context, template *models.LessonTemplate) (int64, error) {
+	template.ID = int64(len(f.templates) + 1)
+	f.templates = append(f.templates, *template)
+	return template.ID, nil
+}
+
+func (f *fakeLessonTemplateRepository) CreateSequence(ctx context.Context, sequence *models.LessonSequence) (int64, error) {
+	sequence.ID = int64(len(f.sequences) + 1)
+	f.sequences = append(f.sequences, *sequence)
+	return sequence.ID, nil
+}
+
+func (f *fakeLessonTemplateRepository) GetSequenceByID(ctx context.Context, sequenceID int64) (*models.LessonSequence, error) {
+	for _, sequence := range f.sequences {
+		if sequence.ID == sequenceID {
+			return &sequence, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func TestLessonTemplateService_CreateTemplateUsesDefaultPlan(t *testing.T) {
+	templateService := NewLessonTemplateService(&fakeLessonTemplateRepository{}, nil, nil)
+
+	if _, err := templateService.CreateTemplate(context.Background(), " ", ""); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for empty name, got %v", err)
+	}
+	template, err := templateService.CreateTemplate(context.Background(), " Aula expositiva ", "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if template.Name != "Aula expositiva" || template.Content != DefaultLessonPlanTemplate {
+		t.Errorf("Unexpected template: %+v", template)
+	}
+	for _, section := range []string{"# Objetivos", "# Habilidades", "# Metodologia", "# Recursos", "# Avaliação"} {
+		if !strings.Contains(template.Content, section) {
+			t.Errorf("Expected default plan to contain %q", section)
+		}
+	}
+}
+
+func TestLessonTemplateService_ApplySequence(t *testing.T) {
+	ctx := context.Background()
+	day := func(d int) time.Time { return time.Date(2025, 5, d, 7, 0, 0, 0, time.UTC) }
+	lessons := newMemoryLessonRepository(
+		models.Lesson{ID: 1, ClassID: 1, Title: "Aula - Turma 1", ScheduledAt: day(5), Status: models.LessonStatusPlanned},
+		models.Lesson{ID: 2, ClassID: 1, Title: "Aula - Turma 1", ScheduledAt: day(7), Status: models.LessonStatusTaught},
+		models.Lesson{ID: 3, ClassID: 1, Title: "Aula - Turma 1", ScheduledAt: day(9), Status: models.LessonStatusPlanned, PlanContent: "Revisão"},
+		models.Lesson{ID: 4, ClassID: 1, Title: "Aula - Turma 1", ScheduledAt: day(12), Status: models.LessonStatusPlanned},
+		models.Lesson{ID: 5, ClassID: 2, Title: "Aula - Turma 2", ScheduledAt: day(6), Status: models.LessonStatusPlanned},
+	)
+	templateService := NewLessonTemplateService(&fakeLessonTemplateRepository{}, lessons, &namedClassRepository{})
+
+	sequence, err := templateService.CreateSequence(ctx, "Frações", "6º ano", []models.LessonSequenceItem{
+		{Title: "Frações equivalentes", PlanContent: "# Objetivos\n- Comparar"},
+		{Title: "Soma de frações", PlanContent: "# Objetivos\n- Somar"},
+	})
+	if err != nil {
+		t.Fatalf("CreateSequence: %v", err)
+	}
+
+	// A aula 3 já tem plano: sem substituir, nada é alterado.
+	if _, err := templateService.ApplySequence(ctx, sequence.ID, 1, day(5), false); !errors.Is(err, ErrConflict) {
+		t.Fatalf("Expected conflict for a lesson with a plan, got %v", err)
+	}
+	if lessons.lessons[1].Title != "Aula - Turma 1" {
+		t.Errorf("Expected no lesson changed after a conflict, got %+v", lessons.lessons[1])
+	}
+
+	// Só há duas aulas planejadas a partir de 08/05.
+	if _, err := templateService.ApplySequence(ctx, sequence.ID, 1, day(10), false); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected conflict when the class has too few lessons, got %v", err)
+	}
+
+	// A aula 2 (ministrada) é pulada; com substituição, as aulas 1 e 3 recebem a sequência.
+	applied, err := templateService.ApplySequence(ctx, sequence.ID, 1, day(5), true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(applied) != 2 || applied[0].ID != 1 || applied[1].ID != 3 {
+		t.Fatalf("Expected lessons 1 and 3, got %+v", applied)
+	}
+	if got := lessons.lessons[3]; got.Title != "Soma de frações" || got.PlanContent != "# Objetivos\n- Somar" {
+		t.Errorf("Unexpected lesson 3 after applying: %+v", got)
+	}
+	if got := lessons.lessons[4]; got.Title != "Aula - Turma 1" {
+		t.Errorf("Expected lesson 4 untouched, got %+v", got)
+	}
+
+	if _, err := templateService.ApplySequence(ctx, 99, 1, day(5), true); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found for unknown sequence, got %v", err)
+	}
+}
+
+func TestLessonTemplateService_ExportImportSequence(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeLessonTemplateRepository{}
+	templateService := NewLessonTemplateService(repo, nil, nil)
+
+	original, err := templateService.CreateSequence(ctx, "Frações", "6º ano", []models.LessonSequenceItem{
+		{Title: "Frações equivalentes", PlanContent: "# Objetivos\n- Comparar"},
+		{Title: "Soma de frações", PlanContent: "- Somar"},
+	})
+	if err != nil {
+		t.Fatalf("CreateSequence: %v", err)
+	}
+	data, err := templateService.ExportSequence(ctx, original.ID)
+	if err != nil {
+		t.Fatalf("ExportSequence: %v", err)
+	}
+	if !strings.Contains(string(data), `"formato": "vigenda.sequencia"`) {
+		t.Errorf("Expected format marker in exported file, got:\n%s", data)
+	}
+
+	imported, err := templateService.ImportSequence(ctx, data, "Frações (cópia)")
+	if err != nil {
+		t.Fatalf("ImportSequence: %v", err)
+	}
+	if imported.Name != "Frações (cópia)" || imported.Description != "6º ano" || len(imported.Items) != 2 {
+		t.Fatalf("Unexpected imported sequence: %+v", imported)
+	}
+	for i, item := range imported.Items {
+		if item.Position != i+1 || item.Title != original.Items[i].Title || item.PlanContent != original.Items[i].PlanContent {
+			t.Errorf("Item %d differs: %+v vs %+v", i, item, original.Items[i])
+		}
+	}
+
+	if _, err := templateService.ImportSequence(ctx, []byte(`{"formato":"outro","aulas":[]}`), ""); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for unknown format, got %v", err)
+	}
+	if _, err := templateService.ImportSequence(ctx, []byte("não é json"), ""); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for invalid JSON, got %v", err)
+	}
+}
@@ -0,0 +1,322 @@
+// Package service contém as implementações concretas das interfaces de serviço.
+// Este arquivo específico implementa a interface PlanningService (planejamento diário).
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+const (
+	// DefaultDayStart e DefaultDayEnd delimitam a janela de trabalho usada quando o professor não informa outra.
+	DefaultDayStart = "07:00"
+	DefaultDayEnd   = "18:00"
+	// DefaultLessonDuration é a duração assumida para uma aula (uma hora-aula).
+	DefaultLessonDuration = 50 * time.Minute
+	// DefaultTaskEstimateMinutes é a duração assumida para tarefas sem estimativa.
+	DefaultTaskEstimateMinutes = 30
+)
+
+// TimeSlot representa um intervalo de horário [Start, End).
+type TimeSlot struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Minutes retorna a duração do intervalo em minutos inteiros.
+func (s TimeSlot) Minutes() int {
+	return int(s.End.Sub(s.Start) / time.Minute)
+}
+
+// TaskSuggestion é uma tarefa pendente candidata a entrar no plano do dia.
+type TaskSuggestion struct {
+	Task            models.Task
+	Minutes         int       // Minutes é a duração considerada (estimativa da tarefa ou DefaultTaskEstimateMinutes).
+	DefaultEstimate bool      // DefaultEstimate indica que a tarefa não tem estimativa própria.
+	Slot            *TimeSlot // Slot é o horário proposto para a tarefa; nil se ela não cabe nos horários livres.
+}
+
+// DayPlanProposal reúne o que o professor precisa para montar o plano do dia:
+// as aulas (blocos fixos), os horários livres e as tarefas sugeridas em ordem de preferência.
+type DayPlanProposal struct {
+	Window      TimeSlot
+	Lessons     []models.PlanBlock
+	FreeSlots   []TimeSlot
+	Suggestions []TaskSuggestion
+}
+
+// NewDayWindow monta a janela de trabalho do dia 'day' a partir de horários no formato HH:MM.
+func NewDayWindow(day time.Time, start, end string) (TimeSlot, error) {
+	parse := func(label, value string) (time.Time, error) {
+		clock, err := time.Parse("15:04", strings.TrimSpace(value))
+		if err != nil {
+			return time.Time{}, ValidationErrorf("horário de %s inválido '%s': use o formato HH:MM", label, value)
+		}
+		return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, day.Location()), nil
+	}
+	startAt, err := parse("início", start)
+	if err != nil {
+		return TimeSlot{}, err
+	}
+	endAt, err := parse("fim", end)
+	if err != nil {
+		return TimeSlot{}, err
+	}
+	if !endAt.After(startAt) {
+		return TimeSlot{}, ValidationErrorf("o fim do dia (%s) deve ser depois do início (%s)", end, start)
+	}
+	return TimeSlot{Start: startAt, End: endAt}, nil
+}
+
+// AllocateTasks distribui as sugestões, na ordem recebida, nos horários livres: cada tarefa ocupa o início
+// do primeiro intervalo livre em que cabe inteira. Retorna uma cópia das sugestões com Slot preenchido
+// (ou nil para as que não couberam). 'free' não é modificado.
+func AllocateTasks(free []TimeSlot, suggestions []TaskSuggestion) []TaskSuggestion {
+	remaining := make([]TimeSlot, len(free))
+	copy(remaining, free)
+
+	allocated := make([]TaskSuggestion, len(suggestions))
+	for i, suggestion := range suggestions {
+		suggestion.Slot = nil
+		duration := time.Duration(suggestion.Minutes) * time.Minute
+		for j := range remaining {
+			if remaining[j].End.Sub(remaining[j].Start) >= duration {
+				slot := TimeSlot{Start: remaining[j].Start, End: remaining[j].Start.Add(duration)}
+				suggestion.Slot = &slot
+				remaining[j].Start = slot.End
+				break
+			}
+		}
+		allocated[i] = suggestion
+	}
+	return allocated
+}
+
+// planningServiceImpl é a implementação concreta de PlanningService.
+type planningServiceImpl struct {
+	taskRepo      repository.TaskRepository
+	lessonService LessonService
+	planRepo      repository.PlanRepository
+}
+
+// NewPlanningService cria uma nova instância de PlanningService.
+// As aulas do dia vêm do LessonService; as tarefas pendentes, do TaskRepository.
+func NewPlanningService(taskRepo repository.TaskRepository, lessonService LessonService, planRepo repository.PlanRepository) PlanningService {
+	return &planningServiceImpl{taskRepo: taskRepo, lessonService: lessonService, planRepo: planRepo}
+}
+
+// ProposeDayPlan calcula os horários livres da janela (descontando as aulas do dia e, se a janela
+// for de hoje, o tempo que já passou) e ordena as tarefas pendentes por prazo, prioridade e estimativa,
+// indicando onde cada uma caberia se todas fossem aceitas nessa ordem.
+func (s *planningServiceImpl) ProposeDayPlan(ctx context.Context, window TimeSlot) (DayPlanProposal, error) {
+	// TODO: Obter UserID do contexto quando a autenticação estiver implementada.
+	userID := int64(1)
+
+	lessons, err := s.lessonService.GetLessonsForDate(ctx, userID, window.Start)
+	if err != nil {
+		return DayPlanProposal{}, fmt.Errorf("planningService.ProposeDayPlan: %w", err)
+	}
+	lessonBlocks := lessonPlanBlocks(userID, window.Start, lessons)
+
+	effective := window
+	if now := timeNow().In(window.Start.Location()); now.After(effective.Start) {
+		effective.Start = now.Truncate(5 * time.Minute)
+		if effective.Start.Before(now) {
+			effective.Start = effective.Start.Add(5 * time.Minute)
+		}
+	}
+	free := freeSlots(effective, lessonBlocks)
+
+	tasks, err := s.taskRepo.GetAllTasks(ctx)
+	if err != nil {
+		return DayPlanProposal{}, fmt.Errorf("planningService.ProposeDayPlan: %w", err)
+	}
+	var suggestions []TaskSuggestion
+	for _, task := range tasks {
+		// Tarefas de sistema (UserID 0) e tarefas aguardando terceiros não entram no plano.
+		if task.IsCompleted || task.UserID == 0 || task.Status == models.TaskStatusWaiting {
+			continue
+		}
+		suggestion := TaskSuggestion{Task: task, Minutes: DefaultTaskEstimateMinutes, DefaultEstimate: true}
+		if task.EstimatedMinutes != nil && *task.EstimatedMinutes > 0 {
+			suggestion.Minutes = *task.EstimatedMinutes
+			suggestion.DefaultEstimate = false
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	sortSuggestions(suggestions)
+
+	return DayPlanProposal{
+		Window:      window,
+		Lessons:     lessonBlocks,
+		FreeSlots:   free,
+		Suggestions: AllocateTasks(free, suggestions),
+	}, nil
+}
+
+// CommitDayPlan grava o plano do dia com as aulas e as tarefas escolhidas pelo professor.
+// As tarefas são encaixadas na ordem de sugestão; se alguma não couber, nada é gravado.
+func (s *planningServiceImpl) CommitDayPlan(ctx context.Context, window TimeSlot, taskIDs []int64) ([]models.PlanBlock, error) {
+	proposal, err := s.ProposeDayPlan(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+
+	chosen := make(map[int64]bool, len(taskIDs))
+	for _, id := range taskIDs {
+		chosen[id] = true
+	}
+	var selected []TaskSuggestion
+	for _, suggestion := range proposal.Suggestions {
+		if chosen[suggestion.Task.ID] {
+			selected = append(selected, suggestion)
+			delete(chosen, suggestion.Task.ID)
+		}
+	}
+	if len(chosen) > 0 {
+		var missing []string
+		for _, id := range taskIDs {
+			if chosen[id] {
+				missing = append(missing, fmt.Sprintf("%d", id))
+			}
+		}
+		return nil, NotFoundErrorf("tarefas não encontradas entre as pendentes: %s", strings.Join(missing, ", "))
+	}
+
+	userID := int64(1)
+	planDate := time.Date(window.Start.Year(), window.Start.Month(), window.Start.Day(), 0, 0, 0, 0, window.Start.Location())
+	blocks := append([]models.PlanBlock{}, proposal.Lessons...)
+	for _, suggestion := range AllocateTasks(proposal.FreeSlots, selected) {
+		if suggestion.Slot == nil {
+			return nil, ConflictErrorf("a tarefa '%s' (%d min) não cabe nos horários livres do dia", suggestion.Task.Title, suggestion.Minutes)
+		}
+		taskID := suggestion.Task.ID
+		blocks = append(blocks, models.PlanBlock{
+			UserID:   userID,
+			PlanDate: planDate,
+			StartAt:  suggestion.Slot.Start,
+			EndAt:    suggestion.Slot.End,
+			Kind:     models.PlanBlockKindTask,
+			TaskID:   &taskID,
+			Title:    suggestion.Task.Title,
+		})
+	}
+	sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].StartAt.Before(blocks[j].StartAt) })
+
+	if err := s.planRepo.ReplaceDayPlan(ctx, userID, window.Start, blocks); err != nil {
+		return nil, fmt.Errorf("planningService.CommitDayPlan: %w", err)
+	}
+	return blocks, nil
+}
+
+// GetDayPlan retorna o plano gravado para o dia 'day' (vazio se o dia ainda não foi planejado).
+func (s *planningServiceImpl) GetDayPlan(ctx context.Context, day time.Time) ([]models.PlanBlock, error) {
+	blocks, err := s.planRepo.GetDayPlan(ctx, 1, day)
+	if err != nil {
+		return nil, fmt.Errorf("planningService.GetDayPlan: %w", err)
+	}
+	return blocks, nil
+}
+
+// CurrentAndNextBlocks retorna o bloco em andamento no instante 'at' e o próximo bloco do dia.
+// Qualquer um dos dois é nil quando não existe.
+func (s *planningServiceImpl) CurrentAndNextBlocks(ctx context.Context, at time.Time) (*models.PlanBlock, *models.PlanBlock, error) {
+	blocks, err := s.GetDayPlan(ctx, at)
+	if err != nil {
+		return nil, nil, err
+	}
+	var current, next *models.PlanBlock
+	for i := range blocks {
+		block := &blocks[i]
+		if current == nil && !at.Before(block.StartAt) && at.Before(block.EndAt) {
+			current = block
+			continue
+		}
+		if block.StartAt.After(at) {
+			next = block
+			break
+		}
+	}
+	return current, next, nil
+}
+
+// lessonPlanBlocks converte as aulas do dia em blocos fixos de DefaultLessonDuration.
+// Aulas canceladas não ocupam a agenda.
+func lessonPlanBlocks(userID int64, day time.Time, lessons []models.Lesson) []models.PlanBlock {
+	planDate := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	blocks := make([]models.PlanBlock, 0, len(lessons))
+	for _, lesson := range lessons {
+		if lesson.Status == models.LessonStatusCancelled {
+			continue
+		}
+		lessonID := lesson.ID
+		start := lesson.ScheduledAt.In(day.Location())
+		blocks = append(blocks, models.PlanBlock{
+			UserID:   userID,
+			PlanDate: planDate,
+			StartAt:  start,
+			EndAt:    start.Add(DefaultLessonDuration),
+			Kind:     models.PlanBlockKindLesson,
+			LessonID: &lessonID,
+			Title:    lesson.Title,
+		})
+	}
+	sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].StartAt.Before(blocks[j].StartAt) })
+	return blocks
+}
+
+// freeSlots subtrai os blocos ocupados (ordenados por início) da janela e devolve os intervalos livres.
+func freeSlots(window TimeSlot, busy []models.PlanBlock) []TimeSlot {
+	var free []TimeSlot
+	cursor := window.Start
+	for _, block := range busy {
+		if !block.EndAt.After(cursor) {
+			continue
+		}
+		if block.StartAt.After(cursor) {
+			end := block.StartAt
+			if end.After(window.End) {
+				end = window.End
+			}
+			if end.After(cursor) {
+				free = append(free, TimeSlot{Start: cursor, End: end})
+			}
+		}
+		cursor = block.EndAt
+		if !cursor.Before(window.End) {
+			return free
+		}
+	}
+	if window.End.After(cursor) {
+		free = append(free, TimeSlot{Start: cursor, End: window.End})
+	}
+	return free
+}
+
+// sortSuggestions ordena as tarefas candidatas: prazo mais próximo primeiro (sem prazo por último),
+// depois maior prioridade, depois menor estimativa.
+func sortSuggestions(suggestions []TaskSuggestion) {
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		switch {
+		case a.Task.DueDate == nil && b.Task.DueDate != nil:
+			return false
+		case a.Task.DueDate != nil && b.Task.DueDate == nil:
+			return true
+		case a.Task.DueDate != nil && !a.Task.DueDate.Equal(*b.Task.DueDate):
+			return a.Task.DueDate.Before(*b.Task.DueDate)
+		}
+		if a.Task.Priority != b.Task.Priority {
+			return a.Task.Priority > b.Task.Priority
+		}
+		if a.Minutes != b.Minutes {
+			return a.Minutes < b.Minutes
+		}
+		return a.Task.ID < b.Task.ID
+	})
+}
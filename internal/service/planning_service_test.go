@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"vigenda/internal/models"
+)
+
+// fakeLessonService devolve uma lista fixa de aulas para GetLessonsForDate.
+type fakeLessonService struct {
+	LessonService
+	lessons []models.Lesson
+}
+
+func (f *fakeLessonService) GetLessonsForDate(ctx context.Context, userID int64, date time.Time) ([]models.Lesson, error) {
+	return f.lessons, nil
+}
+
+// fakePlanRepository guarda em memória o último plano gravado.
+type fakePlanRepository struct {
+	saved []models.PlanBlock
+}
+
+func (f *fakePlanRepository) ReplaceDayPlan(ctx context.Context, userID int64, day time.Time, blocks []models.PlanBlock) error {
+	f.saved = blocks
+	return nil
+}
+
+func (f *fakePlanRepository) GetDayPlan(ctx context.Context, userID int64, day time.Time) ([]models.PlanBlock, error) {
+	return f.saved, nil
+}
+
+func intPtr(v int) *int { return &v }
+
+func newPlanningFixture(t *testing.T) (PlanningService, *fakePlanRepository, TimeSlot) {
+	t.Helper()
+	day := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+	originalTimeNow := timeNow
+	timeNow = func() time.Time { return day.Add(6 * time.Hour) } // Antes do início da janela.
+	t.Cleanup(func() { timeNow = originalTimeNow })
+
+	dueSoon := day.Add(24 * time.Hour)
+	dueLater := day.Add(72 * time.Hour)
+	taskRepo := &MockTaskRepository{
+		GetAllTasksFunc: func(ctx context.Context) ([]models.Task, error) {
+			return []models.Task{
+				{ID: 1, UserID: 1, Title: "Sem prazo", Priority: models.TaskPriorityHigh, EstimatedMinutes: intPtr(20)},
+				{ID: 2, UserID: 1, Title: "Prazo distante", DueDate: &dueLater, Priority: models.TaskPriorityHigh, EstimatedMinutes: intPtr(60)},
+				{ID: 3, UserID: 1, Title: "Prazo próximo, baixa", DueDate: &dueSoon, Priority: models.TaskPriorityLow, EstimatedMinutes: intPtr(30)},
+				{ID: 4, UserID: 1, Title: "Prazo próximo, alta", DueDate: &dueSoon, Priority: models.TaskPriorityHigh, EstimatedMinutes: intPtr(90)},
+				{ID: 5, UserID: 1, Title: "Prazo próximo, alta e curta", DueDate: &dueSoon, Priority: models.TaskPriorityHigh},
+				{ID: 6, UserID: 1, Title: "Concluída", IsCompleted: true},
+				{ID: 7, UserID: 0, Title: "Bug de sistema"},
+				{ID: 8, UserID: 1, Title: "Aguardando", Status: models.TaskStatusWaiting},
+			}, nil
+		},
+	}
+	lessons := &fakeLessonService{lessons: []models.Lesson{
+		{ID: 10, Title: "Frações - 9A", ScheduledAt: day.Add(8 * time.Hour)},
+	}}
+	planRepo := &fakePlanRepository{}
+
+	window, err := NewDayWindow(day, "07:00", "10:00")
+	if err != nil {
+		t.Fatalf("NewDayWindow: %v", err)
+	}
+	return NewPlanningService(taskRepo, lessons, planRepo), planRepo, window
+}
+
+func TestPlanningService_ProposeDayPlan(t *testing.T) {
+	planning, _, window := newPlanningFixture(t)
+
+	proposal, err := planning.ProposeDayPlan(context.Background(), window)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// A aula das 08:00 (50 min) divide a janela 07:00-10:00 em 07:00-08:00 e 08:50-10:00.
+	if len(proposal.FreeSlots) != 2 || proposal.FreeSlots[0].Minutes() != 60 || proposal.FreeSlots[1].Minutes() != 70 {
+		t.Fatalf("Unexpected free slots: %+v", proposal.FreeSlots)
+	}
+
+	var order []int64
+	for _, s := range proposal.Suggestions {
+		order = append(order, s.Task.ID)
+	}
+	expected := []int64{5, 4, 3, 2, 1} // Prazo, depois prioridade, depois estimativa; sem prazo por último.
+	if len(order) != len(expected) {
+		t.Fatalf("Expected suggestions %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("Expected suggestions %v, got %v", expected, order)
+		}
+	}
+
+	first := proposal.Suggestions[0]
+	if !first.DefaultEstimate || first.Minutes != DefaultTaskEstimateMinutes {
+		t.Errorf("Expected task without estimate to use the default, got %+v", first)
+	}
+	if first.Slot == nil || first.Slot.Start.Hour() != 7 || first.Slot.Start.Minute() != 0 {
+		t.Errorf("Expected first suggestion at 07:00, got %+v", first.Slot)
+	}
+	// A tarefa de 90 min não cabe em nenhum intervalo livre.
+	if proposal.Suggestions[1].Slot != nil {
+		t.Errorf("Expected 90-minute task not to fit, got slot %+v", proposal.Suggestions[1].Slot)
+	}
+}
+
+func TestPlanningService_CommitDayPlanAndCurrentAndNext(t *testing.T) {
+	planning, planRepo, window := newPlanningFixture(t)
+	ctx := context.Background()
+
+	blocks, err := planning.CommitDayPlan(ctx, window, []int64{1, 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(blocks) != 3 || len(planRepo.saved) != 3 {
+		t.Fatalf("Expected lesson + 2 tasks, got %+v", blocks)
+	}
+	// Tarefa 3 (prazo mais próximo) vem antes da 1, depois a aula.
+	if blocks[0].TaskID == nil || *blocks[0].TaskID != 3 || blocks[1].TaskID == nil || *blocks[1].TaskID != 1 {
+		t.Fatalf("Unexpected block order: %+v", blocks)
+	}
+	if blocks[2].Kind != models.PlanBlockKindLesson {
+		t.Fatalf("Expected lesson as last block, got %+v", blocks[2])
+	}
+
+	current, next, err := planning.CurrentAndNextBlocks(ctx, blocks[0].StartAt.Add(10*time.Minute))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if current == nil || *current.TaskID != 3 || next == nil || *next.TaskID != 1 {
+		t.Errorf("Unexpected current/next: %+v / %+v", current, next)
+	}
+
+	t.Run("task that does not fit is rejected", func(t *testing.T) {
+		_, err := planning.CommitDayPlan(ctx, window, []int64{4})
+		if err == nil || !strings.Contains(err.Error(), "não cabe") {
+			t.Errorf("Expected 'não cabe' error, got %v", err)
+		}
+	})
+
+	t.Run("unknown task is rejected", func(t *testing.T) {
+		_, err := planning.CommitDayPlan(ctx, window, []int64{6})
+		if err == nil || !strings.Contains(err.Error(), "não encontradas") {
+			t.Errorf("Expected 'não encontradas' error, got %v", err)
+		}
+	})
+}
+
+func TestNewDayWindow_Invalid(t *testing.T) {
+	day := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+	if _, err := NewDayWindow(day, "18:00", "07:00"); err == nil {
+		t.Error("Expected error when end is before start")
+	}
+	if _, err := NewDayWindow(day, "7h", "18:00"); err == nil {
+		t.Error("Expected error for malformed start")
+	}
+}
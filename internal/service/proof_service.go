@@ -26,13 +26,13 @@ func NewProofService(qr repository.QuestionRepository) ProofService {
 // tópico (opcional) e distribui a quantidade de questões por dificuldade.
 func (s *proofServiceImpl) GenerateProof(ctx context.Context, criteria ProofCriteria) ([]models.Question, error) {
 	if criteria.SubjectID == 0 {
-		return nil, fmt.Errorf("SubjectID não pode ser zero")
+		return nil, ValidationErrorf("SubjectID não pode ser zero")
 	}
 	if criteria.EasyCount < 0 || criteria.MediumCount < 0 || criteria.HardCount < 0 {
-		return nil, fmt.Errorf("contagem de questões não pode ser negativa")
+		return nil, ValidationErrorf("contagem de questões não pode ser negativa")
 	}
 	if criteria.EasyCount == 0 && criteria.MediumCount == 0 && criteria.HardCount == 0 {
-		return nil, fmt.Errorf("pelo menos uma contagem de dificuldade deve ser maior que zero")
+		return nil, ValidationErrorf("pelo menos uma contagem de dificuldade deve ser maior que zero")
 	}
 
 	// Converter service.ProofCriteria para repository.ProofCriteria
@@ -75,13 +75,13 @@ func (s *proofServiceImpl) GenerateProof(ctx context.Context, criteria ProofCrit
 		}
 
 		if counts["facil"] < criteria.EasyCount {
-			return nil, fmt.Errorf("não há questões fáceis suficientes (solicitado: %d, disponível: %d)", criteria.EasyCount, counts["facil"])
+			return nil, NotFoundErrorf("não há questões fáceis suficientes (solicitado: %d, disponível: %d)", criteria.EasyCount, counts["facil"])
 		}
 		if counts["media"] < criteria.MediumCount {
-			return nil, fmt.Errorf("não há questões médias suficientes (solicitado: %d, disponível: %d)", criteria.MediumCount, counts["media"])
+			return nil, NotFoundErrorf("não há questões médias suficientes (solicitado: %d, disponível: %d)", criteria.MediumCount, counts["media"])
 		}
 		if counts["dificil"] < criteria.HardCount {
-			return nil, fmt.Errorf("não há questões difíceis suficientes (solicitado: %d, disponível: %d)", criteria.HardCount, counts["dificil"])
+			return nil, NotFoundErrorf("não há questões difíceis suficientes (solicitado: %d, disponível: %d)", criteria.HardCount, counts["dificil"])
 		}
 	}
 
@@ -44,7 +44,7 @@ func (s *questionServiceImpl) AddQuestionsFromJSON(ctx context.Context, jsonData
 	}
 
 	if len(questions) == 0 {
-		return 0, fmt.Errorf("nenhuma questão fornecida no JSON")
+		return 0, ValidationErrorf("nenhuma questão fornecida no JSON")
 	}
 
 	addedCount := 0
@@ -53,19 +53,19 @@ func (s *questionServiceImpl) AddQuestionsFromJSON(ctx context.Context, jsonData
 	for i, qJSON := range questions {
 		// Validação básica dos campos da questão do JSON
 		if qJSON.SubjectName == "" {
-			return addedCount, fmt.Errorf("questão %d: 'disciplina' é obrigatório no JSON", i)
+			return addedCount, ValidationErrorf("questão %d: 'disciplina' é obrigatório no JSON", i)
 		}
 		if qJSON.Statement == "" {
-			return addedCount, fmt.Errorf("questão %d: 'enunciado' é obrigatório no JSON", i)
+			return addedCount, ValidationErrorf("questão %d: 'enunciado' é obrigatório no JSON", i)
 		}
 		if qJSON.Difficulty == "" {
-			return addedCount, fmt.Errorf("questão %d: 'dificuldade' é obrigatório no JSON", i)
+			return addedCount, ValidationErrorf("questão %d: 'dificuldade' é obrigatório no JSON", i)
 		}
 		if qJSON.Type == "" {
-			return addedCount, fmt.Errorf("questão %d: 'tipo' é obrigatório no JSON", i)
+			return addedCount, ValidationErrorf("questão %d: 'tipo' é obrigatório no JSON", i)
 		}
 		if qJSON.CorrectAnswer == "" {
-			return addedCount, fmt.Errorf("questão %d: 'resposta_correta' é obrigatório no JSON", i)
+			return addedCount, ValidationErrorf("questão %d: 'resposta_correta' é obrigatório no JSON", i)
 		}
 
 		// TODO: Obter SubjectID a partir de SubjectName e UserID.
@@ -123,7 +123,7 @@ func (s *questionServiceImpl) AddQuestionsFromJSON(ctx context.Context, jsonData
 		var optionsStr *string
 		if qJSON.Type == "multipla_escolha" {
 			if qJSON.Options == nil {
-				return addedCount, fmt.Errorf("questão %d: 'opcoes' é obrigatório para tipo 'multipla_escolha'", i)
+				return addedCount, ValidationErrorf("questão %d: 'opcoes' é obrigatório para tipo 'multipla_escolha'", i)
 			}
 			optionsBytes, err := json.Marshal(qJSON.Options)
 			if err != nil {
@@ -131,7 +131,7 @@ func (s *questionServiceImpl) AddQuestionsFromJSON(ctx context.Context, jsonData
 			}
 			s := string(optionsBytes)
 			if s == "null" || s == "[]" || s == "" { // "null" se qJSON.Options era nil e foi serializado
-				return addedCount, fmt.Errorf("questão %d: 'opcoes' não pode ser vazio para tipo 'multipla_escolha'", i)
+				return addedCount, ValidationErrorf("questão %d: 'opcoes' não pode ser vazio para tipo 'multipla_escolha'", i)
 			}
 			optionsStr = &s
 		}
@@ -188,7 +188,7 @@ func (s *questionServiceImpl) GenerateTest(ctx context.Context, criteria TestCri
 	var testQuestions []models.Question
 
 	if criteria.EasyCount == 0 && criteria.MediumCount == 0 && criteria.HardCount == 0 {
-		return nil, fmt.Errorf("pelo menos uma contagem de dificuldade deve ser maior que zero para GenerateTest")
+		return nil, ValidationErrorf("pelo menos uma contagem de dificuldade deve ser maior que zero para GenerateTest")
 	}
 
 	// Buscar questões fáceis
@@ -203,7 +203,7 @@ func (s *questionServiceImpl) GenerateTest(ctx context.Context, criteria TestCri
 			return nil, fmt.Errorf("GenerateTest: erro ao buscar questões fáceis: %w", err)
 		}
 		if len(easyQuestions) < criteria.EasyCount {
-			return nil, fmt.Errorf("GenerateTest: não há questões fáceis suficientes (solicitado: %d, disponível: %d)", criteria.EasyCount, len(easyQuestions))
+			return nil, NotFoundErrorf("GenerateTest: não há questões fáceis suficientes (solicitado: %d, disponível: %d)", criteria.EasyCount, len(easyQuestions))
 		}
 		testQuestions = append(testQuestions, easyQuestions...)
 	}
@@ -220,7 +220,7 @@ func (s *questionServiceImpl) GenerateTest(ctx context.Context, criteria TestCri
 			return nil, fmt.Errorf("GenerateTest: erro ao buscar questões médias: %w", err)
 		}
 		if len(mediumQuestions) < criteria.MediumCount {
-			return nil, fmt.Errorf("GenerateTest: não há questões médias suficientes (solicitado: %d, disponível: %d)", criteria.MediumCount, len(mediumQuestions))
+			return nil, NotFoundErrorf("GenerateTest: não há questões médias suficientes (solicitado: %d, disponível: %d)", criteria.MediumCount, len(mediumQuestions))
 		}
 		testQuestions = append(testQuestions, mediumQuestions...)
 	}
@@ -237,7 +237,7 @@ func (s *questionServiceImpl) GenerateTest(ctx context.Context, criteria TestCri
 			return nil, fmt.Errorf("GenerateTest: erro ao buscar questões difíceis: %w", err)
 		}
 		if len(hardQuestions) < criteria.HardCount {
-			return nil, fmt.Errorf("GenerateTest: não há questões difíceis suficientes (solicitado: %d, disponível: %d)", criteria.HardCount, len(hardQuestions))
+			return nil, NotFoundErrorf("GenerateTest: não há questões difíceis suficientes (solicitado: %d, disponível: %d)", criteria.HardCount, len(hardQuestions))
 		}
 		testQuestions = append(testQuestions, hardQuestions...)
 	}
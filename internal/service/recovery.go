@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// RecoveryRules são as regras de recuperação aceitas, na ordem em que são apresentadas.
+var RecoveryRules = []string{
+	models.RecoveryRuleReplaceAverage,
+	models.RecoveryRuleReplaceLowest,
+	models.RecoveryRuleAverage,
+}
+
+// RecoveryRuleLabel descreve uma regra de recuperação para exibição.
+func RecoveryRuleLabel(rule string) string {
+	switch rule {
+	case models.RecoveryRuleReplaceAverage:
+		return "a recuperação substitui a média, se for maior"
+	case models.RecoveryRuleReplaceLowest:
+		return "a recuperação substitui a menor nota, se for maior"
+	case models.RecoveryRuleAverage:
+		return "a média passa a ser a média com a recuperação, se for maior"
+	}
+	return rule
+}
+
+// DefaultPassingGrade é a média de aprovação padrão de uma escala: 60% da nota máxima.
+func DefaultPassingGrade(scale string) float64 {
+	return GradeScaleMax(scale) * 0.6
+}
+
+// IsRecovery informa se a avaliação é uma recuperação.
+func IsRecovery(assessment models.Assessment) bool {
+	return assessment.Kind == models.AssessmentKindRecovery
+}
+
+// isRegularAssessment informa se a avaliação entra diretamente nas médias (nem a "Nota Final" nem
+// as recuperações entram).
+func isRegularAssessment(assessment models.Assessment) bool {
+	return assessment.Name != FinalGradeAssessmentName && !IsRecovery(assessment)
+}
+
+// StudentAverage é a média de um aluno antes e depois de aplicadas as recuperações, já arredondadas.
+type StudentAverage struct {
+	Original  float64 `json:"original_average"`
+	Adjusted  float64 `json:"average"`
+	Recovered bool    `json:"recovered"` // Se alguma recuperação alterou as notas do aluno.
+}
+
+// RecoveryStudent é um aluno abaixo da média de aprovação nas avaliações recuperadas por uma
+// recuperação, com a média dessas avaliações e a nota da recuperação, se já lançada.
+type RecoveryStudent struct {
+	Student models.Student `json:"student"`
+	Average float64        `json:"average"`
+	Grade   *float64       `json:"recovery_grade,omitempty"`
+}
+
+// recoveryScope retorna as avaliações regulares recuperadas pela recuperação: as ligadas a ela ou,
+// se não houver nenhuma, todas as do seu bimestre.
+func recoveryScope(recovery models.Assessment, assessments []models.Assessment) []models.Assessment {
+	linked := make(map[int64]bool)
+	for _, id := range recovery.RecoveryOf {
+		linked[id] = true
+	}
+	var scope []models.Assessment
+	for _, a := range assessments {
+		if !isRegularAssessment(a) || a.ClassID != recovery.ClassID {
+			continue
+		}
+		if (len(linked) > 0 && linked[a.ID]) || (len(linked) == 0 && a.Term == recovery.Term) {
+			scope = append(scope, a)
+		}
+	}
+	return scope
+}
+
+// splitScopeGrades separa as notas do aluno nas avaliações recuperadas das demais. Um aluno sem
+// nenhuma nota nas avaliações recuperadas fica com zero em todas elas (média 0).
+func splitScopeGrades(scope []models.Assessment, grades []studentGrade) (in, out []studentGrade) {
+	inScope := make(map[int64]bool)
+	for _, a := range scope {
+		inScope[a.ID] = true
+	}
+	for _, g := range grades {
+		if inScope[g.assessment.ID] {
+			in = append(in, g)
+		} else {
+			out = append(out, g)
+		}
+	}
+	if len(in) == 0 {
+		for _, a := range scope {
+			in = append(in, studentGrade{assessment: a})
+		}
+	}
+	return in, out
+}
+
+// recoveryAverage é a média do aluno nas avaliações recuperadas, segundo o esquema da política.
+func recoveryAverage(policy models.GradingPolicy, scope []models.Assessment, grades []studentGrade) float64 {
+	in, _ := splitScopeGrades(scope, grades)
+	average, _ := policyAverage(policy, in)
+	return RoundGrade(average, policy.RoundingStep)
+}
+
+// applyRecovery aplica a nota da recuperação às notas do aluno segundo a regra da política. changed
+// é falso se a regra não melhora a média do aluno: a recuperação nunca reduz a média.
+func applyRecovery(policy models.GradingPolicy, scope []models.Assessment, grades []studentGrade, recoveryGrade float64) (updated []studentGrade, changed bool) {
+	in, out := splitScopeGrades(scope, grades)
+	average, _ := policyAverage(policy, in)
+	switch policy.RecoveryRule {
+	case models.RecoveryRuleReplaceLowest:
+		lowest := 0
+		for i := range in {
+			if in[i].grade < in[lowest].grade {
+				lowest = i
+			}
+		}
+		if recoveryGrade <= in[lowest].grade {
+			return grades, false
+		}
+		in[lowest].grade = recoveryGrade
+	default:
+		target := recoveryGrade
+		if policy.RecoveryRule == models.RecoveryRuleAverage {
+			target = (average + recoveryGrade) / 2
+		}
+		if target <= average {
+			return grades, false
+		}
+		// Com todas as notas recuperadas iguais, a média delas é a nova média em qualquer esquema.
+		for i := range in {
+			in[i].grade = target
+		}
+	}
+	return append(out, in...), true
+}
+
+func (s *assessmentServiceImpl) CreateRecoveryAssessment(ctx context.Context, name string, classID int64, term int, recovered []int64, date *time.Time) (models.Assessment, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return models.Assessment{}, ValidationErrorf("nome da avaliação não pode ser vazio")
+	}
+	if classID == 0 {
+		return models.Assessment{}, ValidationErrorf("ID da turma não pode ser zero")
+	}
+
+	var recoveryOf []int64
+	seen := make(map[int64]bool)
+	terms := make(map[int]bool)
+	for _, id := range recovered {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		assessment, err := s.assessmentRepo.GetAssessmentByID(ctx, id)
+		if err != nil {
+			if repository.IsNotFound(err) {
+				return models.Assessment{}, NotFoundErrorf("avaliação com ID %d não encontrada", id)
+			}
+			return models.Assessment{}, fmt.Errorf("service.CreateRecoveryAssessment: %w", err)
+		}
+		if assessment.ClassID != classID {
+			return models.Assessment{}, ValidationErrorf("a avaliação '%s' (ID %d) não é da turma da recuperação", assessment.Name, id)
+		}
+		if !isRegularAssessment(*assessment) {
+			return models.Assessment{}, ValidationErrorf("a avaliação '%s' (ID %d) não pode ser recuperada", assessment.Name, id)
+		}
+		recoveryOf = append(recoveryOf, id)
+		terms[assessment.Term] = true
+	}
+	if term == 0 && len(terms) == 1 {
+		for t := range terms {
+			term = t
+		}
+	}
+	if term <= 0 {
+		return models.Assessment{}, ValidationErrorf("informe o bimestre da recuperação")
+	}
+
+	// A recuperação não tem peso próprio: a sua nota só altera as notas que ela recupera.
+	assessment := models.Assessment{
+		ClassID:        classID,
+		Name:           name,
+		Term:           term,
+		AssessmentDate: date,
+		Kind:           models.AssessmentKindRecovery,
+		RecoveryOf:     recoveryOf,
+	}
+	id, err := s.assessmentRepo.CreateAssessment(ctx, &assessment)
+	if err != nil {
+		return models.Assessment{}, fmt.Errorf("service.CreateRecoveryAssessment: %w", err)
+	}
+	assessment.ID = id
+	return assessment, nil
+}
+
+func (s *assessmentServiceImpl) GetRecoveryStudents(ctx context.Context, recoveryID int64) ([]RecoveryStudent, error) {
+	if recoveryID == 0 {
+		return nil, ValidationErrorf("ID da avaliação não pode ser zero")
+	}
+	recovery, err := s.assessmentRepo.GetAssessmentByID(ctx, recoveryID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, NotFoundErrorf("avaliação com ID %d não encontrada", recoveryID)
+		}
+		return nil, fmt.Errorf("service.GetRecoveryStudents: %w", err)
+	}
+	if !IsRecovery(*recovery) {
+		return nil, ValidationErrorf("a avaliação '%s' (ID %d) não é uma recuperação", recovery.Name, recoveryID)
+	}
+	policy, err := s.GetGradingPolicy(ctx, recovery.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	grades, assessments, students, err := s.assessmentRepo.GetGradesByClassID(ctx, recovery.ClassID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetRecoveryStudents: fetching data: %w", err)
+	}
+	scope := recoveryScope(*recovery, assessments)
+	if len(scope) == 0 {
+		return nil, ValidationErrorf("a recuperação '%s' (ID %d) não tem avaliações a recuperar", recovery.Name, recoveryID)
+	}
+
+	byID := make(map[int64]models.Assessment)
+	for _, a := range assessments {
+		byID[a.ID] = a
+	}
+	studentGrades := make(map[int64][]studentGrade)
+	recoveryGrades := make(map[int64]float64)
+	for _, g := range grades {
+		if g.AssessmentID == recoveryID {
+			recoveryGrades[g.StudentID] = g.Grade
+		} else if a, ok := byID[g.AssessmentID]; ok && isRegularAssessment(a) {
+			studentGrades[g.StudentID] = append(studentGrades[g.StudentID], studentGrade{assessment: a, grade: g.Grade})
+		}
+	}
+
+	var eligible []RecoveryStudent
+	for _, student := range students {
+		if student.Status != "ativo" {
+			continue
+		}
+		average := recoveryAverage(policy, scope, studentGrades[student.ID])
+		if average >= policy.PassingGrade {
+			continue
+		}
+		entry := RecoveryStudent{Student: student, Average: average}
+		if grade, ok := recoveryGrades[student.ID]; ok {
+			entry.Grade = &grade
+		}
+		eligible = append(eligible, entry)
+	}
+	sort.SliceStable(eligible, func(i, j int) bool { return eligible[i].Student.FullName < eligible[j].Student.FullName })
+	return eligible, nil
+}
+
+// validateRecoveryStudents verifica se todos os alunos com nota na recuperação estão abaixo da média
+// de aprovação nas avaliações recuperadas.
+func (s *assessmentServiceImpl) validateRecoveryStudents(ctx context.Context, recovery models.Assessment, studentGrades map[int64]float64) error {
+	eligible, err := s.GetRecoveryStudents(ctx, recovery.ID)
+	if err != nil {
+		return err
+	}
+	allowed := make(map[int64]bool)
+	for _, e := range eligible {
+		allowed[e.Student.ID] = true
+	}
+	var rejected []string
+	for studentID := range studentGrades {
+		if !allowed[studentID] {
+			rejected = append(rejected, fmt.Sprintf("%d", studentID))
+		}
+	}
+	if len(rejected) > 0 {
+		sort.Strings(rejected)
+		return ValidationErrorf("a recuperação '%s' é só para os alunos abaixo da média de aprovação; não se aplica ao(s) aluno(s) ID %s",
+			recovery.Name, strings.Join(rejected, ", "))
+	}
+	return nil
+}
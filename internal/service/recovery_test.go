@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+)
+
+// newRecoveryService monta o boletim de newGradebookService com a recuperação do 1º bimestre (ID 6),
+// em que Bruno (média 5 no bimestre) tirou 8 e Carla (sem notas) tirou 5, sob a regra 'rule'.
+func newRecoveryService(t *testing.T, rule string) (AssessmentService, *gradebookRepository) {
+	t.Helper()
+	repo := newGradebookRepository()
+	policies := &memoryPolicyRepository{policies: map[int64]models.GradingPolicy{}}
+	assessmentService := NewAssessmentService(repo, &namedClassRepository{}, nil, policies)
+	_, err := assessmentService.SetGradingPolicy(context.Background(), models.GradingPolicy{ClassID: 1, RecoveryRule: rule})
+	require.NoError(t, err)
+
+	recovery, err := assessmentService.CreateRecoveryAssessment(context.Background(), "Recuperação 1º bimestre", 1, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), recovery.ID)
+	require.NoError(t, assessmentService.EnterGrades(context.Background(), recovery.ID, map[int64]float64{2: 8, 3: 5}))
+	return assessmentService, repo
+}
+
+func TestCalculateClassAverages_RecoveryRules(t *testing.T) {
+	tests := []struct {
+		rule         string
+		bruno, carla float64
+	}{
+		// Bruno: P1 5 → 8 (substitui a média e a menor nota) ou 6,5 (média entre 5 e 8).
+		// Carla, sem notas no bimestre (zero em P1 e T1): P1 e T1 valem 5; só P1 (a primeira das
+		// menores) vale 5; P1 e T1 valem 2,5. Sem notas no 2º bimestre, a média é a do 1º.
+		{rule: models.RecoveryRuleReplaceAverage, bruno: (16.0 + 14) / 4, carla: 5},
+		{rule: models.RecoveryRuleReplaceLowest, bruno: (16.0 + 14) / 4, carla: 10.0 / 3},
+		{rule: models.RecoveryRuleAverage, bruno: (13.0 + 14) / 4, carla: 2.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			assessmentService, _ := newRecoveryService(t, tt.rule)
+			averages, err := assessmentService.CalculateClassAverages(context.Background(), 1, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, StudentAverage{Original: 47.0 / 6, Adjusted: 47.0 / 6}, averages[1], "Ana não fez recuperação")
+			assert.InDelta(t, 6, averages[2].Original, 1e-9)
+			assert.InDelta(t, tt.bruno, averages[2].Adjusted, 1e-9)
+			assert.True(t, averages[2].Recovered)
+			assert.Equal(t, 0.0, averages[3].Original)
+			assert.InDelta(t, tt.carla, averages[3].Adjusted, 1e-9)
+			assert.True(t, averages[3].Recovered)
+
+			plain, err := assessmentService.CalculateClassAverage(context.Background(), 1, nil)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.bruno, plain[2], 1e-9, "CalculateClassAverage já aplica a recuperação")
+
+			// A recuperação do 1º bimestre não entra na média só do 2º.
+			secondTerm, err := assessmentService.CalculateClassAverages(context.Background(), 1, []int{2})
+			require.NoError(t, err)
+			assert.False(t, secondTerm[2].Recovered)
+		})
+	}
+}
+
+func TestCalculateClassAverages_RecoveryNeverLowers(t *testing.T) {
+	assessmentService, _ := newRecoveryService(t, models.RecoveryRuleReplaceAverage)
+	require.NoError(t, assessmentService.EnterGrades(context.Background(), 6, map[int64]float64{2: 4}))
+
+	averages, err := assessmentService.CalculateClassAverages(context.Background(), 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, StudentAverage{Original: 6, Adjusted: 6}, averages[2])
+}
+
+func TestRecoveryStudents(t *testing.T) {
+	ctx := context.Background()
+	assessmentService, _ := newRecoveryService(t, models.RecoveryRuleReplaceAverage)
+
+	students, err := assessmentService.GetRecoveryStudents(ctx, 6)
+	require.NoError(t, err)
+	require.Len(t, students, 2, "Ana (8,7 no bimestre) e Davi (transferido) ficam de fora")
+	assert.Equal(t, "Bruno", students[0].Student.FullName)
+	assert.Equal(t, 5.0, students[0].Average)
+	require.NotNil(t, students[0].Grade)
+	assert.Equal(t, 8.0, *students[0].Grade)
+	assert.Equal(t, "Carla", students[1].Student.FullName)
+
+	err = assessmentService.EnterGrades(ctx, 6, map[int64]float64{1: 10})
+	assert.True(t, errors.Is(err, ErrValidation), "Ana não está abaixo da média: %v", err)
+
+	// Recuperação só da P2 (2º bimestre): Ana tem 6, que já é a média de aprovação, e Bruno tem 7.
+	recovery, err := assessmentService.CreateRecoveryAssessment(ctx, "Recuperação da P2", 1, 0, []int64{3, 3}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, recovery.Term, "o bimestre vem das avaliações recuperadas")
+	assert.Equal(t, []int64{3}, recovery.RecoveryOf)
+	students, err = assessmentService.GetRecoveryStudents(ctx, recovery.ID)
+	require.NoError(t, err)
+	require.Len(t, students, 1)
+	assert.Equal(t, "Carla", students[0].Student.FullName)
+
+	for _, invalid := range [][]int64{{2, 3}, {5}, {6}} {
+		_, err := assessmentService.CreateRecoveryAssessment(ctx, "Recuperação", 1, 0, invalid, nil)
+		assert.True(t, errors.Is(err, ErrValidation), "%v: %v", invalid, err)
+	}
+	_, err = assessmentService.CreateRecoveryAssessment(ctx, "Recuperação", 1, 0, []int64{99}, nil)
+	assert.True(t, errors.Is(err, ErrNotFound), "%v", err)
+	_, err = assessmentService.GetRecoveryStudents(ctx, 1)
+	assert.True(t, errors.Is(err, ErrValidation), "a P1 não é uma recuperação: %v", err)
+}
+
+func TestGradingPolicy_PassingGradeAndRecoveryRule(t *testing.T) {
+	ctx := context.Background()
+	assessmentService, _ := newGradebookService()
+	for _, invalid := range []models.GradingPolicy{
+		{ClassID: 1, PassingGrade: 11},
+		{ClassID: 1, PassingGrade: -1},
+		{ClassID: 1, RecoveryRule: "ignora"},
+	} {
+		_, err := assessmentService.SetGradingPolicy(ctx, invalid)
+		assert.True(t, errors.Is(err, ErrValidation), "%+v: %v", invalid, err)
+	}
+
+	policy, err := assessmentService.SetGradingPolicy(ctx, models.GradingPolicy{ClassID: 1, PassingGrade: 5, RecoveryRule: " Media "})
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, policy.PassingGrade)
+	assert.Equal(t, models.RecoveryRuleAverage, policy.RecoveryRule)
+	assert.Equal(t, 60.0, DefaultPassingGrade(models.GradeScaleHundred))
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// Resultado final do aluno no boletim.
+const (
+	ReportCardPassed = "aprovado"
+	ReportCardFailed = "reprovado"
+)
+
+// maxReportCardComment é o tamanho máximo, em caracteres, do comentário do boletim.
+const maxReportCardComment = 1000
+
+// ReportCardResultLabel retorna o nome do resultado para exibição ("Aprovado(a)"), ou "" se o
+// aluno ainda não tem resultado.
+func ReportCardResultLabel(result string) string {
+	switch result {
+	case ReportCardPassed:
+		return "Aprovado(a)"
+	case ReportCardFailed:
+		return "Reprovado(a)"
+	}
+	return ""
+}
+
+// ReportCard é o boletim de um aluno: as notas de cada avaliação por bimestre, as médias dos
+// bimestres e do ano (com as recuperações aplicadas), a nota final, o resultado e o comentário
+// do(a) professor(a).
+type ReportCard struct {
+	Student    models.Student       `json:"student"`
+	Policy     models.GradingPolicy `json:"policy"`
+	Terms      []ReportCardTerm     `json:"terms"`
+	Average    *float64             `json:"average,omitempty"`     // Média anual; nula para alunos inativos ou sem notas.
+	FinalGrade *float64             `json:"final_grade,omitempty"` // Nota final lançada ("Nota Final"), se houver.
+	Result     string               `json:"result,omitempty"`      // ReportCardPassed, ReportCardFailed ou "" se não houver média.
+	Comment    string               `json:"comment,omitempty"`
+}
+
+// ReportCardTerm são as notas e a média de um bimestre no boletim.
+type ReportCardTerm struct {
+	Term    int               `json:"term"`
+	Grades  []ReportCardGrade `json:"grades"`
+	Average *float64          `json:"average,omitempty"`
+}
+
+// ReportCardGrade é a nota do aluno em uma avaliação; Grade é nula se não foi lançada.
+type ReportCardGrade struct {
+	Assessment models.Assessment `json:"assessment"`
+	Grade      *float64          `json:"grade,omitempty"`
+}
+
+// GetReportCards monta os boletins dos alunos ativos de uma turma, em ordem de matrícula.
+func (s *assessmentServiceImpl) GetReportCards(ctx context.Context, classID int64) ([]ReportCard, error) {
+	cards, err := s.reportCards(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	active := cards[:0]
+	for _, card := range cards {
+		if card.Student.Status == "ativo" {
+			active = append(active, card)
+		}
+	}
+	return active, nil
+}
+
+// GetStudentReportCard monta o boletim de um aluno, de qualquer situação. Alunos que não estão
+// ativos não têm médias nem resultado.
+func (s *assessmentServiceImpl) GetStudentReportCard(ctx context.Context, studentID int64) (ReportCard, error) {
+	student, err := s.reportCardStudent(ctx, studentID)
+	if err != nil {
+		return ReportCard{}, err
+	}
+	cards, err := s.reportCards(ctx, student.ClassID)
+	if err != nil {
+		return ReportCard{}, err
+	}
+	for _, card := range cards {
+		if card.Student.ID == studentID {
+			return card, nil
+		}
+	}
+	return ReportCard{}, NotFoundErrorf("aluno com ID %d não encontrado na turma %d", studentID, student.ClassID)
+}
+
+// SetReportCardComment grava o comentário do(a) professor(a) no boletim do aluno; um comentário
+// vazio o remove.
+func (s *assessmentServiceImpl) SetReportCardComment(ctx context.Context, studentID int64, comment string) error {
+	if _, err := s.reportCardStudent(ctx, studentID); err != nil {
+		return err
+	}
+	comment = strings.TrimSpace(comment)
+	if utf8.RuneCountInString(comment) > maxReportCardComment {
+		return ValidationErrorf("comentário do boletim muito longo: use até %d caracteres", maxReportCardComment)
+	}
+	if err := s.assessmentRepo.SaveReportCardComment(ctx, studentID, comment); err != nil {
+		return fmt.Errorf("service.SetReportCardComment: %w", err)
+	}
+	return nil
+}
+
+func (s *assessmentServiceImpl) reportCardStudent(ctx context.Context, studentID int64) (*models.Student, error) {
+	if studentID == 0 {
+		return nil, ValidationErrorf("ID do aluno não pode ser zero")
+	}
+	student, err := s.classRepo.GetStudentByID(ctx, studentID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, NotFoundErrorf("aluno com ID %d não encontrado", studentID)
+		}
+		return nil, fmt.Errorf("service: buscar aluno %d: %w", studentID, err)
+	}
+	if student == nil {
+		return nil, NotFoundErrorf("aluno com ID %d não encontrado", studentID)
+	}
+	return student, nil
+}
+
+// reportCards monta os boletins de todos os alunos da turma a partir da planilha de notas
+// (GetGradebook), das notas finais e dos comentários gravados.
+func (s *assessmentServiceImpl) reportCards(ctx context.Context, classID int64) ([]ReportCard, error) {
+	book, err := s.GetGradebook(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	_, finals, err := s.GetFinalGradesByClassID(ctx, classID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetReportCards: %w", err)
+	}
+	comments, err := s.assessmentRepo.GetReportCardComments(ctx, classID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetReportCards: %w", err)
+	}
+
+	students := append([]models.Student(nil), book.Students...)
+	SortStudentsByEnrollment(students)
+	cards := make([]ReportCard, 0, len(students))
+	for _, student := range students {
+		active := student.Status == "ativo"
+		card := ReportCard{Student: student, Policy: book.Policy, Comment: comments[student.ID]}
+		for _, term := range book.Terms {
+			cardTerm := ReportCardTerm{Term: term}
+			for _, assessment := range book.TermAssessments(term) {
+				grade := ReportCardGrade{Assessment: assessment}
+				if value, ok := book.Grade(assessment.ID, student.ID); ok {
+					grade.Grade = &value
+				}
+				cardTerm.Grades = append(cardTerm.Grades, grade)
+			}
+			if average, ok := book.TermAverages[term][student.ID]; ok && active {
+				cardTerm.Average = &average.Adjusted
+			}
+			card.Terms = append(card.Terms, cardTerm)
+		}
+		if average, ok := book.Averages[student.ID]; ok && active {
+			card.Average = &average.Adjusted
+		}
+		if value, ok := finals[student.ID]; ok {
+			card.FinalGrade = &value
+		}
+		// O resultado vem da nota final, se lançada, ou da média anual.
+		if result := card.FinalGrade; active && (result != nil || card.Average != nil) {
+			if result == nil {
+				result = card.Average
+			}
+			card.Result = ReportCardFailed
+			if *result >= book.Policy.PassingGrade {
+				card.Result = ReportCardPassed
+			}
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+)
+
+// reportCardRepository acrescenta à planilha de notas de teste a busca da "Nota Final" e os
+// comentários do boletim.
+type reportCardRepository struct {
+	*gradebookRepository
+	comments map[int64]string
+}
+
+func (r *reportCardRepository) FindAssessmentByNameAndClass(ctx context.Context, name string, classID int64) (*models.Assessment, error) {
+	for _, assessment := range r.assessments {
+		if assessment.Name == name && assessment.ClassID == classID {
+			return &assessment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *reportCardRepository) GetGradesByAssessmentID(ctx context.Context, assessmentID int64) ([]models.Grade, error) {
+	var grades []models.Grade
+	for _, grade := range r.grades {
+		if grade.AssessmentID == assessmentID {
+			grades = append(grades, grade)
+		}
+	}
+	return grades, nil
+}
+
+func (r *reportCardRepository) GetReportCardComments(ctx context.Context, classID int64) (map[int64]string, error) {
+	return r.comments, nil
+}
+
+func (r *reportCardRepository) SaveReportCardComment(ctx context.Context, studentID int64, comment string) error {
+	r.comments[studentID] = comment
+	return nil
+}
+
+// reportCardClassRepository devolve os alunos da planilha de notas de teste.
+type reportCardClassRepository struct {
+	namedClassRepository
+	students []models.Student
+}
+
+func (r *reportCardClassRepository) GetStudentsByClassID(ctx context.Context, classID int64) ([]models.Student, error) {
+	return r.students, nil
+}
+
+func (r *reportCardClassRepository) GetStudentByID(ctx context.Context, studentID int64) (*models.Student, error) {
+	for _, student := range r.students {
+		if student.ID == studentID {
+			return &student, nil
+		}
+	}
+	return nil, fmt.Errorf("aluno %d: %w", studentID, sql.ErrNoRows)
+}
+
+func newReportCardService() (AssessmentService, *reportCardRepository) {
+	repo := &reportCardRepository{gradebookRepository: newGradebookRepository(), comments: map[int64]string{}}
+	classes := &reportCardClassRepository{students: repo.students}
+	policies := &memoryPolicyRepository{policies: map[int64]models.GradingPolicy{}}
+	return NewAssessmentService(repo, classes, nil, policies), repo
+}
+
+func TestGetReportCards(t *testing.T) {
+	assessmentService, _ := newReportCardService()
+	cards, err := assessmentService.GetReportCards(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, cards, 3, "Davi, transferido, não tem boletim da turma")
+
+	ana := cards[0]
+	assert.Equal(t, "Ana", ana.Student.FullName)
+	require.Len(t, ana.Terms, 2)
+	assert.Equal(t, "P1", ana.Terms[0].Grades[0].Assessment.Name)
+	assert.Equal(t, 8.0, *ana.Terms[0].Grades[0].Grade)
+	assert.InDelta(t, 26.0/3, *ana.Terms[0].Average, 0.001)
+	assert.InDelta(t, 47.0/6, *ana.Average, 0.001)
+	// A nota final (2) decide o resultado, mesmo com a média anual acima da média de aprovação.
+	require.NotNil(t, ana.FinalGrade)
+	assert.Equal(t, ReportCardFailed, ana.Result)
+
+	bruno := cards[1]
+	assert.Nil(t, bruno.Terms[0].Grades[1].Grade, "T1 não foi lançada para Bruno")
+	assert.Nil(t, bruno.FinalGrade)
+	assert.Equal(t, ReportCardPassed, bruno.Result, "sem nota final, vale a média anual (6)")
+	assert.Equal(t, ReportCardFailed, cards[2].Result, "Carla não tem notas")
+}
+
+func TestGetStudentReportCard_AndComment(t *testing.T) {
+	assessmentService, repo := newReportCardService()
+	ctx := context.Background()
+
+	require.NoError(t, assessmentService.SetReportCardComment(ctx, 2, "  Participativo; precisa revisar frações.  "))
+	assert.Equal(t, "Participativo; precisa revisar frações.", repo.comments[2])
+	card, err := assessmentService.GetStudentReportCard(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Participativo; precisa revisar frações.", card.Comment)
+
+	davi, err := assessmentService.GetStudentReportCard(ctx, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, *davi.Terms[0].Grades[0].Grade)
+	assert.Nil(t, davi.Average, "alunos transferidos não têm médias")
+	assert.Empty(t, davi.Result)
+
+	_, err = assessmentService.GetStudentReportCard(ctx, 99)
+	assert.True(t, errors.Is(err, ErrNotFound), "%v", err)
+	err = assessmentService.SetReportCardComment(ctx, 1, strings.Repeat("a", maxReportCardComment+1))
+	assert.True(t, errors.Is(err, ErrValidation), "%v", err)
+}
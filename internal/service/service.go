@@ -37,6 +37,17 @@ type TaskService interface {
 	DeleteTask(ctx context.Context, taskID int64) error
 	// GetUpcomingActiveTasks recupera uma lista limitada de tarefas ativas futuras para um usuário específico.
 	GetUpcomingActiveTasks(ctx context.Context, userID int64, fromDate time.Time, limit int) ([]models.Task, error)
+	// PostponeTask adia uma tarefa pelo intervalo 'delay' (ver ParsePostponeDuration) e incrementa seu contador de adiamentos.
+	// Retorna a tarefa com a nova data de vencimento.
+	PostponeTask(ctx context.Context, taskID int64, delay time.Duration) (models.Task, error)
+	// ListOverdueTasks retorna as tarefas ativas cujo prazo já passou, da mais atrasada para a mais recente.
+	ListOverdueTasks(ctx context.Context) ([]models.Task, error)
+	// UpdateTaskStatus move uma tarefa para outra coluna do quadro Kanban (ver models.TaskStatuses).
+	// Mover para 'feito' conclui a tarefa; sair de 'feito' a reabre.
+	UpdateTaskStatus(ctx context.Context, taskID int64, status string) error
+	// SetTaskPlanning define a duração estimada (minutos; nil remove a estimativa) e a prioridade
+	// (ver models.TaskPriority*; 0 mantém a atual) usadas pelo planejamento diário.
+	SetTaskPlanning(ctx context.Context, taskID int64, estimatedMinutes *int, priority int) (models.Task, error)
 }
 
 // ClassService define a interface para a lógica de negócios relacionada a turmas e alunos.
@@ -73,20 +84,64 @@ type ClassService interface {
 type AssessmentService interface {
 	// CreateAssessment cria uma nova avaliação para uma turma.
 	CreateAssessment(ctx context.Context, name string, classID int64, term int, weight float64) (models.Assessment, error)
+	// CreateAssessmentOnDate cria uma avaliação com data de aplicação. Se term for 0, o bimestre é
+	// o do calendário escolar que contém a data.
+	CreateAssessmentOnDate(ctx context.Context, name string, classID int64, term int, weight float64, date time.Time) (models.Assessment, error)
 	// EnterGrades registra ou atualiza as notas de múltiplos alunos para uma avaliação específica.
-	// studentGrades é um mapa onde a chave é o StudentID e o valor é a nota.
+	// studentGrades é um mapa onde a chave é o StudentID e o valor é a nota, que deve estar na escala
+	// da turma (ver ParseGrade para converter conceitos); nenhuma nota é gravada se alguma for inválida.
 	EnterGrades(ctx context.Context, assessmentID int64, studentGrades map[int64]float64) error
-	// CalculateClassAverage calcula a média ponderada das notas para cada aluno de uma turma.
+	// CalculateClassAverage calcula a média de cada aluno de uma turma segundo a política de notas da
+	// turma (ver GetGradingPolicy), já arredondada.
 	// O cálculo pode ser filtrado por períodos (terms). Se terms for nulo ou vazio, todos os períodos são considerados.
+	// As recuperações já estão aplicadas; ver CalculateClassAverages para a média original.
 	CalculateClassAverage(ctx context.Context, classID int64, terms []int) (map[int64]float64, error)
+	// CalculateClassAverages calcula, como CalculateClassAverage, a média de cada aluno antes e depois
+	// de aplicadas as recuperações, segundo a regra de recuperação da política de notas.
+	CalculateClassAverages(ctx context.Context, classID int64, terms []int) (map[int64]StudentAverage, error)
+	// CreateRecoveryAssessment cria uma recuperação das avaliações 'recovered' da turma ou, se nenhuma
+	// for informada, de todas as avaliações do bimestre 'term' (que, com avaliações informadas, pode
+	// ser 0 para usar o bimestre delas).
+	CreateRecoveryAssessment(ctx context.Context, name string, classID int64, term int, recovered []int64, date *time.Time) (models.Assessment, error)
+	// GetRecoveryStudents lista os alunos ativos abaixo da média de aprovação nas avaliações recuperadas
+	// por uma recuperação, os únicos que podem ter nota nela.
+	GetRecoveryStudents(ctx context.Context, recoveryID int64) ([]RecoveryStudent, error)
+	// GetGradebook monta a planilha de notas de uma turma: alunos, avaliações por bimestre, notas e
+	// as médias de cada bimestre e do ano (ver CalculateClassAverages).
+	GetGradebook(ctx context.Context, classID int64) (Gradebook, error)
+	// PreviewGradeImport lê uma planilha de notas (CSV ou XLSX) e associa cada linha a um aluno da
+	// avaliação, pela matrícula ou pelo nome (de forma aproximada), sem gravar nada. As notas das
+	// linhas encontradas (GradeImportPreview.Grades) são depois gravadas de uma vez com EnterGrades.
+	PreviewGradeImport(ctx context.Context, assessmentID int64, data []byte, format string, mapping GradeImportMapping) (GradeImportPreview, error)
+	// GetReportCards monta os boletins dos alunos ativos de uma turma: as notas de cada avaliação por
+	// bimestre, as médias dos bimestres e do ano, a nota final, o resultado e o comentário.
+	GetReportCards(ctx context.Context, classID int64) ([]ReportCard, error)
+	// GetStudentReportCard monta o boletim de um aluno, como GetReportCards.
+	GetStudentReportCard(ctx context.Context, studentID int64) (ReportCard, error)
+	// SetReportCardComment grava o comentário do(a) professor(a) no boletim de um aluno; um
+	// comentário vazio o remove.
+	SetReportCardComment(ctx context.Context, studentID int64, comment string) error
+	// GetGradingPolicy retorna a política de notas de uma turma (escala das notas, cálculo da média e
+	// recuperação), ou a política padrão (ver DefaultGradingPolicy) se a turma não tiver uma.
+	GetGradingPolicy(ctx context.Context, classID int64) (models.GradingPolicy, error)
+	// SetGradingPolicy valida e grava a política de notas de uma turma, retornando-a normalizada.
+	SetGradingPolicy(ctx context.Context, policy models.GradingPolicy) (models.GradingPolicy, error)
+	// SetAssessmentCategory altera a categoria de uma avaliação (usada pelo esquema "categorias").
+	// Uma categoria vazia remove a avaliação de todas as categorias.
+	SetAssessmentCategory(ctx context.Context, assessmentID int64, category string) (models.Assessment, error)
 	// ListAllAssessments retorna uma lista de todas as avaliações.
 	// Em um sistema multiusuário, isso seria filtrado pelo usuário ou turma.
 	ListAllAssessments(ctx context.Context) ([]models.Assessment, error)
+	// GetUpcomingAssessments lista as avaliações de todas as turmas com data entre 'from' e 'to' (dias
+	// inclusivos), com a contagem regressiva e as outras avaliações da mesma turma no mesmo dia.
+	GetUpcomingAssessments(ctx context.Context, from, to time.Time) ([]UpcomingAssessment, error)
 	// DeleteAssessment remove uma avaliação e suas notas associadas.
 	DeleteAssessment(ctx context.Context, assessmentID int64) error
 	// GetStudentsForGrading busca os alunos de uma turma associada a uma avaliação.
 	GetStudentsForGrading(ctx context.Context, assessmentID int64) ([]models.Student, *models.Assessment, error)
-	// EnterFinalGrades registra as notas finais para uma turma.
+	// GetGradesByAssessmentID busca as notas já lançadas em uma avaliação.
+	GetGradesByAssessmentID(ctx context.Context, assessmentID int64) ([]models.Grade, error)
+	// EnterFinalGrades registra as notas finais para uma turma, validadas na escala da turma.
 	EnterFinalGrades(ctx context.Context, classID int64, finalGrades map[int64]float64) error
 	// GetFinalGradesByClassID busca as notas finais de uma turma.
 	GetFinalGradesByClassID(ctx context.Context, classID int64) ([]models.Student, map[int64]float64, error)
@@ -142,10 +197,152 @@ type LessonService interface {
 	// GetLessonsForDate busca aulas/lições para um usuário em uma data específica.
 	// O UserID é usado para filtrar; em um sistema multiusuário, viria do contexto de autenticação.
 	GetLessonsForDate(ctx context.Context, userID int64, date time.Time) ([]models.Lesson, error)
+	// GetLessonsForWeek busca as aulas de um usuário na semana (segunda a domingo) que contém 'date'.
+	GetLessonsForWeek(ctx context.Context, userID int64, date time.Time) ([]models.Lesson, error)
 	// UpdateLesson atualiza os detalhes de uma aula/lição existente.
 	UpdateLesson(ctx context.Context, lessonID int64, title string, planContent string, scheduledAt time.Time) (models.Lesson, error)
 	// DeleteLesson remove uma aula/lição do sistema.
 	DeleteLesson(ctx context.Context, lessonID int64) error
+	// RecordDelivery registra o que aconteceu na aula: a situação (ver models.LessonStatus*), o conteúdo
+	// efetivamente ministrado (obrigatório para aulas ministradas ou parciais) e uma reflexão opcional.
+	RecordDelivery(ctx context.Context, lessonID int64, status string, coveredContent string, reflection string) (models.Lesson, error)
+	// CarryOverContent leva o conteúdo pendente de uma aula parcial ou cancelada para o início do plano
+	// da próxima aula não cancelada da mesma turma, que é retornada. Para aulas canceladas, um conteúdo
+	// pendente vazio leva o plano inteiro.
+	CarryOverContent(ctx context.Context, lessonID int64, pendingContent string) (models.Lesson, error)
+	// GetDeliveredContent retorna as aulas ministradas (inteira ou parcialmente) de uma turma entre
+	// 'from' e 'to' (inclusive), em ordem de data, para o relatório de conteúdo ministrado.
+	GetDeliveredContent(ctx context.Context, classID int64, from, to time.Time) ([]models.Lesson, error)
+}
+
+// PlanningService define a interface para o planejamento diário: combina as aulas do dia com os
+// horários livres, sugere tarefas pendentes que cabem e grava o plano em blocos de horário.
+type PlanningService interface {
+	// ProposeDayPlan calcula aulas, horários livres e tarefas sugeridas (com horário proposto) para a janela informada.
+	ProposeDayPlan(ctx context.Context, window TimeSlot) (DayPlanProposal, error)
+	// CommitDayPlan grava o plano do dia com as aulas e as tarefas escolhidas, substituindo o plano anterior.
+	CommitDayPlan(ctx context.Context, window TimeSlot, taskIDs []int64) ([]models.PlanBlock, error)
+	// GetDayPlan retorna os blocos do plano gravado para o dia.
+	GetDayPlan(ctx context.Context, day time.Time) ([]models.PlanBlock, error)
+	// CurrentAndNextBlocks retorna o bloco em andamento ("agora") e o seguinte ("próximo") no instante 'at'.
+	CurrentAndNextBlocks(ctx context.Context, at time.Time) (current *models.PlanBlock, next *models.PlanBlock, err error)
+}
+
+// TimetableService define a interface para o horário semanal das turmas e para a geração das
+// aulas de um período a partir dele.
+type TimetableService interface {
+	// AddSlot cadastra um horário semanal (dia, início HH:MM e duração) para uma turma.
+	// Retorna erro de conflito se o horário se sobrepõe a outro já cadastrado, de qualquer turma.
+	AddSlot(ctx context.Context, classID int64, weekday time.Weekday, startTime string, durationMinutes int) (models.TimetableSlot, error)
+	// UpdateSlot altera dia, início e duração de um horário, com a mesma verificação de sobreposição.
+	UpdateSlot(ctx context.Context, slotID int64, weekday time.Weekday, startTime string, durationMinutes int) (models.TimetableSlot, error)
+	// DeleteSlot remove um horário. As aulas já geradas a partir dele não são alteradas.
+	DeleteSlot(ctx context.Context, slotID int64) error
+	// ListSlots retorna todos os horários, ordenados por dia da semana e hora de início.
+	ListSlots(ctx context.Context) ([]models.TimetableSlot, error)
+	// FindOverlaps retorna os pares de horários cadastrados que se sobrepõem.
+	FindOverlaps(ctx context.Context) ([]SlotOverlap, error)
+	// GenerateLessons cria uma aula para cada horário em cada semana do período, pulando os dias sem
+	// aula do calendário escolar e os horários em que já existe aula. Com DryRun, apenas calcula o resultado.
+	GenerateLessons(ctx context.Context, opts GenerateLessonsOptions) (LessonGenerationResult, error)
+}
+
+// CalendarService define a interface para o calendário escolar: as datas dos bimestres do ano
+// letivo e os dias sem aula (feriados, recessos e dias de planejamento). Os feriados nacionais
+// são sempre considerados dias sem aula, mesmo sem estarem cadastrados.
+type CalendarService interface {
+	// SetTerm grava as datas de um bimestre, substituindo as anteriores do mesmo bimestre. O ano
+	// letivo é o da data de início. Retorna erro de conflito se o período se sobrepõe a outro bimestre.
+	SetTerm(ctx context.Context, number int, start, end time.Time) (models.AcademicTerm, error)
+	// ListTerms retorna os bimestres do ano letivo informado (0 para todos), ordenados pelo início.
+	ListTerms(ctx context.Context, year int) ([]models.AcademicTerm, error)
+	// DeleteTerm remove um bimestre do calendário.
+	DeleteTerm(ctx context.Context, termID int64) error
+	// AddEvent cadastra um feriado, recesso ou dia de planejamento. Um 'end' zero indica evento de um só dia.
+	AddEvent(ctx context.Context, kind, description string, start, end time.Time) (models.CalendarEvent, error)
+	// ListEvents retorna os eventos cadastrados com algum dia entre 'from' e 'to' (inclusive).
+	ListEvents(ctx context.Context, from, to time.Time) ([]models.CalendarEvent, error)
+	// DeleteEvent remove um evento do calendário.
+	DeleteEvent(ctx context.Context, eventID int64) error
+	// Import lê um calendário em CSV ou iCalendar (ver CalendarFormat*) e grava bimestres e eventos
+	// de uma só vez; se qualquer linha for inválida, nada é gravado.
+	Import(ctx context.Context, data []byte, format string) (CalendarImportResult, error)
+	// TermForDate retorna o bimestre que contém a data, ou nil se ela está fora de todos os bimestres.
+	TermForDate(ctx context.Context, date time.Time) (*models.AcademicTerm, error)
+	// DaysOff retorna os dias sem aula entre 'from' e 'to' (inclusive), indexados por AAAA-MM-DD,
+	// com o motivo (ex: "feriado: Tiradentes", "recesso: Férias de julho").
+	DaysOff(ctx context.Context, from, to time.Time) (map[string]string, error)
+	// DescribeDate descreve a data no calendário escolar (ex: "3º bimestre, semana 5"), ou "" se ela
+	// não está em nenhum bimestre nem em recesso.
+	DescribeDate(ctx context.Context, date time.Time) (string, error)
+}
+
+// LessonTemplateService define a interface para os modelos de plano de aula e as sequências
+// didáticas (unidades com vários planos em ordem, aplicáveis às próximas aulas de uma turma).
+type LessonTemplateService interface {
+	// CreateTemplate cadastra um modelo de plano de aula. Um conteúdo vazio usa DefaultLessonPlanTemplate.
+	CreateTemplate(ctx context.Context, name, content string) (models.LessonTemplate, error)
+	// GetTemplate retorna um modelo pelo ID.
+	GetTemplate(ctx context.Context, templateID int64) (models.LessonTemplate, error)
+	// ListTemplates retorna todos os modelos, ordenados pelo nome.
+	ListTemplates(ctx context.Context) ([]models.LessonTemplate, error)
+	// DeleteTemplate remove um modelo.
+	DeleteTemplate(ctx context.Context, templateID int64) error
+	// CreateSequence cadastra uma sequência didática com os planos de 'items', na ordem dada.
+	CreateSequence(ctx context.Context, name, description string, items []models.LessonSequenceItem) (models.LessonSequence, error)
+	// GetSequence retorna uma sequência pelo ID, com os planos em ordem.
+	GetSequence(ctx context.Context, sequenceID int64) (models.LessonSequence, error)
+	// ListSequences retorna todas as sequências, ordenadas pelo nome.
+	ListSequences(ctx context.Context) ([]models.LessonSequence, error)
+	// DeleteSequence remove uma sequência.
+	DeleteSequence(ctx context.Context, sequenceID int64) error
+	// ApplySequence copia título e plano de cada item da sequência, em ordem, para as próximas aulas
+	// planejadas da turma a partir de 'from'. Sem 'replace', retorna erro de conflito se alguma dessas
+	// aulas já tiver plano. Retorna as aulas alteradas.
+	ApplySequence(ctx context.Context, sequenceID, classID int64, from time.Time, replace bool) ([]models.Lesson, error)
+	// ExportSequence gera o arquivo JSON de compartilhamento de uma sequência.
+	ExportSequence(ctx context.Context, sequenceID int64) ([]byte, error)
+	// ImportSequence cadastra a sequência de um arquivo gerado por ExportSequence. Um 'name' não vazio
+	// substitui o nome gravado no arquivo.
+	ImportSequence(ctx context.Context, data []byte, name string) (models.LessonSequence, error)
+}
+
+// AttachmentService define a interface para os anexos das aulas: arquivos copiados para o diretório
+// de anexos (ao lado do banco de dados) e links.
+type AttachmentService interface {
+	// AttachFile copia o arquivo 'path' para o diretório de anexos e o anexa à aula. Um 'name' vazio
+	// usa o nome do arquivo.
+	AttachFile(ctx context.Context, lessonID int64, path, name string) (models.LessonAttachment, error)
+	// AttachLink anexa uma URL (http ou https) à aula. Um 'name' vazio usa a própria URL.
+	AttachLink(ctx context.Context, lessonID int64, url, name string) (models.LessonAttachment, error)
+	// GetAttachment retorna um anexo pelo ID.
+	GetAttachment(ctx context.Context, attachmentID int64) (models.LessonAttachment, error)
+	// ListAttachments retorna os anexos de uma aula, na ordem em que foram adicionados.
+	ListAttachments(ctx context.Context, lessonID int64) ([]models.LessonAttachment, error)
+	// ListAllAttachments retorna os anexos de todas as aulas, agrupados pelo ID da aula.
+	ListAllAttachments(ctx context.Context) (map[int64][]models.LessonAttachment, error)
+	// DeleteAttachment remove um anexo e, se for um arquivo, a sua cópia no diretório de anexos.
+	DeleteAttachment(ctx context.Context, attachmentID int64) error
+	// DeleteLessonAttachments remove todos os anexos de uma aula (usado ao remover a aula).
+	DeleteLessonAttachments(ctx context.Context, lessonID int64) error
+	// Target retorna o que deve ser aberto para o anexo: o caminho absoluto do arquivo ou a URL.
+	Target(attachment models.LessonAttachment) string
+	// Dir retorna o diretório onde os arquivos anexados são guardados.
+	Dir() string
+}
+
+// AttendanceService define a interface para a chamada: a frequência de cada aluno em cada aula
+// (presente, falta, atraso ou falta justificada) e os totais de faltas por período.
+type AttendanceService interface {
+	// GetRollCall retorna a aula e a lista de chamada: os alunos ativos da turma (e os inativos que já
+	// tenham registro na aula), em ordem alfabética, com a situação registrada ou "" se ainda não houver.
+	GetRollCall(ctx context.Context, lessonID int64) (models.Lesson, []RollCallEntry, error)
+	// RecordAttendance grava a situação de cada aluno em 'statuses' (ID do aluno -> situação, ver
+	// models.Attendance* e ParseAttendanceStatus). Alunos não informados mantêm o registro anterior.
+	RecordAttendance(ctx context.Context, lessonID int64, statuses map[int64]string) error
+	// GetAbsenceSummary totaliza a frequência de cada aluno da turma nas aulas com chamada entre
+	// 'from' e 'to' (inclusive).
+	GetAbsenceSummary(ctx context.Context, classID int64, from, to time.Time) ([]AttendanceSummary, error)
 }
 
 // TODO: Adicionar SubjectService interface para gerenciar CRUD de Disciplinas.
@@ -110,6 +110,30 @@ func (s *stubTaskService) GetUpcomingActiveTasks(ctx context.Context, userID int
 	return s.taskRepo.GetUpcomingActiveTasks(ctx, userID, fromDate, limit)
 }
 
+func (s *stubTaskService) PostponeTask(ctx context.Context, taskID int64, delay time.Duration) (models.Task, error) {
+	fmt.Printf("[StubTaskService] PostponeTask called for TaskID: %d, Delay: %s\n", taskID, delay)
+	newDueDate := time.Now().Add(delay)
+	if err := s.taskRepo.PostponeTask(ctx, taskID, newDueDate); err != nil {
+		return models.Task{}, err
+	}
+	return models.Task{ID: taskID, DueDate: &newDueDate, PostponeCount: 1}, nil
+}
+
+func (s *stubTaskService) ListOverdueTasks(ctx context.Context) ([]models.Task, error) {
+	fmt.Printf("[StubTaskService] ListOverdueTasks called\n")
+	return s.taskRepo.GetOverdueActiveTasks(ctx, 1, time.Now())
+}
+
+func (s *stubTaskService) UpdateTaskStatus(ctx context.Context, taskID int64, status string) error {
+	fmt.Printf("[StubTaskService] UpdateTaskStatus called for TaskID: %d, Status: %s\n", taskID, status)
+	return s.taskRepo.UpdateTaskStatus(ctx, taskID, status)
+}
+
+func (s *stubTaskService) SetTaskPlanning(ctx context.Context, taskID int64, estimatedMinutes *int, priority int) (models.Task, error) {
+	fmt.Printf("[StubTaskService] SetTaskPlanning called for TaskID: %d, Priority: %d\n", taskID, priority)
+	return models.Task{ID: taskID, EstimatedMinutes: estimatedMinutes, Priority: priority}, nil
+}
+
 // StubClassService
 type stubClassService struct {
 	classRepo repository.ClassRepository
@@ -292,6 +316,11 @@ func NewStubAssessmentService(assessmentRepo repository.AssessmentRepository) As
 	return &stubAssessmentService{assessmentRepo: assessmentRepo}
 }
 
+func (s *stubAssessmentService) CreateAssessmentOnDate(ctx context.Context, name string, classID int64, term int, weight float64, date time.Time) (models.Assessment, error) {
+	fmt.Printf("[StubAssessmentService] CreateAssessmentOnDate: %s for ClassID %d on %s\n", name, classID, date.Format("2006-01-02"))
+	return s.CreateAssessment(ctx, name, classID, term, weight)
+}
+
 func (s *stubAssessmentService) CreateAssessment(ctx context.Context, name string, classID int64, term int, weight float64) (models.Assessment, error) {
 	fmt.Printf("[StubAssessmentService] CreateAssessment: %s for ClassID %d\n", name, classID)
 	assessment := models.Assessment{
@@ -333,6 +362,44 @@ func (s *stubAssessmentService) CalculateClassAverage(ctx context.Context, class
 	}, nil
 }
 
+func (s *stubAssessmentService) CalculateClassAverages(ctx context.Context, classID int64, terms []int) (map[int64]StudentAverage, error) {
+	fmt.Printf("[StubAssessmentService] CalculateClassAverages for ClassID %d with terms %v\n", classID, terms)
+	averages, err := s.CalculateClassAverage(ctx, classID, terms)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]StudentAverage, len(averages))
+	for studentID, average := range averages {
+		result[studentID] = StudentAverage{Original: average, Adjusted: average}
+	}
+	return result, nil
+}
+
+func (s *stubAssessmentService) CreateRecoveryAssessment(ctx context.Context, name string, classID int64, term int, recovered []int64, date *time.Time) (models.Assessment, error) {
+	fmt.Printf("[StubAssessmentService] CreateRecoveryAssessment: %s for ClassID %d\n", name, classID)
+	return models.Assessment{ID: 1, ClassID: classID, Name: name, Term: term, Kind: models.AssessmentKindRecovery, RecoveryOf: recovered, AssessmentDate: date}, nil
+}
+
+func (s *stubAssessmentService) GetRecoveryStudents(ctx context.Context, recoveryID int64) ([]RecoveryStudent, error) {
+	fmt.Printf("[StubAssessmentService] GetRecoveryStudents called for AssessmentID %d\n", recoveryID)
+	return nil, nil
+}
+
+func (s *stubAssessmentService) GetGradingPolicy(ctx context.Context, classID int64) (models.GradingPolicy, error) {
+	fmt.Printf("[StubAssessmentService] GetGradingPolicy for ClassID %d\n", classID)
+	return DefaultGradingPolicy(classID), nil
+}
+
+func (s *stubAssessmentService) SetGradingPolicy(ctx context.Context, policy models.GradingPolicy) (models.GradingPolicy, error) {
+	fmt.Printf("[StubAssessmentService] SetGradingPolicy for ClassID %d: %s\n", policy.ClassID, policy.Scheme)
+	return policy, nil
+}
+
+func (s *stubAssessmentService) SetAssessmentCategory(ctx context.Context, assessmentID int64, category string) (models.Assessment, error) {
+	fmt.Printf("[StubAssessmentService] SetAssessmentCategory for AssessmentID %d: %s\n", assessmentID, category)
+	return models.Assessment{ID: assessmentID, Category: category}, nil
+}
+
 func (s *stubAssessmentService) ListAllAssessments(ctx context.Context) ([]models.Assessment, error) {
 	fmt.Printf("[StubAssessmentService] ListAllAssessments called\n")
 	now := time.Now()
@@ -367,6 +434,41 @@ func (s *stubAssessmentService) GetStudentsForGrading(ctx context.Context, asses
 	return students, assessment, nil
 }
 
+func (s *stubAssessmentService) GetGradesByAssessmentID(ctx context.Context, assessmentID int64) ([]models.Grade, error) {
+	fmt.Printf("[StubAssessmentService] GetGradesByAssessmentID called for AssessmentID %d\n", assessmentID)
+	return []models.Grade{{AssessmentID: assessmentID, StudentID: 101, Grade: 8.5}}, nil
+}
+
+func (s *stubAssessmentService) GetGradebook(ctx context.Context, classID int64) (Gradebook, error) {
+	fmt.Printf("[StubAssessmentService] GetGradebook called for ClassID %d\n", classID)
+	return Gradebook{ClassID: classID, Policy: DefaultGradingPolicy(classID)}, nil
+}
+
+func (s *stubAssessmentService) PreviewGradeImport(ctx context.Context, assessmentID int64, data []byte, format string, mapping GradeImportMapping) (GradeImportPreview, error) {
+	fmt.Printf("[StubAssessmentService] PreviewGradeImport called for AssessmentID %d (%s)\n", assessmentID, format)
+	return GradeImportPreview{Assessment: models.Assessment{ID: assessmentID}, Policy: DefaultGradingPolicy(0)}, nil
+}
+
+func (s *stubAssessmentService) GetUpcomingAssessments(ctx context.Context, from, to time.Time) ([]UpcomingAssessment, error) {
+	fmt.Printf("[StubAssessmentService] GetUpcomingAssessments called from %s to %s\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	return []UpcomingAssessment{}, nil
+}
+
+func (s *stubAssessmentService) GetReportCards(ctx context.Context, classID int64) ([]ReportCard, error) {
+	fmt.Printf("[StubAssessmentService] GetReportCards called for ClassID %d\n", classID)
+	return []ReportCard{}, nil
+}
+
+func (s *stubAssessmentService) GetStudentReportCard(ctx context.Context, studentID int64) (ReportCard, error) {
+	fmt.Printf("[StubAssessmentService] GetStudentReportCard called for StudentID %d\n", studentID)
+	return ReportCard{Student: models.Student{ID: studentID, Status: "ativo"}, Policy: DefaultGradingPolicy(0)}, nil
+}
+
+func (s *stubAssessmentService) SetReportCardComment(ctx context.Context, studentID int64, comment string) error {
+	fmt.Printf("[StubAssessmentService] SetReportCardComment called for StudentID %d\n", studentID)
+	return nil
+}
+
 func (s *stubAssessmentService) EnterFinalGrades(ctx context.Context, classID int64, finalGrades map[int64]float64) error {
 	fmt.Printf("[StubAssessmentService] EnterFinalGrades for ClassID %d: %+v\n", classID, finalGrades)
 	// In a real stub, you would find the "Nota Final" assessment and use EnterGrades.
@@ -8,6 +8,7 @@ import (
 	"errors"       // Para criar erros de validação padrão.
 	"fmt"
 	"os"      // Usado temporariamente para logError.
+	"strconv"
 	"strings" // Usado para verificar mensagens de erro específicas.
 	"time"
 	"vigenda/internal/models"
@@ -31,6 +32,10 @@ func NewTaskService(repo repository.TaskRepository) TaskService {
 	}
 }
 
+// timeNow é a fonte de "agora" usada pelo serviço de tarefas.
+// Substituída nos testes para tornar os cálculos de adiamento determinísticos.
+var timeNow = time.Now
+
 // logError é uma função auxiliar interna para registrar erros.
 // Atualmente, imprime para stderr. Em uma aplicação de produção,
 // isso seria substituído por um sistema de logging mais robusto e configurável
@@ -93,6 +98,7 @@ func (s *taskServiceImpl) createTaskInternal(ctx context.Context, userID int64,
 		Description: description,
 		DueDate:     dueDate,
 		IsCompleted: false, // Novas tarefas são sempre não concluídas.
+		Status:      models.TaskStatusTodo,
 	}
 
 	id, err := s.repo.CreateTask(ctx, &task)
@@ -112,7 +118,7 @@ func (s *taskServiceImpl) createTaskInternal(ctx context.Context, userID int64,
 // TODO: Integrar com sistema de autenticação para obter UserID real do contexto.
 func (s *taskServiceImpl) CreateTask(ctx context.Context, title, description string, classID *int64, dueDate *time.Time) (models.Task, error) {
 	if strings.TrimSpace(title) == "" {
-		err := errors.New("título da tarefa não pode ser vazio")
+		err := ValidationErrorf("título da tarefa não pode ser vazio")
 		logError("CreateTask: falha de validação: %v", err)
 		return models.Task{}, err
 	}
@@ -152,7 +158,7 @@ func (s *taskServiceImpl) GetUpcomingActiveTasks(ctx context.Context, userID int
 // TODO: Adicionar verificação de propriedade da tarefa (UserID).
 func (s *taskServiceImpl) UpdateTask(ctx context.Context, task *models.Task) error {
 	if strings.TrimSpace(task.Title) == "" {
-		err := errors.New("título da tarefa não pode ser vazio para atualização")
+		err := ValidationErrorf("título da tarefa não pode ser vazio para atualização")
 		logError("UpdateTask: falha de validação para Tarefa ID %d: %v", task.ID, err)
 		return err
 	}
@@ -249,12 +255,16 @@ func (s *taskServiceImpl) ListAllActiveTasks(ctx context.Context) ([]models.Task
 }
 
 // MarkTaskAsCompleted marca uma tarefa como concluída.
-// Erros, incluindo "tarefa não encontrada", disparam a criação de uma tarefa de bug,
-// pois pode indicar um problema de consistência ou um ID inválido sendo passado.
+// Tarefa inexistente (ou já concluída) é um erro esperado de entrada e não gera tarefa de bug;
+// os demais erros do repositório disparam a criação de uma tarefa de bug.
 func (s *taskServiceImpl) MarkTaskAsCompleted(ctx context.Context, taskID int64) error {
 	// TODO: Adicionar verificação de propriedade da tarefa (UserID).
 	err := s.repo.MarkTaskCompleted(ctx, taskID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logError("MarkTaskAsCompleted: Tarefa ID %d não encontrada ou já concluída: %v", taskID, err)
+			return NotFoundErrorf("tarefa com ID %d não encontrada ou já concluída", taskID)
+		}
 		s.handleErrorAndCreateBugTask(ctx, err, "Falha na Conclusão de Tarefa", "Tentativa de completar Tarefa ID %d", taskID)
 		return fmt.Errorf("MarkTaskAsCompleted: falha ao marcar tarefa como concluída: %w", err)
 	}
@@ -271,7 +281,7 @@ func (s *taskServiceImpl) GetTaskByID(ctx context.Context, taskID int64) (*model
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) || strings.Contains(err.Error(), "no task found") {
 			logError("GetTaskByID: Tarefa não encontrada com ID %d: %v", taskID, err)
-			return nil, fmt.Errorf("tarefa com ID %d não encontrada", taskID) // Retorna erro amigável.
+			return nil, NotFoundErrorf("tarefa com ID %d não encontrada", taskID) // Retorna erro amigável.
 		}
 		// Para outros erros inesperados do banco de dados:
 		s.handleErrorAndCreateBugTask(ctx, err, "Falha na Recuperação de Tarefa", "Tentativa de recuperar Tarefa ID %d", taskID)
@@ -279,3 +289,175 @@ func (s *taskServiceImpl) GetTaskByID(ctx context.Context, taskID int64) (*model
 	}
 	return task, nil
 }
+
+// ParsePostponeDuration interpreta o intervalo de adiamento informado pelo usuário,
+// no formato <número><unidade>: "2d" (dias), "1w" ou "1sem" (semanas) e "3h" (horas).
+// Um número sem unidade é interpretado como dias ("2" equivale a "2d").
+func ParsePostponeDuration(input string) (time.Duration, error) {
+	value := strings.ToLower(strings.TrimSpace(input))
+	if value == "" {
+		return 0, ValidationErrorf("intervalo de adiamento não pode ser vazio")
+	}
+
+	digits := 0
+	for digits < len(value) && value[digits] >= '0' && value[digits] <= '9' {
+		digits++
+	}
+	if digits == 0 {
+		return 0, ValidationErrorf("intervalo de adiamento inválido '%s': use um número seguido de d, w/sem ou h (ex: 2d)", input)
+	}
+	amount, err := strconv.Atoi(value[:digits])
+	if err != nil || amount <= 0 {
+		return 0, ValidationErrorf("intervalo de adiamento inválido '%s': a quantidade deve ser um inteiro positivo", input)
+	}
+
+	switch value[digits:] {
+	case "", "d":
+		return time.Duration(amount) * 24 * time.Hour, nil
+	case "w", "sem":
+		return time.Duration(amount) * 7 * 24 * time.Hour, nil
+	case "h":
+		return time.Duration(amount) * time.Hour, nil
+	default:
+		return 0, ValidationErrorf("unidade de adiamento desconhecida '%s': use d, w/sem ou h", value[digits:])
+	}
+}
+
+// PostponeTask adia uma tarefa ativa pelo intervalo 'delay'.
+// Se o prazo atual ainda não passou, o novo prazo é calculado a partir dele; caso a tarefa
+// esteja atrasada (ou sem prazo), o intervalo é contado a partir de hoje, preservando o horário
+// original do prazo. O contador de adiamentos da tarefa é incrementado pelo repositório.
+func (s *taskServiceImpl) PostponeTask(ctx context.Context, taskID int64, delay time.Duration) (models.Task, error) {
+	if delay <= 0 {
+		err := ValidationErrorf("intervalo de adiamento deve ser positivo")
+		logError("PostponeTask: falha de validação para Tarefa ID %d: %v", taskID, err)
+		return models.Task{}, err
+	}
+
+	task, err := s.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return models.Task{}, err
+	}
+	if task.IsCompleted {
+		return models.Task{}, ConflictErrorf("tarefa com ID %d já está concluída e não pode ser adiada", taskID)
+	}
+
+	now := timeNow()
+	base := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if task.DueDate != nil {
+		if task.DueDate.After(now) {
+			base = *task.DueDate
+		} else {
+			due := task.DueDate.In(now.Location())
+			base = time.Date(now.Year(), now.Month(), now.Day(), due.Hour(), due.Minute(), due.Second(), 0, now.Location())
+		}
+	}
+	newDueDate := base.Add(delay)
+
+	if err := s.repo.PostponeTask(ctx, taskID, newDueDate); err != nil {
+		s.handleErrorAndCreateBugTask(ctx, err, "Falha no Adiamento de Tarefa", "Tentativa de adiar Tarefa ID %d para %s", taskID, newDueDate.Format("02/01/2006"))
+		return models.Task{}, fmt.Errorf("PostponeTask: falha ao adiar tarefa: %w", err)
+	}
+
+	task.DueDate = &newDueDate
+	task.PostponeCount++
+	return *task, nil
+}
+
+// ListOverdueTasks retorna as tarefas ativas cujo prazo é anterior ao momento atual.
+// O UserID é atualmente fixo (1), como em CreateTask.
+func (s *taskServiceImpl) ListOverdueTasks(ctx context.Context) ([]models.Task, error) {
+	userID := int64(1)
+	tasks, err := s.repo.GetOverdueActiveTasks(ctx, userID, timeNow())
+	if err != nil {
+		logError("ListOverdueTasks: falha ao buscar tarefas atrasadas para UserID %d: %v", userID, err)
+		return nil, fmt.Errorf("serviço falhou ao buscar tarefas atrasadas: %w", err)
+	}
+	return tasks, nil
+}
+
+// IsValidTaskStatus informa se 'status' é um dos status de tarefa conhecidos (models.TaskStatuses).
+func IsValidTaskStatus(status string) bool {
+	for _, known := range models.TaskStatuses {
+		if status == known {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateTaskStatus move uma tarefa para a coluna 'status' do quadro Kanban.
+// O repositório mantém is_completed sincronizado com o status 'feito'.
+func (s *taskServiceImpl) UpdateTaskStatus(ctx context.Context, taskID int64, status string) error {
+	if !IsValidTaskStatus(status) {
+		err := ValidationErrorf("status de tarefa inválido '%s': use um de %s", status, strings.Join(models.TaskStatuses, ", "))
+		logError("UpdateTaskStatus: falha de validação para Tarefa ID %d: %v", taskID, err)
+		return err
+	}
+
+	err := s.repo.UpdateTaskStatus(ctx, taskID, status)
+	if err != nil {
+		if strings.Contains(err.Error(), "nenhuma tarefa encontrada") {
+			logError("UpdateTaskStatus: falha ao mover Tarefa ID %d: %v", taskID, err)
+			return NotFoundErrorf("tarefa com ID %d não encontrada", taskID)
+		}
+		s.handleErrorAndCreateBugTask(ctx, err, "Falha na Mudança de Status de Tarefa", "Tentativa de mover Tarefa ID %d para '%s'", taskID, status)
+		return fmt.Errorf("UpdateTaskStatus: falha ao atualizar status da tarefa: %w", err)
+	}
+	return nil
+}
+
+// ParseTaskPriority converte a prioridade informada pelo usuário ("baixa", "media"/"média", "alta"
+// ou os números 1 a 3) para uma das constantes models.TaskPriority*.
+func ParseTaskPriority(input string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "baixa", "1":
+		return models.TaskPriorityLow, nil
+	case "media", "média", "2":
+		return models.TaskPriorityMedium, nil
+	case "alta", "3":
+		return models.TaskPriorityHigh, nil
+	default:
+		return 0, ValidationErrorf("prioridade inválida '%s': use baixa, media ou alta", input)
+	}
+}
+
+// TaskPriorityLabel retorna o nome em português de uma prioridade de tarefa.
+func TaskPriorityLabel(priority int) string {
+	switch priority {
+	case models.TaskPriorityLow:
+		return "baixa"
+	case models.TaskPriorityHigh:
+		return "alta"
+	default:
+		return "média"
+	}
+}
+
+// SetTaskPlanning define a duração estimada (em minutos, nil para remover) e a prioridade de uma tarefa.
+// Prioridade 0 mantém a prioridade atual. Retorna a tarefa atualizada.
+func (s *taskServiceImpl) SetTaskPlanning(ctx context.Context, taskID int64, estimatedMinutes *int, priority int) (models.Task, error) {
+	if estimatedMinutes != nil && *estimatedMinutes <= 0 {
+		err := ValidationErrorf("duração estimada deve ser um número positivo de minutos")
+		logError("SetTaskPlanning: falha de validação para Tarefa ID %d: %v", taskID, err)
+		return models.Task{}, err
+	}
+	if priority != 0 && (priority < models.TaskPriorityLow || priority > models.TaskPriorityHigh) {
+		err := ValidationErrorf("prioridade inválida %d: use um valor entre %d e %d", priority, models.TaskPriorityLow, models.TaskPriorityHigh)
+		logError("SetTaskPlanning: falha de validação para Tarefa ID %d: %v", taskID, err)
+		return models.Task{}, err
+	}
+
+	task, err := s.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return models.Task{}, err
+	}
+	task.EstimatedMinutes = estimatedMinutes
+	if priority != 0 {
+		task.Priority = priority
+	}
+	if err := s.UpdateTask(ctx, task); err != nil {
+		return models.Task{}, err
+	}
+	return *task, nil
+}
@@ -24,6 +24,9 @@ type MockTaskRepository struct {
 	MarkTaskCompletedFunc func(ctx context.Context, taskID int64) error
 	UpdateTaskFunc        func(ctx context.Context, task *models.Task) error // Added
 	DeleteTaskFunc        func(ctx context.Context, taskID int64) error    // Added
+	GetOverdueActiveTasksFunc func(ctx context.Context, userID int64, before time.Time) ([]models.Task, error)
+	PostponeTaskFunc          func(ctx context.Context, taskID int64, newDueDate time.Time) error
+	UpdateTaskStatusFunc      func(ctx context.Context, taskID int64, status string) error
 
 	// Store created bug tasks for verification
 	CreatedBugTasks []models.Task
@@ -86,6 +89,27 @@ func (m *MockTaskRepository) DeleteTask(ctx context.Context, taskID int64) error
 	return errors.New("DeleteTaskFunc not implemented in mock")
 }
 
+func (m *MockTaskRepository) GetOverdueActiveTasks(ctx context.Context, userID int64, before time.Time) ([]models.Task, error) {
+	if m.GetOverdueActiveTasksFunc != nil {
+		return m.GetOverdueActiveTasksFunc(ctx, userID, before)
+	}
+	return nil, errors.New("GetOverdueActiveTasksFunc not implemented in mock")
+}
+
+func (m *MockTaskRepository) PostponeTask(ctx context.Context, taskID int64, newDueDate time.Time) error {
+	if m.PostponeTaskFunc != nil {
+		return m.PostponeTaskFunc(ctx, taskID, newDueDate)
+	}
+	return errors.New("PostponeTaskFunc not implemented in mock")
+}
+
+func (m *MockTaskRepository) UpdateTaskStatus(ctx context.Context, taskID int64, status string) error {
+	if m.UpdateTaskStatusFunc != nil {
+		return m.UpdateTaskStatusFunc(ctx, taskID, status)
+	}
+	return errors.New("UpdateTaskStatusFunc not implemented in mock")
+}
+
 
 func TestTaskService_CreateTask(t *testing.T) {
 	mockRepo := &MockTaskRepository{}
@@ -685,3 +709,169 @@ func TestTaskService_DeleteTask(t *testing.T) {
 		}
 	})
 }
+
+func TestParsePostponeDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"2d", 48 * time.Hour, false},
+		{"2", 48 * time.Hour, false},
+		{"1w", 7 * 24 * time.Hour, false},
+		{"1sem", 7 * 24 * time.Hour, false},
+		{"3h", 3 * time.Hour, false},
+		{" 1D ", 24 * time.Hour, false},
+		{"", 0, true},
+		{"d", 0, true},
+		{"0d", 0, true},
+		{"2x", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePostponeDuration(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePostponeDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePostponeDuration(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTaskService_PostponeTask(t *testing.T) {
+	mockRepo := &MockTaskRepository{}
+	taskService := NewTaskService(mockRepo)
+	ctx := context.Background()
+
+	now := time.Date(2025, 6, 20, 14, 30, 0, 0, time.UTC)
+	originalTimeNow := timeNow
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = originalTimeNow }()
+
+	var savedDueDate time.Time
+	mockRepo.PostponeTaskFunc = func(ctx context.Context, taskID int64, newDueDate time.Time) error {
+		savedDueDate = newDueDate
+		return nil
+	}
+
+	t.Run("future due date is pushed from the due date", func(t *testing.T) {
+		due := time.Date(2025, 6, 23, 0, 0, 0, 0, time.UTC)
+		mockRepo.GetTaskByIDFunc = func(ctx context.Context, id int64) (*models.Task, error) {
+			return &models.Task{ID: id, Title: "Corrigir provas", DueDate: &due, PostponeCount: 1}, nil
+		}
+
+		task, err := taskService.PostponeTask(ctx, 1, 48*time.Hour)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC)
+		if !savedDueDate.Equal(expected) || task.DueDate == nil || !task.DueDate.Equal(expected) {
+			t.Errorf("Expected new due date %v, got saved %v / returned %v", expected, savedDueDate, task.DueDate)
+		}
+		if task.PostponeCount != 2 {
+			t.Errorf("Expected postpone count 2, got %d", task.PostponeCount)
+		}
+	})
+
+	t.Run("overdue task is pushed from today keeping the time of day", func(t *testing.T) {
+		due := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+		mockRepo.GetTaskByIDFunc = func(ctx context.Context, id int64) (*models.Task, error) {
+			return &models.Task{ID: id, Title: "Lançar notas", DueDate: &due}, nil
+		}
+
+		if _, err := taskService.PostponeTask(ctx, 2, 24*time.Hour); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := time.Date(2025, 6, 21, 9, 0, 0, 0, time.UTC)
+		if !savedDueDate.Equal(expected) {
+			t.Errorf("Expected new due date %v, got %v", expected, savedDueDate)
+		}
+	})
+
+	t.Run("completed task cannot be postponed", func(t *testing.T) {
+		mockRepo.GetTaskByIDFunc = func(ctx context.Context, id int64) (*models.Task, error) {
+			return &models.Task{ID: id, Title: "Feita", IsCompleted: true}, nil
+		}
+		if _, err := taskService.PostponeTask(ctx, 3, 24*time.Hour); err == nil {
+			t.Error("Expected error for completed task, got nil")
+		}
+	})
+
+	t.Run("non-positive delay is rejected", func(t *testing.T) {
+		if _, err := taskService.PostponeTask(ctx, 1, 0); err == nil {
+			t.Error("Expected validation error, got nil")
+		}
+	})
+}
+
+func TestTaskService_ListOverdueTasks(t *testing.T) {
+	mockRepo := &MockTaskRepository{}
+	taskService := NewTaskService(mockRepo)
+	ctx := context.Background()
+
+	now := time.Date(2025, 6, 20, 8, 0, 0, 0, time.UTC)
+	originalTimeNow := timeNow
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = originalTimeNow }()
+
+	due := time.Date(2025, 6, 18, 0, 0, 0, 0, time.UTC)
+	mockRepo.GetOverdueActiveTasksFunc = func(ctx context.Context, userID int64, before time.Time) ([]models.Task, error) {
+		if userID != 1 || !before.Equal(now) {
+			return nil, fmt.Errorf("unexpected arguments: userID=%d before=%v", userID, before)
+		}
+		return []models.Task{{ID: 7, Title: "Atrasada", DueDate: &due}}, nil
+	}
+
+	tasks, err := taskService.ListOverdueTasks(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != 7 {
+		t.Errorf("Expected overdue task 7, got %+v", tasks)
+	}
+}
+
+func TestTaskService_UpdateTaskStatus(t *testing.T) {
+	mockRepo := &MockTaskRepository{}
+	taskService := NewTaskService(mockRepo)
+	ctx := context.Background()
+
+	t.Run("valid status is forwarded to the repository", func(t *testing.T) {
+		var gotStatus string
+		mockRepo.UpdateTaskStatusFunc = func(ctx context.Context, taskID int64, status string) error {
+			gotStatus = status
+			return nil
+		}
+		if err := taskService.UpdateTaskStatus(ctx, 1, models.TaskStatusWaiting); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if gotStatus != models.TaskStatusWaiting {
+			t.Errorf("Expected status %q, got %q", models.TaskStatusWaiting, gotStatus)
+		}
+	})
+
+	t.Run("unknown status is rejected", func(t *testing.T) {
+		mockRepo.UpdateTaskStatusFunc = func(ctx context.Context, taskID int64, status string) error {
+			t.Fatal("repository should not be called for an invalid status")
+			return nil
+		}
+		if err := taskService.UpdateTaskStatus(ctx, 1, "arquivada"); err == nil {
+			t.Error("Expected validation error, got nil")
+		}
+	})
+
+	t.Run("missing task returns not found", func(t *testing.T) {
+		mockRepo.CreatedBugTasks = []models.Task{}
+		mockRepo.UpdateTaskStatusFunc = func(ctx context.Context, taskID int64, status string) error {
+			return fmt.Errorf("taskRepository.UpdateTaskStatus: nenhuma tarefa encontrada com ID %d", taskID)
+		}
+		err := taskService.UpdateTaskStatus(ctx, 99, models.TaskStatusDoing)
+		if err == nil || !strings.Contains(err.Error(), "não encontrada") {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+		if len(mockRepo.CreatedBugTasks) != 0 {
+			t.Errorf("Expected no bug tasks for not found, got %d", len(mockRepo.CreatedBugTasks))
+		}
+	})
+}
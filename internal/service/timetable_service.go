@@ -0,0 +1,313 @@
+// Package service contém as implementações concretas das interfaces de serviço.
+// Este arquivo específico implementa a interface TimetableService (horário semanal e geração de aulas).
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// SlotOverlap é um par de horários semanais que se sobrepõem.
+type SlotOverlap struct {
+	First  models.TimetableSlot
+	Second models.TimetableSlot
+}
+
+// GenerateLessonsOptions configura GenerateLessons.
+type GenerateLessonsOptions struct {
+	From      time.Time   // From é o primeiro dia do período (inclusive).
+	To        time.Time   // To é o último dia do período (inclusive).
+	ClassID   int64       // ClassID restringe a geração a uma turma; 0 gera para todas.
+	SkipDates []time.Time // SkipDates são dias sem aula além dos feriados e eventos do calendário escolar (ex: emendas).
+	DryRun    bool        // DryRun calcula o resultado sem criar as aulas.
+}
+
+// SkippedLesson é uma aula que o gerador deixou de criar, com o motivo.
+type SkippedLesson struct {
+	Slot   models.TimetableSlot
+	Start  time.Time
+	Reason string
+}
+
+// LessonGenerationResult é o resultado de GenerateLessons.
+type LessonGenerationResult struct {
+	Created []models.Lesson // Created são as aulas criadas (ou que seriam criadas, com DryRun; sem ID).
+	Skipped []SkippedLesson // Skipped são os horários pulados, na ordem do calendário.
+}
+
+// weekdayNames são os nomes dos dias da semana, indexados por time.Weekday.
+var weekdayNames = [...]string{"Domingo", "Segunda", "Terça", "Quarta", "Quinta", "Sexta", "Sábado"}
+
+// WeekdayName retorna o nome do dia da semana em pt-BR (ex: "Segunda").
+func WeekdayName(weekday time.Weekday) string {
+	return weekdayNames[weekday]
+}
+
+// ParseWeekday interpreta um dia da semana em pt-BR: nome completo ("segunda", "segunda-feira")
+// ou abreviado ("seg"), com ou sem acento.
+func ParseWeekday(value string) (time.Weekday, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	normalized = strings.TrimSuffix(strings.TrimSuffix(normalized, "-feira"), " feira")
+	normalized = strings.NewReplacer("ç", "c", "á", "a").Replace(normalized)
+	for weekday, name := range weekdayNames {
+		full := strings.NewReplacer("ç", "c", "á", "a").Replace(strings.ToLower(name))
+		if normalized != "" && (normalized == full || (len(normalized) == 3 && strings.HasPrefix(full, normalized))) {
+			return time.Weekday(weekday), nil
+		}
+	}
+	return 0, ValidationErrorf("dia da semana inválido '%s': use seg, ter, qua, qui, sex, sab ou dom", value)
+}
+
+// parseSlotStart valida e normaliza a hora de início de um horário para HH:MM.
+func parseSlotStart(startTime string) (string, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(startTime))
+	if err != nil {
+		return "", ValidationErrorf("hora de início inválida '%s': use o formato HH:MM", startTime)
+	}
+	return clock.Format("15:04"), nil
+}
+
+// slotInterval retorna o intervalo ocupado pelo horário no dia 'day'.
+func slotInterval(slot models.TimetableSlot, day time.Time) TimeSlot {
+	clock, _ := time.Parse("15:04", slot.StartTime)
+	start := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, day.Location())
+	return TimeSlot{Start: start, End: start.Add(time.Duration(slot.DurationMinutes) * time.Minute)}
+}
+
+// overlaps informa se dois intervalos [Start, End) se sobrepõem.
+func overlaps(a, b TimeSlot) bool {
+	return a.Start.Before(b.End) && b.Start.Before(a.End)
+}
+
+// slotsOverlap informa se dois horários semanais se sobrepõem (mesmo dia e intervalos com interseção).
+func slotsOverlap(a, b models.TimetableSlot) bool {
+	if a.Weekday != b.Weekday {
+		return false
+	}
+	reference := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	return overlaps(slotInterval(a, reference), slotInterval(b, reference))
+}
+
+// timetableServiceImpl é a implementação concreta de TimetableService.
+type timetableServiceImpl struct {
+	timetableRepo repository.TimetableRepository
+	lessonRepo    repository.LessonRepository
+	classRepo     repository.ClassRepository
+	calendar      CalendarService
+}
+
+// NewTimetableService cria uma nova instância de TimetableService.
+// O LessonRepository é usado pelo gerador de aulas; o ClassRepository valida as turmas.
+// O CalendarService informa os dias sem aula do calendário escolar; se for nil, o gerador
+// considera apenas os feriados nacionais.
+func NewTimetableService(timetableRepo repository.TimetableRepository, lessonRepo repository.LessonRepository, classRepo repository.ClassRepository, calendar CalendarService) TimetableService {
+	return &timetableServiceImpl{timetableRepo: timetableRepo, lessonRepo: lessonRepo, classRepo: classRepo, calendar: calendar}
+}
+
+func (s *timetableServiceImpl) AddSlot(ctx context.Context, classID int64, weekday time.Weekday, startTime string, durationMinutes int) (models.TimetableSlot, error) {
+	slot := models.TimetableSlot{ClassID: classID, Weekday: weekday}
+	if err := s.validateSlot(ctx, &slot, startTime, durationMinutes); err != nil {
+		return models.TimetableSlot{}, err
+	}
+	if _, err := s.classRepo.GetClassByID(ctx, classID); err != nil {
+		if repository.IsNotFound(err) {
+			return models.TimetableSlot{}, &Error{Category: CategoryNotFound, Message: fmt.Sprintf("turma com ID %d não encontrada", classID), Err: err}
+		}
+		return models.TimetableSlot{}, fmt.Errorf("timetableService.AddSlot: %w", err)
+	}
+
+	id, err := s.timetableRepo.CreateSlot(ctx, &slot)
+	if err != nil {
+		return models.TimetableSlot{}, fmt.Errorf("timetableService.AddSlot: %w", err)
+	}
+	slot.ID = id
+	return slot, nil
+}
+
+func (s *timetableServiceImpl) UpdateSlot(ctx context.Context, slotID int64, weekday time.Weekday, startTime string, durationMinutes int) (models.TimetableSlot, error) {
+	current, err := s.timetableRepo.GetSlotByID(ctx, slotID)
+	if err != nil {
+		return models.TimetableSlot{}, slotLookupError("timetableService.UpdateSlot", slotID, err)
+	}
+	slot := *current
+	slot.Weekday = weekday
+	if err := s.validateSlot(ctx, &slot, startTime, durationMinutes); err != nil {
+		return models.TimetableSlot{}, err
+	}
+	if err := s.timetableRepo.UpdateSlot(ctx, &slot); err != nil {
+		return models.TimetableSlot{}, slotLookupError("timetableService.UpdateSlot", slotID, err)
+	}
+	return slot, nil
+}
+
+func (s *timetableServiceImpl) DeleteSlot(ctx context.Context, slotID int64) error {
+	if err := s.timetableRepo.DeleteSlot(ctx, slotID); err != nil {
+		return slotLookupError("timetableService.DeleteSlot", slotID, err)
+	}
+	return nil
+}
+
+func (s *timetableServiceImpl) ListSlots(ctx context.Context) ([]models.TimetableSlot, error) {
+	slots, err := s.timetableRepo.ListSlots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("timetableService.ListSlots: %w", err)
+	}
+	return slots, nil
+}
+
+func (s *timetableServiceImpl) FindOverlaps(ctx context.Context) ([]SlotOverlap, error) {
+	slots, err := s.ListSlots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []SlotOverlap
+	for i := range slots {
+		for j := i + 1; j < len(slots); j++ {
+			if slotsOverlap(slots[i], slots[j]) {
+				result = append(result, SlotOverlap{First: slots[i], Second: slots[j]})
+			}
+		}
+	}
+	return result, nil
+}
+
+// validateSlot normaliza a hora de início, valida a duração e recusa horários que se sobrepõem
+// a outro já cadastrado (o professor não dá duas aulas ao mesmo tempo).
+func (s *timetableServiceImpl) validateSlot(ctx context.Context, slot *models.TimetableSlot, startTime string, durationMinutes int) error {
+	if slot.Weekday < time.Sunday || slot.Weekday > time.Saturday {
+		return ValidationErrorf("dia da semana inválido: %d", slot.Weekday)
+	}
+	start, err := parseSlotStart(startTime)
+	if err != nil {
+		return err
+	}
+	if durationMinutes <= 0 || durationMinutes > 24*60 {
+		return ValidationErrorf("duração inválida: %d minutos", durationMinutes)
+	}
+	slot.StartTime = start
+	slot.DurationMinutes = durationMinutes
+
+	existing, err := s.timetableRepo.ListSlots(ctx)
+	if err != nil {
+		return fmt.Errorf("timetableService: verificar sobreposição: %w", err)
+	}
+	for _, other := range existing {
+		if other.ID != slot.ID && slotsOverlap(*slot, other) {
+			end := slotInterval(other, time.Now()).End.Format("15:04")
+			return ConflictErrorf("o horário se sobrepõe ao horário ID %d (%s %s–%s, turma ID %d)",
+				other.ID, WeekdayName(other.Weekday), other.StartTime, end, other.ClassID)
+		}
+	}
+	return nil
+}
+
+func (s *timetableServiceImpl) GenerateLessons(ctx context.Context, opts GenerateLessonsOptions) (LessonGenerationResult, error) {
+	from := time.Date(opts.From.Year(), opts.From.Month(), opts.From.Day(), 0, 0, 0, 0, opts.From.Location())
+	to := time.Date(opts.To.Year(), opts.To.Month(), opts.To.Day(), 0, 0, 0, 0, opts.To.Location())
+	if to.Before(from) {
+		return LessonGenerationResult{}, ValidationErrorf("a data final (%s) é anterior à inicial (%s)", to.Format("02/01/2006"), from.Format("02/01/2006"))
+	}
+
+	slots, err := s.ListSlots(ctx)
+	if err != nil {
+		return LessonGenerationResult{}, err
+	}
+	classNames := make(map[int64]string)
+	var selected []models.TimetableSlot
+	for _, slot := range slots {
+		if opts.ClassID != 0 && slot.ClassID != opts.ClassID {
+			continue
+		}
+		if _, ok := classNames[slot.ClassID]; !ok {
+			class, err := s.classRepo.GetClassByID(ctx, slot.ClassID)
+			if err != nil {
+				return LessonGenerationResult{}, fmt.Errorf("timetableService.GenerateLessons: turma ID %d: %w", slot.ClassID, err)
+			}
+			classNames[slot.ClassID] = class.Name
+		}
+		selected = append(selected, slot)
+	}
+	if opts.ClassID != 0 && len(selected) == 0 {
+		return LessonGenerationResult{}, ValidationErrorf("a turma ID %d não tem horários cadastrados", opts.ClassID)
+	}
+
+	// TODO: Obter UserID do contexto quando a autenticação estiver implementada.
+	userID := int64(1)
+	existing, err := s.lessonRepo.GetLessonsByDateRange(ctx, userID, from, to)
+	if err != nil {
+		return LessonGenerationResult{}, fmt.Errorf("timetableService.GenerateLessons: buscar aulas existentes: %w", err)
+	}
+
+	daysOff := nationalDaysOff(from, to)
+	if s.calendar != nil {
+		if daysOff, err = s.calendar.DaysOff(ctx, from, to); err != nil {
+			return LessonGenerationResult{}, fmt.Errorf("timetableService.GenerateLessons: buscar dias sem aula: %w", err)
+		}
+	}
+	for _, day := range opts.SkipDates {
+		daysOff[day.Format("2006-01-02")] = "dia sem aula"
+	}
+
+	var result LessonGenerationResult
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for _, slot := range selected {
+			if slot.Weekday != day.Weekday() {
+				continue
+			}
+			interval := slotInterval(slot, day)
+			if reason, off := daysOff[day.Format("2006-01-02")]; off {
+				result.Skipped = append(result.Skipped, SkippedLesson{Slot: slot, Start: interval.Start, Reason: reason})
+				continue
+			}
+			if reason := existingLessonConflict(existing, slot, interval, classNames); reason != "" {
+				result.Skipped = append(result.Skipped, SkippedLesson{Slot: slot, Start: interval.Start, Reason: reason})
+				continue
+			}
+
+			lesson := models.Lesson{ClassID: slot.ClassID, Title: "Aula - " + classNames[slot.ClassID], ScheduledAt: interval.Start}
+			if !opts.DryRun {
+				id, err := s.lessonRepo.CreateLesson(ctx, &lesson)
+				if err != nil {
+					return result, fmt.Errorf("timetableService.GenerateLessons: erro ao criar aula de %s (%d aulas já criadas): %w",
+						interval.Start.Format("02/01/2006 15:04"), len(result.Created), err)
+				}
+				lesson.ID = id
+			}
+			existing = append(existing, lesson)
+			result.Created = append(result.Created, lesson)
+		}
+	}
+	return result, nil
+}
+
+// existingLessonConflict retorna o motivo para não criar uma aula no intervalo, ou "" se não há
+// aula já marcada que o ocupe. Aulas existentes ocupam DefaultLessonDuration a partir do início.
+func existingLessonConflict(existing []models.Lesson, slot models.TimetableSlot, interval TimeSlot, classNames map[int64]string) string {
+	for _, lesson := range existing {
+		start := lesson.ScheduledAt.In(interval.Start.Location())
+		if !overlaps(interval, TimeSlot{Start: start, End: start.Add(DefaultLessonDuration)}) {
+			continue
+		}
+		if lesson.ClassID == slot.ClassID {
+			return fmt.Sprintf("já existe a aula '%s' às %s", lesson.Title, start.Format("15:04"))
+		}
+		name, ok := classNames[lesson.ClassID]
+		if !ok {
+			name = fmt.Sprintf("turma ID %d", lesson.ClassID)
+		}
+		return fmt.Sprintf("conflito com a aula '%s' (%s) às %s", lesson.Title, name, start.Format("15:04"))
+	}
+	return ""
+}
+
+// slotLookupError converte a ausência do horário em erro de registro não encontrado.
+func slotLookupError(op string, slotID int64, err error) error {
+	if repository.IsNotFound(err) {
+		return &Error{Category: CategoryNotFound, Message: fmt.Sprintf("horário com ID %d não encontrado", slotID), Err: err}
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
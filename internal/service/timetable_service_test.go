@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// fakeTimetableRepository guarda os horários em memória.
+type fakeTimetableRepository struct {
+	slots  []models.TimetableSlot
+	nextID int64
+}
+
+func (f *fakeTimetableRepository) CreateSlot(ctx context.Context, slot *models.TimetableSlot) (int64, error) {
+	f.nextID++
+	stored := *slot
+	stored.ID = f.nextID
+	f.slots = append(f.slots, stored)
+	return f.nextID, nil
+}
+
+func (f *fakeTimetableRepository) GetSlotByID(ctx context.Context, slotID int64) (*models.TimetableSlot, error) {
+	for _, slot := range f.slots {
+		if slot.ID == slotID {
+			return &slot, nil
+		}
+	}
+	return nil, fmt.Errorf("horário %d: %w", slotID, sql.ErrNoRows)
+}
+
+func (f *fakeTimetableRepository) ListSlots(ctx context.Context) ([]models.TimetableSlot, error) {
+	return f.slots, nil
+}
+
+func (f *fakeTimetableRepository) UpdateSlot(ctx context.Context, slot *models.TimetableSlot) error {
+	for i := range f.slots {
+		if f.slots[i].ID == slot.ID {
+			f.slots[i] = *slot
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (f *fakeTimetableRepository) DeleteSlot(ctx context.Context, slotID int64) error {
+	return sql.ErrNoRows
+}
+
+// generatorLessonRepository registra as aulas criadas pelo gerador.
+type generatorLessonRepository struct {
+	repository.LessonRepository
+	lessons []models.Lesson
+}
+
+func (f *generatorLessonRepository) GetLessonsByDateRange(ctx context.Context, userID int64, startDate time.Time, endDate time.Time) ([]models.Lesson, error) {
+	return f.lessons, nil
+}
+
+func (f *generatorLessonRepository) CreateLesson(ctx context.Context, lesson *models.Lesson) (int64, error) {
+	f.lessons = append(f.lessons, *lesson)
+	return int64(len(f.lessons)), nil
+}
+
+// namedClassRepository devolve turmas com o nome "Turma <ID>" para IDs de 1 a 9.
+type namedClassRepository struct {
+	repository.ClassRepository
+}
+
+func (f *namedClassRepository) GetClassByID(ctx context.Context, classID int64) (*models.Class, error) {
+	if classID < 1 || classID > 9 {
+		return nil, fmt.Errorf("turma %d: %w", classID, sql.ErrNoRows)
+	}
+	return &models.Class{ID: classID, UserID: 1, Name: fmt.Sprintf("Turma %d", classID)}, nil
+}
+
+func TestParseWeekday(t *testing.T) {
+	for input, expected := range map[string]time.Weekday{
+		"seg": time.Monday, "Terça-feira": time.Tuesday, "quarta": time.Wednesday,
+		"QUI": time.Thursday, "sexta-feira": time.Friday, "sábado": time.Saturday, "dom": time.Sunday,
+	} {
+		got, err := ParseWeekday(input)
+		if err != nil || got != expected {
+			t.Errorf("ParseWeekday(%q) = %v, %v; expected %v", input, got, err, expected)
+		}
+	}
+	if _, err := ParseWeekday("segundo"); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for an unknown weekday, got %v", err)
+	}
+}
+
+func TestNationalHolidays_MovableDates(t *testing.T) {
+	names := make(map[string]string)
+	for _, holiday := range NationalHolidays(2025) {
+		names[holiday.Date.Format("2006-01-02")] = holiday.Name
+	}
+	// Páscoa de 2025: 20 de abril.
+	for date, name := range map[string]string{
+		"2025-03-03": "Carnaval", "2025-03-04": "Carnaval", "2025-04-18": "Sexta-feira Santa",
+		"2025-04-21": "Tiradentes", "2025-06-19": "Corpus Christi",
+	} {
+		if names[date] != name {
+			t.Errorf("Expected %s on %s, got %q", name, date, names[date])
+		}
+	}
+}
+
+func TestTimetableService_AddSlotRejectsOverlap(t *testing.T) {
+	ctx := context.Background()
+	timetableService := NewTimetableService(&fakeTimetableRepository{}, &generatorLessonRepository{}, &namedClassRepository{}, nil)
+
+	if _, err := timetableService.AddSlot(ctx, 1, time.Monday, "7:00", 50); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// 07:30 na segunda se sobrepõe à aula das 07:00 (até 07:50), mesmo sendo de outra turma.
+	if _, err := timetableService.AddSlot(ctx, 2, time.Monday, "07:30", 50); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+	if _, err := timetableService.AddSlot(ctx, 2, time.Monday, "07:50", 50); err != nil {
+		t.Errorf("Expected back-to-back slot to be accepted, got %v", err)
+	}
+	if _, err := timetableService.AddSlot(ctx, 42, time.Tuesday, "07:00", 50); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found error for unknown class, got %v", err)
+	}
+	if _, err := timetableService.AddSlot(ctx, 1, time.Tuesday, "25:00", 50); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for invalid start, got %v", err)
+	}
+}
+
+func TestTimetableService_GenerateLessons(t *testing.T) {
+	ctx := context.Background()
+	timetableRepo := &fakeTimetableRepository{slots: []models.TimetableSlot{
+		{ID: 1, ClassID: 1, Weekday: time.Monday, StartTime: "07:00", DurationMinutes: 50},
+		{ID: 2, ClassID: 2, Weekday: time.Monday, StartTime: "08:00", DurationMinutes: 50},
+		{ID: 3, ClassID: 1, Weekday: time.Wednesday, StartTime: "10:00", DurationMinutes: 50},
+	}}
+	existing := models.Lesson{ID: 99, ClassID: 1, Title: "Revisão", ScheduledAt: time.Date(2025, 4, 23, 10, 0, 0, 0, time.Local)}
+	lessonRepo := &generatorLessonRepository{lessons: []models.Lesson{existing}}
+	timetableService := NewTimetableService(timetableRepo, lessonRepo, &namedClassRepository{}, nil)
+
+	// 14/04 a 30/04/2025: segundas 14, 21 (Tiradentes) e 28; quartas 16, 23 (aula existente) e 30 (pulado).
+	result, err := timetableService.GenerateLessons(ctx, GenerateLessonsOptions{
+		From:      time.Date(2025, 4, 14, 0, 0, 0, 0, time.Local),
+		To:        time.Date(2025, 4, 30, 0, 0, 0, 0, time.Local),
+		SkipDates: []time.Time{time.Date(2025, 4, 30, 0, 0, 0, 0, time.Local)},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var created []string
+	for _, lesson := range result.Created {
+		created = append(created, lesson.ScheduledAt.Format("02/01 15:04")+" "+lesson.Title)
+	}
+	expectedCreated := []string{
+		"14/04 07:00 Aula - Turma 1", "14/04 08:00 Aula - Turma 2", "16/04 10:00 Aula - Turma 1",
+		"28/04 07:00 Aula - Turma 1", "28/04 08:00 Aula - Turma 2",
+	}
+	if fmt.Sprint(created) != fmt.Sprint(expectedCreated) {
+		t.Errorf("Unexpected lessons created.\nExpected: %v\nGot:      %v", expectedCreated, created)
+	}
+	if len(lessonRepo.lessons) != 1+len(expectedCreated) {
+		t.Errorf("Expected %d lessons stored, got %d", 1+len(expectedCreated), len(lessonRepo.lessons))
+	}
+
+	var skipped []string
+	for _, s := range result.Skipped {
+		skipped = append(skipped, s.Start.Format("02/01 15:04")+" "+s.Reason)
+	}
+	expectedSkipped := []string{
+		"21/04 07:00 feriado: Tiradentes", "21/04 08:00 feriado: Tiradentes",
+		"23/04 10:00 já existe a aula 'Revisão' às 10:00", "30/04 10:00 dia sem aula",
+	}
+	if fmt.Sprint(skipped) != fmt.Sprint(expectedSkipped) {
+		t.Errorf("Unexpected skipped slots.\nExpected: %v\nGot:      %v", expectedSkipped, skipped)
+	}
+
+	// Gerar de novo o mesmo período não duplica aulas.
+	again, err := timetableService.GenerateLessons(ctx, GenerateLessonsOptions{
+		From: time.Date(2025, 4, 14, 0, 0, 0, 0, time.Local), To: time.Date(2025, 4, 16, 0, 0, 0, 0, time.Local), DryRun: true,
+	})
+	if err != nil || len(again.Created) != 0 || len(again.Skipped) != 3 {
+		t.Errorf("Expected regeneration to skip all 3 slots, got created=%d skipped=%d err=%v", len(again.Created), len(again.Skipped), err)
+	}
+}
+
+func TestTimetableService_GenerateLessonsSkipsCalendarDays(t *testing.T) {
+	timetableRepo := &fakeTimetableRepository{slots: []models.TimetableSlot{
+		{ID: 1, ClassID: 1, Weekday: time.Friday, StartTime: "07:00", DurationMinutes: 50},
+	}}
+	calendar := NewCalendarService(&fakeCalendarRepository{events: []models.CalendarEvent{
+		{Kind: models.CalendarEventKindRecess, Description: "Recesso de julho", StartDate: day(2025, 7, 7), EndDate: day(2025, 7, 18)},
+	}})
+	timetableService := NewTimetableService(timetableRepo, &generatorLessonRepository{}, &namedClassRepository{}, calendar)
+
+	// Sextas de 04/07 a 25/07/2025: 11 e 18 caem no recesso.
+	result, err := timetableService.GenerateLessons(context.Background(), GenerateLessonsOptions{From: day(2025, 7, 4), To: day(2025, 7, 25), DryRun: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Created) != 2 || len(result.Skipped) != 2 || result.Skipped[0].Reason != "recesso: Recesso de julho" {
+		t.Errorf("Expected 2 lessons and 2 recess days skipped, got created=%d skipped=%+v", len(result.Created), result.Skipped)
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"vigenda/internal/models"
+)
+
+// UpcomingAssessment é uma avaliação agendada, com o nome da turma, os dias que faltam para ela e
+// as outras avaliações da mesma turma marcadas para o mesmo dia.
+type UpcomingAssessment struct {
+	Assessment models.Assessment   `json:"assessment"`
+	ClassName  string              `json:"class_name"`
+	DaysLeft   int                 `json:"days_left"` // 0 para hoje, 1 para amanhã.
+	SameDay    []models.Assessment `json:"same_day,omitempty"`
+}
+
+// Countdown descreve quanto falta para a avaliação: "hoje", "amanhã" ou "em N dias".
+func (u UpcomingAssessment) Countdown() string {
+	switch u.DaysLeft {
+	case 0:
+		return "hoje"
+	case 1:
+		return "amanhã"
+	default:
+		return fmt.Sprintf("em %d dias", u.DaysLeft)
+	}
+}
+
+// GetUpcomingAssessments lista as avaliações de todas as turmas com data entre 'from' e 'to' (dias
+// inclusivos), da mais próxima para a mais distante. Os dias que faltam são contados a partir de
+// 'from', e SameDay aponta as outras avaliações da mesma turma no mesmo dia.
+func (s *assessmentServiceImpl) GetUpcomingAssessments(ctx context.Context, from, to time.Time) ([]UpcomingAssessment, error) {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, to.Location())
+	if to.Before(from) {
+		return nil, ValidationErrorf("período inválido: %s é anterior a %s", to.Format("02/01/2006"), from.Format("02/01/2006"))
+	}
+	assessments, err := s.assessmentRepo.GetUpcomingAssessments(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetUpcomingAssessments: %w", err)
+	}
+
+	// day é o dia da avaliação no fuso de 'from'.
+	day := func(assessment models.Assessment) time.Time {
+		date := assessment.AssessmentDate.In(from.Location())
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, from.Location())
+	}
+	type classDay struct {
+		classID int64
+		day     time.Time
+	}
+	byDay := make(map[classDay][]models.Assessment)
+	classNames := make(map[int64]string)
+	for _, assessment := range assessments {
+		key := classDay{assessment.ClassID, day(assessment)}
+		byDay[key] = append(byDay[key], assessment)
+		if _, ok := classNames[assessment.ClassID]; ok {
+			continue
+		}
+		class, err := s.classRepo.GetClassByID(ctx, assessment.ClassID)
+		if err != nil {
+			return nil, fmt.Errorf("service.GetUpcomingAssessments: turma %d: %w", assessment.ClassID, err)
+		}
+		classNames[assessment.ClassID] = class.Name
+	}
+
+	upcoming := make([]UpcomingAssessment, 0, len(assessments))
+	for _, assessment := range assessments {
+		item := UpcomingAssessment{
+			Assessment: assessment,
+			ClassName:  classNames[assessment.ClassID],
+			DaysLeft:   int(day(assessment).Sub(from).Hours()+12) / 24, // +12h absorve as mudanças de horário de verão.
+		}
+		for _, other := range byDay[classDay{assessment.ClassID, day(assessment)}] {
+			if other.ID != assessment.ID {
+				item.SameDay = append(item.SameDay, other)
+			}
+		}
+		upcoming = append(upcoming, item)
+	}
+	return upcoming, nil
+}
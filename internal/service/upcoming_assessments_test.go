@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vigenda/internal/models"
+	"vigenda/internal/repository"
+)
+
+// upcomingAssessmentRepository devolve as avaliações datadas entre 'from' e 'to', como a consulta
+// do repositório faria.
+type upcomingAssessmentRepository struct {
+	repository.AssessmentRepository
+	assessments []models.Assessment
+}
+
+func (r *upcomingAssessmentRepository) GetUpcomingAssessments(ctx context.Context, from, to time.Time) ([]models.Assessment, error) {
+	var found []models.Assessment
+	for _, assessment := range r.assessments {
+		if !assessment.AssessmentDate.Before(from) && !assessment.AssessmentDate.After(to) {
+			found = append(found, assessment)
+		}
+	}
+	return found, nil
+}
+
+func TestGetUpcomingAssessments_CountdownAndSameDayConflicts(t *testing.T) {
+	day := func(d int) *time.Time {
+		date := time.Date(2026, time.October, d, 0, 0, 0, 0, time.Local)
+		return &date
+	}
+	repo := &upcomingAssessmentRepository{assessments: []models.Assessment{
+		{ID: 1, ClassID: 1, Name: "Prova 1", AssessmentDate: day(19)},
+		{ID: 2, ClassID: 2, Name: "Trabalho", AssessmentDate: day(20)},
+		{ID: 3, ClassID: 1, Name: "Seminário", AssessmentDate: day(23)},
+		{ID: 4, ClassID: 1, Name: "Teste", AssessmentDate: day(23)},
+		{ID: 5, ClassID: 2, Name: "Prova 2", AssessmentDate: day(23)},
+	}}
+	assessmentService := NewAssessmentService(repo, &namedClassRepository{}, nil, nil)
+
+	// 'from' no meio do dia: a contagem é por dias, não por horas.
+	from := time.Date(2026, time.October, 19, 15, 30, 0, 0, time.Local)
+	upcoming, err := assessmentService.GetUpcomingAssessments(context.Background(), from, from.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	require.Len(t, upcoming, 5)
+
+	assert.Equal(t, "Turma 1", upcoming[0].ClassName)
+	assert.Equal(t, "hoje", upcoming[0].Countdown())
+	assert.Equal(t, "amanhã", upcoming[1].Countdown())
+	assert.Equal(t, "em 4 dias", upcoming[2].Countdown())
+	assert.Empty(t, upcoming[0].SameDay)
+	require.Len(t, upcoming[2].SameDay, 1)
+	assert.Equal(t, "Teste", upcoming[2].SameDay[0].Name)
+	assert.Equal(t, "Seminário", upcoming[3].SameDay[0].Name)
+	assert.Empty(t, upcoming[4].SameDay, "avaliações de outra turma no mesmo dia não conflitam")
+
+	_, err = assessmentService.GetUpcomingAssessments(context.Background(), from, from.AddDate(0, 0, -1))
+	assert.True(t, errors.Is(err, ErrValidation), "%v", err)
+}
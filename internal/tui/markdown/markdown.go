@@ -0,0 +1,190 @@
+// Package markdown renderiza planos de aula escritos em Markdown para o terminal.
+// Fica separado de internal/tui para poder ser usado pelos módulos de internal/app,
+// já que internal/tui importa internal/app.
+// Cobre o subconjunto usado nos planos: títulos, listas (inclusive de tarefas), citações,
+// tabelas, blocos de código, linhas horizontais e ênfase inline (**negrito**, *itálico*, `código`).
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	mdHeading1Style = lipgloss.NewStyle().Bold(true).Underline(true).Foreground(lipgloss.Color("205"))
+	mdHeadingStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	mdQuoteStyle    = lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("245"))
+	mdCodeStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	mdRuleStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	mdBoldStyle     = lipgloss.NewStyle().Bold(true)
+	mdItalicStyle   = lipgloss.NewStyle().Italic(true)
+
+	mdOrderedItem  = regexp.MustCompile(`^(\d+)[.)]\s+(.*)$`)
+	mdTableDivider = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+	mdInlineCode   = regexp.MustCompile("`([^`]+)`")
+	mdBold         = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdItalic       = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+)
+
+// Render converte 'source' em texto formatado para o terminal, quebrando parágrafos
+// em 'width' colunas (width <= 0 desativa a quebra). Linhas que não são Markdown reconhecido
+// são mantidas como texto comum, então um plano em texto puro aparece sem alterações.
+func Render(source string, width int) string {
+	var out []string
+	inCode := false
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out = append(out, "    "+mdCodeStyle.Render(line))
+			continue
+		}
+
+		indent := strings.Repeat(" ", len(line)-len(strings.TrimLeft(line, " \t")))
+		switch {
+		case trimmed == "":
+			out = append(out, "")
+		case strings.HasPrefix(trimmed, "#"):
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			text := strings.TrimSpace(trimmed[level:])
+			if level == 1 {
+				out = append(out, mdHeading1Style.Render(strings.ToUpper(text)))
+			} else {
+				out = append(out, mdHeadingStyle.Render(text))
+			}
+		case trimmed == "---" || trimmed == "***" || trimmed == "___":
+			ruleWidth := width
+			if ruleWidth <= 0 || ruleWidth > 60 {
+				ruleWidth = 40
+			}
+			out = append(out, mdRuleStyle.Render(strings.Repeat("─", ruleWidth)))
+		case strings.HasPrefix(trimmed, "|"):
+			var rows []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				rows = append(rows, strings.TrimSpace(lines[i]))
+			}
+			i--
+			out = append(out, renderTable(rows)...)
+		case strings.HasPrefix(trimmed, ">"):
+			text := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			out = append(out, wrapPrefixed(indent+"│ ", mdQuoteStyle.Render(renderInline(text)), width)...)
+		case isBullet(trimmed):
+			text := strings.TrimSpace(trimmed[2:])
+			marker := "• "
+			switch {
+			case strings.HasPrefix(text, "[ ] "):
+				marker, text = "☐ ", text[4:]
+			case strings.HasPrefix(strings.ToLower(text), "[x] "):
+				marker, text = "☑ ", text[4:]
+			}
+			out = append(out, wrapPrefixed(indent+marker, renderInline(text), width)...)
+		case mdOrderedItem.MatchString(trimmed):
+			match := mdOrderedItem.FindStringSubmatch(trimmed)
+			out = append(out, wrapPrefixed(indent+match[1]+". ", renderInline(match[2]), width)...)
+		default:
+			out = append(out, wrapPrefixed(indent, renderInline(trimmed), width)...)
+		}
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+// isBullet informa se a linha (sem indentação) é um item de lista não numerada.
+func isBullet(trimmed string) bool {
+	return len(trimmed) > 1 && strings.ContainsAny(trimmed[:1], "-*+") && trimmed[1] == ' '
+}
+
+// renderTable alinha as colunas de uma tabela Markdown ('| a | b |'). A linha divisória
+// (|---|---|) vira um traço sob o cabeçalho, que aparece em negrito.
+func renderTable(rows []string) []string {
+	var cells [][]string
+	hasHeader := false
+	for i, row := range rows {
+		if mdTableDivider.MatchString(row) {
+			hasHeader = hasHeader || i == 1
+			continue
+		}
+		row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+		var rowCells []string
+		for _, cell := range strings.Split(row, "|") {
+			rowCells = append(rowCells, renderInline(strings.TrimSpace(cell)))
+		}
+		cells = append(cells, rowCells)
+	}
+
+	var widths []int
+	for _, row := range cells {
+		for c, cell := range row {
+			if c == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[c] = max(widths[c], lipgloss.Width(cell))
+		}
+	}
+
+	var out []string
+	for r, row := range cells {
+		parts := make([]string, len(widths))
+		for c := range widths {
+			cell := ""
+			if c < len(row) {
+				cell = row[c]
+			}
+			if r == 0 && hasHeader {
+				cell = mdBoldStyle.Render(cell)
+			}
+			parts[c] = cell + strings.Repeat(" ", widths[c]-lipgloss.Width(cell))
+		}
+		out = append(out, strings.TrimRight(strings.Join(parts, " │ "), " "))
+		if r == 0 && hasHeader {
+			dividers := make([]string, len(widths))
+			for c, w := range widths {
+				dividers[c] = strings.Repeat("─", w)
+			}
+			out = append(out, mdRuleStyle.Render(strings.Join(dividers, "─┼─")))
+		}
+	}
+	return out
+}
+
+// renderInline aplica a ênfase inline (código, negrito e itálico) a um trecho de texto.
+func renderInline(text string) string {
+	text = mdInlineCode.ReplaceAllStringFunc(text, func(m string) string {
+		return mdCodeStyle.Render(mdInlineCode.FindStringSubmatch(m)[1])
+	})
+	text = mdBold.ReplaceAllStringFunc(text, func(m string) string {
+		sub := mdBold.FindStringSubmatch(m)
+		return mdBoldStyle.Render(sub[1] + sub[2])
+	})
+	return mdItalic.ReplaceAllStringFunc(text, func(m string) string {
+		sub := mdItalic.FindStringSubmatch(m)
+		return mdItalicStyle.Render(sub[1] + sub[2])
+	})
+}
+
+// wrapPrefixed quebra 'text' em linhas de até 'width' colunas; a primeira linha recebe 'prefix'
+// e as seguintes são alinhadas com espaços de mesma largura.
+func wrapPrefixed(prefix, text string, width int) []string {
+	if width <= 0 {
+		return []string{prefix + text}
+	}
+	prefixWidth := lipgloss.Width(prefix)
+	wrapped := lipgloss.NewStyle().Width(max(width-prefixWidth, 10)).Render(text)
+	lines := strings.Split(wrapped, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " ")
+		if i == 0 {
+			lines[i] = prefix + line
+		} else {
+			lines[i] = strings.Repeat(" ", prefixWidth) + line
+		}
+	}
+	return lines
+}
@@ -0,0 +1,60 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	source := "# Frações\n\n## Objetivos\n- Comparar **frações** equivalentes\n- [ ] Corrigir exercícios\n- [x] Preparar slides\n1. Aquecimento com `pizza`\n> Lembrar da tarefa de casa\n```\ncódigo_literal **sem ênfase**\n```\n---\nTexto com *ênfase*."
+
+	got := Render(source, 0)
+
+	expectedLines := []string{
+		"FRAÇÕES",
+		"Objetivos",
+		"• Comparar frações equivalentes",
+		"☐ Corrigir exercícios",
+		"☑ Preparar slides",
+		"1. Aquecimento com pizza",
+		"│ Lembrar da tarefa de casa",
+		"    código_literal **sem ênfase**",
+		"Texto com ênfase.",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(got, line) {
+			t.Errorf("Expected rendered markdown to contain %q, got:\n%s", line, got)
+		}
+	}
+	if strings.Contains(got, "```") || strings.Contains(got, "# ") {
+		t.Errorf("Markdown markers should not appear in the output, got:\n%s", got)
+	}
+}
+
+func TestRender_WrapsLongLines(t *testing.T) {
+	source := "- " + strings.Repeat("palavra ", 10)
+
+	got := Render(source, 30)
+
+	lines := strings.Split(got, "\n")
+	if len(lines) < 2 {
+		t.Fatalf("Expected long bullet to wrap, got:\n%s", got)
+	}
+	if !strings.HasPrefix(lines[0], "• ") || !strings.HasPrefix(lines[1], "  palavra") {
+		t.Errorf("Expected continuation lines aligned with the bullet text, got:\n%s", got)
+	}
+}
+
+func TestRender_Table(t *testing.T) {
+	source := "| Etapa | Tempo |\n|---|:---:|\n| Aquecimento | 10 min |\n| Exercícios | 30 min |"
+
+	got := Render(source, 0)
+
+	expected := "Etapa       │ Tempo\n" +
+		"────────────┼───────\n" +
+		"Aquecimento │ 10 min\n" +
+		"Exercícios  │ 30 min"
+	if got != expected {
+		t.Errorf("Unexpected table rendering.\nExpected:\n%s\nGot:\n%s", expected, got)
+	}
+}
@@ -4,7 +4,8 @@ package tui
 
 import (
 	"fmt"
-	"io" // Para io.Writer na função ShowTable.
+	"io" // Para io.Writer nas funções ShowTable e WriteTable.
+	"strings"
 	// "os" // Comentado pois os.Stdout é usado apenas no exemplo.
 
 	"github.com/charmbracelet/bubbles/table"
@@ -103,6 +104,42 @@ func ShowTable(columns []table.Column, rows []table.Row, output io.Writer) {
 	}
 }
 
+// WriteTable escreve uma tabela em texto simples, sem interação, no formato usado pelos comandos da CLI:
+//
+//	ID  | TAREFA      | PRAZO
+//	--- | ----------- | ----------
+//	1   | Corrigir... | 23/06/2025
+//
+// Cada coluna é alinhada à largura (Width) definida; a última coluna não recebe preenchimento.
+// Valores maiores que a largura não são truncados.
+func WriteTable(output io.Writer, columns []table.Column, rows []table.Row) {
+	writeRow := func(cells []string) {
+		for i, col := range columns {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			if i == len(columns)-1 {
+				fmt.Fprintf(output, "%s\n", cell)
+			} else {
+				fmt.Fprintf(output, "%-*s | ", col.Width, cell)
+			}
+		}
+	}
+
+	titles := make([]string, len(columns))
+	separators := make([]string, len(columns))
+	for i, col := range columns {
+		titles[i] = col.Title
+		separators[i] = strings.Repeat("-", col.Width)
+	}
+	writeRow(titles)
+	writeRow(separators)
+	for _, row := range rows {
+		writeRow(row)
+	}
+}
+
 // Exemplo de Uso (pode ser movido para um arquivo main_example.go ou _test.go)
 /*
 package main // Ou um pacote de teste
@@ -141,3 +141,26 @@ func TestShowTable(t *testing.T) {
 	}
 	// This doesn't test ShowTable's p.Run(), but tests the model it would use.
 }
+
+func TestWriteTable(t *testing.T) {
+	columns := []table.Column{
+		{Title: "ID", Width: 3},
+		{Title: "TAREFA", Width: 10},
+		{Title: "PRAZO", Width: 10},
+	}
+	rows := []table.Row{
+		{"1", "Corrigir", "23/06/2025"},
+		{"12", "Texto maior que a coluna", "N/A"},
+	}
+
+	var sb strings.Builder
+	WriteTable(&sb, columns, rows)
+
+	expected := "ID  | TAREFA     | PRAZO\n" +
+		"--- | ---------- | ----------\n" +
+		"1   | Corrigir   | 23/06/2025\n" +
+		"12  | Texto maior que a coluna | N/A\n"
+	if sb.String() != expected {
+		t.Errorf("WriteTable output mismatch.\nExpected:\n%s\nGot:\n%s", expected, sb.String())
+	}
+}